@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// activitySample records whether a pane produced output during the Interval
+// seconds before Time. Samples are appended to a JSONL file by "report record".
+type activitySample struct {
+	Time     time.Time `json:"time"`
+	PaneID   string    `json:"pane_id"`
+	Pane     string    `json:"pane"`
+	Session  string    `json:"session"`
+	Command  string    `json:"command"`
+	Busy     bool      `json:"busy"`
+	Interval float64   `json:"interval"`
+}
+
+func defaultActivityFile() string {
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_ACTIVITY")); env != "" {
+		return env
+	}
+	if dir, err := os.UserCacheDir(); err == nil && strings.TrimSpace(dir) != "" {
+		return filepath.Join(dir, "arc-tmux", "activity.jsonl")
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".arc-tmux-activity.jsonl")
+	}
+	return "activity.jsonl"
+}
+
+func appendActivitySamples(path string, samples []activitySample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	policy, err := loadRotationPolicy()
+	if err != nil {
+		return err
+	}
+	f, err := openRotatingFile(path, policy)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, s := range samples {
+		data, err := json.Marshal(s)
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	if _, err := io.WriteString(f, b.String()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// loadActivitySamples reads the samples taken at or after since, including
+// those in rotated copies of the file. A missing file yields no samples;
+// malformed lines are skipped.
+func loadActivitySamples(path string, since time.Time) ([]activitySample, error) {
+	var samples []activitySample
+	rotated, err := rotatedLogs(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, r := range rotated {
+		if r.Rotated.Before(since) {
+			continue
+		}
+		if samples, err = readActivitySamples(r.Path, since, samples); err != nil {
+			return nil, err
+		}
+	}
+	return readActivitySamples(path, since, samples)
+}
+
+// readActivitySamples appends the samples in one file, gzipped or not, to
+// samples.
+func readActivitySamples(path string, since time.Time, samples []activitySample) ([]activitySample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return samples, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		defer func() { _ = zr.Close() }()
+		r = zr
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var s activitySample
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			continue
+		}
+		if s.Time.Before(since) {
+			continue
+		}
+		samples = append(samples, s)
+	}
+	return samples, scanner.Err()
+}
+
+// parseSince parses a --since window: a Go duration ("90m", "24h") or a
+// number of days ("7d").
+func parseSince(raw string) (time.Duration, error) {
+	trimmed := strings.TrimSpace(raw)
+	if days, ok := strings.CutSuffix(trimmed, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err == nil && n > 0 {
+			return time.Duration(n * float64(24*time.Hour)), nil
+		}
+	} else if d, err := time.ParseDuration(trimmed); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid --since %q (e.g. 90m, 24h, 7d)", raw)
+}
+
+type activityReport struct {
+	Since time.Time `json:"since" yaml:"since"`
+	Until time.Time `json:"until" yaml:"until"`
+	// Hours holds the start of each heatmap column.
+	Hours []time.Time           `json:"hours" yaml:"hours"`
+	Panes []activityPaneSummary `json:"panes" yaml:"panes"`
+}
+
+type activityPaneSummary struct {
+	PaneID         string  `json:"pane_id" yaml:"pane_id"`
+	Pane           string  `json:"pane" yaml:"pane"`
+	Session        string  `json:"session" yaml:"session"`
+	Command        string  `json:"command" yaml:"command"`
+	BusySeconds    float64 `json:"busy_seconds" yaml:"busy_seconds"`
+	SampledSeconds float64 `json:"sampled_seconds" yaml:"sampled_seconds"`
+	// HourlyBusySeconds is busy time per column of Hours.
+	HourlyBusySeconds []float64 `json:"hourly_busy_seconds" yaml:"hourly_busy_seconds"`
+}
+
+// buildActivityReport totals busy time per pane and per hour between since
+// and until. Each sample counts toward the hour it was taken in; a pane's
+// target and command are taken from its latest sample. Panes are ordered
+// busiest first.
+func buildActivityReport(samples []activitySample, since time.Time, until time.Time) activityReport {
+	report := activityReport{Since: since, Until: until, Panes: []activityPaneSummary{}}
+	first := since.Truncate(time.Hour)
+	for h := first; h.Before(until); h = h.Add(time.Hour) {
+		report.Hours = append(report.Hours, h)
+	}
+	byPane := map[string]*activityPaneSummary{}
+	latest := map[string]time.Time{}
+	for _, s := range samples {
+		if s.Time.Before(since) || s.Time.After(until) {
+			continue
+		}
+		key := s.Session + "\x00" + s.PaneID
+		sum, ok := byPane[key]
+		if !ok {
+			sum = &activityPaneSummary{PaneID: s.PaneID, HourlyBusySeconds: make([]float64, len(report.Hours))}
+			byPane[key] = sum
+		}
+		if !s.Time.Before(latest[key]) {
+			latest[key] = s.Time
+			sum.Pane, sum.Session, sum.Command = s.Pane, s.Session, s.Command
+		}
+		sum.SampledSeconds += s.Interval
+		if !s.Busy {
+			continue
+		}
+		sum.BusySeconds += s.Interval
+		if idx := int(s.Time.Sub(first) / time.Hour); idx >= 0 && idx < len(sum.HourlyBusySeconds) {
+			sum.HourlyBusySeconds[idx] += s.Interval
+		}
+	}
+	for _, sum := range byPane {
+		report.Panes = append(report.Panes, *sum)
+	}
+	sort.Slice(report.Panes, func(i, j int) bool {
+		a, b := report.Panes[i], report.Panes[j]
+		if a.BusySeconds != b.BusySeconds {
+			return a.BusySeconds > b.BusySeconds
+		}
+		return a.Pane < b.Pane
+	})
+	return report
+}
+
+// heatmapShades shade an hour by its busy fraction.
+var heatmapShades = []rune{'·', '░', '▒', '▓', '█'}
+
+// activityHeatmap renders hourly busy seconds as one shade per hour.
+func activityHeatmap(hourly []float64) string {
+	var b strings.Builder
+	for _, busy := range hourly {
+		level := 0
+		if busy > 0 {
+			level = 1 + int(busy/3600*float64(len(heatmapShades)-1))
+			if level >= len(heatmapShades) {
+				level = len(heatmapShades) - 1
+			}
+		}
+		b.WriteRune(heatmapShades[level])
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBuildActivityReport(t *testing.T) {
+	since := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+	until := since.Add(2 * time.Hour)
+	samples := []activitySample{
+		{Time: since.Add(10 * time.Minute), PaneID: "%1", Pane: "dev:1.0", Session: "dev", Command: "bash", Busy: true, Interval: 60},
+		{Time: since.Add(40 * time.Minute), PaneID: "%1", Pane: "dev:2.0", Session: "dev", Command: "node", Busy: true, Interval: 60},
+		{Time: since.Add(41 * time.Minute), PaneID: "%1", Pane: "dev:2.0", Session: "dev", Command: "node", Busy: false, Interval: 60},
+		{Time: since.Add(50 * time.Minute), PaneID: "%2", Pane: "dev:3.0", Session: "dev", Command: "vim", Busy: false, Interval: 60},
+		{Time: since.Add(-time.Minute), PaneID: "%2", Pane: "dev:3.0", Session: "dev", Busy: true, Interval: 60},
+	}
+	report := buildActivityReport(samples, since, until)
+	if len(report.Hours) != 3 || !report.Hours[0].Equal(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected hours: %v", report.Hours)
+	}
+	if len(report.Panes) != 2 {
+		t.Fatalf("expected 2 panes, got %+v", report.Panes)
+	}
+	busiest := report.Panes[0]
+	if busiest.PaneID != "%1" || busiest.Pane != "dev:2.0" || busiest.Command != "node" {
+		t.Fatalf("expected latest target and command, got %+v", busiest)
+	}
+	if busiest.BusySeconds != 120 || busiest.SampledSeconds != 180 {
+		t.Fatalf("unexpected totals: %+v", busiest)
+	}
+	if busiest.HourlyBusySeconds[0] != 60 || busiest.HourlyBusySeconds[1] != 60 {
+		t.Fatalf("unexpected hourly totals: %v", busiest.HourlyBusySeconds)
+	}
+	if report.Panes[1].BusySeconds != 0 {
+		t.Fatalf("sample before since should be ignored: %+v", report.Panes[1])
+	}
+}
+
+func TestActivitySamplesRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "activity.jsonl")
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := appendActivitySamples(path, []activitySample{{Time: now.Add(-2 * time.Hour), PaneID: "%1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendActivitySamples(path, []activitySample{{Time: now, PaneID: "%2", Busy: true}}); err != nil {
+		t.Fatal(err)
+	}
+	samples, err := loadActivitySamples(path, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 1 || samples[0].PaneID != "%2" || !samples[0].Busy {
+		t.Fatalf("unexpected samples: %+v", samples)
+	}
+}
+
+func TestActivitySamplesIncludeRotatedFiles(t *testing.T) {
+	t.Setenv("ARC_TMUX_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+	path := filepath.Join(t.TempDir(), "activity.jsonl")
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := appendActivitySamples(path, []activitySample{{Time: now.Add(-30 * time.Minute), PaneID: "%1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rotateLogFile(path, rotationPolicy{Compress: true}, now.Add(-10*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendActivitySamples(path, []activitySample{{Time: now, PaneID: "%2"}}); err != nil {
+		t.Fatal(err)
+	}
+	samples, err := loadActivitySamples(path, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 2 || samples[0].PaneID != "%1" || samples[1].PaneID != "%2" {
+		t.Fatalf("unexpected samples: %+v", samples)
+	}
+}
+
+func TestParseSinceAndHeatmap(t *testing.T) {
+	if d, err := parseSince("7d"); err != nil || d != 7*24*time.Hour {
+		t.Fatalf("parseSince(7d) = %v, %v", d, err)
+	}
+	if d, err := parseSince("90m"); err != nil || d != 90*time.Minute {
+		t.Fatalf("parseSince(90m) = %v, %v", d, err)
+	}
+	if _, err := parseSince("yesterday"); err == nil {
+		t.Fatalf("expected invalid --since to fail")
+	}
+	if got := activityHeatmap([]float64{0, 60, 1800, 3600}); got != "·░▓█" {
+		t.Fatalf("activityHeatmap = %q", got)
+	}
+}
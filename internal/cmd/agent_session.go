@@ -18,6 +18,9 @@ func resolveAgentSessionName(input string) (string, bool, error) {
 	if target == "" {
 		target = resolveManagedSession()
 	}
+	if err := tmux.ValidateSessionName(target); err != nil {
+		return "", false, newCodedError(errInvalidSession, err.Error(), nil)
+	}
 	if strings.HasPrefix(target, agentSessionPrefix) {
 		exists, err := tmux.HasSession(target)
 		if err != nil {
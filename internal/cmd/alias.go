@@ -5,10 +5,15 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,17 +21,28 @@ func newAliasCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "alias",
 		Short: "Manage pane aliases",
-		Long:  "Create, list, resolve, and delete pane aliases for quick targeting.",
+		Long: `Create, list, resolve, and delete pane aliases for quick targeting.
+
+Aliases live in one of three scopes, resolved in this order:
+  session  stored on the current tmux session (or --session); removed with it
+  project  .arc-tmux/aliases.json in the working directory or a parent
+  global   the user alias file (ARC_TMUX_ALIASES or config dir)
+Names may be namespaced ("web:api") to avoid collisions between projects.`,
 		Example: `  arc-tmux alias set api --pane=@current
-  arc-tmux alias list
+  arc-tmux alias set api --pane=dev:1.0 --scope session
+  arc-tmux alias set web:api --pane=web:0.1
+  arc-tmux alias list --scope project
+  arc-tmux alias gc --dry-run
   arc-tmux send "npm test" --pane=@api`,
 	}
 
 	cmd.AddCommand(
 		newAliasListCmd(),
 		newAliasSetCmd(),
+		newAliasSetFromWindowCmd(),
 		newAliasUnsetCmd(),
 		newAliasResolveCmd(),
+		newAliasGCCmd(),
 	)
 
 	return cmd
@@ -35,6 +51,9 @@ func newAliasCmd() *cobra.Command {
 func newAliasListCmd() *cobra.Command {
 	var outputOpts output.OutputOptions
 	var file string
+	var scope string
+	var session string
+	var porcelain bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -43,15 +62,18 @@ func newAliasListCmd() *cobra.Command {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-			path := aliasPath(file)
-			aliases, err := loadAliases(path)
+			if err := checkPorcelain(cmd, porcelain); err != nil {
+				return err
+			}
+			entries, err := listAliases(scope, file, session)
 			if err != nil {
 				return err
 			}
-			entries := aliasesToEntries(aliases)
 			out := cmd.OutOrStdout()
 
 			switch {
+			case porcelain:
+				return writePorcelain(out, aliasPorcelainRows(entries))
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
@@ -75,7 +97,7 @@ func newAliasListCmd() *cobra.Command {
 			}
 			_, _ = fmt.Fprintln(out, "Aliases:")
 			for _, entry := range entries {
-				_, _ = fmt.Fprintf(out, "  %s => %s\n", entry.Name, entry.Target)
+				_, _ = fmt.Fprintf(out, "  %s => %s (%s)\n", entry.Name, entry.Target, entry.Scope)
 			}
 			return nil
 		},
@@ -83,12 +105,17 @@ func newAliasListCmd() *cobra.Command {
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
 	cmd.Flags().StringVar(&file, "file", "", "Alias file path (default: ARC_TMUX_ALIASES or config dir)")
+	cmd.Flags().StringVar(&scope, "scope", aliasScopeAll, "Scope to list (session|project|global|all)")
+	cmd.Flags().StringVar(&session, "session", "", "Session for session-scoped aliases (default: current session or ARC_TMUX_SESSION)")
+	addPorcelainFlag(cmd, "alias list", &porcelain)
 	return cmd
 }
 
 func newAliasSetCmd() *cobra.Command {
 	var file string
 	var paneArg string
+	var scope string
+	var session string
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
@@ -116,20 +143,25 @@ func newAliasSetCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
 				return err
 			}
+			target = handle.Target
 
-			path := aliasPath(file)
-			aliases, err := loadAliases(path)
+			store, err := aliasStoreFor(scope, file, session)
+			if err != nil {
+				return err
+			}
+			aliases, err := store.load()
 			if err != nil {
 				return err
 			}
 			aliases[name] = target
-			if err := saveAliases(path, aliases); err != nil {
+			if err := store.save(aliases); err != nil {
 				return err
 			}
-			entry := aliasEntry{Name: name, Target: target}
+			entry := aliasEntry{Name: name, Target: target, Scope: store.Scope}
 			out := cmd.OutOrStdout()
 			switch {
 			case outputOpts.Is(output.OutputJSON):
@@ -144,7 +176,7 @@ func newAliasSetCmd() *cobra.Command {
 				_, _ = fmt.Fprintln(out, entry.Name)
 				return nil
 			}
-			_, _ = fmt.Fprintf(out, "Alias %s => %s\n", name, target)
+			_, _ = fmt.Fprintf(out, "Alias %s => %s (%s)\n", name, target, store.Scope)
 			return nil
 		},
 	}
@@ -152,17 +184,231 @@ func newAliasSetCmd() *cobra.Command {
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
 	cmd.Flags().StringVar(&file, "file", "", "Alias file path (default: ARC_TMUX_ALIASES or config dir)")
 	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&scope, "scope", aliasScopeGlobal, "Scope to store the alias in (session|project|global)")
+	cmd.Flags().StringVar(&session, "session", "", "Session for --scope session (default: current session or ARC_TMUX_SESSION)")
 	return cmd
 }
 
+type aliasFromWindowResult struct {
+	Session string           `json:"session" yaml:"session"`
+	Window  int              `json:"window_index" yaml:"window_index"`
+	Scope   string           `json:"scope" yaml:"scope"`
+	DryRun  bool             `json:"dry_run" yaml:"dry_run"`
+	Aliases []aliasEntry     `json:"aliases" yaml:"aliases"`
+	Skipped []aliasSkipEntry `json:"skipped" yaml:"skipped"`
+}
+
+type aliasSkipEntry struct {
+	Pane   string `json:"pane" yaml:"pane"`
+	Title  string `json:"title" yaml:"title"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+func newAliasSetFromWindowCmd() *cobra.Command {
+	var file string
+	var session string
+	var window string
+	var namespace string
+	var scope string
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "set-from-window",
+		Short: "Create aliases from pane titles in a window",
+		Long: `Create an alias for every titled pane in a window: a pane titled "server"
+becomes @server. Titles are lowercased and non-alias characters become "-".
+Panes with the default title (the hostname), reserved names, or a title shared
+with another pane are skipped.`,
+		Example: `  arc-tmux alias set-from-window --session dev --window 2
+  arc-tmux alias set-from-window --session dev --window api --namespace web --scope project`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if strings.TrimSpace(window) == "" {
+				return errors.New("--window is required")
+			}
+			namespace = strings.ToLower(strings.TrimSpace(namespace))
+			if !validAliasChars(namespace) {
+				return fmt.Errorf("invalid --namespace %q", namespace)
+			}
+			sess, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			if sess == "" {
+				sess = defaultPaneSession()
+			}
+			if sess == "" {
+				return newCodedError(errInvalidSession, "--session is required outside tmux (or set ARC_TMUX_SESSION)", nil)
+			}
+			sess, err = resolveExistingSessionName(sess)
+			if err != nil {
+				return err
+			}
+			wins, err := tmux.ListWindows(sess)
+			if err != nil {
+				return err
+			}
+			windowIndex, err := windowIndexByName(wins, strings.TrimSpace(window))
+			if err != nil {
+				return err
+			}
+			panes, err := panesForWindow(sess, windowIndex)
+			if err != nil {
+				return err
+			}
+			if len(panes) == 0 {
+				return newCodedError(errInvalidPane, fmt.Sprintf("no panes in %s:%d", sess, windowIndex), nil)
+			}
+
+			store, err := aliasStoreFor(scope, file, sess)
+			if err != nil {
+				return err
+			}
+			hostname, _ := os.Hostname()
+			entries, skipped := aliasesFromPanes(panes, namespace, hostname)
+			result := aliasFromWindowResult{
+				Session: sess,
+				Window:  windowIndex,
+				Scope:   store.Scope,
+				DryRun:  dryRun,
+				Aliases: entries,
+				Skipped: skipped,
+			}
+			for i := range result.Aliases {
+				result.Aliases[i].Scope = store.Scope
+			}
+			if !dryRun && len(entries) > 0 {
+				aliases, err := store.load()
+				if err != nil {
+					return err
+				}
+				for _, entry := range entries {
+					aliases[entry.Name] = entry.Target
+				}
+				if err := store.save(aliases); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				for _, entry := range result.Aliases {
+					_, _ = fmt.Fprintln(out, entry.Name)
+				}
+				return nil
+			}
+			prefix := ""
+			if dryRun {
+				prefix = "[dry-run] "
+			}
+			for _, entry := range result.Aliases {
+				_, _ = fmt.Fprintf(out, "%sAlias %s => %s (%s)\n", prefix, entry.Name, entry.Target, entry.Scope)
+			}
+			for _, skip := range result.Skipped {
+				_, _ = fmt.Fprintf(out, "Skipped %s (title %q): %s\n", skip.Pane, skip.Title, skip.Reason)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&file, "file", "", "Alias file path (default: ARC_TMUX_ALIASES or config dir)")
+	cmd.Flags().StringVar(&session, "session", "", "Session name or selector (@current|@managed; default: current session or ARC_TMUX_SESSION)")
+	cmd.Flags().StringVar(&window, "window", "", "Window index or name")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Prefix alias names with a namespace (e.g. web -> @web:server)")
+	cmd.Flags().StringVar(&scope, "scope", aliasScopeGlobal, "Scope to store the aliases in (session|project|global)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the aliases that would be created without saving them")
+	return cmd
+}
+
+// aliasesFromPanes derives alias entries from pane titles, ordered by pane
+// index. Titles equal to hostname are tmux's default and are skipped.
+func aliasesFromPanes(panes []tmux.PaneDetails, namespace string, hostname string) ([]aliasEntry, []aliasSkipEntry) {
+	sorted := append([]tmux.PaneDetails(nil), panes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PaneIndex < sorted[j].PaneIndex })
+
+	type candidate struct {
+		name string
+		pane tmux.PaneDetails
+	}
+	var candidates []candidate
+	counts := make(map[string]int)
+	skipped := []aliasSkipEntry{}
+	for _, p := range sorted {
+		title := strings.TrimSpace(p.Title)
+		skip := aliasSkipEntry{Pane: formattedPaneID(&p), Title: p.Title}
+		if isDefaultPaneTitle(title, hostname) {
+			skip.Reason = "untitled"
+			skipped = append(skipped, skip)
+			continue
+		}
+		name := aliasNameFromTitle(title)
+		if namespace != "" && name != "" {
+			name = namespace + ":" + name
+		}
+		normalized, err := normalizeAliasName(name)
+		if err != nil {
+			skip.Reason = err.Error()
+			skipped = append(skipped, skip)
+			continue
+		}
+		counts[normalized]++
+		candidates = append(candidates, candidate{name: normalized, pane: p})
+	}
+
+	entries := []aliasEntry{}
+	for _, c := range candidates {
+		if counts[c.name] > 1 {
+			skipped = append(skipped, aliasSkipEntry{Pane: formattedPaneID(&c.pane), Title: c.pane.Title, Reason: fmt.Sprintf("title shared by %d panes", counts[c.name])})
+			continue
+		}
+		entries = append(entries, aliasEntry{Name: c.name, Target: formattedPaneID(&c.pane)})
+	}
+	return entries, skipped
+}
+
+// aliasNameFromTitle lowercases a title and replaces runs of characters not
+// allowed in alias names with "-".
+func aliasNameFromTitle(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '.' {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
+
 func newAliasUnsetCmd() *cobra.Command {
 	var file string
+	var scope string
+	var session string
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
-		Use:   "unset <name>",
-		Short: "Remove an alias",
-		Args:  cobra.MinimumNArgs(1),
+		Use:               "unset <name>",
+		Short:             "Remove an alias",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeAliasNames,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
@@ -171,38 +417,144 @@ func newAliasUnsetCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			path := aliasPath(file)
-			aliases, err := loadAliases(path)
-			if err != nil {
-				return err
+			// Without --scope, remove the alias from the scope it resolves from.
+			var stores []aliasStore
+			if scope == "" {
+				stores = aliasStores(file, session)
+			} else {
+				store, err := aliasStoreFor(scope, file, session)
+				if err != nil {
+					return err
+				}
+				stores = []aliasStore{store}
 			}
 			out := cmd.OutOrStdout()
-			if _, ok := aliases[name]; !ok {
-				result := aliasUnsetResult{Name: name, Removed: false}
+			for _, store := range stores {
+				aliases, err := store.load()
+				if err != nil {
+					return err
+				}
+				if _, ok := aliases[name]; !ok {
+					continue
+				}
+				delete(aliases, name)
+				if err := store.save(aliases); err != nil {
+					return err
+				}
+				result := aliasUnsetResult{Name: name, Removed: true, Scope: store.Scope}
 				return writeAliasUnset(out, outputOpts, result)
 			}
-			delete(aliases, name)
-			if err := saveAliases(path, aliases); err != nil {
+			result := aliasUnsetResult{Name: name, Removed: false}
+			return writeAliasUnset(out, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&file, "file", "", "Alias file path (default: ARC_TMUX_ALIASES or config dir)")
+	cmd.Flags().StringVar(&scope, "scope", "", "Scope to remove from (session|project|global; default: where it resolves)")
+	cmd.Flags().StringVar(&session, "session", "", "Session for session-scoped aliases (default: current session or ARC_TMUX_SESSION)")
+	return cmd
+}
+
+type aliasGCResult struct {
+	DryRun  bool         `json:"dry_run" yaml:"dry_run"`
+	Removed []aliasEntry `json:"removed" yaml:"removed"`
+}
+
+func newAliasGCCmd() *cobra.Command {
+	var file string
+	var scope string
+	var session string
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove aliases whose pane no longer exists",
+		Long: `Remove aliases that point at panes which no longer exist, e.g. panes closed
+outside arc-tmux. Requires a running tmux server so live panes can be told apart
+from dangling ones.`,
+		Example: `  arc-tmux alias gc --dry-run
+  arc-tmux alias gc --scope global`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			var stores []aliasStore
+			if scope == "" || scope == aliasScopeAll {
+				stores = aliasStores(file, session)
+			} else {
+				store, err := aliasStoreFor(scope, file, session)
+				if err != nil {
+					return err
+				}
+				stores = []aliasStore{store}
+			}
+			removed, err := pruneAliases(stores, danglingAliasTarget, dryRun)
+			if err != nil {
 				return err
 			}
-			result := aliasUnsetResult{Name: name, Removed: true}
-			return writeAliasUnset(out, outputOpts, result)
+			result := aliasGCResult{DryRun: dryRun, Removed: removed}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				for _, entry := range result.Removed {
+					_, _ = fmt.Fprintln(out, entry.Name)
+				}
+				return nil
+			}
+			if len(result.Removed) == 0 {
+				_, _ = fmt.Fprintln(out, "No dangling aliases.")
+				return nil
+			}
+			prefix := ""
+			if dryRun {
+				prefix = "[dry-run] "
+			}
+			for _, entry := range result.Removed {
+				_, _ = fmt.Fprintf(out, "%sRemoved alias %s => %s (%s)\n", prefix, entry.Name, entry.Target, entry.Scope)
+			}
+			return nil
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
 	cmd.Flags().StringVar(&file, "file", "", "Alias file path (default: ARC_TMUX_ALIASES or config dir)")
+	cmd.Flags().StringVar(&scope, "scope", aliasScopeAll, "Scope to clean (session|project|global|all)")
+	cmd.Flags().StringVar(&session, "session", "", "Session for session-scoped aliases (default: current session or ARC_TMUX_SESSION)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the aliases that would be removed without saving")
 	return cmd
 }
 
+// danglingAliasTarget reports whether target no longer resolves to a pane. It
+// fails without a running server, where every target would look dangling.
+func danglingAliasTarget(target string) (bool, error) {
+	_, err := tmux.ResolveTarget(target)
+	if errors.Is(err, tmux.ErrNoTmuxServer) {
+		return false, err
+	}
+	return err != nil, nil
+}
+
 func newAliasResolveCmd() *cobra.Command {
 	var outputOpts output.OutputOptions
 	var file string
+	var session string
 
 	cmd := &cobra.Command{
-		Use:   "resolve <name>",
-		Short: "Resolve an alias to a pane id",
-		Args:  cobra.MinimumNArgs(1),
+		Use:               "resolve <name>",
+		Short:             "Resolve an alias to a pane id",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeAliasNames,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
@@ -211,17 +563,14 @@ func newAliasResolveCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			path := aliasPath(file)
-			aliases, err := loadAliases(path)
+			entry, ok, err := lookupAlias(name, file, session)
 			if err != nil {
 				return err
 			}
-			target, ok := aliases[name]
 			if !ok {
 				return fmt.Errorf("alias %s not found", name)
 			}
 
-			entry := aliasEntry{Name: name, Target: target}
 			out := cmd.OutOrStdout()
 			switch {
 			case outputOpts.Is(output.OutputJSON):
@@ -236,13 +585,14 @@ func newAliasResolveCmd() *cobra.Command {
 				_, _ = fmt.Fprintln(out, entry.Target)
 				return nil
 			}
-			_, _ = fmt.Fprintf(out, "%s => %s\n", entry.Name, entry.Target)
+			_, _ = fmt.Fprintf(out, "%s => %s (%s)\n", entry.Name, entry.Target, entry.Scope)
 			return nil
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
 	cmd.Flags().StringVar(&file, "file", "", "Alias file path (default: ARC_TMUX_ALIASES or config dir)")
+	cmd.Flags().StringVar(&session, "session", "", "Session for session-scoped aliases (default: current session or ARC_TMUX_SESSION)")
 	return cmd
 }
 
@@ -256,6 +606,7 @@ func aliasPath(file string) string {
 type aliasUnsetResult struct {
 	Name    string `json:"name" yaml:"name"`
 	Removed bool   `json:"removed" yaml:"removed"`
+	Scope   string `json:"scope,omitempty" yaml:"scope,omitempty"`
 }
 
 func writeAliasUnset(out interface{ Write([]byte) (int, error) }, outputOpts output.OutputOptions, result aliasUnsetResult) error {
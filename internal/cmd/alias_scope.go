@@ -0,0 +1,215 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+const (
+	aliasScopeSession = "session"
+	aliasScopeProject = "project"
+	aliasScopeGlobal  = "global"
+	aliasScopeAll     = "all"
+)
+
+// sessionAliasOption holds a session's aliases as a JSON object.
+const sessionAliasOption = "@arc_tmux_aliases"
+
+// projectAliasFile is looked up from the working directory upwards.
+const projectAliasFile = ".arc-tmux/aliases.json"
+
+// aliasStore is one alias scope. Session aliases live in a tmux session option
+// and disappear with the session; project and global aliases live in files.
+type aliasStore struct {
+	Scope   string
+	Session string
+	Path    string
+}
+
+func (s aliasStore) load() (map[string]string, error) {
+	if s.Scope != aliasScopeSession {
+		return loadAliases(s.Path)
+	}
+	aliases := make(map[string]string)
+	raw, ok, err := tmux.SessionOption(s.Session, sessionAliasOption)
+	if err != nil || !ok {
+		return aliases, err
+	}
+	if err := json.Unmarshal([]byte(raw), &aliases); err != nil {
+		return nil, fmt.Errorf("session %s aliases: %w", s.Session, err)
+	}
+	return aliases, nil
+}
+
+// save writes aliases and journals the scope's previous contents for undo.
+func (s aliasStore) save(aliases map[string]string) error {
+	previous, prevErr := s.load()
+	if err := s.write(aliases); err != nil {
+		return err
+	}
+	if prevErr == nil {
+		recordAliasUndo(s, previous, aliases)
+	}
+	return nil
+}
+
+func (s aliasStore) write(aliases map[string]string) error {
+	if s.Scope != aliasScopeSession {
+		return saveAliases(s.Path, aliases)
+	}
+	if len(aliases) == 0 {
+		return tmux.UnsetSessionOption(s.Session, sessionAliasOption)
+	}
+	data, err := json.Marshal(aliases)
+	if err != nil {
+		return err
+	}
+	return tmux.SetSessionOption(s.Session, sessionAliasOption, string(data))
+}
+
+// findProjectAliasFile returns the nearest project alias file at or above dir.
+func findProjectAliasFile(dir string) (string, bool) {
+	for {
+		path := filepath.Join(dir, projectAliasFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// aliasStores returns the available stores in resolution order: session
+// (the given or current session), project, then global.
+func aliasStores(file string, session string) []aliasStore {
+	var stores []aliasStore
+	if session == "" {
+		session = defaultPaneSession()
+	}
+	if session != "" {
+		stores = append(stores, aliasStore{Scope: aliasScopeSession, Session: session})
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if path, ok := findProjectAliasFile(cwd); ok {
+			stores = append(stores, aliasStore{Scope: aliasScopeProject, Path: path})
+		}
+	}
+	return append(stores, aliasStore{Scope: aliasScopeGlobal, Path: aliasPath(file)})
+}
+
+// aliasStoreFor returns the store a write to scope goes to. A project store is
+// created in the working directory when no project file exists yet.
+func aliasStoreFor(scope string, file string, session string) (aliasStore, error) {
+	switch scope {
+	case "", aliasScopeGlobal:
+		return aliasStore{Scope: aliasScopeGlobal, Path: aliasPath(file)}, nil
+	case aliasScopeSession:
+		sess, err := defaultPaneSessionArg(session)
+		if err != nil {
+			return aliasStore{}, err
+		}
+		return aliasStore{Scope: aliasScopeSession, Session: sess}, nil
+	case aliasScopeProject:
+		cwd, err := os.Getwd()
+		if err != nil {
+			return aliasStore{}, err
+		}
+		path, ok := findProjectAliasFile(cwd)
+		if !ok {
+			path = filepath.Join(cwd, projectAliasFile)
+		}
+		return aliasStore{Scope: aliasScopeProject, Path: path}, nil
+	default:
+		return aliasStore{}, fmt.Errorf("invalid --scope %q (session|project|global)", scope)
+	}
+}
+
+// lookupAlias finds name in the first store that defines it.
+func lookupAlias(name string, file string, session string) (aliasEntry, bool, error) {
+	for _, store := range aliasStores(file, session) {
+		aliases, err := store.load()
+		if err != nil {
+			return aliasEntry{}, false, err
+		}
+		if target, ok := aliases[name]; ok {
+			return aliasEntry{Name: name, Target: target, Scope: store.Scope}, true, nil
+		}
+	}
+	return aliasEntry{}, false, nil
+}
+
+// listAliases returns entries from every store (scope "all") or a single one,
+// ordered by name and then resolution order.
+func listAliases(scope string, file string, session string) ([]aliasEntry, error) {
+	var stores []aliasStore
+	if scope == "" || scope == aliasScopeAll {
+		stores = aliasStores(file, session)
+	} else {
+		store, err := aliasStoreFor(scope, file, session)
+		if err != nil {
+			return nil, err
+		}
+		stores = []aliasStore{store}
+	}
+	entries := []aliasEntry{}
+	for _, store := range stores {
+		aliases, err := store.load()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range aliasesToEntries(aliases) {
+			entry.Scope = store.Scope
+			entries = append(entries, entry)
+		}
+	}
+	sortAliasEntries(entries)
+	return entries, nil
+}
+
+// pruneAliases removes the aliases whose target matches from each store and
+// returns them. Session stores that cannot be read (typically because the
+// session is gone) are skipped; with dryRun nothing is saved.
+func pruneAliases(stores []aliasStore, match func(target string) (bool, error), dryRun bool) ([]aliasEntry, error) {
+	removed := []aliasEntry{}
+	for _, store := range stores {
+		aliases, err := store.load()
+		if err != nil {
+			if store.Scope == aliasScopeSession {
+				continue
+			}
+			return removed, err
+		}
+		changed := false
+		for _, entry := range aliasesToEntries(aliases) {
+			ok, err := match(entry.Target)
+			if err != nil {
+				return removed, err
+			}
+			if !ok {
+				continue
+			}
+			entry.Scope = store.Scope
+			removed = append(removed, entry)
+			delete(aliases, entry.Name)
+			changed = true
+		}
+		// Dropping aliases of closed panes is housekeeping (gc, kill, and
+		// tmux hooks all do it), so it bypasses the undo journal.
+		if changed && !dryRun {
+			if err := store.write(aliases); err != nil {
+				return removed, err
+			}
+		}
+	}
+	return removed, nil
+}
@@ -16,6 +16,7 @@ import (
 type aliasEntry struct {
 	Name   string `json:"name" yaml:"name"`
 	Target string `json:"target" yaml:"target"`
+	Scope  string `json:"scope,omitempty" yaml:"scope,omitempty"`
 }
 
 func defaultAliasFile() string {
@@ -31,6 +32,8 @@ func defaultAliasFile() string {
 	return "aliases.json"
 }
 
+// normalizeAliasName lowercases and validates an alias name. A name may carry
+// one namespace prefix ("web:api") so projects can share short names.
 func normalizeAliasName(name string) (string, error) {
 	trimmed := strings.TrimSpace(name)
 	if trimmed == "" {
@@ -38,16 +41,36 @@ func normalizeAliasName(name string) (string, error) {
 	}
 	trimmed = strings.TrimPrefix(trimmed, "@")
 	trimmed = strings.ToLower(trimmed)
-	if trimmed == "current" || trimmed == "active" {
+	if ns, alias, ok := strings.Cut(trimmed, ":"); ok {
+		if ns == "" || alias == "" || strings.Contains(alias, ":") {
+			return "", fmt.Errorf("invalid alias name: %q", name)
+		}
+		if !validAliasChars(ns) || !validAliasChars(alias) {
+			return "", fmt.Errorf("invalid alias name: %q", name)
+		}
+		return trimmed, nil
+	}
+	switch trimmed {
+	case "current", "active", "last", "up", "down", "left", "right":
 		return "", fmt.Errorf("alias %q is reserved", trimmed)
 	}
-	for _, r := range trimmed {
+	if strings.HasPrefix(trimmed, "current+") || strings.HasPrefix(trimmed, "current-") {
+		return "", fmt.Errorf("alias %q is reserved", trimmed)
+	}
+	if !validAliasChars(trimmed) {
+		return "", fmt.Errorf("invalid alias name: %q", name)
+	}
+	return trimmed, nil
+}
+
+func validAliasChars(name string) bool {
+	for _, r := range name {
 		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
 			continue
 		}
-		return "", fmt.Errorf("invalid alias name: %q", name)
+		return false
 	}
-	return trimmed, nil
+	return true
 }
 
 func loadAliases(path string) (map[string]string, error) {
@@ -87,6 +110,12 @@ func aliasesToEntries(aliases map[string]string) []aliasEntry {
 	for name, target := range aliases {
 		entries = append(entries, aliasEntry{Name: name, Target: target})
 	}
-	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	sortAliasEntries(entries)
 	return entries
 }
+
+// sortAliasEntries orders entries by name, keeping the existing order (scope
+// precedence) for equal names.
+func sortAliasEntries(entries []aliasEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+}
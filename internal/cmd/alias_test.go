@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
 )
 
 func TestNormalizeAliasName(t *testing.T) {
@@ -41,3 +43,127 @@ func TestAliasLoadSave(t *testing.T) {
 		t.Fatalf("expected empty aliases, got %#v", loaded)
 	}
 }
+
+func TestNormalizeAliasNameNamespace(t *testing.T) {
+	name, err := normalizeAliasName("@Web:API")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "web:api" {
+		t.Fatalf("unexpected normalized name: %s", name)
+	}
+	for _, bad := range []string{"web:", ":api", "a:b:c", "web:a b"} {
+		if _, err := normalizeAliasName(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+	if _, err := normalizeAliasName("web:current"); err != nil {
+		t.Fatalf("namespaced names should not be reserved: %v", err)
+	}
+}
+
+func TestLookupAliasPrefersProjectOverGlobal(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("ARC_TMUX_SESSION", "")
+	root := t.TempDir()
+	global := filepath.Join(root, "global.json")
+	t.Setenv("ARC_TMUX_ALIASES", global)
+	if err := saveAliases(global, map[string]string{"api": "dev:1.0", "db": "dev:2.0"}); err != nil {
+		t.Fatalf("save global: %v", err)
+	}
+	if err := saveAliases(filepath.Join(root, projectAliasFile), map[string]string{"api": "web:0.0"}); err != nil {
+		t.Fatalf("save project: %v", err)
+	}
+	sub := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	wd, _ := os.Getwd()
+	if err := os.Chdir(sub); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	entry, ok, err := lookupAlias("api", "", "")
+	if err != nil || !ok {
+		t.Fatalf("lookup api: ok=%v err=%v", ok, err)
+	}
+	if entry.Target != "web:0.0" || entry.Scope != aliasScopeProject {
+		t.Fatalf("expected project alias, got %+v", entry)
+	}
+	entry, ok, _ = lookupAlias("db", "", "")
+	if !ok || entry.Scope != aliasScopeGlobal {
+		t.Fatalf("expected global fallback, got %+v", entry)
+	}
+
+	entries, err := listAliases(aliasScopeAll, "", "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Scope != aliasScopeProject || entries[1].Scope != aliasScopeGlobal {
+		t.Fatalf("unexpected listing order: %+v", entries)
+	}
+}
+
+func TestAliasNameFromTitle(t *testing.T) {
+	cases := map[string]string{
+		"server":      "server",
+		"Web Server":  "web-server",
+		"api (v2)!":   "api-v2",
+		"db_primary":  "db_primary",
+		"  --tests--": "tests",
+	}
+	for title, want := range cases {
+		if got := aliasNameFromTitle(title); got != want {
+			t.Fatalf("aliasNameFromTitle(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestAliasesFromPanes(t *testing.T) {
+	panes := []tmux.PaneDetails{
+		{Session: "dev", WindowIndex: 2, PaneIndex: 1, Title: "worker"},
+		{Session: "dev", WindowIndex: 2, PaneIndex: 0, Title: "Server"},
+		{Session: "dev", WindowIndex: 2, PaneIndex: 2, Title: "worker"},
+		{Session: "dev", WindowIndex: 2, PaneIndex: 3, Title: "box.example.com"},
+		{Session: "dev", WindowIndex: 2, PaneIndex: 4, Title: "current"},
+	}
+	entries, skipped := aliasesFromPanes(panes, "", "box.example.com")
+	if len(entries) != 1 || entries[0].Name != "server" || entries[0].Target != "dev:2.0" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if len(skipped) != 4 {
+		t.Fatalf("expected 4 skipped panes, got %+v", skipped)
+	}
+
+	entries, _ = aliasesFromPanes(panes[:2], "web", "")
+	if len(entries) != 2 || entries[0].Name != "web:server" || entries[1].Name != "web:worker" {
+		t.Fatalf("unexpected namespaced entries: %+v", entries)
+	}
+}
+
+func TestPruneAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	if err := saveAliases(path, map[string]string{"api": "dev:1.0", "web": "dev:1.1", "db": "%7"}); err != nil {
+		t.Fatalf("saveAliases error: %v", err)
+	}
+	stores := []aliasStore{{Scope: aliasScopeGlobal, Path: path}}
+	match := func(target string) (bool, error) { return target == "dev:1.0" || target == "%7", nil }
+
+	removed, err := pruneAliases(stores, match, true)
+	if err != nil || len(removed) != 2 {
+		t.Fatalf("expected 2 dry-run removals, got %v %v", removed, err)
+	}
+	if loaded, _ := loadAliases(path); len(loaded) != 3 {
+		t.Fatalf("dry run should not save, got %#v", loaded)
+	}
+
+	removed, err = pruneAliases(stores, match, false)
+	if err != nil || len(removed) != 2 || removed[0].Name != "api" || removed[0].Scope != aliasScopeGlobal {
+		t.Fatalf("unexpected removals: %v %v", removed, err)
+	}
+	loaded, _ := loadAliases(path)
+	if len(loaded) != 1 || loaded["web"] != "dev:1.1" {
+		t.Fatalf("expected only web to remain, got %#v", loaded)
+	}
+}
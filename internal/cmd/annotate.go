@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// annotateRule frames a marker line so it stands out in a pane and in
+// captures.
+const annotateRule = "―――"
+
+// annotateSettle bounds how long annotate waits for the marker to show up in
+// the pane before checkpointing it.
+const annotateSettle = 2 * time.Second
+
+type annotateResult struct {
+	Pane   string    `json:"pane" yaml:"pane"`
+	Marker string    `json:"marker" yaml:"marker"`
+	Via    string    `json:"via" yaml:"via"`
+	Time   time.Time `json:"time" yaml:"time"`
+	// Checkpoint is the checkpoint recorded right after the marker, usable
+	// with "diff --since" and "checkpoint compare".
+	Checkpoint string `json:"checkpoint" yaml:"checkpoint"`
+	Hash       string `json:"hash" yaml:"hash"`
+}
+
+var unsafeCheckpointChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+func newAnnotateCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var name string
+	var via string
+	var lines int
+
+	cmd := &cobra.Command{
+		Use:   "annotate [text]",
+		Short: "Print a timestamped marker line into a pane",
+		Long: `Print a marker line such as "――― deploy v1.2 started · 2025-06-01 14:03:07 ―――"
+into a pane and record a checkpoint right after it, so later captures and
+diffs can be split at the marker.
+
+--via tty (the default) writes the marker to the pane's terminal: it shows up
+whatever is running, and the program never reads it. --via send types a printf
+command instead, for panes at a shell prompt whose output is logged by the
+shell itself.
+
+The checkpoint is named --name, or after the text ("deploy-v1.2-started");
+"diff --since NAME" then shows what the pane printed after the marker.`,
+		Example: `  arc-tmux annotate "deploy v1.2 started" --pane @deploy
+  ./deploy.sh
+  arc-tmux diff --pane @deploy --since deploy-v1.2-started
+
+  arc-tmux annotate "retry 2" --pane @tests --name retry-2 --via send`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			text := strings.TrimSpace(strings.Join(args, " "))
+			if text == "" || strings.ContainsAny(text, "\r\n") {
+				return fmt.Errorf("annotation text must be a single non-empty line")
+			}
+			if strings.TrimSpace(name) == "" {
+				name = annotationCheckpointName(text)
+			}
+			normalized, err := normalizeCheckpointName(name)
+			if err != nil {
+				return err
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			marker := annotationMarker(text, now)
+			switch via {
+			case "tty":
+				details, err := tmux.PaneDetailsForTarget(handle.ID)
+				if err != nil {
+					return err
+				}
+				// Start on a fresh line so the marker is a line of its own.
+				prefix := ""
+				if details.CursorX > 0 {
+					prefix = "\r\n"
+				}
+				if err := tmux.WritePaneTerminal(handle.ID, prefix+"\x1b[1m"+marker+"\x1b[0m\r\n"); err != nil {
+					return err
+				}
+			case "send":
+				if err := tmux.SendLiteral(handle.ID, "printf '%s\\n' "+shellQuoteSingle(marker), true, 0); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("invalid --via %q (use tty or send)", via)
+			}
+			waitForMarker(handle.ID, marker, lines)
+
+			cp, err := snapshotPaneCheckpoint(normalized, handle, lines)
+			if err != nil {
+				return err
+			}
+			cp.Marker = marker
+			if err := saveCheckpoint(defaultCheckpointDir(), cp); err != nil {
+				return err
+			}
+			return writeAnnotateResult(cmd, outputOpts, annotateResult{
+				Pane:       handle.Target,
+				Marker:     marker,
+				Via:        via,
+				Time:       now.UTC().Truncate(time.Second),
+				Checkpoint: normalized,
+				Hash:       cp.Hash,
+			})
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().StringVar(&name, "name", "", "Checkpoint name (default: derived from the text)")
+	cmd.Flags().StringVar(&via, "via", "tty", "How to print the marker: tty (write to the pane's terminal) or send (type a printf command)")
+	cmd.Flags().IntVar(&lines, "lines", 200, "Limit capture to last N lines for hashing (0 for full)")
+	return cmd
+}
+
+// annotationMarker is the marker line for text at t.
+func annotationMarker(text string, t time.Time) string {
+	return fmt.Sprintf("%s %s · %s %s", annotateRule, text, t.Format("2006-01-02 15:04:05"), annotateRule)
+}
+
+// annotationCheckpointName derives a checkpoint name from annotation text:
+// lowercased, with runs of other characters turned into dashes.
+func annotationCheckpointName(text string) string {
+	name := unsafeCheckpointChars.ReplaceAllString(strings.ToLower(text), "-")
+	name = strings.Trim(name, "-._")
+	if len(name) > 64 {
+		name = strings.TrimRight(name[:64], "-._")
+	}
+	if name == "" {
+		name = fmt.Sprintf("annotation-%d", time.Now().Unix())
+	}
+	return name
+}
+
+// waitForMarker gives tmux a moment to draw the marker as a line of its own
+// (not just the printf that --via send typed), so the checkpoint taken next
+// includes it.
+func waitForMarker(paneID string, marker string, lines int) {
+	deadline := time.Now().Add(annotateSettle)
+	for time.Now().Before(deadline) {
+		if content, err := tmux.Capture(paneID, lines); err == nil {
+			for _, line := range splitLines(content) {
+				if strings.TrimSpace(line) == marker {
+					return
+				}
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func writeAnnotateResult(cmd *cobra.Command, outputOpts output.OutputOptions, result annotateResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		_, _ = fmt.Fprintln(out, result.Checkpoint)
+		return nil
+	}
+	_, _ = fmt.Fprintf(out, "Marked %s: %s\nSaved checkpoint %s; diff --since %s shows output after the marker.\n", result.Pane, result.Marker, result.Checkpoint, result.Checkpoint)
+	return nil
+}
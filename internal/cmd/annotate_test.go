@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnnotationCheckpointName(t *testing.T) {
+	cases := map[string]string{
+		"deploy v1.2 started": "deploy-v1.2-started",
+		"  Retry #2!  ":       "retry-2",
+		"――― ":                "",
+	}
+	for text, want := range cases {
+		got := annotationCheckpointName(text)
+		if want == "" {
+			if !strings.HasPrefix(got, "annotation-") {
+				t.Fatalf("%q: name = %q", text, got)
+			}
+			continue
+		}
+		if got != want {
+			t.Fatalf("%q: name = %q, want %q", text, got, want)
+		}
+		if _, err := normalizeCheckpointName(got); err != nil {
+			t.Fatalf("%q: %v", got, err)
+		}
+	}
+}
+
+func TestAnnotationMarker(t *testing.T) {
+	at := time.Date(2025, 6, 1, 14, 3, 7, 0, time.Local)
+	got := annotationMarker("deploy v1.2 started", at)
+	if got != "――― deploy v1.2 started · 2025-06-01 14:03:07 ―――" {
+		t.Fatalf("marker = %q", got)
+	}
+}
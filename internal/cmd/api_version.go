@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	minAPIVersion    = 1
+	latestAPIVersion = schemaVersion
+)
+
+// selectedAPIVersion is the output schema version for this invocation.
+var selectedAPIVersion = latestAPIVersion
+
+func addAPIVersionFlag(root *cobra.Command) {
+	root.PersistentFlags().Int("api-version", 0, fmt.Sprintf("Output schema version (%d-%d, default latest; env ARC_TMUX_API_VERSION)", minAPIVersion, latestAPIVersion))
+}
+
+// applyAPIVersion resolves --api-version/ARC_TMUX_API_VERSION and warns on stderr
+// when an older schema version is requested.
+func applyAPIVersion(cmd *cobra.Command) error {
+	flagSet, flagValue := false, 0
+	if f := cmd.Flags().Lookup("api-version"); f != nil && f.Changed {
+		flagSet = true
+		flagValue, _ = strconv.Atoi(f.Value.String())
+	}
+	v, err := resolveAPIVersion(flagSet, flagValue, os.Getenv("ARC_TMUX_API_VERSION"))
+	if err != nil {
+		return err
+	}
+	selectedAPIVersion = v
+	if v < latestAPIVersion {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: api version %d is deprecated; latest is %d\n", v, latestAPIVersion)
+	}
+	return nil
+}
+
+// resolveAPIVersion prefers an explicit --api-version over the environment and
+// falls back to the latest version when neither is set.
+func resolveAPIVersion(flagSet bool, flagValue int, env string) (int, error) {
+	v := flagValue
+	if !flagSet {
+		trimmed := strings.TrimSpace(env)
+		if trimmed == "" {
+			return latestAPIVersion, nil
+		}
+		parsed, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(trimmed), "v"))
+		if err != nil {
+			return 0, newCodedError(errUnsupportedAPI, fmt.Sprintf("invalid ARC_TMUX_API_VERSION %q", env), nil)
+		}
+		v = parsed
+	}
+	if v < minAPIVersion || v > latestAPIVersion {
+		return 0, newCodedError(errUnsupportedAPI, fmt.Sprintf("unsupported api version %d (supported %d-%d)", v, minAPIVersion, latestAPIVersion), nil)
+	}
+	return v, nil
+}
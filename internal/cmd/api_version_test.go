@@ -0,0 +1,27 @@
+package cmd
+
+import "testing"
+
+func TestResolveAPIVersion(t *testing.T) {
+	v, err := resolveAPIVersion(false, 0, "")
+	if err != nil || v != latestAPIVersion {
+		t.Fatalf("expected latest version, got %d (%v)", v, err)
+	}
+	v, err = resolveAPIVersion(false, 0, "v1")
+	if err != nil || v != 1 {
+		t.Fatalf("expected env version 1, got %d (%v)", v, err)
+	}
+	if _, err := resolveAPIVersion(true, latestAPIVersion+1, ""); err == nil {
+		t.Fatal("expected error for future api version")
+	}
+	if _, err := resolveAPIVersion(true, 0, "1"); err == nil {
+		t.Fatal("expected error for explicit --api-version 0")
+	}
+	v, err = resolveAPIVersion(true, 1, "banana")
+	if err != nil || v != 1 {
+		t.Fatalf("expected flag to override env, got %d (%v)", v, err)
+	}
+	if _, err := resolveAPIVersion(false, 0, "banana"); err == nil {
+		t.Fatal("expected error for invalid env value")
+	}
+}
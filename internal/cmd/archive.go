@@ -0,0 +1,241 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// sessionArchive is the manifest.json cleanup --archive writes next to the
+// captured scrollback.
+type sessionArchive struct {
+	Session    string          `json:"session" yaml:"session"`
+	ArchivedAt time.Time       `json:"archived_at" yaml:"archived_at"`
+	Actor      string          `json:"actor" yaml:"actor"`
+	Windows    []archiveWindow `json:"windows" yaml:"windows"`
+	Aliases    []aliasEntry    `json:"aliases" yaml:"aliases"`
+	// InputLog and Transcript name the files holding the session's input
+	// audit records and transcript records, when there were any.
+	InputLog   string `json:"input_log,omitempty" yaml:"input_log,omitempty"`
+	Transcript string `json:"transcript,omitempty" yaml:"transcript,omitempty"`
+}
+
+type archiveWindow struct {
+	Index  int    `json:"index" yaml:"index"`
+	Name   string `json:"name" yaml:"name"`
+	Active bool   `json:"active" yaml:"active"`
+	// Layout is tmux's window_layout, which select-layout accepts.
+	Layout string        `json:"layout" yaml:"layout"`
+	Panes  []archivePane `json:"panes" yaml:"panes"`
+}
+
+type archivePane struct {
+	FormattedID string            `json:"formatted_id" yaml:"formatted_id"`
+	PaneID      string            `json:"pane_id" yaml:"pane_id"`
+	Active      bool              `json:"active" yaml:"active"`
+	Command     string            `json:"command" yaml:"command"`
+	Title       string            `json:"title,omitempty" yaml:"title,omitempty"`
+	Path        string            `json:"path" yaml:"path"`
+	PID         int               `json:"pid" yaml:"pid"`
+	Dead        bool              `json:"dead,omitempty" yaml:"dead,omitempty"`
+	DeadStatus  int               `json:"dead_status,omitempty" yaml:"dead_status,omitempty"`
+	LastCommand *tmux.HookCommand `json:"last_command,omitempty" yaml:"last_command,omitempty"`
+	// Scrollback names the file with the pane's history and screen.
+	Scrollback string `json:"scrollback" yaml:"scrollback"`
+	Lines      int    `json:"lines" yaml:"lines"`
+}
+
+var unsafeArchiveChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// resolveCleanupArchive picks the archive directory from --archive or the
+// config's cleanup_archive, expanding a leading ~; "" means no archive.
+func resolveCleanupArchive(flag string, skip bool) string {
+	if skip {
+		return ""
+	}
+	dir := strings.TrimSpace(flag)
+	if dir == "" {
+		cfg, _ := loadConfig(defaultConfigFile())
+		dir = strings.TrimSpace(cfg.CleanupArchive)
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+		}
+	}
+	return dir
+}
+
+// archiveSession writes session's panes, layout, and metadata, with the input
+// audit and transcript records that touch it, into a new directory under dir
+// and returns its path.
+func archiveSession(cmd *cobra.Command, dir string, session string) (string, error) {
+	now := time.Now().UTC()
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s", unsafeArchiveChars.ReplaceAllString(session, "_"), now.Format("20060102T150405Z")))
+	if err := os.MkdirAll(filepath.Join(path, "panes"), 0o700); err != nil {
+		return "", err
+	}
+
+	wins, err := tmux.ListWindows(session)
+	if err != nil {
+		return "", err
+	}
+	panes, err := tmux.ListPanesDetailed()
+	if err != nil {
+		return "", err
+	}
+	archive := sessionArchive{
+		Session:    session,
+		ArchivedAt: now,
+		Actor:      currentActor,
+		Windows:    []archiveWindow{},
+		Aliases:    []aliasEntry{},
+	}
+	sort.Slice(wins, func(i, j int) bool { return wins[i].WindowIndex < wins[j].WindowIndex })
+	paneIDs := map[string]bool{}
+	for _, w := range wins {
+		window := archiveWindow{Index: w.WindowIndex, Name: w.Name, Active: w.Active, Panes: []archivePane{}}
+		for _, p := range panes {
+			if p.Session != session || p.WindowIndex != w.WindowIndex {
+				continue
+			}
+			if window.Layout == "" {
+				if window.Layout, err = tmux.WindowLayout(p.PaneID); err != nil {
+					return "", err
+				}
+			}
+			// -S -N with the whole history size takes every line of
+			// scrollback as well as the screen.
+			capture, err := tmux.Capture(p.PaneID, p.HistorySize)
+			if err != nil {
+				return "", err
+			}
+			name := filepath.Join("panes", fmt.Sprintf("%d.%d.txt", p.WindowIndex, p.PaneIndex))
+			if err := os.WriteFile(filepath.Join(path, name), []byte(capture), 0o600); err != nil {
+				return "", err
+			}
+			window.Panes = append(window.Panes, archivePane{
+				FormattedID: fmt.Sprintf("%s:%d.%d", p.Session, p.WindowIndex, p.PaneIndex),
+				PaneID:      p.PaneID,
+				Active:      p.Active,
+				Command:     p.Command,
+				Title:       p.Title,
+				Path:        p.Path,
+				PID:         p.PID,
+				Dead:        p.Dead,
+				DeadStatus:  p.DeadStatus,
+				LastCommand: p.LastCommand,
+				Scrollback:  name,
+				Lines:       len(splitLines(capture)),
+			})
+			paneIDs[p.PaneID] = true
+		}
+		archive.Windows = append(archive.Windows, window)
+	}
+
+	aliases, err := listAliases(aliasScopeAll, "", session)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range aliases {
+		if a.Scope == aliasScopeSession || paneIDs[a.Target] || strings.HasPrefix(a.Target, session+":") {
+			archive.Aliases = append(archive.Aliases, a)
+		}
+	}
+
+	if auditPath := defaultAuditFile(); auditPath != "" {
+		var inputs []inputAuditEntry
+		for id := range paneIDs {
+			entries, err := loadAuditEntries(auditPath, id, time.Time{})
+			if err != nil {
+				return "", err
+			}
+			inputs = append(inputs, entries...)
+		}
+		sort.SliceStable(inputs, func(i, j int) bool { return inputs[i].Time.Before(inputs[j].Time) })
+		if len(inputs) > 0 {
+			archive.InputLog = "input.jsonl"
+			err := writeArchiveLines(filepath.Join(path, archive.InputLog), func(enc *json.Encoder) error {
+				for _, e := range inputs {
+					if err := enc.Encode(e); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if transcript := configuredTranscript(cmd); transcript != "" {
+		entries, err := loadTranscriptEntries(transcriptPath(transcript, []string{session + ":"}), func(e transcriptEntry) bool {
+			return transcriptTouchesSession(e, session, paneIDs)
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(entries) > 0 {
+			archive.Transcript = "transcript.jsonl"
+			err := writeArchiveLines(filepath.Join(path, archive.Transcript), func(enc *json.Encoder) error {
+				for _, e := range entries {
+					if err := enc.Encode(e); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(path, "manifest.json"), append(data, '\n'), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// transcriptTouchesSession reports whether a transcript record targeted a
+// pane in session; targets read "session:window.pane (%id)".
+func transcriptTouchesSession(e transcriptEntry, session string, paneIDs map[string]bool) bool {
+	for _, target := range e.Targets {
+		if strings.HasPrefix(target, session+":") {
+			return true
+		}
+		if i := strings.LastIndex(target, "(%"); i >= 0 && paneIDs[strings.TrimSuffix(target[i+1:], ")")] {
+			return true
+		}
+	}
+	return false
+}
+
+// writeArchiveLines creates path and lets write encode its JSON lines.
+func writeArchiveLines(path string, write func(enc *json.Encoder) error) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := write(json.NewEncoder(f)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
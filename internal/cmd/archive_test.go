@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTranscriptTouchesSession(t *testing.T) {
+	ids := map[string]bool{"%7": true}
+	cases := []struct {
+		targets []string
+		want    bool
+	}{
+		{[]string{"dev:0.1 (%3)"}, true},
+		{[]string{"renamed:1.0 (%7)"}, true},
+		{[]string{"dev2:0.0 (%9)"}, false},
+		{nil, false},
+	}
+	for _, c := range cases {
+		if got := transcriptTouchesSession(transcriptEntry{Targets: c.targets}, "dev", ids); got != c.want {
+			t.Fatalf("targets %v: got %v, want %v", c.targets, got, c.want)
+		}
+	}
+}
+
+func TestLoadTranscriptEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "t.jsonl")
+	if entries, err := loadTranscriptEntries(path, func(transcriptEntry) bool { return true }); err != nil || len(entries) != 0 {
+		t.Fatalf("missing transcript: %v %v", entries, err)
+	}
+	data := `{"command":"arc-tmux send","targets":["dev:0.0 (%1)"]}
+not json
+{"command":"arc-tmux capture","targets":["ops:0.0 (%2)"]}
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := loadTranscriptEntries(path, func(e transcriptEntry) bool {
+		return transcriptTouchesSession(e, "dev", nil)
+	})
+	if err != nil || len(entries) != 1 || entries[0].Command != "arc-tmux send" {
+		t.Fatalf("unexpected entries %+v (%v)", entries, err)
+	}
+}
+
+func TestResolveCleanupArchive(t *testing.T) {
+	dir := t.TempDir()
+	cfg := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(cfg, []byte("cleanup_archive: ~/archives\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ARC_TMUX_CONFIG", cfg)
+	t.Setenv("HOME", dir)
+	if got := resolveCleanupArchive("", false); got != filepath.Join(dir, "archives") {
+		t.Fatalf("config default = %q", got)
+	}
+	if got := resolveCleanupArchive("/srv/archive", false); got != "/srv/archive" {
+		t.Fatalf("flag = %q", got)
+	}
+	if got := resolveCleanupArchive("", true); got != "" {
+		t.Fatalf("--no-archive = %q", got)
+	}
+}
@@ -80,14 +80,34 @@ func newCleanupCmd() *cobra.Command {
 	var session string
 	var yes bool
 	var dryRun bool
+	var archiveDir string
+	var noArchive bool
+	var trash bool
+	var trashTTL string
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
 		Use:   "cleanup",
 		Short: "Kill managed tmux session",
-		Long:  "Force-kill the managed tmux session (defaults to 'arc-tmux').",
+		Long: `Force-kill the managed tmux session (defaults to 'arc-tmux').
+
+With --archive DIR, or cleanup_archive in the config, the session is first
+archived into a new directory under DIR named after the session and the time:
+every pane's full scrollback (panes/WINDOW.PANE.txt), and a manifest.json with
+each window's layout, each pane's command, path, and last shell-hook command,
+and the aliases pointing into the session, plus the session's records from the
+input audit log (input.jsonl) and the transcript (transcript.jsonl). If the
+archive cannot be written the session is left running. --no-archive skips the
+configured archive.
+
+With --trash, or cleanup_trash_ttl in the config, the session is not killed:
+its clients are detached and it is renamed into the trash (trash-NAME-EPOCH),
+where "arc-tmux undo" can restore it until --trash-ttl (default 24h) passes.
+Each cleanup and undo kills trashed sessions whose TTL has passed.`,
 		Example: `  arc-tmux cleanup
-  arc-tmux cleanup --session fe --yes`,
+  arc-tmux cleanup --session fe --yes
+  arc-tmux cleanup --session fe --archive ~/arc-archives --yes
+  arc-tmux cleanup --session fe --trash --trash-ttl 2h --yes`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
@@ -102,12 +122,25 @@ func newCleanupCmd() *cobra.Command {
 			}
 			session = resolved
 
+			archiveDir = resolveCleanupArchive(archiveDir, noArchive)
+			toTrash, ttl, err := resolveCleanupTrash(cmd, trash, trashTTL)
+			if err != nil {
+				return err
+			}
+			// A session already in the trash is killed outright.
+			if strings.HasPrefix(session, tmux.TrashPrefix) {
+				toTrash = false
+			}
 			if dryRun {
-				return writeCleanupResult(cmd, outputOpts, cleanupResult{Session: session, DryRun: true})
+				return writeCleanupResult(cmd, outputOpts, cleanupResult{Session: session, DryRun: true, Archive: archiveDir, Trash: toTrash})
 			}
 
 			if !yes {
-				ok, err := confirmPrompt(cmd, fmt.Sprintf("Kill tmux session %q? [y/N]: ", session))
+				question := fmt.Sprintf("Kill tmux session %q? [y/N]: ", session)
+				if toTrash {
+					question = fmt.Sprintf("Move tmux session %q to the trash? [y/N]: ", session)
+				}
+				ok, err := confirmPrompt(cmd, question)
 				if err != nil {
 					return err
 				}
@@ -117,10 +150,36 @@ func newCleanupCmd() *cobra.Command {
 				}
 			}
 
+			purged, err := purgeExpiredTrash()
+			if err != nil {
+				return err
+			}
+			result := cleanupResult{Session: session, Purged: purged}
+			if archiveDir != "" {
+				path, err := archiveSession(cmd, archiveDir, session)
+				if err != nil {
+					return fmt.Errorf("archive session %q (not killed): %w", session, err)
+				}
+				result.Archive = path
+			}
+			if toTrash {
+				trashed, err := tmux.TrashSession(session, ttl)
+				if err != nil {
+					return err
+				}
+				recordUndo(undoOp{
+					Kind:    undoKindTrash,
+					Summary: fmt.Sprintf("moved session %s to the trash as %s", session, trashed.Name),
+					Trash:   &trashed,
+				})
+				result.Trashed = &trashed
+				return writeCleanupResult(cmd, outputOpts, result)
+			}
 			if err := tmux.Cleanup(session); err != nil {
 				return err
 			}
-			return writeCleanupResult(cmd, outputOpts, cleanupResult{Session: session, Killed: true})
+			result.Killed = true
+			return writeCleanupResult(cmd, outputOpts, result)
 		},
 	}
 
@@ -128,12 +187,20 @@ func newCleanupCmd() *cobra.Command {
 	cmd.Flags().StringVar(&session, "session", "", "Session to kill (default: arc-tmux)")
 	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview without killing")
+	cmd.Flags().StringVar(&archiveDir, "archive", "", "Archive the session's scrollback, layout, and logs under this directory before killing it (default: cleanup_archive in the config)")
+	cmd.Flags().BoolVar(&noArchive, "no-archive", false, "Skip the archive configured by cleanup_archive")
+	cmd.Flags().BoolVar(&trash, "trash", false, "Move the session to the trash, restorable with undo, instead of killing it (default: on when cleanup_trash_ttl is set)")
+	cmd.Flags().StringVar(&trashTTL, "trash-ttl", "24h", "With --trash, how long the session stays restorable (e.g. 30m, 24h, 7d; default: cleanup_trash_ttl)")
 
 	return cmd
 }
 
 func newLaunchCmd() *cobra.Command {
 	var split string
+	var targetPane string
+	var before bool
+	var after bool
+	var fullWidth bool
 	var session string
 	var cwd string
 	var envVars []string
@@ -144,9 +211,18 @@ func newLaunchCmd() *cobra.Command {
 		Short: "Launch a shell or command in a new pane/window",
 		Long: `Launch a new tmux pane/window and immediately run a command.
 
-Inside tmux: splits the current window.
+Inside tmux: splits the current pane.
 Outside tmux: ensures the managed session exists and opens a fresh window there.
-Commands are executed via "sh -lc", so full shell strings are supported.`,
+With --target-pane, splits that pane instead, inside tmux or out.
+
+--before puts the new pane left of or above the split pane instead of after
+it, and --full-width makes it span the whole window (full width with --split v,
+full height with --split h) rather than just the split pane. With
+--target-pane, where the pane lands depends only on the flags, not on which
+pane happens to be active.
+
+Commands are executed via "sh -lc" (see --wrap-shell and --login-shell), so
+full shell strings are supported.`,
 		Example: `  # Split current tmux window
   arc-tmux launch "htop" --split v
 
@@ -154,7 +230,10 @@ Commands are executed via "sh -lc", so full shell strings are supported.`,
   arc-tmux launch --cwd /srv/app --env NODE_ENV=development
 
   # Outside tmux, create/open the managed session
-  arc-tmux launch`,
+  arc-tmux launch
+
+  # Add a full-width log pane along the top of the dev window
+  arc-tmux launch "tail -f app.log" --target-pane dev:1.0 --split v --before --full-width`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := outputOpts.Resolve(); err != nil {
@@ -168,7 +247,32 @@ Commands are executed via "sh -lc", so full shell strings are supported.`,
 			if err != nil {
 				return newCodedError(errInvalidEnv, err.Error(), err)
 			}
-			command = buildRunCommand(command, strings.TrimSpace(cwd), envPairs)
+			startDir, err := resolveStartDir(cwd)
+			if err != nil {
+				return err
+			}
+			command = buildRunCommand(command, "", envPairs)
+			if before && after {
+				return fmt.Errorf("--before cannot be combined with --after")
+			}
+			placement := tmux.SplitPlacement{Before: before, Full: fullWidth}
+			if strings.TrimSpace(targetPane) != "" {
+				if strings.TrimSpace(session) != "" {
+					return fmt.Errorf("--target-pane cannot be combined with --session: the new pane goes in the target pane's window")
+				}
+				target, err := resolvePaneTarget(targetPane)
+				if err != nil {
+					return err
+				}
+				paneID, err := tmux.SplitWindowPlaced(target, split, 0, placement, command, startDir)
+				if err != nil {
+					return err
+				}
+				return writeLaunchResult(cmd, outputOpts, paneID, startDir)
+			}
+			if !tmux.InTmux() && (before || fullWidth) {
+				return fmt.Errorf("--before and --full-width place a split: run inside tmux or pass --target-pane")
+			}
 
 			sess := session
 			if !tmux.InTmux() && strings.TrimSpace(sess) == "" {
@@ -188,7 +292,7 @@ Commands are executed via "sh -lc", so full shell strings are supported.`,
 				}
 			}
 
-			paneID, err := tmux.Launch(sess, command, split)
+			paneID, err := tmux.LaunchPlaced(sess, command, split, placement, startDir)
 			if err != nil {
 				return err
 			}
@@ -200,32 +304,17 @@ Commands are executed via "sh -lc", so full shell strings are supported.`,
 				}
 			}
 
-			out := cmd.OutOrStdout()
-			switch {
-			case outputOpts.Is(output.OutputJSON):
-				result := launchResult{PaneID: paneID}
-				fillLaunchResult(&result, paneID)
-				enc := json.NewEncoder(out)
-				enc.SetIndent("", "  ")
-				return enc.Encode(result)
-			case outputOpts.Is(output.OutputYAML):
-				result := launchResult{PaneID: paneID}
-				fillLaunchResult(&result, paneID)
-				enc := yaml.NewEncoder(out)
-				defer func() { _ = enc.Close() }()
-				return enc.Encode(result)
-			case outputOpts.Is(output.OutputQuiet):
-				_, _ = fmt.Fprintln(out, paneID)
-				return nil
-			}
-			_, _ = fmt.Fprintln(out, paneID)
-			return nil
+			return writeLaunchResult(cmd, outputOpts, paneID, startDir)
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
-	cmd.Flags().StringVar(&split, "split", "", "Inside tmux: split direction (h|v)")
-	cmd.Flags().StringVar(&session, "session", "", "Managed session name when outside tmux")
+	cmd.Flags().StringVar(&split, "split", "", "Inside tmux or with --target-pane: split direction (h|v)")
+	cmd.Flags().StringVar(&targetPane, "target-pane", "", "Split this pane instead of the current one")
+	cmd.Flags().BoolVar(&before, "before", false, "Put the new pane left of or above the split pane")
+	cmd.Flags().BoolVar(&after, "after", false, "Put the new pane right of or below the split pane (the default)")
+	cmd.Flags().BoolVar(&fullWidth, "full-width", false, "Span the whole window instead of the split pane (full height with --split h)")
+	cmd.Flags().StringVar(&session, "session", "", "Managed session name when outside tmux (not with --target-pane)")
 	cmd.Flags().StringVar(&cwd, "cwd", "", "Start the new pane/window in this working directory")
 	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Set environment variables for the new pane (KEY=VAL). Repeatable.")
 
@@ -235,6 +324,7 @@ Commands are executed via "sh -lc", so full shell strings are supported.`,
 func newWindowsCmd() *cobra.Command {
 	var session string
 	var outputOpts output.OutputOptions
+	var porcelain bool
 
 	cmd := &cobra.Command{
 		Use:   "windows",
@@ -246,6 +336,9 @@ func newWindowsCmd() *cobra.Command {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
+			if err := checkPorcelain(cmd, porcelain); err != nil {
+				return err
+			}
 			if session != "" {
 				resolved, err := resolveSessionTarget(session)
 				if err != nil {
@@ -267,6 +360,9 @@ func newWindowsCmd() *cobra.Command {
 
 			wins, err := tmux.ListWindows(session)
 			if err != nil {
+				if porcelain && (errors.Is(err, tmux.ErrNoTmuxServer) || errors.Is(err, tmux.ErrSessionNotFound)) {
+					return nil
+				}
 				if errors.Is(err, tmux.ErrNoTmuxServer) {
 					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
 					return nil
@@ -280,6 +376,8 @@ func newWindowsCmd() *cobra.Command {
 
 			out := cmd.OutOrStdout()
 			switch {
+			case porcelain:
+				return writePorcelain(out, windowPorcelainRows(wins))
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
@@ -300,35 +398,27 @@ func newWindowsCmd() *cobra.Command {
 				return nil
 			}
 
-			bySess := map[string][]tmux.Window{}
+			sort.Slice(wins, func(i, j int) bool {
+				if wins[i].Session != wins[j].Session {
+					return wins[i].Session < wins[j].Session
+				}
+				return wins[i].WindowIndex < wins[j].WindowIndex
+			})
+			table := newTextTable("WINDOW", "STATE", "NAME")
 			for _, w := range wins {
-				bySess[w.Session] = append(bySess[w.Session], w)
-			}
-
-			sessions := make([]string, 0, len(bySess))
-			for s := range bySess {
-				sessions = append(sessions, s)
-			}
-			sort.Strings(sessions)
-
-			for _, s := range sessions {
-				_, _ = fmt.Fprintf(out, "%s:\n", s)
-				ws := bySess[s]
-				sort.Slice(ws, func(i, j int) bool { return ws[i].WindowIndex < ws[j].WindowIndex })
-				for _, w := range ws {
-					status := "inactive"
-					if w.Active {
-						status = "active"
-					}
-					_, _ = fmt.Fprintf(out, "  %d  (%s)  %s\n", w.WindowIndex, status, w.Name)
+				state := styledCell("inactive", styleMuted)
+				if w.Active {
+					state = styledCell("active", styleActive)
 				}
+				table.addRow(cell(fmt.Sprintf("%s:%d", w.Session, w.WindowIndex)), state, cell(w.Name))
 			}
-			return nil
+			return table.render(out)
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
 	cmd.Flags().StringVar(&session, "session", "", "Session name or selector (@current|@managed)")
+	addPorcelainFlag(cmd, "windows", &porcelain)
 
 	return cmd
 }
@@ -337,6 +427,9 @@ func resolveManagedSession() string {
 	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_SESSION")); env != "" {
 		return env
 	}
+	if cfg, err := loadConfig(defaultConfigFile()); err == nil && strings.TrimSpace(cfg.Session) != "" {
+		return strings.TrimSpace(cfg.Session)
+	}
 	return "arc-tmux"
 }
 
@@ -344,6 +437,16 @@ type cleanupResult struct {
 	Session string `json:"session" yaml:"session"`
 	DryRun  bool   `json:"dry_run" yaml:"dry_run"`
 	Killed  bool   `json:"killed" yaml:"killed"`
+	// Archive is the directory the session was archived into; on a dry run,
+	// the directory it would be archived under.
+	Archive string `json:"archive,omitempty" yaml:"archive,omitempty"`
+	// Trash is set on a dry run that would move the session to the trash.
+	Trash bool `json:"trash,omitempty" yaml:"trash,omitempty"`
+	// Trashed is the session's place in the trash when it was moved there
+	// instead of being killed.
+	Trashed *tmux.TrashedSession `json:"trashed,omitempty" yaml:"trashed,omitempty"`
+	// Purged names trashed sessions killed because their TTL passed.
+	Purged []string `json:"purged,omitempty" yaml:"purged,omitempty"`
 }
 
 func writeCleanupResult(cmd *cobra.Command, outputOpts output.OutputOptions, result cleanupResult) error {
@@ -360,10 +463,28 @@ func writeCleanupResult(cmd *cobra.Command, outputOpts output.OutputOptions, res
 	case outputOpts.Is(output.OutputQuiet):
 		return nil
 	}
+	for _, name := range result.Purged {
+		_, _ = fmt.Fprintf(out, "Purged expired trashed session %q\n", name)
+	}
 	if result.DryRun {
+		if result.Trash {
+			_, _ = fmt.Fprintf(out, "Dry run: would move tmux session %q to the trash\n", result.Session)
+			return nil
+		}
+		if result.Archive != "" {
+			_, _ = fmt.Fprintf(out, "Dry run: would archive tmux session %q under %s and kill it\n", result.Session, result.Archive)
+			return nil
+		}
 		_, _ = fmt.Fprintf(out, "Dry run: would kill tmux session %q\n", result.Session)
 		return nil
 	}
+	if result.Archive != "" {
+		_, _ = fmt.Fprintf(out, "Archived tmux session %q to %s\n", result.Session, result.Archive)
+	}
+	if result.Trashed != nil {
+		_, _ = fmt.Fprintf(out, "Moved tmux session %q to the trash as %q; arc-tmux undo restores it until %s\n", result.Session, result.Trashed.Name, formatTime(result.Trashed.ExpiresAt))
+		return nil
+	}
 	if result.Killed {
 		_, _ = fmt.Fprintf(out, "Killed tmux session %q\n", result.Session)
 		return nil
@@ -399,11 +520,36 @@ type launchResult struct {
 	Session     string `json:"session,omitempty" yaml:"session,omitempty"`
 	WindowIndex int    `json:"window_index,omitempty" yaml:"window_index,omitempty"`
 	PaneIndex   int    `json:"pane_index,omitempty" yaml:"pane_index,omitempty"`
+	Cwd         string `json:"cwd,omitempty" yaml:"cwd,omitempty"`
+}
+
+func writeLaunchResult(cmd *cobra.Command, outputOpts output.OutputOptions, paneID string, startDir string) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		result := launchResult{PaneID: paneID}
+		fillLaunchResult(&result, paneID, startDir)
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		result := launchResult{PaneID: paneID}
+		fillLaunchResult(&result, paneID, startDir)
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		_, _ = fmt.Fprintln(out, paneID)
+		return nil
+	}
+	_, _ = fmt.Fprintln(out, paneID)
+	return nil
 }
 
-func fillLaunchResult(result *launchResult, paneID string) {
+func fillLaunchResult(result *launchResult, paneID string, startDir string) {
 	session, window, pane := parseFormattedPaneID(paneID)
 	result.Session = session
 	result.WindowIndex = window
 	result.PaneIndex = pane
+	result.Cwd = settledPanePath(paneID, startDir)
 }
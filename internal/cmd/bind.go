@@ -0,0 +1,403 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// Markers delimiting the block arc-tmux manages inside tmux.conf.
+const (
+	bindBlockStart = "# >>> arc-tmux bindings >>>"
+	bindBlockEnd   = "# <<< arc-tmux bindings <<<"
+)
+
+// tmuxBinding is one prefix-table key installed by arc-tmux. Args is the tmux
+// command bound to Key, kept as argv so it can be rendered into tmux.conf or
+// passed to "tmux bind-key" directly.
+type tmuxBinding struct {
+	Key         string   `json:"key" yaml:"key"`
+	Description string   `json:"description" yaml:"description"`
+	Args        []string `json:"args" yaml:"args"`
+}
+
+type bindResult struct {
+	Action   string        `json:"action" yaml:"action"`
+	Path     string        `json:"path,omitempty" yaml:"path,omitempty"`
+	Live     bool          `json:"live" yaml:"live"`
+	DryRun   bool          `json:"dry_run" yaml:"dry_run"`
+	Bindings []tmuxBinding `json:"bindings" yaml:"bindings"`
+	Hint     string        `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+func newBindCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bind",
+		Short: "Install tmux keybindings for arc-tmux",
+		Long: `Manage tmux keybindings that connect interactive tmux users to arc-tmux:
+
+  prefix+A  menu for the current pane (inspect, follow, capture, interrupt, ...)
+  prefix+F  follow the current pane in a popup
+  prefix+S  choose a pane in the managed session
+
+Bindings are written to a marked block in tmux.conf, so re-installing replaces
+them instead of appending duplicates. --live binds them on the running server
+without touching any file. Popups need tmux 3.2 or newer.`,
+		Example: `  arc-tmux bind install
+  arc-tmux bind install --live
+  arc-tmux bind show >> ~/.tmux.conf
+  arc-tmux bind uninstall`,
+	}
+
+	cmd.AddCommand(
+		newBindInstallCmd(),
+		newBindUninstallCmd(),
+		newBindShowCmd(),
+	)
+
+	return cmd
+}
+
+func newBindInstallCmd() *cobra.Command {
+	var tmuxConf string
+	var session string
+	var live bool
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Write the keybindings to tmux.conf (or bind them live)",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			sess, err := bindSessionArg(session)
+			if err != nil {
+				return err
+			}
+			bindings := defaultBindings(arcTmuxExecutable(), sess)
+			result := bindResult{Action: "install", Live: live, DryRun: dryRun, Bindings: bindings}
+			if live {
+				if !dryRun {
+					for _, binding := range bindings {
+						if err := tmux.BindKey(binding.Key, binding.Args...); err != nil {
+							return err
+						}
+					}
+				}
+				return writeBindResult(cmd, outputOpts, result)
+			}
+
+			if result.Path, err = bindConfArg(tmuxConf); err != nil {
+				return err
+			}
+			if !dryRun {
+				if err := writeBindBlock(result.Path, renderBindBlock(bindings)); err != nil {
+					return err
+				}
+			}
+			result.Hint = "reload with: tmux source-file " + result.Path
+			return writeBindResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&tmuxConf, "tmux-conf", "", "tmux.conf to write (default: ~/.config/tmux/tmux.conf or ~/.tmux.conf)")
+	cmd.Flags().StringVar(&session, "session", "", "Session shown by the pane chooser (default: managed session)")
+	cmd.Flags().BoolVar(&live, "live", false, "Bind keys on the running tmux server instead of writing tmux.conf")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the bindings without writing or binding them")
+	return cmd
+}
+
+func newBindUninstallCmd() *cobra.Command {
+	var tmuxConf string
+	var live bool
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the keybindings from tmux.conf (or unbind them live)",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			bindings := defaultBindings(arcTmuxExecutable(), resolveManagedSession())
+			result := bindResult{Action: "uninstall", Live: live, DryRun: dryRun, Bindings: bindings}
+			if live {
+				if !dryRun {
+					for _, binding := range bindings {
+						if err := tmux.UnbindKey(binding.Key); err != nil {
+							return err
+						}
+					}
+				}
+				return writeBindResult(cmd, outputOpts, result)
+			}
+
+			path, err := bindConfArg(tmuxConf)
+			if err != nil {
+				return err
+			}
+			result.Path = path
+			if !dryRun {
+				if err := writeBindBlock(path, ""); err != nil {
+					return err
+				}
+			}
+			result.Hint = "keys stay bound on running servers until tmux restarts or 'arc-tmux bind uninstall --live'"
+			return writeBindResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&tmuxConf, "tmux-conf", "", "tmux.conf to edit (default: ~/.config/tmux/tmux.conf or ~/.tmux.conf)")
+	cmd.Flags().BoolVar(&live, "live", false, "Unbind keys on the running tmux server instead of editing tmux.conf")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the bindings without editing or unbinding them")
+	return cmd
+}
+
+func newBindShowCmd() *cobra.Command {
+	var session string
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the tmux.conf snippet",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			sess, err := bindSessionArg(session)
+			if err != nil {
+				return err
+			}
+			bindings := defaultBindings(arcTmuxExecutable(), sess)
+			if outputOpts.Is(output.OutputTable) {
+				_, _ = fmt.Fprint(cmd.OutOrStdout(), renderBindBlock(bindings))
+				return nil
+			}
+			return writeBindResult(cmd, outputOpts, bindResult{Action: "show", DryRun: true, Bindings: bindings})
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Session shown by the pane chooser (default: managed session)")
+	return cmd
+}
+
+func bindSessionArg(session string) (string, error) {
+	sess := strings.TrimSpace(session)
+	if sess == "" {
+		return resolveManagedSession(), nil
+	}
+	if err := tmux.ValidateSessionName(sess); err != nil {
+		return "", newCodedError(errInvalidSession, err.Error(), nil)
+	}
+	return sess, nil
+}
+
+func bindConfArg(path string) (string, error) {
+	if strings.TrimSpace(path) != "" {
+		return path, nil
+	}
+	return defaultTmuxConf()
+}
+
+func writeBindResult(cmd *cobra.Command, outputOpts output.OutputOptions, result bindResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, binding := range result.Bindings {
+			_, _ = fmt.Fprintln(out, binding.Key)
+		}
+		return nil
+	}
+	for _, binding := range result.Bindings {
+		_, _ = fmt.Fprintf(out, "prefix+%-3s %s\n", binding.Key, binding.Description)
+	}
+	where := result.Path
+	if result.Live {
+		where = "running tmux server"
+	}
+	verb := "Installed in"
+	if result.Action == "uninstall" {
+		verb = "Removed from"
+	}
+	if result.DryRun {
+		verb = "Would update"
+	}
+	_, _ = fmt.Fprintf(out, "%s %s\n", verb, where)
+	if result.Hint != "" && !result.DryRun {
+		_, _ = fmt.Fprintln(out, result.Hint)
+	}
+	return nil
+}
+
+// defaultBindings returns the prefix-table bindings; exe is the arc-tmux
+// binary tmux should invoke and session the managed session the chooser shows.
+func defaultBindings(exe string, session string) []tmuxBinding {
+	popup := func(command string) string {
+		return fmt.Sprintf("display-popup -E -w 90%% -h 80%% \"%s\"", command)
+	}
+	run := func(command string) string {
+		return fmt.Sprintf("run-shell \"%s\"", command)
+	}
+	pane := "--pane #{pane_id}"
+	menu := []string{
+		"display-menu", "-T", "#[align=centre]arc-tmux", "-x", "P", "-y", "P",
+		"Inspect pane", "i", popup(exe + " inspect " + pane + " | less -R"),
+		"Follow pane", "f", popup(exe + " follow " + pane),
+		"Copy output to buffer", "c", run(exe + " capture " + pane + " --lines 0 | tmux load-buffer -"),
+		"Interrupt (Ctrl+C)", "C", run(exe + " interrupt " + pane),
+		"",
+		"Set as default pane", "d", run(exe + " default set " + pane),
+		"Alias panes by title", "a", run(exe + " alias set-from-window --session '#{session_name}' --window " + tmux.WindowIndexFormat() + " --scope session"),
+	}
+	return []tmuxBinding{
+		{Key: "A", Description: "Open the arc-tmux menu for the current pane", Args: menu},
+		{Key: "F", Description: "Follow the current pane in a popup (Ctrl+C closes it)", Args: []string{
+			"display-popup", "-E", "-w", "90%", "-h", "80%", exe + " follow " + pane,
+		}},
+		{Key: "S", Description: "Choose a pane in the " + session + " session", Args: []string{
+			"choose-tree", "-Z", "-f", "#{==:#{session_name}," + session + "}",
+		}},
+	}
+}
+
+// arcTmuxExecutable returns the absolute path of the running binary, falling
+// back to "arc-tmux" on PATH.
+func arcTmuxExecutable() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return "arc-tmux"
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if strings.ContainsAny(exe, " '\"") {
+		return "arc-tmux"
+	}
+	return exe
+}
+
+// defaultTmuxConf returns ~/.config/tmux/tmux.conf when it exists, else ~/.tmux.conf.
+func defaultTmuxConf() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	config := strings.TrimSpace(os.Getenv("XDG_CONFIG_HOME"))
+	if config == "" {
+		config = filepath.Join(home, ".config")
+	}
+	xdg := filepath.Join(config, "tmux", "tmux.conf")
+	if _, err := os.Stat(xdg); err == nil {
+		return xdg, nil
+	}
+	return filepath.Join(home, ".tmux.conf"), nil
+}
+
+// tmuxConfQuote quotes a tmux.conf argument. Single quotes are literal in
+// tmux.conf, so values containing one are double-quoted with escapes instead.
+func tmuxConfQuote(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(arg, " \t'\"#;$\\{}~") {
+		return arg
+	}
+	if !strings.Contains(arg, "'") {
+		return "'" + arg + "'"
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`)
+	return `"` + r.Replace(arg) + `"`
+}
+
+// renderBindBlock renders bindings as the marked tmux.conf block.
+func renderBindBlock(bindings []tmuxBinding) string {
+	var b strings.Builder
+	b.WriteString(bindBlockStart + "\n")
+	b.WriteString("# Managed by arc-tmux; edits inside this block are overwritten.\n")
+	for _, binding := range bindings {
+		b.WriteString("# prefix+" + binding.Key + ": " + binding.Description + "\n")
+		quoted := []string{"bind-key", tmuxConfQuote(binding.Key)}
+		for _, arg := range binding.Args {
+			quoted = append(quoted, tmuxConfQuote(arg))
+		}
+		b.WriteString(strings.Join(quoted, " ") + "\n")
+	}
+	b.WriteString(bindBlockEnd + "\n")
+	return b.String()
+}
+
+// replaceBindBlock returns conf with the managed block replaced by block (or
+// removed when block is ""), appending it when absent.
+func replaceBindBlock(conf string, block string) string {
+	return replaceConfBlock(conf, bindBlockStart, bindBlockEnd, block)
+}
+
+// writeBindBlock installs (or with block "" removes) the managed block in path.
+func writeBindBlock(path string, block string) error {
+	return writeConfBlock(path, bindBlockStart, bindBlockEnd, block)
+}
+
+// replaceConfBlock returns conf with the block delimited by the start and end
+// markers replaced by block (or removed when block is ""), appending it when
+// absent.
+func replaceConfBlock(conf string, startMarker string, endMarker string, block string) string {
+	start := strings.Index(conf, startMarker)
+	end := strings.Index(conf, endMarker)
+	if start >= 0 && end > start {
+		end += len(endMarker)
+		if end < len(conf) && conf[end] == '\n' {
+			end++
+		}
+		return conf[:start] + block + conf[end:]
+	}
+	if block == "" {
+		return conf
+	}
+	if conf != "" && !strings.HasSuffix(conf, "\n") {
+		conf += "\n"
+	}
+	if conf != "" {
+		conf += "\n"
+	}
+	return conf + block
+}
+
+// writeConfBlock installs (or with block "" removes) a marked block in path.
+func writeConfBlock(path string, startMarker string, endMarker string, block string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	updated := replaceConfBlock(string(data), startMarker, endMarker, block)
+	if updated == string(data) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(updated), 0o644)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReplaceBindBlock(t *testing.T) {
+	block := renderBindBlock(defaultBindings("/usr/local/bin/arc-tmux", "arc-tmux"))
+	conf := "set -g mouse on"
+
+	installed := replaceBindBlock(conf, block)
+	if !strings.HasPrefix(installed, "set -g mouse on\n\n"+bindBlockStart) {
+		t.Fatalf("expected block appended after existing config, got %q", installed)
+	}
+	if again := replaceBindBlock(installed, block); again != installed {
+		t.Fatalf("expected reinstall to be idempotent, got %q", again)
+	}
+	if removed := replaceBindBlock(installed+"set -g status off\n", ""); removed != "set -g mouse on\n\nset -g status off\n" {
+		t.Fatalf("unexpected config after removal: %q", removed)
+	}
+}
+
+func TestTmuxConfQuote(t *testing.T) {
+	cases := map[string]string{
+		"display-menu":                  "display-menu",
+		"":                              "''",
+		"#{pane_id}":                    "'#{pane_id}'",
+		`run-shell "x --session 'a b'"`: `"run-shell \"x --session 'a b'\""`,
+	}
+	for in, want := range cases {
+		if got := tmuxConfQuote(in); got != want {
+			t.Fatalf("tmuxConfQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
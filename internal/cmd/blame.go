@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"gopkg.in/yaml.v3"
+)
+
+// blameActor totals the input one actor sent to a pane.
+type blameActor struct {
+	Actor     string    `json:"actor" yaml:"actor"`
+	Inputs    int       `json:"inputs" yaml:"inputs"`
+	FirstAt   time.Time `json:"first_at" yaml:"first_at"`
+	LastAt    time.Time `json:"last_at" yaml:"last_at"`
+	LastInput string    `json:"last_input" yaml:"last_input"`
+}
+
+type blameResult struct {
+	PaneID string    `json:"pane_id" yaml:"pane_id"`
+	Since  time.Time `json:"since" yaml:"since"`
+	// Actors is sorted by most recent input first.
+	Actors []blameActor `json:"actors" yaml:"actors"`
+	// Recent is the last --limit inputs, oldest first.
+	Recent []inputAuditEntry `json:"recent" yaml:"recent"`
+}
+
+func newBlameCmd() *cobra.Command {
+	var paneArg string
+	var since string
+	var limit int
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "blame",
+		Short: "Show who sent recent input to a pane",
+		Long: `Summarize the input arc-tmux sent to a pane, by actor, so it is clear who
+typed what once several agents and people share a session.
+
+With the input audit log on, every command that sends text or keys to a pane
+records it with the acting identity: --actor, ARC_TMUX_ACTOR, or the OS user.
+The log is off by default, since it keeps what was typed; set audit_log in the
+config or ARC_TMUX_AUDIT_LOG to a file, or to "on" for input.jsonl under the
+user cache directory. It is readable only by you and follows log_rotation. Input typed
+directly into the pane is not in the log; see history for that.`,
+		Example: `  arc-tmux blame --pane @api
+  arc-tmux blame --pane dev:1.0 --since 2h --limit 20 -o json
+  ARC_TMUX_ACTOR=reviewer-bot arc-tmux send "git status" --pane @api`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			window, err := parseSince(since)
+			if err != nil {
+				return err
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			path := defaultAuditFile()
+			if path == "" {
+				return fmt.Errorf("the input audit log is off; set audit_log or ARC_TMUX_AUDIT_LOG to a file or \"on\"")
+			}
+			from := time.Now().Add(-window)
+			entries, err := loadAuditEntries(path, handle.ID, from)
+			if err != nil {
+				return err
+			}
+			result := summarizeBlame(entries, limit)
+			result.PaneID = handle.Target
+			result.Since = from.UTC()
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				for _, a := range result.Actors {
+					_, _ = fmt.Fprintln(out, a.Actor)
+				}
+				return nil
+			}
+
+			if len(result.Actors) == 0 {
+				_, _ = fmt.Fprintf(out, "No input sent to %s in the last %s.\n", result.PaneID, since)
+				return nil
+			}
+			table := newTextTable("ACTOR", "INPUTS", "LAST", "LAST INPUT")
+			for _, a := range result.Actors {
+				table.addRow(cell(a.Actor), cell(strconv.Itoa(a.Inputs)), cell(formatRelative(a.LastAt)), cell(a.LastInput))
+			}
+			if err := table.render(out); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(out)
+			recent := newTextTable("WHEN", "ACTOR", "COMMAND", "INPUT")
+			for _, e := range result.Recent {
+				recent.addRow(cell(formatRelative(e.Time)), cell(e.Actor), cell(e.Command), cell(describeAuditInput(e)))
+			}
+			return recent.render(out)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().StringVar(&since, "since", "24h", "How far back to look (e.g. 90m, 24h, 7d)")
+	cmd.Flags().IntVar(&limit, "limit", 10, "Number of recent inputs to list (0 for all)")
+	return cmd
+}
+
+// summarizeBlame totals entries (oldest first) by actor and keeps the last
+// limit of them.
+func summarizeBlame(entries []inputAuditEntry, limit int) blameResult {
+	byActor := map[string]*blameActor{}
+	for _, e := range entries {
+		a, ok := byActor[e.Actor]
+		if !ok {
+			a = &blameActor{Actor: e.Actor, FirstAt: e.Time}
+			byActor[e.Actor] = a
+		}
+		a.Inputs++
+		a.LastAt = e.Time
+		a.LastInput = describeAuditInput(e)
+	}
+	result := blameResult{Actors: make([]blameActor, 0, len(byActor)), Recent: entries}
+	for _, a := range byActor {
+		result.Actors = append(result.Actors, *a)
+	}
+	sort.Slice(result.Actors, func(i, j int) bool {
+		if !result.Actors[i].LastAt.Equal(result.Actors[j].LastAt) {
+			return result.Actors[i].LastAt.After(result.Actors[j].LastAt)
+		}
+		return result.Actors[i].Actor < result.Actors[j].Actor
+	})
+	if limit > 0 && len(result.Recent) > limit {
+		result.Recent = result.Recent[len(result.Recent)-limit:]
+	}
+	if result.Recent == nil {
+		result.Recent = []inputAuditEntry{}
+	}
+	return result
+}
+
+// describeAuditInput shows the text sent, or the keys when no text was.
+func describeAuditInput(e inputAuditEntry) string {
+	if e.Text != "" {
+		return e.Text
+	}
+	return strings.Join(e.Keys, " ")
+}
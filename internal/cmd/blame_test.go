@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditLogBlame(t *testing.T) {
+	t.Setenv("ARC_TMUX_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	path := filepath.Join(t.TempDir(), "input.jsonl")
+	base := time.Now().UTC().Add(-time.Hour)
+	for i, e := range []inputAuditEntry{
+		{Actor: "alice", Pane: "%1", Command: "arc-tmux send", Text: "make test", Keys: []string{"C-m"}},
+		{Actor: "agent-a", Pane: "%1", Command: "arc-tmux run", Text: "go vet ./...", Keys: []string{"C-m"}},
+		{Actor: "agent-b", Pane: "%2", Command: "arc-tmux send", Text: "ls"},
+		{Actor: "alice", Pane: "%1", Command: "arc-tmux interrupt", Keys: []string{"C-c"}},
+	} {
+		e.Time = base.Add(time.Duration(i) * time.Minute)
+		if err := appendAuditEntry(path, rotationPolicy{}, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := loadAuditEntries(path, "%1", base.Add(30*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries for %%1 after the first, got %+v", entries)
+	}
+
+	entries, err = loadAuditEntries(path, "%1", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := summarizeBlame(entries, 2)
+	if len(result.Actors) != 2 || result.Actors[0].Actor != "alice" || result.Actors[0].Inputs != 2 || result.Actors[0].LastInput != "C-c" {
+		t.Fatalf("unexpected actors: %+v", result.Actors)
+	}
+	if len(result.Recent) != 2 || result.Recent[0].Actor != "agent-a" {
+		t.Fatalf("unexpected recent inputs: %+v", result.Recent)
+	}
+}
+
+func TestAuditLogOptIn(t *testing.T) {
+	t.Setenv("ARC_TMUX_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv("ARC_TMUX_AUDIT_LOG", "")
+	if path := defaultAuditFile(); path != "" {
+		t.Fatalf("audit log should be off by default, got %q", path)
+	}
+	t.Setenv("ARC_TMUX_AUDIT_LOG", "on")
+	if path := defaultAuditFile(); !strings.HasSuffix(path, "input.jsonl") {
+		t.Fatalf("on should pick the default file, got %q", path)
+	}
+
+	path := filepath.Join(t.TempDir(), "input.jsonl")
+	if err := appendAuditEntry(path, rotationPolicy{}, inputAuditEntry{Actor: "alice", Pane: "%1", Text: "hunter2"}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("audit log mode = %o, want 600", perm)
+	}
+}
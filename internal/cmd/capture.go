@@ -4,8 +4,11 @@
 package cmd
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/yourorg/arc-sdk/output"
@@ -16,64 +19,296 @@ import (
 func newCaptureCmd() *cobra.Command {
 	var paneArg string
 	var lines int
+	var base64Out bool
+	var format string
+	var outPath string
+	var windowArg string
+	var layout bool
+	var compact bool
+	var outputLimit outputCap
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
 		Use:   "capture",
 		Short: "Capture output from a tmux pane",
-		Long:  "Capture the visible scrollback from a pane (default last 200 lines).",
+		Long: `Capture the visible scrollback from a pane (default last 200 lines).
+
+--base64 captures the pane byte for byte instead, with color and attribute
+escape sequences and trailing spaces kept, and returns it base64-encoded (in
+output_base64 with --output json), so curses screens and control sequences
+can be reproduced exactly. Cutting it could split an escape sequence, so the
+output caps (--max-lines, --max-bytes, --max-tokens) do not apply; use
+--lines to bound it.
+
+--format png or svg renders the pane's visible screen, colors included, into
+an image file (--out, default named after the pane, e.g. dev-2-0.png) for
+tickets, chat, and reports. PNG uses a built-in bitmap font covering ASCII
+and box drawing; SVG leaves glyphs to the viewer's monospace font.
+
+--window SESSION:WINDOW captures the window's active pane, whichever it is
+when the command runs. With --layout it captures every pane in the window
+instead, composing their visible screens as they are laid out, separators
+included, into one text grid or image: what someone attached to the window
+sees.
+
+--compact trims a text capture for reading by people or LLMs: spinner frames
+and runs of blank lines are dropped, and repeated lines (including prompts
+left by pressing Enter) collapse into one plus a "[previous line repeated N
+more times]" note.`,
 		Example: `  # Tail the last 50 lines
   arc-tmux capture --pane=fe:2.0 | tail -50
 
   # Save entire buffer
-  arc-tmux capture --pane=fe:2.0 --lines=0 > pane.log`,
+  arc-tmux capture --pane=fe:2.0 --lines=0 > pane.log
+
+  # Cap a huge scrollback, keeping its start and end
+  arc-tmux capture --pane=fe:2.0 --lines=0 --max-bytes 65536 --keep both -o json
+
+  # Fewer tokens when feeding a noisy pane to an LLM
+  arc-tmux capture --pane=fe:2.0 --lines=0 --compact
+
+  # Raw bytes, escape sequences included, for replaying elsewhere
+  arc-tmux capture --pane=fe:2.0 --base64 | base64 -d > screen.ans
+
+  # Screenshot a pane for a bug report
+  arc-tmux capture --pane=fe:2.0 --format png --out failing-tests.png
+
+  # Snapshot a whole window as laid out
+  arc-tmux capture --window dev:2 --layout
+  arc-tmux capture --window dev:api --layout --format png
+
+  # Capture every pane in a session
+  arc-tmux panes --session dev -o quiet | arc-tmux capture --pane - --lines 20`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-			target, err := resolvePaneTarget(paneArg)
-			if err != nil {
+			if err := outputLimit.validate(); err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			if err := checkBase64Caps(base64Out, outputLimit); err != nil {
 				return err
 			}
-
-			s, err := tmux.Capture(target, lines)
+			image, err := captureImageFormat(cmd, format, base64Out)
 			if err != nil {
 				return err
 			}
+			if image && !cmd.Flags().Changed("lines") {
+				lines = 0
+			}
+			var handles []tmux.PaneHandle
+			var bulk bool
+			var win paneWindow
+			if strings.TrimSpace(windowArg) != "" {
+				if strings.TrimSpace(paneArg) != "" {
+					return fmt.Errorf("use either --pane or --window, not both")
+				}
+				if win, err = resolveWindowArg(windowArg); err != nil {
+					return err
+				}
+				if !layout {
+					active, err := win.activePane()
+					if err != nil {
+						return err
+					}
+					handles = []tmux.PaneHandle{active}
+				}
+			} else {
+				if layout {
+					return fmt.Errorf("--layout requires --window")
+				}
+				if handles, bulk, err = resolvePaneTargets(cmd, paneArg); err != nil {
+					return err
+				}
+			}
+			if layout && base64Out {
+				return fmt.Errorf("--base64 cannot be combined with --layout")
+			}
+			if compact && (base64Out || image || layout) {
+				return fmt.Errorf("--compact applies to plain text captures only")
+			}
+			if strings.TrimSpace(outPath) != "" && (!image || bulk) {
+				return fmt.Errorf("--out requires --format png or svg and a single pane or --layout")
+			}
 
+			results := make([]captureResult, 0, len(handles)+1)
+			if layout {
+				result, err := captureWindowLayout(win, format, image, outPath, outputLimit)
+				if err != nil {
+					return err
+				}
+				results = append(results, result)
+			}
+			for _, h := range handles {
+				result := captureResult{PaneID: h.Target}
+				if image {
+					path := strings.TrimSpace(outPath)
+					if path == "" {
+						path = captureImageName(h.Target, format)
+					}
+					raw, err := tmux.CaptureRaw(h.ID, lines)
+					if err != nil {
+						return err
+					}
+					if err := writeScreenImage(parseANSIScreen(string(raw)), format, path); err != nil {
+						return err
+					}
+					result.Image = path
+				} else if base64Out {
+					raw, err := tmux.CaptureRaw(h.ID, lines)
+					if err != nil {
+						return err
+					}
+					result.OutputBase64 = base64.StdEncoding.EncodeToString(raw)
+				} else {
+					s, err := tmux.Capture(h.ID, lines)
+					if err != nil {
+						return err
+					}
+					if compact {
+						s = compactOutput(s)
+					}
+					result.Output, result.Truncated = outputLimit.apply(s)
+				}
+				results = append(results, result)
+			}
+
+			var doc any = results[0]
+			if bulk {
+				doc = results
+			}
 			out := cmd.OutOrStdout()
 			switch {
 			case outputOpts.Is(output.OutputJSON):
-				result := captureResult{PaneID: target, Output: s}
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
-				return enc.Encode(result)
+				return enc.Encode(doc)
 			case outputOpts.Is(output.OutputYAML):
-				result := captureResult{PaneID: target, Output: s}
 				enc := yaml.NewEncoder(out)
 				defer func() { _ = enc.Close() }()
-				return enc.Encode(result)
-			case outputOpts.Is(output.OutputQuiet):
-				_, err := fmt.Fprint(out, s)
-				return err
+				return enc.Encode(doc)
 			}
-			_, err = fmt.Fprint(out, s)
-			return err
+			for _, result := range results {
+				if bulk {
+					if _, err := fmt.Fprintf(out, "==> %s <==\n", result.PaneID); err != nil {
+						return err
+					}
+				}
+				if result.Image != "" {
+					if _, err := fmt.Fprintf(out, "Wrote %s\n", result.Image); err != nil {
+						return err
+					}
+					continue
+				}
+				if base64Out {
+					if _, err := fmt.Fprintln(out, result.OutputBase64); err != nil {
+						return err
+					}
+					continue
+				}
+				if _, err := fmt.Fprint(out, result.Output); err != nil {
+					return err
+				}
+			}
+			return nil
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
-	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, - for stdin)")
 	cmd.Flags().IntVar(&lines, "lines", 200, "Limit capture to last N lines (0 for full)")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().BoolVar(&base64Out, "base64", false, "Capture raw bytes (escape sequences and trailing spaces kept) and print them base64-encoded")
+	cmd.Flags().StringVar(&format, "format", "text", "Capture format: text, png, or svg (an image of the visible screen)")
+	cmd.Flags().StringVar(&outPath, "out", "", "Image file to write with --format png|svg (default: named after the pane)")
+	cmd.Flags().StringVar(&windowArg, "window", "", "Capture the active pane of this window, or all its panes with --layout (SESSION:WINDOW, index or name)")
+	cmd.Flags().BoolVar(&layout, "layout", false, "With --window, compose the panes as laid out into one text grid or image")
+	cmd.Flags().BoolVar(&compact, "compact", false, "Drop spinner frames and blank runs, and collapse repeated lines and prompts")
+	addOutputCapFlags(cmd, &outputLimit)
 
 	return cmd
 }
 
 type captureResult struct {
+	// PaneID is the captured pane, or the window with --layout.
 	PaneID string `json:"pane_id" yaml:"pane_id"`
 	Output string `json:"output" yaml:"output"`
+	// OutputBase64 holds the raw capture with --base64; Output is then empty.
+	OutputBase64 string `json:"output_base64,omitempty" yaml:"output_base64,omitempty"`
+	// Truncated is set when --max-bytes, --max-lines, or --max-tokens cut the output.
+	Truncated bool `json:"truncated" yaml:"truncated"`
+	// Image is the file written with --format png|svg.
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+}
+
+// checkBase64Caps rejects output caps with --base64: cutting the raw capture
+// could split an escape sequence.
+func checkBase64Caps(base64Out bool, c outputCap) error {
+	if base64Out && c.limited() {
+		return fmt.Errorf("--base64 cannot be combined with --max-lines, --max-bytes, or --max-tokens (it would cut escape sequences); use --lines")
+	}
+	return nil
+}
+
+// captureImageFormat validates --format and reports whether it renders an image.
+func captureImageFormat(cmd *cobra.Command, format string, base64Out bool) (bool, error) {
+	switch format {
+	case "text":
+		return false, nil
+	case "png", "svg":
+	default:
+		return false, fmt.Errorf("invalid --format %q (text|png|svg)", format)
+	}
+	if base64Out {
+		return false, fmt.Errorf("--base64 cannot be combined with --format %s", format)
+	}
+	if cmd.Flags().Changed("max-bytes") || cmd.Flags().Changed("max-lines") || cmd.Flags().Changed("max-tokens") {
+		return false, fmt.Errorf("--max-bytes, --max-lines, and --max-tokens do not apply to --format %s", format)
+	}
+	return true, nil
+}
+
+// captureImageName names an image after the pane: "dev:2.0" becomes "dev-2-0.png".
+func captureImageName(target string, format string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == ':' || r == '.' || r == '/' || r == ' ' {
+			return '-'
+		}
+		return r
+	}, target)
+	return name + "." + format
+}
+
+// captureWindowLayout captures w's panes composed as laid out, as text or
+// as an image written to outPath.
+func captureWindowLayout(w paneWindow, format string, image bool, outPath string, outputLimit outputCap) (captureResult, error) {
+	result := captureResult{PaneID: w.target()}
+	scr, err := captureWindowScreen(w)
+	if err != nil {
+		return result, err
+	}
+	if !image {
+		result.Output, result.Truncated = outputLimit.apply(screenText(scr))
+		return result, nil
+	}
+	result.Image = strings.TrimSpace(outPath)
+	if result.Image == "" {
+		result.Image = captureImageName(w.target(), format)
+	}
+	return result, writeScreenImage(scr, format, result.Image)
+}
+
+func writeScreenImage(scr screen, format string, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if format == "svg" {
+		err = renderScreenSVG(scr, f)
+	} else {
+		err = renderScreenPNG(scr, f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
 }
@@ -0,0 +1,17 @@
+package cmd
+
+import "testing"
+
+func TestCheckBase64Caps(t *testing.T) {
+	for _, c := range []outputCap{{MaxLines: 10}, {MaxBytes: 100}, {MaxTokens: 50}} {
+		if err := checkBase64Caps(true, c); err == nil {
+			t.Fatalf("expected --base64 with %+v to be rejected", c)
+		}
+		if err := checkBase64Caps(false, c); err != nil {
+			t.Fatalf("plain capture with %+v: %v", c, err)
+		}
+	}
+	if err := checkBase64Caps(true, outputCap{Keep: keepTail}); err != nil {
+		t.Fatalf("--base64 without caps: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// captureWindowScreen captures every pane in w and composes them as laid out.
+func captureWindowScreen(w paneWindow) (screen, error) {
+	screens := make([]screen, len(w.Panes))
+	for i, p := range w.Panes {
+		raw, err := tmux.CaptureRaw(p.ID, 0)
+		if err != nil {
+			return screen{}, err
+		}
+		screens[i] = parseANSIScreen(string(raw))
+	}
+	return composeWindowScreen(w.Panes, screens), nil
+}
+
+// composeWindowScreen places each pane's screen at its position in the window
+// and draws the separators tmux shows between panes in the cells no pane
+// covers.
+func composeWindowScreen(panes []tmux.PaneGeometry, screens []screen) screen {
+	width, height := 0, 0
+	for _, p := range panes {
+		if p.Left+p.Width > width {
+			width = p.Left + p.Width
+		}
+		if p.Top+p.Height > height {
+			height = p.Top + p.Height
+		}
+	}
+	rows := make([][]screenCell, height)
+	covered := make([][]bool, height)
+	for y := range rows {
+		rows[y] = make([]screenCell, width)
+		covered[y] = make([]bool, width)
+		for x := range rows[y] {
+			rows[y][x] = screenCell{Rune: ' '}
+		}
+	}
+	for i, p := range panes {
+		for dy := 0; dy < p.Height; dy++ {
+			for dx := 0; dx < p.Width; dx++ {
+				y, x := p.Top+dy, p.Left+dx
+				covered[y][x] = true
+				if dy < len(screens[i].Rows) && dx < len(screens[i].Rows[dy]) {
+					rows[y][x] = screens[i].Rows[dy][dx]
+				}
+			}
+		}
+	}
+	border := func(x int, y int) bool {
+		return x >= 0 && y >= 0 && x < width && y < height && !covered[y][x]
+	}
+	for y := range rows {
+		for x := range rows[y] {
+			if covered[y][x] {
+				continue
+			}
+			rows[y][x].Rune = separatorRune(border(x-1, y), border(x+1, y), border(x, y-1), border(x, y+1))
+		}
+	}
+	return screen{Rows: rows, Width: width}
+}
+
+// separatorRunes maps the arms of a separator cell (left, right, up, down)
+// to the box-drawing character joining them.
+var separatorRunes = map[[4]bool]rune{
+	{true, false, false, false}: '─', {false, true, false, false}: '─', {true, true, false, false}: '─',
+	{false, false, true, false}: '│', {false, false, false, true}: '│', {false, false, true, true}: '│',
+	{false, true, false, true}: '┌', {true, false, false, true}: '┐',
+	{false, true, true, false}: '└', {true, false, true, false}: '┘',
+	{false, true, true, true}: '├', {true, false, true, true}: '┤',
+	{true, true, false, true}: '┬', {true, true, true, false}: '┴',
+	{true, true, true, true}: '┼',
+}
+
+func separatorRune(left bool, right bool, up bool, down bool) rune {
+	if r, ok := separatorRunes[[4]bool{left, right, up, down}]; ok {
+		return r
+	}
+	return ' '
+}
+
+// screenText renders scr as plain text, trimming trailing spaces.
+func screenText(scr screen) string {
+	var b strings.Builder
+	for _, row := range scr.Rows {
+		line := make([]rune, len(row))
+		for i, cell := range row {
+			line[i] = cell.Rune
+		}
+		b.WriteString(strings.TrimRight(string(line), " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
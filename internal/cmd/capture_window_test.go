@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestComposeWindowScreen(t *testing.T) {
+	panes := []tmux.PaneGeometry{
+		{ID: "%1", Left: 0, Top: 0, Width: 3, Height: 3},
+		{ID: "%2", Left: 4, Top: 0, Width: 3, Height: 1},
+		{ID: "%3", Left: 4, Top: 2, Width: 3, Height: 1},
+	}
+	screens := []screen{
+		parseANSIScreen("abc\nd\n"),
+		parseANSIScreen("top\n"),
+		parseANSIScreen("bot\n"),
+	}
+	got := screenText(composeWindowScreen(panes, screens))
+	want := "abc│top\nd  ├───\n   │bot\n"
+	if got != want {
+		t.Fatalf("composed screen:\n%s\nwant:\n%s", got, want)
+	}
+}
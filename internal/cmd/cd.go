@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type cdResult struct {
+	PaneID   string `json:"pane_id" yaml:"pane_id"`
+	Path     string `json:"path" yaml:"path"`
+	Previous string `json:"previous" yaml:"previous"`
+}
+
+func newCdCmd() *cobra.Command {
+	var paneArg string
+	var timeout float64
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "cd <dir>",
+		Short: "Change a pane's working directory and verify it",
+		Long: `Change the working directory of the shell in a pane.
+
+The directory is resolved against the pane's current path (~ expands to your
+home), checked to exist, and sent as a quoted cd. arc-tmux then waits until
+tmux reports the new pane_current_path, failing with ERR_CWD_UNCHANGED if it
+never does, e.g. because a foreground program read the input.`,
+		Example: `  arc-tmux cd --pane=fe:2.0 /srv/app
+  arc-tmux cd --pane=@current ../api --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			pane, err := tmux.PaneDetailsForTarget(handle.ID)
+			if err != nil {
+				return err
+			}
+			if !isShellCommand(pane.Command) {
+				return newCodedError(errPaneBusy, fmt.Sprintf("pane %s is running %q; cd needs an idle shell", handle.Target, pane.Command), nil)
+			}
+			dir, err := resolvePaneDir(args[0], pane.Path)
+			if err != nil {
+				return err
+			}
+			result := cdResult{PaneID: handle.Target, Path: dir, Previous: pane.Path}
+			if !samePath(pane.Path, dir) {
+				if err := tmux.SendLiteral(handle.ID, " cd "+shellQuoteSingle(dir), true, 0); err != nil {
+					return err
+				}
+				if err := waitForPanePath(handle.ID, dir, time.Duration(timeout*float64(time.Second))); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				return nil
+			}
+			_, _ = fmt.Fprintf(out, "%s is now in %s\n", result.PaneID, result.Path)
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().Float64Var(&timeout, "timeout", 5, "Seconds to wait for tmux to report the new directory")
+	cmd.ValidArgsFunction = func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+
+	return cmd
+}
+
+// resolvePaneDir turns dir into the absolute, symlink-free path tmux will
+// report once the shell is there.
+func resolvePaneDir(dir string, panePath string) (string, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return "", fmt.Errorf("directory is required")
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expand %s: %w", dir, err)
+		}
+		dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+	}
+	if !filepath.IsAbs(dir) {
+		if panePath == "" {
+			return "", fmt.Errorf("pane path unknown; use an absolute directory")
+		}
+		dir = filepath.Join(panePath, dir)
+	}
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("directory %s: %w", dir, err)
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", fmt.Errorf("directory %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+	return resolved, nil
+}
+
+func samePath(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && ra == rb
+}
+
+func waitForPanePath(paneID string, dir string, timeout time.Duration) error {
+	deadline := time.Now().Add(tmux.BoundTimeout(timeout))
+	last := ""
+	for {
+		time.Sleep(100 * time.Millisecond)
+		pane, err := tmux.PaneDetailsForTarget(paneID)
+		if err != nil {
+			return err
+		}
+		last = pane.Path
+		if samePath(last, dir) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if tmux.DeadlineExceeded() {
+				return tmux.ErrDeadlineExceeded
+			}
+			return newCodedError(errCwdUnchanged, fmt.Sprintf("pane is still in %s, not %s; a foreground program may have read the input", last, dir), nil)
+		}
+	}
+}
+
+// settledPanePath returns the pane's working directory. tmux reports an empty
+// pane_current_path until a new pane's process has started, so it retries
+// briefly and falls back to fallback.
+func settledPanePath(paneID string, fallback string) string {
+	for i := 0; i < 10; i++ {
+		pane, err := tmux.PaneDetailsForTarget(paneID)
+		if err != nil {
+			break
+		}
+		if pane.Path != "" {
+			return pane.Path
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return fallback
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePaneDir(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "app", "web"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "app"), filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "file"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"web":                            filepath.Join(root, "app", "web"),
+		"../link/web":                    filepath.Join(root, "app", "web"),
+		filepath.Join(root, "app") + "/": filepath.Join(root, "app"),
+	}
+	for dir, want := range cases {
+		got, err := resolvePaneDir(dir, filepath.Join(root, "app"))
+		if err != nil || got != want {
+			t.Fatalf("resolvePaneDir(%q) = %q, %v; want %q", dir, got, err, want)
+		}
+	}
+	for _, dir := range []string{"", "missing", "../file"} {
+		if _, err := resolvePaneDir(dir, filepath.Join(root, "app")); err == nil {
+			t.Fatalf("resolvePaneDir(%q) expected error", dir)
+		}
+	}
+}
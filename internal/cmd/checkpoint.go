@@ -0,0 +1,288 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// checkpointFields are the pane state fields checkpoint compare checks.
+var checkpointFields = []string{"output", "cursor", "cwd", "command"}
+
+type checkpointResult struct {
+	Name    string            `json:"name" yaml:"name"`
+	Pane    string            `json:"pane" yaml:"pane"`
+	SavedAt time.Time         `json:"saved_at" yaml:"saved_at"`
+	Saved   bool              `json:"saved" yaml:"saved"`
+	Hash    string            `json:"hash" yaml:"hash"`
+	Cursor  *checkpointCursor `json:"cursor,omitempty" yaml:"cursor,omitempty"`
+	Path    string            `json:"path" yaml:"path"`
+	Command string            `json:"command" yaml:"command"`
+	// Changed and Changes are set by compare; the state fields above then
+	// describe the pane now.
+	Changed bool               `json:"changed" yaml:"changed"`
+	Changes []checkpointChange `json:"changes" yaml:"changes"`
+}
+
+type checkpointChange struct {
+	Field   string `json:"field" yaml:"field"`
+	Saved   string `json:"saved" yaml:"saved"`
+	Current string `json:"current" yaml:"current"`
+}
+
+func newCheckpointCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "checkpoint",
+		Short: "Save and compare named snapshots of pane state",
+		Long: `Record a pane's state under a name and check it later, so scripts can
+assert that nothing unexpected happened in the pane in between.
+
+A checkpoint holds the hash of the pane's output, the cursor position, the
+working directory, and the foreground command. Checkpoints share their store
+with "diff --save" (ARC_TMUX_CHECKPOINTS, default: the user cache dir), so
+"diff --since NAME" shows what the output change was.`,
+		Example: `  arc-tmux checkpoint save --pane @deploy --name pre-deploy
+  arc-tmux checkpoint compare --name pre-deploy
+  arc-tmux checkpoint compare --name pre-deploy --ignore cursor,output`,
+	}
+
+	cmd.AddCommand(
+		newCheckpointSaveCmd(),
+		newCheckpointCompareCmd(),
+	)
+
+	return cmd
+}
+
+func newCheckpointSaveCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var name string
+	var lines int
+
+	cmd := &cobra.Command{
+		Use:   "save",
+		Short: "Save a pane's state as a named checkpoint",
+		Long:  "Save the pane's output hash, cursor, working directory, and foreground command under --name, replacing any checkpoint of that name.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			normalized, err := normalizeCheckpointName(name)
+			if err != nil {
+				return err
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			cp, err := snapshotPaneCheckpoint(normalized, handle, lines)
+			if err != nil {
+				return err
+			}
+			if err := saveCheckpoint(defaultCheckpointDir(), cp); err != nil {
+				return err
+			}
+			result := newCheckpointResult(cp)
+			result.Saved = true
+			return writeCheckpointResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().StringVar(&name, "name", "", "Checkpoint name")
+	cmd.Flags().IntVar(&lines, "lines", 200, "Limit capture to last N lines for hashing (0 for full)")
+	return cmd
+}
+
+func newCheckpointCompareCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var name string
+	var lines int
+	var ignore []string
+
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare a pane with a named checkpoint",
+		Long: `Compare the pane's current state with a saved checkpoint and list what
+changed: output, cursor, cwd, or command. --pane defaults to the pane the
+checkpoint was taken from; --ignore skips fields. Any change fails with
+ERR_CHECKPOINT_CHANGED after the report is written.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			normalized, err := normalizeCheckpointName(name)
+			if err != nil {
+				return err
+			}
+			skip := map[string]bool{}
+			for _, field := range ignore {
+				field = strings.ToLower(strings.TrimSpace(field))
+				if !slices.Contains(checkpointFields, field) {
+					return fmt.Errorf("unknown --ignore field %q (use %s)", field, strings.Join(checkpointFields, ", "))
+				}
+				skip[field] = true
+			}
+			saved, ok, err := loadCheckpoint(defaultCheckpointDir(), normalized)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return newCodedError(errCheckpointNotFound, fmt.Sprintf("no checkpoint named %q", normalized), nil)
+			}
+			if strings.TrimSpace(paneArg) == "" {
+				paneArg = saved.PaneID
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			current, err := snapshotPaneCheckpoint(normalized, handle, lines)
+			if err != nil {
+				return err
+			}
+			result := newCheckpointResult(current)
+			result.SavedAt = saved.SavedAt
+			result.Changes = compareCheckpoints(saved, current, skip)
+			result.Changed = len(result.Changes) > 0
+			if err := writeCheckpointResult(cmd, outputOpts, result); err != nil {
+				return err
+			}
+			if result.Changed {
+				fields := make([]string, 0, len(result.Changes))
+				for _, c := range result.Changes {
+					fields = append(fields, c.Field)
+				}
+				return newCodedError(errCheckpointChanged, fmt.Sprintf("%s changed since checkpoint %s: %s", handle.Target, normalized, strings.Join(fields, ", ")), nil)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (default: the checkpoint's pane)")
+	cmd.Flags().StringVar(&name, "name", "", "Checkpoint name")
+	cmd.Flags().IntVar(&lines, "lines", 200, "Limit capture to last N lines for hashing (0 for full)")
+	cmd.Flags().StringSliceVar(&ignore, "ignore", nil, "Fields to skip: output, cursor, cwd, command")
+	return cmd
+}
+
+// snapshotPaneCheckpoint captures the state of handle as checkpoint name.
+func snapshotPaneCheckpoint(name string, handle tmux.PaneHandle, lines int) (paneCheckpoint, error) {
+	content, err := tmux.Capture(handle.ID, lines)
+	if err != nil {
+		return paneCheckpoint{}, err
+	}
+	details, err := tmux.PaneDetailsForTarget(handle.ID)
+	if err != nil {
+		return paneCheckpoint{}, err
+	}
+	return paneCheckpoint{
+		Name:    name,
+		Pane:    handle.Target,
+		PaneID:  handle.ID,
+		SavedAt: time.Now().UTC(),
+		Hash:    tmux.OutputHash(content),
+		Cursor:  &checkpointCursor{X: details.CursorX, Y: details.CursorY},
+		Path:    details.Path,
+		Command: details.Command,
+		Content: content,
+	}, nil
+}
+
+// compareCheckpoints lists the fields of current that differ from saved,
+// skipping fields saved did not record.
+func compareCheckpoints(saved paneCheckpoint, current paneCheckpoint, skip map[string]bool) []checkpointChange {
+	changes := []checkpointChange{}
+	savedHash := saved.Hash
+	if savedHash == "" {
+		savedHash = tmux.OutputHash(saved.Content)
+	}
+	if !skip["output"] && savedHash != current.Hash {
+		changes = append(changes, checkpointChange{Field: "output", Saved: savedHash, Current: current.Hash})
+	}
+	if !skip["cursor"] && saved.Cursor != nil && current.Cursor != nil && *saved.Cursor != *current.Cursor {
+		changes = append(changes, checkpointChange{Field: "cursor", Saved: saved.Cursor.String(), Current: current.Cursor.String()})
+	}
+	if !skip["cwd"] && saved.Path != "" && saved.Path != current.Path {
+		changes = append(changes, checkpointChange{Field: "cwd", Saved: saved.Path, Current: current.Path})
+	}
+	if !skip["command"] && saved.Command != "" && saved.Command != current.Command {
+		changes = append(changes, checkpointChange{Field: "command", Saved: saved.Command, Current: current.Command})
+	}
+	return changes
+}
+
+func (c checkpointCursor) String() string {
+	return fmt.Sprintf("%d,%d", c.X, c.Y)
+}
+
+func newCheckpointResult(cp paneCheckpoint) checkpointResult {
+	return checkpointResult{
+		Name:    cp.Name,
+		Pane:    cp.Pane,
+		SavedAt: cp.SavedAt,
+		Hash:    cp.Hash,
+		Cursor:  cp.Cursor,
+		Path:    cp.Path,
+		Command: cp.Command,
+		Changes: []checkpointChange{},
+	}
+}
+
+func writeCheckpointResult(cmd *cobra.Command, outputOpts output.OutputOptions, result checkpointResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		if result.Saved {
+			_, _ = fmt.Fprintln(out, result.Hash)
+			return nil
+		}
+		for _, c := range result.Changes {
+			_, _ = fmt.Fprintln(out, c.Field)
+		}
+		return nil
+	}
+	if result.Saved {
+		_, _ = fmt.Fprintf(out, "Saved checkpoint %s from %s (cursor %s, cwd %s, command %s).\n", result.Name, result.Pane, result.Cursor, result.Path, result.Command)
+		return nil
+	}
+	if !result.Changed {
+		_, _ = fmt.Fprintf(out, "Pane %s matches checkpoint %s (saved %s).\n", result.Pane, result.Name, result.SavedAt.Local().Format(time.RFC3339))
+		return nil
+	}
+	table := newTextTable("FIELD", "SAVED", "CURRENT")
+	for _, c := range result.Changes {
+		table.addRow(cell(c.Field), cell(c.Saved), cell(c.Current))
+	}
+	return table.render(out)
+}
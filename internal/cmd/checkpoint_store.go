@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// paneCheckpoint is a named capture of a pane saved for later comparison.
+// Checkpoints saved before the pane state fields existed leave them empty.
+type paneCheckpoint struct {
+	Name    string            `json:"name" yaml:"name"`
+	Pane    string            `json:"pane" yaml:"pane"`
+	PaneID  string            `json:"pane_id" yaml:"pane_id"`
+	SavedAt time.Time         `json:"saved_at" yaml:"saved_at"`
+	Hash    string            `json:"hash,omitempty" yaml:"hash,omitempty"`
+	Cursor  *checkpointCursor `json:"cursor,omitempty" yaml:"cursor,omitempty"`
+	Path    string            `json:"path,omitempty" yaml:"path,omitempty"`
+	Command string            `json:"command,omitempty" yaml:"command,omitempty"`
+	// Marker is the line annotate printed just before the checkpoint.
+	Marker  string `json:"marker,omitempty" yaml:"marker,omitempty"`
+	Content string `json:"content" yaml:"content"`
+}
+
+type checkpointCursor struct {
+	X int `json:"x" yaml:"x"`
+	Y int `json:"y" yaml:"y"`
+}
+
+var checkpointNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+func defaultCheckpointDir() string {
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_CHECKPOINTS")); env != "" {
+		return env
+	}
+	if dir, err := os.UserCacheDir(); err == nil && strings.TrimSpace(dir) != "" {
+		return filepath.Join(dir, "arc-tmux", "checkpoints")
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".arc-tmux-checkpoints")
+	}
+	return "checkpoints"
+}
+
+// normalizeCheckpointName lowercases and validates a checkpoint name, which
+// doubles as its file name.
+func normalizeCheckpointName(name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		return "", errors.New("checkpoint name is required")
+	}
+	if !checkpointNamePattern.MatchString(normalized) {
+		return "", fmt.Errorf("invalid checkpoint name %q (use letters, digits, '.', '_' or '-')", name)
+	}
+	return normalized, nil
+}
+
+func checkpointPath(dir string, name string) string {
+	return filepath.Join(dir, name+".json")
+}
+
+// saveCheckpoint writes cp owner-only; checkpoints hold full pane captures.
+func saveCheckpoint(dir string, cp paneCheckpoint) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, cp.Name+".json.*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), checkpointPath(dir, cp.Name)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// loadCheckpoint reads a checkpoint; ok is false when none is saved under name.
+func loadCheckpoint(dir string, name string) (paneCheckpoint, bool, error) {
+	data, err := os.ReadFile(checkpointPath(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return paneCheckpoint{}, false, nil
+		}
+		return paneCheckpoint{}, false, err
+	}
+	var cp paneCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return paneCheckpoint{}, false, fmt.Errorf("checkpoint %s: %w", name, err)
+	}
+	return cp, true, nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestCompareCheckpoints(t *testing.T) {
+	saved := paneCheckpoint{
+		Hash:    tmux.OutputHash("$ make\n"),
+		Cursor:  &checkpointCursor{X: 2, Y: 1},
+		Path:    "/srv/app",
+		Command: "bash",
+	}
+	current := saved
+	if changes := compareCheckpoints(saved, current, nil); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+
+	current.Hash = tmux.OutputHash("$ make\nok\n")
+	current.Cursor = &checkpointCursor{X: 2, Y: 2}
+	current.Command = "make"
+	changes := compareCheckpoints(saved, current, map[string]bool{"cursor": true})
+	if len(changes) != 2 || changes[0].Field != "output" || changes[1].Field != "command" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if changes[1].Saved != "bash" || changes[1].Current != "make" {
+		t.Fatalf("unexpected command change: %+v", changes[1])
+	}
+}
+
+func TestCompareCheckpointsFromDiffSave(t *testing.T) {
+	// Checkpoints saved by diff --save only carry the content.
+	saved := paneCheckpoint{Content: "$ ls\n"}
+	current := paneCheckpoint{
+		Hash:    tmux.OutputHash("$ ls\n"),
+		Cursor:  &checkpointCursor{X: 0, Y: 3},
+		Path:    "/tmp",
+		Command: "bash",
+	}
+	if changes := compareCheckpoints(saved, current, nil); len(changes) != 0 {
+		t.Fatalf("expected unrecorded fields to be skipped, got %+v", changes)
+	}
+}
+
+func TestSaveCheckpointIsOwnerOnly(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "checkpoints")
+	if err := saveCheckpoint(dir, paneCheckpoint{Name: "build", Content: "secret\n"}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o700 {
+		t.Fatalf("checkpoint dir mode = %o, want 700", perm)
+	}
+	info, err = os.Stat(checkpointPath(dir, "build"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("checkpoint mode = %o, want 600", perm)
+	}
+	cp, ok, err := loadCheckpoint(dir, "build")
+	if err != nil || !ok || cp.Content != "secret\n" {
+		t.Fatalf("unexpected checkpoint %+v ok=%v err=%v", cp, ok, err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	if len(matches) != 0 {
+		t.Fatalf("left temp files: %v", matches)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// colorDisabled is set by --no-color for this invocation.
+var colorDisabled bool
+
+type cellStyle int
+
+const (
+	styleNone cellStyle = iota
+	styleHeader
+	styleActive
+	styleMuted
+	styleWarn
+	styleError
+)
+
+var styleCodes = map[cellStyle]string{
+	styleHeader: "\x1b[1m",
+	styleActive: "\x1b[32m",
+	styleMuted:  "\x1b[2m",
+	styleWarn:   "\x1b[33m",
+	styleError:  "\x1b[31m",
+}
+
+func addColorFlag(root *cobra.Command) {
+	root.PersistentFlags().Bool("no-color", false, "Disable colored output (also NO_COLOR)")
+}
+
+// applyColor records --no-color for the table renderer and error output.
+func applyColor(cmd *cobra.Command) {
+	if f := cmd.Flags().Lookup("no-color"); f != nil && f.Changed {
+		colorDisabled = f.Value.String() == "true"
+	}
+}
+
+// colorEnabled reports whether out should receive ANSI styling: it must be a
+// terminal, and neither --no-color, NO_COLOR, nor TERM=dumb may be set.
+func colorEnabled(out io.Writer) bool {
+	if colorDisabled || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := out.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+func colorize(text string, style cellStyle, enabled bool) string {
+	code := styleCodes[style]
+	if !enabled || code == "" || text == "" {
+		return text
+	}
+	return code + text + "\x1b[0m"
+}
+
+// FormatError renders a command error for w, highlighting the ERR_* code.
+func FormatError(w io.Writer, err error) string {
+	msg := err.Error()
+	if !colorEnabled(w) {
+		return msg
+	}
+	if code, rest, ok := strings.Cut(msg, ":"); ok && strings.HasPrefix(code, "ERR_") {
+		return colorize(code, styleError, true) + ":" + rest
+	}
+	return colorize("error", styleError, true) + ": " + msg
+}
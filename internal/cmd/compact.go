@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// barePromptPattern matches a shell or REPL prompt with nothing typed after it.
+var barePromptPattern = regexp.MustCompile(`^\S.{0,79}[$#%>❯»]$|^(>>>|\.\.\.|In \[\d+\]:)$`)
+
+// spinnerGlyphs are the characters CLI spinners cycle through, besides the
+// braille patterns (U+2800-U+28FF).
+const spinnerGlyphs = "◐◓◑◒◴◷◶◵◰◳◲◱✶✸✹✺✷⏳⌛"
+
+// outputCompactor is the --compact pass over capture and follow output. It
+// drops spinner frames and blank-line runs and collapses repeated lines into
+// one line followed by a repeat note. Lines are fed in order; flush ends the
+// stream.
+type outputCompactor struct {
+	last         string
+	lastPrompt   bool
+	repeats      int
+	pendingBlank bool
+	emittedAny   bool
+}
+
+// compactOutput applies the --compact pass to a whole capture.
+func compactOutput(s string) string {
+	var c outputCompactor
+	var out []string
+	for _, line := range splitLines(s) {
+		out = append(out, c.feed(line)...)
+	}
+	out = append(out, c.flush()...)
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// feed takes the next line and returns the lines to emit now.
+func (c *outputCompactor) feed(line string) []string {
+	line = strings.TrimRight(line, " \t")
+	if strings.TrimSpace(line) == "" {
+		if c.emittedAny {
+			c.pendingBlank = true
+		}
+		return nil
+	}
+	key, spinner := spinnerKey(line)
+	if key == "" {
+		// A frame of a bare spinner.
+		return nil
+	}
+	if c.emittedAny && key == c.last {
+		if spinner {
+			// Another frame of the spinner already shown.
+			return nil
+		}
+		// Blank lines separate distinct output, except between identical
+		// prompts left by pressing Enter.
+		if !c.pendingBlank || c.lastPrompt {
+			c.repeats++
+			c.pendingBlank = false
+			return nil
+		}
+	}
+	out := c.repeatNote()
+	if c.pendingBlank {
+		out = append(out, "")
+		c.pendingBlank = false
+	}
+	c.last = key
+	c.lastPrompt = barePromptPattern.MatchString(line)
+	c.emittedAny = true
+	return append(out, line)
+}
+
+// flush returns the note for a repeat run still open at the end.
+func (c *outputCompactor) flush() []string {
+	c.pendingBlank = false
+	return c.repeatNote()
+}
+
+func (c *outputCompactor) repeatNote() []string {
+	if c.repeats == 0 {
+		return nil
+	}
+	n := c.repeats
+	c.repeats = 0
+	if n == 1 {
+		return []string{"[previous line repeated 1 more time]"}
+	}
+	return []string{fmt.Sprintf("[previous line repeated %d more times]", n)}
+}
+
+// spinnerKey returns line without a leading spinner glyph, so successive
+// spinner frames compare equal, and whether a glyph was removed. A lone
+// ASCII spinner character (| / - \) only counts when followed by a space.
+func spinnerKey(line string) (string, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	r, size := utf8.DecodeRuneInString(trimmed)
+	rest := trimmed[size:]
+	switch {
+	case isSpinnerGlyph(r):
+	case strings.ContainsRune(`|/-\`, r) && strings.HasPrefix(rest, " "):
+	default:
+		return line, false
+	}
+	return strings.TrimLeft(strings.TrimLeftFunc(rest, isSpinnerGlyph), " "), true
+}
+
+func isSpinnerGlyph(r rune) bool {
+	return (r >= 0x2800 && r <= 0x28FF) || strings.ContainsRune(spinnerGlyphs, r)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import "testing"
+
+func TestCompactOutput(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "repeated lines",
+			in:   "retrying\nretrying\nretrying\ndone\n",
+			want: "retrying\n[previous line repeated 2 more times]\ndone\n",
+		},
+		{
+			name: "spinner frames",
+			in:   "⠋ Installing\n⠙ Installing\n⠹ Installing\n⠸\n- Installing\nadded 12 packages\n",
+			want: "⠋ Installing\nadded 12 packages\n",
+		},
+		{
+			name: "repeated prompts across blank lines",
+			in:   "user@host:~$\n\nuser@host:~$\nuser@host:~$\n\n\n",
+			want: "user@host:~$\n[previous line repeated 2 more times]\n",
+		},
+		{
+			name: "blank runs squashed, separated output kept",
+			in:   "\n\nok\n\n\n\nok\nnext\n",
+			want: "ok\n\nok\nnext\n",
+		},
+		{
+			name: "separators are not spinners",
+			in:   "-\n----\n| a | b |\n",
+			want: "-\n----\n| a | b |\n",
+		},
+	}
+	for _, c := range cases {
+		if got := compactOutput(c.in); got != c.want {
+			t.Errorf("%s: compactOutput = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestOutputCompactorStream(t *testing.T) {
+	var c outputCompactor
+	var got []string
+	for _, batch := range [][]string{{"tick", "tick"}, {"tick"}, {"tock"}} {
+		for _, line := range batch {
+			got = append(got, c.feed(line)...)
+		}
+	}
+	got = append(got, c.flush()...)
+	want := []string{"tick", "[previous line repeated 2 more times]", "tock"}
+	if !equalSlice(got, want) {
+		t.Fatalf("stream = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+type completionInstallResult struct {
+	Shell  string `json:"shell" yaml:"shell"`
+	Path   string `json:"path" yaml:"path"`
+	DryRun bool   `json:"dry_run" yaml:"dry_run"`
+	Hint   string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+func newCompletionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate or install shell completions",
+		Long: `Generate shell completion scripts, or install them with "completion install".
+
+Completions are dynamic: --pane suggests live panes, selectors, and aliases;
+--session suggests running sessions and session selectors.`,
+		Example: `  arc-tmux completion zsh > "${fpath[1]}/_arc-tmux"
+  arc-tmux completion install
+  arc-tmux completion install --shell fish --dry-run`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: completionShells,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			return writeCompletionScript(cmd.Root(), args[0], cmd.OutOrStdout())
+		},
+	}
+
+	cmd.AddCommand(newCompletionInstallCmd())
+	return cmd
+}
+
+func newCompletionInstallCmd() *cobra.Command {
+	var shell string
+	var path string
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install shell completions for the current user",
+		Long: `Write the completion script to the conventional per-user location for the
+shell (detected from $SHELL unless --shell is given).
+
+  bash  $XDG_DATA_HOME/bash-completion/completions/arc-tmux
+  zsh   ~/.zfunc/_arc-tmux (add ~/.zfunc to fpath)
+  fish  $XDG_CONFIG_HOME/fish/completions/arc-tmux.fish`,
+		Example: `  arc-tmux completion install
+  arc-tmux completion install --shell zsh --path ~/.zsh/completions/_arc-tmux`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			result, err := installCompletions(cmd.Root(), shell, path, dryRun)
+			if err != nil {
+				return err
+			}
+			shell, target, hint := result.Shell, result.Path, result.Hint
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				_, _ = fmt.Fprintln(out, result.Path)
+				return nil
+			}
+			if dryRun {
+				_, _ = fmt.Fprintf(out, "[dry-run] Would install %s completions to %s\n", shell, target)
+			} else {
+				_, _ = fmt.Fprintf(out, "Installed %s completions to %s\n", shell, target)
+			}
+			if hint != "" {
+				_, _ = fmt.Fprintln(out, hint)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&shell, "shell", "", "Shell to install for: bash|zsh|fish (default: from $SHELL)")
+	cmd.Flags().StringVar(&path, "path", "", "Override the install path")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show where completions would be installed")
+	_ = cmd.RegisterFlagCompletionFunc("shell", cobra.FixedCompletions(completionShells[:3], cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+// installCompletions writes the completion script for shell (default: from
+// $SHELL) to path (default: the shell's per-user location).
+func installCompletions(root *cobra.Command, shell string, path string, dryRun bool) (completionInstallResult, error) {
+	if strings.TrimSpace(shell) == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+	}
+	shell = strings.ToLower(strings.TrimSpace(shell))
+
+	target := strings.TrimSpace(path)
+	hint := ""
+	if target == "" {
+		var err error
+		target, hint, err = defaultCompletionPath(shell)
+		if err != nil {
+			return completionInstallResult{}, err
+		}
+	}
+
+	var script bytes.Buffer
+	if err := writeCompletionScript(root, shell, &script); err != nil {
+		return completionInstallResult{}, err
+	}
+
+	result := completionInstallResult{Shell: shell, Path: target, DryRun: dryRun, Hint: hint}
+	if dryRun {
+		return result, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return completionInstallResult{}, err
+	}
+	if err := os.WriteFile(target, script.Bytes(), 0o644); err != nil {
+		return completionInstallResult{}, err
+	}
+	return result, nil
+}
+
+func writeCompletionScript(root *cobra.Command, shell string, out io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(out, true)
+	case "zsh":
+		return root.GenZshCompletion(out)
+	case "fish":
+		return root.GenFishCompletion(out, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(out)
+	default:
+		return fmt.Errorf("unsupported shell %q (expected one of: %s)", shell, strings.Join(completionShells, ", "))
+	}
+}
+
+func defaultCompletionPath(shell string) (string, string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", err
+	}
+	switch shell {
+	case "bash":
+		data := strings.TrimSpace(os.Getenv("XDG_DATA_HOME"))
+		if data == "" {
+			data = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(data, "bash-completion", "completions", "arc-tmux"), "", nil
+	case "zsh":
+		return filepath.Join(home, ".zfunc", "_arc-tmux"),
+			"Ensure ~/.zfunc is on fpath before compinit: fpath=(~/.zfunc $fpath)", nil
+	case "fish":
+		config := strings.TrimSpace(os.Getenv("XDG_CONFIG_HOME"))
+		if config == "" {
+			config = filepath.Join(home, ".config")
+		}
+		return filepath.Join(config, "fish", "completions", "arc-tmux.fish"), "", nil
+	default:
+		return "", "", fmt.Errorf("cannot install completions for shell %q; pass --shell bash|zsh|fish or --path", shell)
+	}
+}
+
+// registerDynamicCompletions attaches live tmux completers to every --pane and
+// --session flag in the command tree.
+func registerDynamicCompletions(cmd *cobra.Command) {
+	if cmd.LocalNonPersistentFlags().Lookup("pane") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("pane", completePaneTargets)
+	}
+	if cmd.LocalNonPersistentFlags().Lookup("session") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("session", completeSessionTargets)
+	}
+	for _, child := range cmd.Commands() {
+		registerDynamicCompletions(child)
+	}
+}
+
+func completePaneTargets(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	candidates := []string{
+		"@current\tcurrent pane",
+		"@active\tactive pane",
+		"@last\tpreviously active pane",
+		"@up\tpane above the current pane",
+		"@down\tpane below the current pane",
+		"@left\tpane left of the current pane",
+		"@right\tpane right of the current pane",
+		"@current+1\tnext pane in the current window",
+	}
+	if entries, err := listAliases(aliasScopeAll, "", ""); err == nil {
+		for _, entry := range entries {
+			candidates = append(candidates, fmt.Sprintf("@%s\t%s alias for %s", entry.Name, entry.Scope, entry.Target))
+		}
+	}
+	if cfg, err := loadConfig(defaultConfigFile()); err == nil {
+		seen := map[string]bool{}
+		for _, link := range cfg.Links {
+			if seen[link.Name] {
+				continue
+			}
+			seen[link.Name] = true
+			candidates = append(candidates, fmt.Sprintf("%s%s\tlinked to %s", linkedSelectorPrefix, link.Name, link.Pane))
+		}
+	}
+	if panes, err := tmux.ListPanes(); err == nil {
+		ids := make([]string, 0, len(panes))
+		for _, p := range panes {
+			ids = append(ids, fmt.Sprintf("%s\t%s", p.FormattedID(), p.Command))
+		}
+		sort.Strings(ids)
+		candidates = append(candidates, ids...)
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeSessionTargets(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	candidates := []string{"@current\tcurrent session", "@managed\tmanaged session"}
+	if sessions, err := tmux.ListSessions(); err == nil {
+		names := make([]string, 0, len(sessions))
+		for _, s := range sessions {
+			names = append(names, s.Name)
+		}
+		sort.Strings(names)
+		candidates = append(candidates, names...)
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeAliasNames(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	entries, err := listAliases(aliasScopeAll, "", "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, fmt.Sprintf("%s\t%s (%s)", entry.Name, entry.Target, entry.Scope))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// composeFileNames are the Compose files looked for, in Compose's own order.
+var composeFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+type composeResult struct {
+	File        string `json:"file" yaml:"file"`
+	Session     string `json:"session" yaml:"session"`
+	Window      string `json:"window" yaml:"window"`
+	WindowIndex int    `json:"window_index" yaml:"window_index"`
+	// Mode is "logs" or "attach".
+	Mode     string               `json:"mode" yaml:"mode"`
+	Created  bool                 `json:"created" yaml:"created"`
+	Services []composeServicePane `json:"services" yaml:"services"`
+}
+
+// composeServicePane maps a service to the pane titled with its name.
+type composeServicePane struct {
+	Service string `json:"service" yaml:"service"`
+	PaneID  string `json:"pane_id" yaml:"pane_id"`
+	ID      string `json:"id" yaml:"id"`
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+func newComposeCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var file string
+	var session string
+	var window string
+	var attach bool
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:   "compose [service]...",
+		Short: "Open a pane per Docker Compose service",
+		Long: `Open a window with one pane per Docker Compose service, each following the
+service's logs (docker compose logs -f) or, with --attach, attached to it.
+Panes are titled with the service name, so "alias set-from-window" can turn
+them into @service aliases for watch, capture --grep, wait, and the rest.
+
+Services are read from --file (default: compose.yaml, compose.yml,
+docker-compose.yaml, or docker-compose.yml in the working directory), in file
+order; name services to open only those. Running compose again reports the
+existing window's panes instead of opening a second set.`,
+		Example: `  arc-tmux compose --session shop
+  arc-tmux compose api worker --tail 500
+  arc-tmux compose --file deploy/compose.yaml --attach -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if tail < 0 {
+				return fmt.Errorf("--tail must be >= 0")
+			}
+			path, err := findComposeFile(file)
+			if err != nil {
+				return err
+			}
+			services, err := composeServices(path)
+			if err != nil {
+				return err
+			}
+			services, err = selectComposeServices(services, args)
+			if err != nil {
+				return err
+			}
+
+			mode := "logs"
+			if attach {
+				mode = "attach"
+			}
+			sess, shouldStyle, err := resolveEnsureSession(session)
+			if err != nil {
+				return err
+			}
+			exists, err := tmux.HasSession(sess)
+			if err != nil {
+				return err
+			}
+			if err := tmux.EnsureSession(sess); err != nil {
+				return fmt.Errorf("failed to ensure session %q: %w", sess, err)
+			}
+			if !exists {
+				if err := applyAgentStyleIfNeeded(sess, shouldStyle); err != nil {
+					return err
+				}
+			}
+			result := composeResult{File: path, Session: sess, Window: strings.TrimSpace(window), Mode: mode, Services: []composeServicePane{}}
+			wins, err := tmux.ListWindows(sess)
+			if err != nil {
+				return err
+			}
+			if win, found := findWindowByName(wins, result.Window); found {
+				result.WindowIndex = win.WindowIndex
+				panes, err := panesForWindow(sess, win.WindowIndex)
+				if err != nil {
+					return err
+				}
+				for _, service := range services {
+					if match := findPaneByTitle(panes, service); match != nil {
+						result.Services = append(result.Services, composeServicePane{Service: service, PaneID: formattedPaneID(match), ID: match.PaneID})
+					}
+				}
+				return writeComposeResult(cmd, outputOpts, result)
+			}
+
+			commands := make([]string, len(services))
+			for i, service := range services {
+				commands[i] = composeServiceCommand(path, service, attach, tail)
+			}
+			panes, windowIndex, err := buildTiledWindow(sess, result.Window, filepath.Dir(path), services, commands)
+			if err != nil {
+				return err
+			}
+			result.Created = true
+			result.WindowIndex = windowIndex
+			for _, p := range panes {
+				result.Services = append(result.Services, composeServicePane{Service: p.Title, PaneID: p.PaneID, ID: p.ID, Command: p.Command})
+			}
+			return writeComposeResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Compose file (default: compose.yaml or docker-compose.yml in the working directory)")
+	cmd.Flags().StringVar(&session, "session", "", "Session name or selector (@current|@managed)")
+	cmd.Flags().StringVar(&window, "window", "services", "Name for the window")
+	cmd.Flags().BoolVar(&attach, "attach", false, "Attach to each service instead of following its logs")
+	cmd.Flags().IntVar(&tail, "tail", 100, "Log lines to show from before the pane opened (0 for all)")
+	return cmd
+}
+
+// findComposeFile returns file as an absolute path, or the first Compose file
+// in the working directory when file is empty.
+func findComposeFile(file string) (string, error) {
+	if strings.TrimSpace(file) != "" {
+		path, err := filepath.Abs(strings.TrimSpace(file))
+		if err != nil {
+			return "", err
+		}
+		if !fileExists(path) {
+			return "", fmt.Errorf("compose file %s not found", path)
+		}
+		return path, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range composeFileNames {
+		if path := filepath.Join(wd, name); fileExists(path) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no Compose file in %s (looked for %s); pass --file", wd, strings.Join(composeFileNames, ", "))
+}
+
+// composeServices lists the services defined in a Compose file, in file order.
+func composeServices(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Services yaml.Node `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if doc.Services.Kind != yaml.MappingNode || len(doc.Services.Content) == 0 {
+		return nil, fmt.Errorf("%s defines no services", path)
+	}
+	services := make([]string, 0, len(doc.Services.Content)/2)
+	for i := 0; i < len(doc.Services.Content); i += 2 {
+		services = append(services, doc.Services.Content[i].Value)
+	}
+	return services, nil
+}
+
+// selectComposeServices keeps the requested services, in the order given;
+// with none requested, all services are kept.
+func selectComposeServices(services []string, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return services, nil
+	}
+	known := map[string]bool{}
+	for _, s := range services {
+		known[s] = true
+	}
+	selected := make([]string, 0, len(requested))
+	for _, name := range requested {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown service %q (available: %s)", name, strings.Join(services, ", "))
+		}
+		selected = append(selected, name)
+	}
+	return selected, nil
+}
+
+func composeServiceCommand(file string, service string, attach bool, tail int) string {
+	base := "docker compose -f " + shellQuoteSingle(file)
+	if attach {
+		return base + " attach " + shellQuoteSingle(service)
+	}
+	tailArg := "all"
+	if tail > 0 {
+		tailArg = fmt.Sprint(tail)
+	}
+	return fmt.Sprintf("%s logs -f --tail %s %s", base, tailArg, shellQuoteSingle(service))
+}
+
+// titledPane is a pane created by buildTiledWindow.
+type titledPane struct {
+	Title   string
+	PaneID  string
+	ID      string
+	Command string
+}
+
+// buildTiledWindow opens window with one pane per title, titled accordingly.
+// The layout is re-tiled after every split so any number of panes fits, and
+// each non-empty command starts only once every pane exists, like
+// buildPresetWindow. It returns the panes and the window index.
+func buildTiledWindow(session string, window string, dir string, titles []string, commands []string) ([]titledPane, int, error) {
+	panes := make([]titledPane, 0, len(titles))
+	for i, title := range titles {
+		var target string
+		var err error
+		if i == 0 {
+			target, err = tmux.NewWindowDir(session, window, "", dir)
+		} else {
+			target, err = tmux.SplitWindowPercent(panes[i-1].ID, "v", 0, "", dir)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("pane %q: %w", title, err)
+		}
+		handle, err := canonicalPaneTarget(target)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := tmux.SetPaneTitle(handle.ID, title); err != nil {
+			return nil, 0, err
+		}
+		panes = append(panes, titledPane{Title: title, ID: handle.ID, Command: commands[i]})
+		if i > 0 {
+			if err := tmux.SelectLayout(panes[0].ID, "tiled"); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	if len(panes) == 0 {
+		return nil, 0, fmt.Errorf("no panes to create")
+	}
+	if isAgentSessionName(session) {
+		if pane, err := tmux.PaneDetailsForTarget(panes[0].ID); err == nil {
+			if err := tmux.ApplyAgentWindowStyle(session, pane.WindowIndex); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	windowIndex := 0
+	for i := range panes {
+		pane, err := tmux.PaneDetailsForTarget(panes[i].ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		panes[i].PaneID = formattedPaneID(&pane)
+		windowIndex = pane.WindowIndex
+	}
+	for _, p := range panes {
+		if p.Command == "" {
+			continue
+		}
+		if err := tmux.RespawnPaneDir(p.ID, p.Command, dir); err != nil {
+			return nil, 0, fmt.Errorf("pane %q: %w", p.Title, err)
+		}
+	}
+	_ = tmux.SelectPane(panes[0].ID)
+	return panes, windowIndex, nil
+}
+
+func writeComposeResult(cmd *cobra.Command, outputOpts output.OutputOptions, result composeResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, s := range result.Services {
+			_, _ = fmt.Fprintf(out, "%s\t%s\n", s.Service, s.PaneID)
+		}
+		return nil
+	}
+	if result.Created {
+		_, _ = fmt.Fprintf(out, "Opened window %q (index %d) in session %q with %d service pane(s) from %s.\n", result.Window, result.WindowIndex, result.Session, len(result.Services), result.File)
+	} else {
+		_, _ = fmt.Fprintf(out, "Window %q already exists in session %q (index %d).\n", result.Window, result.Session, result.WindowIndex)
+	}
+	if len(result.Services) == 0 {
+		return nil
+	}
+	table := newTextTable("SERVICE", "PANE", "ID")
+	for _, s := range result.Services {
+		table.addRow(cell(s.Service), cell(s.PaneID), cell(s.ID))
+	}
+	return table.render(out)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestComposeServices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "compose.yaml")
+	data := "services:\n  web:\n    image: nginx\n  api:\n    build: .\n  db:\n    image: postgres\nvolumes:\n  data: {}\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	services, err := composeServices(path)
+	if err != nil {
+		t.Fatalf("composeServices: %v", err)
+	}
+	if !reflect.DeepEqual(services, []string{"web", "api", "db"}) {
+		t.Fatalf("expected services in file order, got %v", services)
+	}
+	selected, err := selectComposeServices(services, []string{"db", "web"})
+	if err != nil || !reflect.DeepEqual(selected, []string{"db", "web"}) {
+		t.Fatalf("unexpected selection: %v (%v)", selected, err)
+	}
+	if _, err := selectComposeServices(services, []string{"cache"}); err == nil {
+		t.Fatal("expected error for unknown service")
+	}
+}
+
+func TestComposeServiceCommand(t *testing.T) {
+	if got := composeServiceCommand("/srv/compose.yaml", "api", false, 0); got != "docker compose -f '/srv/compose.yaml' logs -f --tail all 'api'" {
+		t.Fatalf("unexpected logs command: %s", got)
+	}
+	if got := composeServiceCommand("/srv/compose.yaml", "api", true, 100); got != "docker compose -f '/srv/compose.yaml' attach 'api'" {
+		t.Fatalf("unexpected attach command: %s", got)
+	}
+}
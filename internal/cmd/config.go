@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// cliConfig is the user configuration file (config.yaml).
+type cliConfig struct {
+	// Session is the managed session name used when ARC_TMUX_SESSION is unset.
+	Session string   `yaml:"session,omitempty"`
+	Recipes []recipe `yaml:"recipes,omitempty"`
+	// ProtectedCommands are regular expressions for command lines that
+	// interrupt refuses to Ctrl+C without --force. Unset protects nothing.
+	ProtectedCommands []string `yaml:"protected_commands,omitempty"`
+	// ProgressPatterns are extra regular expressions for progress indicators,
+	// capturing (?P<percent>) or (?P<done>) and (?P<total>).
+	ProgressPatterns []string `yaml:"progress_patterns,omitempty"`
+	// WrapShell runs command strings in new panes and run --segment; empty
+	// means sh, "default-shell" means tmux's default-shell option.
+	WrapShell string `yaml:"wrap_shell,omitempty"`
+	// LoginShell runs WrapShell as a login shell (-lc); unset means true.
+	LoginShell *bool `yaml:"login_shell,omitempty"`
+	// IndexOrigin is "tmux" (default) to report indexes as the server numbers
+	// them, or "0" to count windows and panes from zero.
+	IndexOrigin string `yaml:"index_origin,omitempty"`
+	// Sockets are extra tmux server sockets (names as for tmux -L, or paths)
+	// visited by --all-servers alongside those found in TMUX_TMPDIR.
+	Sockets []string `yaml:"sockets,omitempty"`
+	// Transcript is a file each command appends a JSON record to; {session}
+	// expands to the target session.
+	Transcript string `yaml:"transcript,omitempty"`
+	// AuditLog is the file every input sent to a pane is recorded in, with
+	// the actor that sent it; "on" uses the default file. Off when unset.
+	AuditLog string `yaml:"audit_log,omitempty"`
+	// CleanupArchive is the directory cleanup archives a session into before
+	// killing it, unless --no-archive is given.
+	CleanupArchive string `yaml:"cleanup_archive,omitempty"`
+	// CleanupTrashTTL makes cleanup move sessions to the trash, restorable
+	// with undo for this long (e.g. "24h"), unless --trash=false is given.
+	CleanupTrashTTL string `yaml:"cleanup_trash_ttl,omitempty"`
+	// LogRotation rotates and prunes the files arc-tmux appends to.
+	LogRotation *logRotation `yaml:"log_rotation,omitempty"`
+	// PromptPolicies answer or flag interactive prompts detected by monitor.
+	PromptPolicies []promptPolicy `yaml:"prompt_policies,omitempty"`
+	// Presets are multi-pane window layouts for "preset" and "ensure --preset",
+	// overriding built-ins of the same name.
+	Presets []windowPreset `yaml:"presets,omitempty"`
+	// Defaults override the built-in --idle, --timeout, and --lines defaults,
+	// keyed by flag ("timeout") or command and flag ("run.timeout").
+	Defaults map[string]string `yaml:"defaults,omitempty"`
+	// WatchRules are the output-triggered actions run by "watch".
+	WatchRules []watchRule `yaml:"watch_rules,omitempty"`
+	// Links name the pane linked to another (@linked:NAME) and the command
+	// watch runs there on a trigger line or file change.
+	Links []paneLink `yaml:"links,omitempty"`
+	// TriagePatterns are extra error signatures for "triage", tried before
+	// the built-in ones.
+	TriagePatterns []triagePattern `yaml:"triage_patterns,omitempty"`
+}
+
+func defaultConfigFile() string {
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_CONFIG")); env != "" {
+		return env
+	}
+	if dir, err := os.UserConfigDir(); err == nil && strings.TrimSpace(dir) != "" {
+		return filepath.Join(dir, "arc-tmux", "config.yaml")
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".arc-tmux.yaml")
+	}
+	return "arc-tmux.yaml"
+}
+
+// loadConfig reads the config file; a missing file yields an empty config.
+func loadConfig(path string) (cliConfig, error) {
+	var cfg cliConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func saveConfig(path string, cfg cliConfig) error {
+	dir := filepath.Dir(path)
+	if dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type copyModeResult struct {
+	PaneID string `json:"pane_id" yaml:"pane_id"`
+	Search string `json:"search,omitempty" yaml:"search,omitempty"`
+	Line   string `json:"line,omitempty" yaml:"line,omitempty"`
+	Row    int    `json:"row" yaml:"row"`
+	Lines  int    `json:"lines,omitempty" yaml:"lines,omitempty"`
+	Buffer string `json:"buffer,omitempty" yaml:"buffer,omitempty"`
+	Text   string `json:"text,omitempty" yaml:"text,omitempty"`
+	InMode bool   `json:"in_mode" yaml:"in_mode"`
+}
+
+func newCopyModeCmd() *cobra.Command {
+	var paneArg string
+	var search string
+	var forward bool
+	var jump string
+	var selectLine bool
+	var lines int
+	var buffer string
+	var exit bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "copy-mode",
+		Short: "Search, select, and copy pane history with copy mode",
+		Long: `Drive tmux copy mode to extract a specific region of a pane.
+
+Steps run in order: enter copy mode, --jump to the top or bottom of the
+history, --search for literal text (backwards from the cursor, or --forward),
+--select-line to select the matching line (plus --lines-1 lines below), and
+--to-buffer to copy those lines into a named paste buffer and leave copy mode.
+A search that finds nothing leaves copy mode and fails with ERR_NO_MATCH.
+
+Without --to-buffer the pane stays in copy mode so a viewer sees the result;
+--exit leaves copy mode.`,
+		Example: `  arc-tmux copy-mode --pane=fe:2.0 --search "error" --select-line --to-buffer err
+  arc-tmux copy-mode --pane=@current --jump top --search "FAIL" --forward
+  arc-tmux copy-mode --pane=@current --exit`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if lines < 1 {
+				return fmt.Errorf("--lines must be at least 1")
+			}
+			if jump != "" && jump != "top" && jump != "bottom" {
+				return fmt.Errorf("invalid --jump %q (expected top or bottom)", jump)
+			}
+			buffer = strings.TrimSpace(buffer)
+			if cmd.Flags().Changed("to-buffer") && buffer == "" {
+				return fmt.Errorf("--to-buffer requires a buffer name")
+			}
+			if exit && (search != "" || jump != "" || selectLine || buffer != "") {
+				return fmt.Errorf("--exit cannot be combined with other copy-mode steps")
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+
+			result := copyModeResult{PaneID: handle.Target, Search: search}
+			if exit {
+				cursor, err := tmux.CopyCursorPosition(handle.ID)
+				if err != nil {
+					return err
+				}
+				if cursor.InMode {
+					if err := tmux.CopyModeCommand(handle.ID, "cancel"); err != nil {
+						return err
+					}
+				}
+				return writeCopyModeResult(cmd, outputOpts, result, "Left copy mode")
+			}
+
+			cursor, err := runCopyModeSteps(handle.ID, search, forward, jump, selectLine || buffer != "", lines)
+			if err != nil {
+				return err
+			}
+			result.Line = cursor.Line
+			result.Row = cursor.Row
+			result.InMode = true
+			if selectLine || buffer != "" {
+				result.Lines = lines
+			}
+			if buffer != "" {
+				text, err := tmux.CaptureRange(handle.ID, cursor.Row, cursor.Row+lines-1)
+				if err != nil {
+					return err
+				}
+				text = strings.TrimSuffix(text, "\n")
+				if err := tmux.SetBuffer(buffer, text); err != nil {
+					return err
+				}
+				if err := tmux.CopyModeCommand(handle.ID, "cancel"); err != nil {
+					return err
+				}
+				result.Buffer = buffer
+				result.Text = text
+				result.InMode = false
+			}
+			return writeCopyModeResult(cmd, outputOpts, result, copyModeMessage(result))
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&search, "search", "", "Search for literal text in the pane history")
+	cmd.Flags().BoolVar(&forward, "forward", false, "Search forward (down) from the cursor instead of backward")
+	cmd.Flags().StringVar(&jump, "jump", "", "Jump to the history top or bottom before searching")
+	cmd.Flags().BoolVar(&selectLine, "select-line", false, "Select the line under the cursor")
+	cmd.Flags().IntVar(&lines, "lines", 1, "Number of lines to select, starting at the cursor")
+	cmd.Flags().StringVar(&buffer, "to-buffer", "", "Copy the selected lines into this paste buffer and leave copy mode")
+	cmd.Flags().BoolVar(&exit, "exit", false, "Leave copy mode")
+
+	return cmd
+}
+
+// runCopyModeSteps enters copy mode and applies jump, search, and selection,
+// returning the cursor position the selection starts at.
+func runCopyModeSteps(paneID string, search string, forward bool, jump string, selectLine bool, lines int) (tmux.CopyCursor, error) {
+	if err := tmux.CopyMode(paneID); err != nil {
+		return tmux.CopyCursor{}, err
+	}
+	if jump != "" {
+		if err := tmux.CopyModeCommand(paneID, "history-"+jump); err != nil {
+			return tmux.CopyCursor{}, err
+		}
+	}
+	if search != "" {
+		direction := "search-backward-text"
+		if forward {
+			direction = "search-forward-text"
+		}
+		if err := tmux.CopyModeCommand(paneID, direction, search); err != nil {
+			return tmux.CopyCursor{}, err
+		}
+	}
+	cursor, err := tmux.CopyCursorPosition(paneID)
+	if err != nil {
+		return tmux.CopyCursor{}, err
+	}
+	if search != "" && !copyLineMatches(cursor.Line, search) {
+		_ = tmux.CopyModeCommand(paneID, "cancel")
+		return tmux.CopyCursor{}, newCodedError(errNoMatch, fmt.Sprintf("%q not found in pane history", search), nil)
+	}
+	if selectLine {
+		if err := tmux.CopyModeCommand(paneID, "select-line"); err != nil {
+			return tmux.CopyCursor{}, err
+		}
+		for i := 1; i < lines; i++ {
+			if err := tmux.CopyModeCommand(paneID, "cursor-down"); err != nil {
+				return tmux.CopyCursor{}, err
+			}
+		}
+	}
+	return cursor, nil
+}
+
+// copyLineMatches reports whether a search landed on a matching line. tmux
+// searches case-insensitively when the text is all lowercase.
+func copyLineMatches(line string, search string) bool {
+	if search == strings.ToLower(search) {
+		return strings.Contains(strings.ToLower(line), search)
+	}
+	return strings.Contains(line, search)
+}
+
+func copyModeMessage(result copyModeResult) string {
+	switch {
+	case result.Buffer != "":
+		return fmt.Sprintf("Copied %d line(s) to buffer %s (%s)", result.Lines, result.Buffer, result.PaneID)
+	case result.Search != "":
+		return fmt.Sprintf("Found %q at line %d: %s (%s)", result.Search, result.Row, result.Line, result.PaneID)
+	default:
+		return fmt.Sprintf("Entered copy mode (%s)", result.PaneID)
+	}
+}
+
+func writeCopyModeResult(cmd *cobra.Command, outputOpts output.OutputOptions, result copyModeResult, message string) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		return nil
+	}
+	_, _ = fmt.Fprintln(out, message)
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type defaultPaneResult struct {
+	Session string `json:"session" yaml:"session"`
+	Set     bool   `json:"set" yaml:"set"`
+	PaneID  string `json:"pane_id,omitempty" yaml:"pane_id,omitempty"`
+	Target  string `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+func newDefaultCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "default",
+		Short: "Set the pane used when --pane is omitted",
+		Long: `Store a per-session default pane (in the tmux session option
+@arc_tmux_default_pane). Commands that take --pane fall back to the default of
+the current tmux session, or of ARC_TMUX_SESSION when run outside tmux.`,
+		Example: `  arc-tmux default set --pane=dev:1.0
+  arc-tmux send "npm test"
+  arc-tmux default show
+  arc-tmux default clear`,
+	}
+
+	cmd.AddCommand(
+		newDefaultSetCmd(),
+		newDefaultShowCmd(),
+		newDefaultClearCmd(),
+	)
+
+	return cmd
+}
+
+func newDefaultSetCmd() *cobra.Command {
+	var paneArg string
+	var session string
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "set [pane]",
+		Short: "Set the session's default pane",
+		Long:  "Set the default pane. It is stored on the pane's own session unless --session is given.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			paneInput := paneArg
+			if paneInput == "" && len(args) > 0 {
+				paneInput = args[0]
+			}
+			if strings.TrimSpace(paneInput) == "" {
+				return newCodedError(errPaneRequired, "pane target is required", nil)
+			}
+			target, err := resolvePaneTarget(paneInput)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+
+			sess := strings.TrimSpace(session)
+			if sess == "" {
+				sess, _, _ = strings.Cut(handle.Target, ":")
+			} else if sess, err = resolveExistingSessionName(sess); err != nil {
+				return err
+			}
+			if err := setSessionOptionUndoable(sess, tmux.DefaultPaneOption, handle.ID); err != nil {
+				return err
+			}
+			result := defaultPaneResult{Session: sess, Set: true, PaneID: handle.ID, Target: handle.Target}
+			return writeDefaultPaneResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&session, "session", "", "Session to store the default on (default: the pane's session)")
+	return cmd
+}
+
+func newDefaultShowCmd() *cobra.Command {
+	var session string
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the session's default pane",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			sess, err := defaultPaneSessionArg(session)
+			if err != nil {
+				return err
+			}
+			result := defaultPaneResult{Session: sess}
+			id, ok, err := tmux.SessionOption(sess, tmux.DefaultPaneOption)
+			if err != nil {
+				return err
+			}
+			if ok {
+				result.Set = true
+				result.PaneID = id
+				if handle, err := tmux.ResolveTarget(id); err == nil {
+					result.Target = handle.Target
+				}
+			}
+			return writeDefaultPaneResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Session to read (default: current session or ARC_TMUX_SESSION)")
+	return cmd
+}
+
+func newDefaultClearCmd() *cobra.Command {
+	var session string
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove the session's default pane",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			sess, err := defaultPaneSessionArg(session)
+			if err != nil {
+				return err
+			}
+			if err := setSessionOptionUndoable(sess, tmux.DefaultPaneOption, ""); err != nil {
+				return err
+			}
+			return writeDefaultPaneResult(cmd, outputOpts, defaultPaneResult{Session: sess})
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Session to clear (default: current session or ARC_TMUX_SESSION)")
+	return cmd
+}
+
+// defaultPaneSession returns the session whose default pane applies: the
+// current tmux session, else ARC_TMUX_SESSION, else "".
+func defaultPaneSession() string {
+	if strings.TrimSpace(os.Getenv("TMUX")) != "" {
+		if sess, _, _, _, err := tmux.CurrentLocation(); err == nil {
+			return sess
+		}
+	}
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_SESSION")); env != "" {
+		if sess, err := resolveExistingSessionName(env); err == nil {
+			return sess
+		}
+	}
+	return ""
+}
+
+func defaultPaneSessionArg(session string) (string, error) {
+	if strings.TrimSpace(session) != "" {
+		return resolveExistingSessionName(session)
+	}
+	sess := defaultPaneSession()
+	if sess == "" {
+		return "", newCodedError(errInvalidSession, "--session is required outside tmux (or set ARC_TMUX_SESSION)", nil)
+	}
+	return sess, nil
+}
+
+// defaultPaneTarget resolves an omitted --pane to the session's default pane.
+func defaultPaneTarget() (string, error) {
+	sess := defaultPaneSession()
+	if sess == "" {
+		return "", newCodedError(errPaneRequired, "--pane is required", nil)
+	}
+	id, ok, err := tmux.SessionOption(sess, tmux.DefaultPaneOption)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", newCodedError(errPaneRequired, fmt.Sprintf("--pane is required (no default pane set for session %s)", sess), nil)
+	}
+	return id, nil
+}
+
+func writeDefaultPaneResult(cmd *cobra.Command, outputOpts output.OutputOptions, result defaultPaneResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		if result.Set {
+			_, _ = fmt.Fprintln(out, result.PaneID)
+		}
+		return nil
+	}
+	if !result.Set {
+		_, _ = fmt.Fprintf(out, "No default pane for session %q.\n", result.Session)
+		return nil
+	}
+	if result.Target != "" {
+		_, _ = fmt.Fprintf(out, "Default pane for session %q: %s (%s)\n", result.Session, result.Target, result.PaneID)
+	} else {
+		_, _ = fmt.Fprintf(out, "Default pane for session %q: %s (no longer exists)\n", result.Session, result.PaneID)
+	}
+	return nil
+}
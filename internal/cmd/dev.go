@@ -0,0 +1,446 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// devConfigName is the per-project file "dev" looks for in the project root.
+const devConfigName = ".arc-tmux.yaml"
+
+// devDefaultPreset is the preset "dev" builds when the project does not name one.
+const devDefaultPreset = "dev"
+
+// devProjectConfig is the schema of .arc-tmux.yaml.
+type devProjectConfig struct {
+	Session string `yaml:"session,omitempty"`
+	Window  string `yaml:"window,omitempty"`
+	// Preset names a preset; Slots (and Layout) define one inline instead.
+	Preset string       `yaml:"preset,omitempty"`
+	Layout string       `yaml:"layout,omitempty"`
+	Slots  []presetSlot `yaml:"slots,omitempty"`
+	// Commands sets slot commands, overriding detected ones.
+	Commands map[string]string `yaml:"commands,omitempty"`
+}
+
+type devResult struct {
+	Project string `json:"project" yaml:"project"`
+	// Config is the .arc-tmux.yaml used, if any.
+	Config string `json:"config,omitempty" yaml:"config,omitempty"`
+	// Detected lists the project files slot commands were derived from.
+	Detected    []string         `json:"detected" yaml:"detected"`
+	Session     string           `json:"session" yaml:"session"`
+	Window      string           `json:"window" yaml:"window"`
+	WindowIndex int              `json:"window_index" yaml:"window_index"`
+	Preset      string           `json:"preset" yaml:"preset"`
+	Created     bool             `json:"created" yaml:"created"`
+	DryRun      bool             `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+	Slots       []presetSlotPane `json:"slots" yaml:"slots"`
+}
+
+func newDevCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var window string
+	var presetName string
+	var slotCommands []string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "dev [dir]",
+		Short: "Bring up a dev window for the current project",
+		Long: `Bring up a working tmux layout for a project with no setup: an editor,
+a server, tests, and a shell, each in a titled pane.
+
+The project root is the nearest directory (from [dir], default: the working
+directory) holding .arc-tmux.yaml or .git. Without .arc-tmux.yaml, slot
+commands are detected from the project files:
+
+  package.json        server: the dev or start script; test: the test script
+                      (run with pnpm or yarn when their lockfile is present)
+  compose.yaml        server: docker compose up (also docker-compose.yml)
+  Makefile            server: make dev, run, or serve; test: make test
+  $EDITOR             editor: $EDITOR .
+
+.arc-tmux.yaml overrides any of it:
+
+  session: shop
+  window: dev
+  preset: ide          # or inline slots/layout, as in config presets
+  commands:
+    server: npm run dev
+    logs: tail -f log/development.log
+
+The session defaults to the project directory's name and the window to
+"dev". Running dev again reports the existing window's slots instead of
+building a second one. --dry-run prints the plan without touching tmux.`,
+		Example: `  arc-tmux dev
+  arc-tmux dev ~/src/shop --dry-run
+  arc-tmux dev --preset quad --cmd top-left="nvim ." -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			start := "."
+			if len(args) == 1 {
+				start = args[0]
+			}
+			start, err := filepath.Abs(start)
+			if err != nil {
+				return err
+			}
+			if info, err := os.Stat(start); err != nil || !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", start)
+			}
+			root, configPath := findDevProjectRoot(start)
+			var project devProjectConfig
+			if configPath != "" {
+				if project, err = loadDevProjectConfig(configPath); err != nil {
+					return err
+				}
+			}
+			preset, err := devProjectPreset(project, presetName)
+			if err != nil {
+				return err
+			}
+			commands, detected := detectDevCommands(root, preset)
+			for slot, command := range project.Commands {
+				if !presetHasSlot(preset, slot) {
+					return newCodedError(errInvalidPreset, fmt.Sprintf("%s: preset %q has no slot %q", configPath, preset.Name, slot), nil)
+				}
+				commands[slot] = command
+			}
+			overrides, err := parseSlotCommands(slotCommands, preset)
+			if err != nil {
+				return err
+			}
+			for slot, command := range overrides {
+				commands[slot] = command
+			}
+
+			name := firstNonEmpty(session, project.Session, devSessionName(root))
+			result := devResult{
+				Project:  root,
+				Config:   configPath,
+				Detected: detected,
+				Window:   firstNonEmpty(window, project.Window, "dev"),
+				Preset:   preset.Name,
+				DryRun:   dryRun,
+				Slots:    []presetSlotPane{},
+			}
+			if dryRun {
+				result.Session = name
+				// New sessions get the agent prefix; report it when tmux can say.
+				if sess, _, err := resolveEnsureSession(name); err == nil {
+					result.Session = sess
+				}
+				for _, slot := range preset.Slots {
+					result.Slots = append(result.Slots, presetSlotPane{Slot: slot.Name, Command: strings.TrimSpace(commands[slot.Name])})
+				}
+				return writeDevResult(cmd, outputOpts, result)
+			}
+
+			sess, shouldStyle, err := resolveEnsureSession(name)
+			if err != nil {
+				return err
+			}
+			result.Session = sess
+			exists, err := tmux.HasSession(sess)
+			if err != nil {
+				return err
+			}
+			if err := tmux.EnsureSession(sess); err != nil {
+				return fmt.Errorf("failed to ensure session %q: %w", sess, err)
+			}
+			if !exists {
+				if err := applyAgentStyleIfNeeded(sess, shouldStyle); err != nil {
+					return err
+				}
+			}
+			wins, err := tmux.ListWindows(sess)
+			if err != nil {
+				return err
+			}
+			if win, found := findWindowByName(wins, result.Window); found {
+				result.WindowIndex = win.WindowIndex
+				panes, err := panesForWindow(sess, win.WindowIndex)
+				if err != nil {
+					return err
+				}
+				if slots := matchPresetSlots(preset, panes); len(slots) > 0 {
+					result.Slots = slots
+				}
+				return writeDevResult(cmd, outputOpts, result)
+			}
+			built, err := buildPresetWindow(sess, result.Window, preset, commands, root, nil)
+			if err != nil {
+				return err
+			}
+			result.Created = true
+			result.WindowIndex = built.WindowIndex
+			result.Slots = built.Slots
+			return writeDevResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Session name (default: .arc-tmux.yaml's, else the project directory name)")
+	cmd.Flags().StringVar(&window, "window", "", "Window name (default: .arc-tmux.yaml's, else dev)")
+	cmd.Flags().StringVar(&presetName, "preset", "", "Build the window from this preset instead of the project's")
+	cmd.Flags().StringArrayVar(&slotCommands, "cmd", nil, "Command for a slot (SLOT=COMMAND). Repeatable.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the detected layout and commands without creating anything")
+	return cmd
+}
+
+// findDevProjectRoot walks up from start to the nearest directory holding
+// .arc-tmux.yaml or .git, returning it and the .arc-tmux.yaml path if any.
+// The home directory's .arc-tmux.yaml is the user config, so it is skipped.
+// With no match, start is the root.
+func findDevProjectRoot(start string) (string, string) {
+	home, _ := os.UserHomeDir()
+	for dir := start; ; {
+		if dir != home {
+			config := filepath.Join(dir, devConfigName)
+			if info, err := os.Stat(config); err == nil && !info.IsDir() {
+				return dir, config
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return start, ""
+		}
+		dir = parent
+	}
+}
+
+func loadDevProjectConfig(path string) (devProjectConfig, error) {
+	var project devProjectConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return project, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&project); err != nil && !errors.Is(err, io.EOF) {
+		return project, fmt.Errorf("%s: %w", path, err)
+	}
+	return project, nil
+}
+
+// devProjectPreset picks the preset to build: --preset, then the project's
+// inline slots or named preset, then the built-in dev preset.
+func devProjectPreset(project devProjectConfig, override string) (windowPreset, error) {
+	if strings.TrimSpace(override) != "" {
+		return findPreset(override)
+	}
+	if len(project.Slots) > 0 {
+		if project.Preset != "" {
+			return windowPreset{}, newCodedError(errInvalidPreset, devConfigName+": set preset or slots, not both", nil)
+		}
+		preset := windowPreset{Name: "project", Slots: project.Slots, Layout: project.Layout, Source: devConfigName}
+		if err := validatePreset(preset); err != nil {
+			return windowPreset{}, newCodedError(errInvalidPreset, err.Error(), err)
+		}
+		return preset, nil
+	}
+	if project.Preset != "" {
+		return findPreset(project.Preset)
+	}
+	return findPreset(devDefaultPreset)
+}
+
+func presetHasSlot(preset windowPreset, name string) bool {
+	for _, slot := range preset.Slots {
+		if slot.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// detectDevCommands derives editor, server, and test commands from the
+// project files in root, keeping only slots the preset has. The first file
+// to supply a slot wins; detected lists the files that supplied any.
+func detectDevCommands(root string, preset windowPreset) (map[string]string, []string) {
+	commands := map[string]string{}
+	detected := []string{}
+	set := func(source string, slot string, command string) {
+		if command == "" || !presetHasSlot(preset, slot) || commands[slot] != "" {
+			return
+		}
+		commands[slot] = command
+		if len(detected) == 0 || detected[len(detected)-1] != source {
+			detected = append(detected, source)
+		}
+	}
+
+	if scripts, ok := packageScripts(filepath.Join(root, "package.json")); ok {
+		runner := packageRunner(root)
+		switch {
+		case scripts["dev"]:
+			set("package.json", "server", runner+" run dev")
+		case scripts["start"]:
+			set("package.json", "server", runner+" start")
+		}
+		if scripts["test"] {
+			set("package.json", "test", runner+" test")
+		}
+	}
+	for _, name := range composeFileNames {
+		if fileExists(filepath.Join(root, name)) {
+			set(name, "server", "docker compose up")
+			break
+		}
+	}
+	if targets := makefileTargets(filepath.Join(root, "Makefile")); len(targets) > 0 {
+		for _, target := range []string{"dev", "run", "serve"} {
+			if targets[target] {
+				set("Makefile", "server", "make "+target)
+				break
+			}
+		}
+		if targets["test"] {
+			set("Makefile", "test", "make test")
+		}
+	}
+	if editor := strings.TrimSpace(os.Getenv("EDITOR")); editor != "" {
+		set("$EDITOR", "editor", editor+" .")
+	}
+	return commands, detected
+}
+
+// packageScripts reports which scripts package.json defines.
+func packageScripts(path string) (map[string]bool, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, false
+	}
+	scripts := map[string]bool{}
+	for name := range pkg.Scripts {
+		scripts[name] = true
+	}
+	return scripts, true
+}
+
+// packageRunner picks the package manager from the lockfile in root.
+func packageRunner(root string) string {
+	switch {
+	case fileExists(filepath.Join(root, "pnpm-lock.yaml")):
+		return "pnpm"
+	case fileExists(filepath.Join(root, "yarn.lock")):
+		return "yarn"
+	default:
+		return "npm"
+	}
+}
+
+var makeTargetRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_.-]*)\s*:([^=]|$)`)
+
+// makefileTargets lists the explicit targets defined in a Makefile.
+func makefileTargets(path string) map[string]bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+	targets := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := makeTargetRe.FindStringSubmatch(scanner.Text()); m != nil {
+			targets[m[1]] = true
+		}
+	}
+	return targets
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if trimmed := strings.TrimSpace(v); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// devSessionName turns the project directory's name into a valid session
+// name: tmux does not allow "." or ":" in session names.
+func devSessionName(root string) string {
+	name := strings.NewReplacer(".", "-", ":", "-").Replace(filepath.Base(root))
+	if strings.Trim(name, "-/") == "" {
+		return "dev"
+	}
+	return name
+}
+
+func writeDevResult(cmd *cobra.Command, outputOpts output.OutputOptions, result devResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, s := range result.Slots {
+			_, _ = fmt.Fprintf(out, "%s\t%s\n", s.Slot, s.PaneID)
+		}
+		return nil
+	}
+	source := "detected from " + strings.Join(result.Detected, ", ")
+	switch {
+	case result.Config != "":
+		source = "configured by " + result.Config
+	case len(result.Detected) == 0:
+		source = "nothing detected"
+	}
+	switch {
+	case result.DryRun:
+		_, _ = fmt.Fprintf(out, "Would create window %q in session %q from preset %s (%s).\n", result.Window, result.Session, result.Preset, source)
+	case result.Created:
+		_, _ = fmt.Fprintf(out, "Created window %q (index %d) in session %q from preset %s (%s).\n", result.Window, result.WindowIndex, result.Session, result.Preset, source)
+	default:
+		_, _ = fmt.Fprintf(out, "Window %q already exists in session %q (index %d).\n", result.Window, result.Session, result.WindowIndex)
+	}
+	if len(result.Slots) == 0 {
+		return nil
+	}
+	table := newTextTable("SLOT", "PANE", "COMMAND")
+	for _, s := range result.Slots {
+		table.addRow(cell(s.Slot), cell(s.PaneID), cell(s.Command))
+	}
+	return table.render(out)
+}
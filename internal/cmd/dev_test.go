@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeDevFile(t *testing.T, dir string, name string, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindDevProjectRoot(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(repo, "src", "app")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if root, config := findDevProjectRoot(nested); root != repo || config != "" {
+		t.Fatalf("expected git root %s, got %s (%s)", repo, root, config)
+	}
+	writeDevFile(t, repo, "src/.arc-tmux.yaml", "window: api\n")
+	root, config := findDevProjectRoot(nested)
+	if root != filepath.Join(repo, "src") || config != filepath.Join(repo, "src", devConfigName) {
+		t.Fatalf("expected nearest .arc-tmux.yaml, got %s (%s)", root, config)
+	}
+}
+
+func TestDetectDevCommands(t *testing.T) {
+	t.Setenv("EDITOR", "nvim")
+	dir := t.TempDir()
+	writeDevFile(t, dir, "package.json", `{"scripts": {"start": "node ."}}`)
+	writeDevFile(t, dir, "yarn.lock", "")
+	writeDevFile(t, dir, "Makefile", "VAR := 1\n.PHONY: test\nbuild:\n\tgo build\ntest: build\n\tgo test ./...\n")
+	preset := builtinPresets()[len(builtinPresets())-1]
+	commands, detected := detectDevCommands(dir, preset)
+	want := map[string]string{"server": "yarn start", "test": "make test", "editor": "nvim ."}
+	if !reflect.DeepEqual(commands, want) {
+		t.Fatalf("unexpected commands: %v", commands)
+	}
+	if !reflect.DeepEqual(detected, []string{"package.json", "Makefile", "$EDITOR"}) {
+		t.Fatalf("unexpected detected: %v", detected)
+	}
+}
+
+func TestDevProjectPreset(t *testing.T) {
+	t.Setenv("ARC_TMUX_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+	preset, err := devProjectPreset(devProjectConfig{}, "")
+	if err != nil || preset.Name != devDefaultPreset {
+		t.Fatalf("expected the dev preset, got %q (%v)", preset.Name, err)
+	}
+	inline := devProjectConfig{Slots: []presetSlot{{Name: "api"}, {Name: "db", Split: "h"}}}
+	if preset, err = devProjectPreset(inline, ""); err != nil || len(preset.Slots) != 2 {
+		t.Fatalf("expected inline slots, got %+v (%v)", preset, err)
+	}
+	inline.Preset = "ide"
+	if _, err := devProjectPreset(inline, ""); err == nil {
+		t.Fatal("expected error for both preset and slots")
+	}
+}
+
+func TestDevSessionName(t *testing.T) {
+	if got := devSessionName("/src/shop.io"); got != "shop-io" {
+		t.Fatalf("unexpected session name: %s", got)
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// maxDiffCells bounds the LCS table; larger differing regions are reported
+// as a single replacement.
+const maxDiffCells = 4_000_000
+
+type diffResult struct {
+	Pane       string     `json:"pane" yaml:"pane"`
+	Against    string     `json:"against,omitempty" yaml:"against,omitempty"`
+	Checkpoint string     `json:"checkpoint,omitempty" yaml:"checkpoint,omitempty"`
+	SavedAt    *time.Time `json:"saved_at,omitempty" yaml:"saved_at,omitempty"`
+	Saved      bool       `json:"saved,omitempty" yaml:"saved,omitempty"`
+	Changed    bool       `json:"changed" yaml:"changed"`
+	Added      int        `json:"added" yaml:"added"`
+	Removed    int        `json:"removed" yaml:"removed"`
+	Hunks      []diffHunk `json:"hunks" yaml:"hunks"`
+}
+
+// diffHunk is one unified-diff hunk; Lines carry a " ", "-", or "+" prefix.
+type diffHunk struct {
+	OldStart int      `json:"old_start" yaml:"old_start"`
+	OldLines int      `json:"old_lines" yaml:"old_lines"`
+	NewStart int      `json:"new_start" yaml:"new_start"`
+	NewLines int      `json:"new_lines" yaml:"new_lines"`
+	Lines    []string `json:"lines" yaml:"lines"`
+}
+
+type diffOp struct {
+	Kind byte // ' ', '-', '+'
+	Text string
+}
+
+func newDiffCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var save string
+	var since string
+	var with string
+	var lines int
+	var context int
+	var update bool
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Diff pane output against a checkpoint or another pane",
+		Long: `Compare pane output over time or across panes.
+
+--save NAME stores the pane's current capture as a named checkpoint.
+--since NAME diffs the pane against that checkpoint; --pane defaults to the
+pane the checkpoint was taken from, and --update re-saves it afterwards so the
+next diff shows only newer changes. --with PANE diffs two panes.
+
+Text output is a unified diff; --output json reports the hunks. Checkpoints
+live in ARC_TMUX_CHECKPOINTS (default: the user cache dir).`,
+		Example: `  arc-tmux diff --pane @dashboard --save dash
+  arc-tmux diff --since dash
+  arc-tmux diff --since dash --update --output json
+  arc-tmux diff --pane fe:1.0 --with fe:1.1`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			modes := 0
+			for _, v := range []string{save, since, with} {
+				if strings.TrimSpace(v) != "" {
+					modes++
+				}
+			}
+			if modes != 1 {
+				return errors.New("use exactly one of --save, --since, or --with")
+			}
+			if update && strings.TrimSpace(since) == "" {
+				return errors.New("--update requires --since")
+			}
+			if context < 0 {
+				context = 0
+			}
+			dir := defaultCheckpointDir()
+
+			var checkpoint paneCheckpoint
+			if strings.TrimSpace(since) != "" {
+				name, err := normalizeCheckpointName(since)
+				if err != nil {
+					return err
+				}
+				cp, ok, err := loadCheckpoint(dir, name)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return newCodedError(errCheckpointNotFound, fmt.Sprintf("no checkpoint named %q", name), nil)
+				}
+				checkpoint = cp
+				if strings.TrimSpace(paneArg) == "" {
+					paneArg = cp.PaneID
+				}
+			}
+
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			current, err := tmux.Capture(handle.ID, lines)
+			if err != nil {
+				return err
+			}
+
+			result := diffResult{Pane: handle.Target, Hunks: []diffHunk{}}
+			var old string
+			switch {
+			case strings.TrimSpace(save) != "":
+				name, err := normalizeCheckpointName(save)
+				if err != nil {
+					return err
+				}
+				now := time.Now().UTC()
+				if err := saveCheckpoint(dir, paneCheckpoint{Name: name, Pane: handle.Target, PaneID: handle.ID, SavedAt: now, Content: current}); err != nil {
+					return err
+				}
+				result.Checkpoint = name
+				result.SavedAt = &now
+				result.Saved = true
+				return writeDiffResult(cmd, outputOpts, result)
+			case strings.TrimSpace(with) != "":
+				otherTarget, err := resolvePaneTarget(with)
+				if err != nil {
+					return err
+				}
+				other, err := canonicalPaneTarget(otherTarget)
+				if err != nil {
+					return err
+				}
+				otherContent, err := tmux.Capture(other.ID, lines)
+				if err != nil {
+					return err
+				}
+				result.Against = other.Target
+				old = otherContent
+			default:
+				savedAt := checkpoint.SavedAt
+				result.Against = "checkpoint " + checkpoint.Name
+				result.Checkpoint = checkpoint.Name
+				result.SavedAt = &savedAt
+				old = checkpoint.Content
+			}
+
+			ops := lineEditScript(splitCaptureLines(old), splitCaptureLines(current))
+			for _, op := range ops {
+				switch op.Kind {
+				case '+':
+					result.Added++
+				case '-':
+					result.Removed++
+				}
+			}
+			result.Changed = result.Added+result.Removed > 0
+			result.Hunks = buildDiffHunks(ops, context)
+
+			if update {
+				now := time.Now().UTC()
+				checkpoint.Pane = handle.Target
+				checkpoint.PaneID = handle.ID
+				checkpoint.SavedAt = now
+				checkpoint.Content = current
+				if err := saveCheckpoint(dir, checkpoint); err != nil {
+					return err
+				}
+				result.Saved = true
+			}
+			return writeDiffResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().StringVar(&save, "save", "", "Save the pane's output as a named checkpoint")
+	cmd.Flags().StringVar(&since, "since", "", "Diff the pane against a named checkpoint")
+	cmd.Flags().StringVar(&with, "with", "", "Diff the pane against another pane")
+	cmd.Flags().IntVar(&lines, "lines", 200, "Limit capture to last N lines (0 for full)")
+	cmd.Flags().IntVar(&context, "context", 3, "Unchanged lines of context around each hunk")
+	cmd.Flags().BoolVar(&update, "update", false, "With --since, re-save the checkpoint after diffing")
+	return cmd
+}
+
+func writeDiffResult(cmd *cobra.Command, outputOpts output.OutputOptions, result diffResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		if result.Against == "" {
+			return nil
+		}
+		if result.Changed {
+			_, _ = fmt.Fprintln(out, "changed")
+		} else {
+			_, _ = fmt.Fprintln(out, "unchanged")
+		}
+		return nil
+	}
+	if result.Against == "" {
+		_, _ = fmt.Fprintf(out, "Saved checkpoint %s from %s.\n", result.Checkpoint, result.Pane)
+		return nil
+	}
+	if !result.Changed {
+		_, _ = fmt.Fprintf(out, "No changes in %s against %s.\n", result.Pane, result.Against)
+		return nil
+	}
+	return writeUnifiedDiff(out, result)
+}
+
+func writeUnifiedDiff(out io.Writer, result diffResult) error {
+	var b strings.Builder
+	oldLabel := result.Against
+	if result.SavedAt != nil {
+		oldLabel += "\t" + result.SavedAt.Format(time.RFC3339)
+	}
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldLabel, result.Pane)
+	for _, h := range result.Hunks {
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+		for _, line := range h.Lines {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// splitCaptureLines splits a capture into lines, dropping the blank rows
+// tmux pads the visible screen with.
+func splitCaptureLines(capture string) []string {
+	trimmed := strings.TrimRight(capture, "\n")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "\n")
+}
+
+// lineEditScript returns the edit script turning a into b, using an LCS over the
+// region left after trimming the common prefix and suffix.
+func lineEditScript(a []string, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{Kind: ' ', Text: line})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{Kind: ' ', Text: line})
+	}
+	return ops
+}
+
+func diffMiddle(a []string, b []string) []diffOp {
+	var ops []diffOp
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			ops = append(ops, diffOp{Kind: '-', Text: line})
+		}
+		for _, line := range b {
+			ops = append(ops, diffOp{Kind: '+', Text: line})
+		}
+		return ops
+	}
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{Kind: ' ', Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{Kind: '-', Text: a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{Kind: '+', Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{Kind: '-', Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{Kind: '+', Text: b[j]})
+	}
+	return ops
+}
+
+// buildDiffHunks groups changes into unified-diff hunks with context lines
+// on each side; changes closer than 2*context lines share a hunk.
+func buildDiffHunks(ops []diffOp, context int) []diffHunk {
+	// oldPos/newPos count the lines consumed before ops[k].
+	oldPos := make([]int, len(ops)+1)
+	newPos := make([]int, len(ops)+1)
+	for k, op := range ops {
+		oldPos[k+1], newPos[k+1] = oldPos[k], newPos[k]
+		if op.Kind != '+' {
+			oldPos[k+1]++
+		}
+		if op.Kind != '-' {
+			newPos[k+1]++
+		}
+	}
+	hunks := []diffHunk{}
+	for i := 0; i < len(ops); {
+		if ops[i].Kind == ' ' {
+			i++
+			continue
+		}
+		start := max(0, i-context)
+		last := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].Kind != ' ' {
+				last = j
+			} else if j-last > 2*context {
+				break
+			}
+		}
+		stop := min(len(ops), last+context+1)
+		h := diffHunk{
+			OldStart: oldPos[start] + 1,
+			OldLines: oldPos[stop] - oldPos[start],
+			NewStart: newPos[start] + 1,
+			NewLines: newPos[stop] - newPos[start],
+		}
+		if h.OldLines == 0 {
+			h.OldStart--
+		}
+		if h.NewLines == 0 {
+			h.NewStart--
+		}
+		for _, op := range ops[start:stop] {
+			h.Lines = append(h.Lines, string(op.Kind)+op.Text)
+		}
+		hunks = append(hunks, h)
+		i = stop
+	}
+	return hunks
+}
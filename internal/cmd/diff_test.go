@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLineEditScriptAndHunks(t *testing.T) {
+	old := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	cur := []string{"a", "b", "c", "D", "e", "f", "g", "h", "i", "j", "k"}
+	ops := lineEditScript(old, cur)
+	hunks := buildDiffHunks(ops, 1)
+	want := []diffHunk{
+		{OldStart: 3, OldLines: 3, NewStart: 3, NewLines: 3, Lines: []string{" c", "-d", "+D", " e"}},
+		{OldStart: 10, OldLines: 1, NewStart: 10, NewLines: 2, Lines: []string{" j", "+k"}},
+	}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Fatalf("hunks = %+v, want %+v", hunks, want)
+	}
+	if got := buildDiffHunks(lineEditScript(old, old), 3); len(got) != 0 {
+		t.Fatalf("expected no hunks for identical input, got %+v", got)
+	}
+}
+
+func TestBuildDiffHunksFromEmpty(t *testing.T) {
+	hunks := buildDiffHunks(lineEditScript(nil, []string{"x", "y"}), 3)
+	if len(hunks) != 1 || hunks[0].OldStart != 0 || hunks[0].OldLines != 0 || hunks[0].NewStart != 1 || hunks[0].NewLines != 2 {
+		t.Fatalf("unexpected hunks %+v", hunks)
+	}
+}
+
+func TestCheckpointStore(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := normalizeCheckpointName("../x"); err == nil {
+		t.Fatalf("expected invalid name error")
+	}
+	name, err := normalizeCheckpointName(" Dash ")
+	if err != nil || name != "dash" {
+		t.Fatalf("normalizeCheckpointName = %q, %v", name, err)
+	}
+	if _, ok, err := loadCheckpoint(dir, name); ok || err != nil {
+		t.Fatalf("expected missing checkpoint, got ok=%v err=%v", ok, err)
+	}
+	cp := paneCheckpoint{Name: name, Pane: "dev:0.0", PaneID: "%1", SavedAt: time.Unix(1700000000, 0).UTC(), Content: "hello\n"}
+	if err := saveCheckpoint(dir, cp); err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err := loadCheckpoint(dir, name)
+	if err != nil || !ok || !reflect.DeepEqual(got, cp) {
+		t.Fatalf("loadCheckpoint = %+v, %v, %v", got, ok, err)
+	}
+}
+
+func TestWriteUnifiedDiff(t *testing.T) {
+	var b strings.Builder
+	result := diffResult{Pane: "dev:0.0", Against: "dev:0.1", Changed: true, Hunks: []diffHunk{{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1, Lines: []string{"-a", "+b"}}}}
+	if err := writeUnifiedDiff(&b, result); err != nil {
+		t.Fatal(err)
+	}
+	want := "--- dev:0.1\n+++ dev:0.0\n@@ -1,1 +1,1 @@\n-a\n+b\n"
+	if b.String() != want {
+		t.Fatalf("unified diff = %q, want %q", b.String(), want)
+	}
+}
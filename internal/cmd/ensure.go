@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/yourorg/arc-sdk/output"
@@ -27,6 +29,17 @@ type ensureResult struct {
 	CreatedPane    bool   `json:"created_pane" yaml:"created_pane"`
 	AddedPanes     int    `json:"added_panes" yaml:"added_panes"`
 	LayoutApplied  bool   `json:"layout_applied" yaml:"layout_applied"`
+	Respawned      bool   `json:"respawned" yaml:"respawned"`
+	RespawnReason  string `json:"respawn_reason,omitempty" yaml:"respawn_reason,omitempty"`
+	// Cwd is the target pane's working directory as tmux reports it.
+	Cwd string `json:"cwd,omitempty" yaml:"cwd,omitempty"`
+	// Preset and Slots report the --preset the window was built from and the
+	// pane in each of its slots.
+	Preset string           `json:"preset,omitempty" yaml:"preset,omitempty"`
+	Slots  []presetSlotPane `json:"slots,omitempty" yaml:"slots,omitempty"`
+	// DeadlineExceeded marks a partial result reported when --deadline hit.
+	DeadlineExceeded bool   `json:"deadline_exceeded,omitempty" yaml:"deadline_exceeded,omitempty"`
+	Error            string `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
 func newEnsureCmd() *cobra.Command {
@@ -38,6 +51,11 @@ func newEnsureCmd() *cobra.Command {
 	var split string
 	var cwd string
 	var envVars []string
+	var reconcile bool
+	var respawnDead bool
+	var deadline float64
+	var presetName string
+	var slotCommands []string
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
@@ -46,14 +64,32 @@ func newEnsureCmd() *cobra.Command {
 		Long: `Ensure a session, window, and optional pane exist without duplication.
 
 If the target already exists, this is a no-op. When creating panes, optional
-command/cwd/env are only applied to newly created panes.`,
+command/cwd/env are only applied to newly created panes.
+
+With --reconcile, an existing pane matching --pane-title is checked against the
+expected command (pane command and process tree) and respawned if it is running
+something else. With --respawn-dead, a target pane whose command has exited
+(remain-on-exit) is respawned with the given command, or its original one.
+
+With --preset, a missing window is built from a multi-pane preset (see
+"arc-tmux preset --list"); for an existing window, the slots are matched to
+panes by title. Either way the slot-to-pane mapping is reported.`,
 		Example: `  # Ensure a window exists, run a command once if created
   arc-tmux ensure "npm test" --session dev --window build
 
   # Ensure a named pane exists with a layout
-  arc-tmux ensure "npm run dev" --session dev --window api --pane-title server --panes 2 --layout tiled`,
+  arc-tmux ensure "npm run dev" --session dev --window api --pane-title server --panes 2 --layout tiled
+
+  # Restart the server pane if it is no longer running the dev server
+  arc-tmux ensure "npm run dev" --session dev --window api --pane-title server --reconcile
+
+  # Bring back a pane whose command exited
+  arc-tmux ensure --session dev --window api --pane-title server --respawn-dead
+
+  # Build an editor/server/logs window once
+  arc-tmux ensure --session dev --window api --preset ide --cmd server="npm run dev"`,
 		Args: cobra.MaximumNArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) (retErr error) {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
@@ -70,20 +106,75 @@ command/cwd/env are only applied to newly created panes.`,
 			if len(args) > 0 {
 				command = args[0]
 			}
+			if reconcile && (strings.TrimSpace(command) == "" || paneTitle == "") {
+				return errors.New("--reconcile requires a command and --pane-title")
+			}
+			var preset windowPreset
+			var presetCommands map[string]string
+			if strings.TrimSpace(presetName) != "" {
+				if command != "" || paneTitle != "" || panes > 0 || split != "" || layout != "" || reconcile || respawnDead {
+					return errors.New("--preset cannot be combined with a command, --pane-title, --panes, --split, --layout, --reconcile, or --respawn-dead")
+				}
+				var err error
+				if preset, err = findPreset(presetName); err != nil {
+					return err
+				}
+				if presetCommands, err = parseSlotCommands(slotCommands, preset); err != nil {
+					return err
+				}
+			} else if len(slotCommands) > 0 {
+				return errors.New("--cmd requires --preset")
+			}
 
 			envPairs, err := parseEnvVars(envVars)
 			if err != nil {
 				return newCodedError(errInvalidEnv, err.Error(), err)
 			}
-			paneCommand := buildRunCommand(command, strings.TrimSpace(cwd), envPairs)
-			spawnCommand := buildRunCommand("", strings.TrimSpace(cwd), envPairs)
+			startDir, err := resolveStartDir(cwd)
+			if err != nil {
+				return err
+			}
+			paneCommand := buildRunCommand(command, "", envPairs)
+			spawnCommand := buildRunCommand("", "", envPairs)
+
+			result := ensureResult{Window: window, PaneTitle: paneTitle}
+			createdSession := false
+			windowCreated := false
+			paneCreated := false
+			addedPanes := 0
+			layoutApplied := false
+			var windowIndex int
+			var targetPaneID string
+			fillResult := func() {
+				result.CreatedSession = createdSession
+				result.CreatedWindow = windowCreated
+				result.CreatedPane = paneCreated
+				result.AddedPanes = addedPanes
+				result.LayoutApplied = layoutApplied
+				result.WindowIndex = windowIndex
+				result.PaneID = targetPaneID
+			}
+			if deadline > 0 {
+				tmux.SetDeadline(time.Now().Add(time.Duration(deadline * float64(time.Second))))
+				defer tmux.SetDeadline(time.Time{})
+				defer func() {
+					if retErr == nil || !tmux.DeadlineExceeded() {
+						return
+					}
+					fillResult()
+					result.DeadlineExceeded = true
+					result.Error = retErr.Error()
+					_ = writeEnsureResult(cmd, outputOpts, result, layout)
+					retErr = newCodedError(errDeadlineExceeded, "ensure did not finish before --deadline", retErr)
+				}()
+			}
 
 			sess, shouldStyle, err := resolveEnsureSession(session)
 			if err != nil {
 				return err
 			}
+			result.Session = sess
 
-			createdSession := false
 			exists, err := tmux.HasSession(sess)
 			if err != nil {
 				return err
@@ -100,24 +191,41 @@ command/cwd/env are only applied to newly created panes.`,
 				}
 			}
 
-			result := ensureResult{Session: sess, Window: window, PaneTitle: paneTitle}
-
 			wins, err := tmux.ListWindows(sess)
 			if err != nil {
 				return err
 			}
 
 			win, found := findWindowByName(wins, window)
-			windowCreated := false
-			paneCreated := false
-			addedPanes := 0
-			layoutApplied := false
-			var windowIndex int
-			var targetPaneID string
 			windowTarget := ""
 
-			if !found {
-				paneID, err := tmux.NewWindow(sess, window, paneCommand)
+			if preset.Name != "" {
+				result.Preset = preset.Name
+				if !found {
+					built, err := buildPresetWindow(sess, window, preset, presetCommands, startDir, envPairs)
+					if err != nil {
+						return err
+					}
+					windowCreated = true
+					paneCreated = true
+					windowIndex = built.WindowIndex
+					targetPaneID = built.Slots[0].PaneID
+					result.Slots = built.Slots
+				} else {
+					windowIndex = win.WindowIndex
+					panesList, err := panesForWindow(sess, windowIndex)
+					if err != nil {
+						return err
+					}
+					result.Slots = matchPresetSlots(preset, panesList)
+					if len(result.Slots) > 0 {
+						targetPaneID = result.Slots[0].PaneID
+					} else if targetPaneID, err = pickPaneID(panesList, sess, windowIndex); err != nil {
+						return err
+					}
+				}
+			} else if !found {
+				paneID, err := tmux.NewWindowDir(sess, window, paneCommand, startDir)
 				if err != nil {
 					return err
 				}
@@ -134,7 +242,7 @@ command/cwd/env are only applied to newly created panes.`,
 				} else {
 					windowIndex = parsedWindow
 				}
-				windowTarget = fmt.Sprintf("%s:%d", sess, windowIndex)
+				windowTarget = tmux.WindowTarget(sess, windowIndex)
 
 				if isAgentSessionName(sess) {
 					if err := tmux.ApplyAgentWindowStyle(sess, windowIndex); err != nil {
@@ -149,7 +257,7 @@ command/cwd/env are only applied to newly created panes.`,
 				if panes > 1 {
 					current := 1
 					for current < panes {
-						if _, err := tmux.SplitWindow(windowTarget, split, spawnCommand); err != nil {
+						if _, err := tmux.SplitWindowDir(windowTarget, split, spawnCommand, startDir); err != nil {
 							return err
 						}
 						addedPanes++
@@ -158,7 +266,7 @@ command/cwd/env are only applied to newly created panes.`,
 				}
 			} else {
 				windowIndex = win.WindowIndex
-				windowTarget = fmt.Sprintf("%s:%d", sess, windowIndex)
+				windowTarget = tmux.WindowTarget(sess, windowIndex)
 
 				panesList, err := panesForWindow(sess, windowIndex)
 				if err != nil {
@@ -169,7 +277,7 @@ command/cwd/env are only applied to newly created panes.`,
 					if match := findPaneByTitle(panesList, paneTitle); match != nil {
 						targetPaneID = formattedPaneID(match)
 					} else {
-						paneID, err := tmux.SplitWindow(windowTarget, split, paneCommand)
+						paneID, err := tmux.SplitWindowDir(windowTarget, split, paneCommand, startDir)
 						if err != nil {
 							return err
 						}
@@ -189,13 +297,32 @@ command/cwd/env are only applied to newly created panes.`,
 					targetPaneID = paneID
 				}
 
+				if (reconcile || respawnDead) && !paneCreated {
+					pane, err := tmux.PaneDetailsForTarget(targetPaneID)
+					if err != nil {
+						return err
+					}
+					if reason := respawnReason(pane, command, reconcile, respawnDead); reason != "" {
+						if err := tmux.RespawnPaneDir(pane.PaneID, paneCommand, startDir); err != nil {
+							return err
+						}
+						if paneTitle != "" {
+							if err := tmux.SetPaneTitle(pane.PaneID, paneTitle); err != nil {
+								return err
+							}
+						}
+						result.Respawned = true
+						result.RespawnReason = reason
+					}
+				}
+
 				current := len(panesList)
 				if paneCreated {
 					current++
 				}
 				if panes > 0 && current < panes {
 					for current < panes {
-						if _, err := tmux.SplitWindow(windowTarget, split, spawnCommand); err != nil {
+						if _, err := tmux.SplitWindowDir(windowTarget, split, spawnCommand, startDir); err != nil {
 							return err
 						}
 						addedPanes++
@@ -205,60 +332,17 @@ command/cwd/env are only applied to newly created panes.`,
 			}
 
 			if layout != "" && (windowCreated || paneCreated || addedPanes > 0) {
-				if err := tmux.SelectLayout(windowTarget, layout); err != nil {
+				if err := selectLayoutUndoable(windowTarget, layout); err != nil {
 					return err
 				}
 				layoutApplied = true
 			}
 
-			result.CreatedSession = createdSession
-			result.CreatedWindow = windowCreated
-			result.CreatedPane = paneCreated
-			result.AddedPanes = addedPanes
-			result.LayoutApplied = layoutApplied
-			result.WindowIndex = windowIndex
-			result.PaneID = targetPaneID
-
-			out := cmd.OutOrStdout()
-			switch {
-			case outputOpts.Is(output.OutputJSON):
-				enc := json.NewEncoder(out)
-				enc.SetIndent("", "  ")
-				return enc.Encode(result)
-			case outputOpts.Is(output.OutputYAML):
-				enc := yaml.NewEncoder(out)
-				defer func() { _ = enc.Close() }()
-				return enc.Encode(result)
-			case outputOpts.Is(output.OutputQuiet):
-				if result.PaneID != "" {
-					_, _ = fmt.Fprintln(out, result.PaneID)
-				}
-				return nil
-			}
-
-			if result.CreatedWindow {
-				_, _ = fmt.Fprintf(out, "Ensured window %q in session %q (index %d).\n", result.Window, result.Session, result.WindowIndex)
-			} else {
-				_, _ = fmt.Fprintf(out, "Window %q already exists in session %q (index %d).\n", result.Window, result.Session, result.WindowIndex)
-			}
-			if result.PaneID != "" {
-				status := "existing"
-				if result.CreatedPane {
-					status = "created"
-				}
-				if result.PaneTitle != "" {
-					_, _ = fmt.Fprintf(out, "Pane %s (%s, title=%q).\n", result.PaneID, status, result.PaneTitle)
-				} else {
-					_, _ = fmt.Fprintf(out, "Pane %s (%s).\n", result.PaneID, status)
-				}
-			}
-			if result.AddedPanes > 0 {
-				_, _ = fmt.Fprintf(out, "Added panes: %d\n", result.AddedPanes)
-			}
-			if result.LayoutApplied {
-				_, _ = fmt.Fprintf(out, "Layout applied: %s\n", layout)
+			fillResult()
+			if targetPaneID != "" {
+				result.Cwd = settledPanePath(targetPaneID, startDir)
 			}
-			return nil
+			return writeEnsureResult(cmd, outputOpts, result, layout)
 		},
 	}
 
@@ -271,10 +355,117 @@ command/cwd/env are only applied to newly created panes.`,
 	cmd.Flags().StringVar(&split, "split", "", "Split direction when creating panes (h|v)")
 	cmd.Flags().StringVar(&cwd, "cwd", "", "Working directory for newly created panes")
 	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Set environment variables for newly created panes (KEY=VAL). Repeatable.")
+	cmd.Flags().BoolVar(&reconcile, "reconcile", false, "Respawn the --pane-title pane if it is not running the command")
+	cmd.Flags().BoolVar(&respawnDead, "respawn-dead", false, "Respawn the target pane if its command has exited (pane_dead)")
+	cmd.Flags().StringVar(&presetName, "preset", "", "Build a missing window from this multi-pane preset")
+	cmd.Flags().StringArrayVar(&slotCommands, "cmd", nil, "Command for a --preset slot in a new window (SLOT=COMMAND). Repeatable.")
+	cmd.Flags().Float64Var(&deadline, "deadline", 0, "Maximum seconds for the whole operation; reports partial progress when hit (0 for none)")
 
 	return cmd
 }
 
+func writeEnsureResult(cmd *cobra.Command, outputOpts output.OutputOptions, result ensureResult, layout string) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		if result.PaneID != "" {
+			_, _ = fmt.Fprintln(out, result.PaneID)
+		}
+		return nil
+	}
+
+	if result.CreatedWindow {
+		_, _ = fmt.Fprintf(out, "Ensured window %q in session %q (index %d).\n", result.Window, result.Session, result.WindowIndex)
+	} else {
+		_, _ = fmt.Fprintf(out, "Window %q already exists in session %q (index %d).\n", result.Window, result.Session, result.WindowIndex)
+	}
+	if result.PaneID != "" {
+		status := "existing"
+		if result.CreatedPane {
+			status = "created"
+		}
+		if result.Respawned {
+			status = "respawned: " + strings.ReplaceAll(result.RespawnReason, "_", " ")
+		}
+		if result.PaneTitle != "" {
+			status += fmt.Sprintf(", title=%q", result.PaneTitle)
+		}
+		if result.Cwd != "" {
+			status += ", cwd=" + result.Cwd
+		}
+		_, _ = fmt.Fprintf(out, "Pane %s (%s).\n", result.PaneID, status)
+	}
+	for _, slot := range result.Slots {
+		_, _ = fmt.Fprintf(out, "Slot %s: %s (%s)\n", slot.Slot, slot.PaneID, slot.ID)
+	}
+	if result.AddedPanes > 0 {
+		_, _ = fmt.Fprintf(out, "Added panes: %d\n", result.AddedPanes)
+	}
+	if result.LayoutApplied {
+		_, _ = fmt.Fprintf(out, "Layout applied: %s\n", layout)
+	}
+	return nil
+}
+
+// respawnReason returns why an existing pane should be respawned, or "".
+func respawnReason(pane tmux.PaneDetails, command string, reconcile bool, respawnDead bool) string {
+	if pane.Dead {
+		if reconcile || respawnDead {
+			return "dead"
+		}
+		return ""
+	}
+	if reconcile {
+		tree, _ := tmux.ProcessTree(pane.PID)
+		if !paneRunsCommand(pane, tree, command) {
+			return "command_mismatch"
+		}
+	}
+	return ""
+}
+
+// paneRunsCommand reports whether the pane's foreground command or any process
+// in its tree matches the expected command line.
+func paneRunsCommand(pane tmux.PaneDetails, tree []tmux.ProcessNode, command string) bool {
+	want := strings.TrimSpace(command)
+	if want == "" {
+		return true
+	}
+	program := commandProgram(want)
+	if program != "" && pane.Command == program {
+		return true
+	}
+	for _, node := range tree {
+		if strings.Contains(node.Command, want) {
+			return true
+		}
+		if program != "" && commandProgram(node.Command) == program {
+			return true
+		}
+	}
+	return false
+}
+
+// commandProgram returns the base name of the program in a command line,
+// skipping leading VAR=value assignments.
+func commandProgram(command string) string {
+	for _, field := range strings.Fields(command) {
+		if strings.Contains(field, "=") {
+			continue
+		}
+		return filepath.Base(field)
+	}
+	return ""
+}
+
 func resolveEnsureSession(raw string) (string, bool, error) {
 	trimmed := strings.TrimSpace(raw)
 	if strings.HasPrefix(trimmed, "@") {
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestPaneRunsCommand(t *testing.T) {
+	tree := []tmux.ProcessNode{
+		{PID: 10, Command: "-zsh", Depth: 0},
+		{PID: 11, PPID: 10, Command: "npm run dev", Depth: 1},
+		{PID: 12, PPID: 11, Command: "/usr/bin/node server.js", Depth: 2},
+	}
+	pane := tmux.PaneDetails{Command: "node", PID: 10}
+	if !paneRunsCommand(pane, tree, "npm run dev") {
+		t.Fatal("expected npm run dev to match process tree")
+	}
+	if !paneRunsCommand(pane, tree, "NODE_ENV=dev node other.js") {
+		t.Fatal("expected node program to match")
+	}
+	if paneRunsCommand(pane, tree[:1], "npm run dev") {
+		t.Fatal("expected idle shell not to match")
+	}
+}
+
+func TestCommandProgram(t *testing.T) {
+	cases := map[string]string{
+		"npm run dev":               "npm",
+		"/usr/local/bin/go test":    "go",
+		"FOO=1 BAR=2 python app.py": "python",
+		"":                          "",
+	}
+	for in, want := range cases {
+		if got := commandProgram(in); got != want {
+			t.Fatalf("%q: expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestPlanScale(t *testing.T) {
+	running := []tmux.PaneDetails{{PaneIndex: 0}, {PaneIndex: 2}, {PaneIndex: 1}}
+	if add, remove := planScale(running, 5); add != 2 || len(remove) != 0 {
+		t.Fatalf("expected add 2, got add=%d remove=%d", add, len(remove))
+	}
+	add, remove := planScale(running, 1)
+	if add != 0 || len(remove) != 2 || remove[0].PaneIndex != 2 || remove[1].PaneIndex != 1 {
+		t.Fatalf("expected to remove panes 2 and 1, got add=%d remove=%+v", add, remove)
+	}
+}
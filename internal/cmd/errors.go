@@ -33,13 +33,33 @@ func newCodedError(code string, message string, cause error) error {
 }
 
 const (
-	errPaneRequired      = "ERR_PANE_REQUIRED"
-	errInvalidPane       = "ERR_INVALID_PANE"
-	errUnknownSelector   = "ERR_UNKNOWN_SELECTOR"
-	errNoActivePane      = "ERR_NO_ACTIVE_PANE"
-	errNoCurrentPane     = "ERR_NO_CURRENT_PANE"
-	errNoTmuxClient      = "ERR_NOT_IN_TMUX"
-	errSignalUnsupported = "ERR_SIGNAL_UNSUPPORTED"
-	errCommandExit       = "ERR_COMMAND_EXIT"
-	errInvalidEnv        = "ERR_INVALID_ENV"
+	errPaneRequired         = "ERR_PANE_REQUIRED"
+	errInvalidPane          = "ERR_INVALID_PANE"
+	errUnknownSelector      = "ERR_UNKNOWN_SELECTOR"
+	errNoActivePane         = "ERR_NO_ACTIVE_PANE"
+	errNoCurrentPane        = "ERR_NO_CURRENT_PANE"
+	errNoTmuxClient         = "ERR_NOT_IN_TMUX"
+	errSignalUnsupported    = "ERR_SIGNAL_UNSUPPORTED"
+	errCommandExit          = "ERR_COMMAND_EXIT"
+	errInvalidEnv           = "ERR_INVALID_ENV"
+	errUnsupportedAPI       = "ERR_UNSUPPORTED_API_VERSION"
+	errInvalidFilter        = "ERR_INVALID_FILTER"
+	errAmbiguousPane        = "ERR_AMBIGUOUS_PANE"
+	errInvalidSession       = "ERR_INVALID_SESSION"
+	errInvalidPipeline      = "ERR_INVALID_PIPELINE"
+	errPipelineFailed       = "ERR_PIPELINE_FAILED"
+	errDeadlineExceeded     = "ERR_DEADLINE_EXCEEDED"
+	errConfirmationRequired = "ERR_CONFIRMATION_REQUIRED"
+	errPaneBusy             = "ERR_PANE_BUSY"
+	errPaneProtected        = "ERR_PANE_PROTECTED"
+	errNoMatch              = "ERR_NO_MATCH"
+	errReplNotReady         = "ERR_REPL_NOT_READY"
+	errCwdUnchanged         = "ERR_CWD_UNCHANGED"
+	errCheckpointNotFound   = "ERR_CHECKPOINT_NOT_FOUND"
+	errCheckpointChanged    = "ERR_CHECKPOINT_CHANGED"
+	errInvalidPreset        = "ERR_INVALID_PRESET"
+	errMaxRuntime           = "ERR_MAX_RUNTIME"
+	errCommandHung          = "ERR_COMMAND_HUNG"
+	errNoShellHook          = "ERR_NO_SHELL_HOOK"
+	errNothingToUndo        = "ERR_NOTHING_TO_UNDO"
 )
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// serverEvents are the lifecycle events "arc-tmux _event" handles.
+var serverEvents = map[string]bool{
+	"pane-exited":     true,
+	"window-unlinked": true,
+	"session-closed":  true,
+}
+
+type eventResult struct {
+	Event           string       `json:"event" yaml:"event"`
+	ID              string       `json:"id,omitempty" yaml:"id,omitempty"`
+	RemovedAliases  []aliasEntry `json:"removed_aliases" yaml:"removed_aliases"`
+	ClearedDefaults []string     `json:"cleared_defaults" yaml:"cleared_defaults"`
+}
+
+func newEventCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:    "_event <event> [id]",
+		Short:  "Handle a tmux lifecycle hook (used by arc-tmux hooks)",
+		Hidden: true,
+		Args:   cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if !serverEvents[args[0]] {
+				return fmt.Errorf("unknown event %q", args[0])
+			}
+			result := eventResult{Event: args[0], RemovedAliases: []aliasEntry{}, ClearedDefaults: []string{}}
+			if len(args) > 1 {
+				result.ID = args[1]
+			}
+			if err := syncServerState(&result); err != nil {
+				return err
+			}
+			return writeEventResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	return cmd
+}
+
+// syncServerState drops aliases and default panes that point at panes which
+// no longer exist. Every store is checked rather than only the pane named by
+// the event: a closed window or session takes its panes with it without a
+// pane-exited hook for each.
+func syncServerState(result *eventResult) error {
+	sessions, err := tmux.ListSessions()
+	if err != nil {
+		if errors.Is(err, tmux.ErrNoTmuxServer) {
+			return nil
+		}
+		return err
+	}
+	var stores []aliasStore
+	for _, s := range sessions {
+		stores = append(stores, aliasStore{Scope: aliasScopeSession, Session: s.Name})
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if path, ok := findProjectAliasFile(cwd); ok {
+			stores = append(stores, aliasStore{Scope: aliasScopeProject, Path: path})
+		}
+	}
+	stores = append(stores, aliasStore{Scope: aliasScopeGlobal, Path: aliasPath("")})
+	removed, err := pruneAliases(stores, danglingAliasTarget, false)
+	result.RemovedAliases = append(result.RemovedAliases, removed...)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		pane, ok, err := tmux.SessionOption(s.Name, tmux.DefaultPaneOption)
+		if err != nil || !ok {
+			continue
+		}
+		dangling, err := danglingAliasTarget(pane)
+		if err != nil {
+			return err
+		}
+		if !dangling {
+			continue
+		}
+		if err := tmux.UnsetSessionOption(s.Name, tmux.DefaultPaneOption); err != nil {
+			return err
+		}
+		result.ClearedDefaults = append(result.ClearedDefaults, s.Name)
+	}
+	return nil
+}
+
+func writeEventResult(cmd *cobra.Command, outputOpts output.OutputOptions, result eventResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, entry := range result.RemovedAliases {
+			_, _ = fmt.Fprintln(out, entry.Name)
+		}
+		return nil
+	}
+	for _, entry := range result.RemovedAliases {
+		_, _ = fmt.Fprintf(out, "Removed alias %s => %s (%s)\n", entry.Name, entry.Target, entry.Scope)
+	}
+	for _, session := range result.ClearedDefaults {
+		_, _ = fmt.Fprintf(out, "Cleared default pane of %s\n", session)
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// execResult is one NDJSON line emitted by "arc-tmux exec" as each command
+// finishes.
+type execResult struct {
+	Index           int     `json:"index" yaml:"index"`
+	Command         string  `json:"command" yaml:"command"`
+	Pane            string  `json:"pane,omitempty" yaml:"pane,omitempty"`
+	ExitCode        *int    `json:"exit_code,omitempty" yaml:"exit_code,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds" yaml:"duration_seconds"`
+	Error           string  `json:"error,omitempty" yaml:"error,omitempty"`
+	Output          string  `json:"output" yaml:"output"`
+}
+
+func (r execResult) failed() bool {
+	return r.Error != "" || r.ExitCode == nil || *r.ExitCode != 0
+}
+
+// execOptions controls how each command runs in its temporary pane.
+type execOptions struct {
+	Session string
+	Pool    int
+	Cwd     string
+	Env     []envVar
+	Timeout float64
+	Lines   int
+}
+
+func newExecCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var file string
+	var session string
+	var pool int
+	var cwd string
+	var envVars []string
+	var timeout float64
+	var lines int
+
+	cmd := &cobra.Command{
+		Use:   "exec [command...]",
+		Short: "Run commands in temporary panes and collect their exit codes",
+		Long: `Run one or more commands, each in a fresh window created in the background
+of the managed session (or --session), and remove the window once the command
+finishes. Each argument is one command; --file reads one command per line
+("-" for stdin), skipping blank lines and # comments.
+
+At most --pool commands run at once. Results are written as each command
+finishes, so with --output json the stream is NDJSON: one object per command
+with its index, pane, exit code, duration, and output. The exit status is
+ERR_COMMAND_EXIT when any command exits non-zero or times out.`,
+		Example: `  arc-tmux exec "make lint"
+  arc-tmux exec --pool 4 "go test ./pkg/..." "go vet ./..." "npm test"
+  arc-tmux exec --pool 4 --file checks.txt --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			commands := execCommandsFromArgs(args)
+			if strings.TrimSpace(file) != "" {
+				fromFile, err := readExecCommands(cmd, file)
+				if err != nil {
+					return err
+				}
+				commands = append(commands, fromFile...)
+			}
+			if len(commands) == 0 {
+				return fmt.Errorf("no commands given; pass them as arguments or with --file")
+			}
+			if pool < 1 {
+				return fmt.Errorf("--pool must be at least 1")
+			}
+			envPairs, err := parseEnvVars(envVars)
+			if err != nil {
+				return newCodedError(errInvalidEnv, err.Error(), err)
+			}
+			resolved, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			if resolved == "" {
+				resolved = resolveManagedSession()
+			}
+			if err := tmux.EnsureSession(resolved); err != nil {
+				return err
+			}
+
+			writer := newExecWriter(cmd.OutOrStdout(), outputOpts)
+			defer writer.close()
+			failed := runExecPool(commands, execOptions{
+				Session: resolved,
+				Pool:    pool,
+				Cwd:     strings.TrimSpace(cwd),
+				Env:     envPairs,
+				Timeout: timeout,
+				Lines:   lines,
+			}, writer.write)
+			if writer.err != nil {
+				return writer.err
+			}
+			if failed > 0 {
+				return newCodedError(errCommandExit, fmt.Sprintf("%d of %d commands failed", failed, len(commands)), nil)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&file, "file", "", "Read commands from this file, one per line (- for stdin)")
+	cmd.Flags().StringVar(&session, "session", "", "Session to create temporary windows in (name, @current, or @managed; default: managed session)")
+	cmd.Flags().IntVar(&pool, "pool", 1, "Maximum number of commands running at once")
+	cmd.Flags().StringVar(&cwd, "cwd", "", "Run each command from this working directory")
+	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Set environment variables for each command (KEY=VAL). Repeatable.")
+	cmd.Flags().Float64Var(&timeout, "timeout", 600.0, "Maximum seconds to wait for each command")
+	cmd.Flags().IntVar(&lines, "lines", 0, "Limit each command's output to its last N lines (0 for full)")
+	return cmd
+}
+
+func execCommandsFromArgs(args []string) []string {
+	commands := make([]string, 0, len(args))
+	for _, arg := range args {
+		if trimmed := strings.TrimSpace(arg); trimmed != "" {
+			commands = append(commands, trimmed)
+		}
+	}
+	return commands
+}
+
+// readExecCommands reads one command per line, skipping blank lines and lines
+// starting with #.
+func readExecCommands(cmd *cobra.Command, path string) ([]string, error) {
+	var r io.Reader
+	if strings.TrimSpace(path) == "-" {
+		r = cmd.InOrStdin()
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	return parseExecCommands(r)
+}
+
+func parseExecCommands(r io.Reader) ([]string, error) {
+	var commands []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		commands = append(commands, line)
+	}
+	return commands, scanner.Err()
+}
+
+// runExecPool runs commands across at most opts.Pool temporary panes, calling
+// emit (serialized) as each one finishes. It returns the number that failed.
+func runExecPool(commands []string, opts execOptions, emit func(execResult)) int {
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failed := 0
+	workers := opts.Pool
+	if workers > len(commands) {
+		workers = len(commands)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res := runExecCommand(i, commands[i], opts)
+				mu.Lock()
+				if res.failed() {
+					failed++
+				}
+				emit(res)
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range commands {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return failed
+}
+
+// runExecCommand opens a background window, runs command in its shell with
+// exit-code sentinels, and kills the window afterwards.
+func runExecCommand(index int, command string, opts execOptions) (res execResult) {
+	res = execResult{Index: index, Command: command}
+	start := time.Now()
+	defer func() { res.DurationSeconds = time.Since(start).Seconds() }()
+
+	if tmux.DeadlineExceeded() {
+		res.Error = tmux.ErrDeadlineExceeded.Error()
+		return res
+	}
+	target, err := tmux.NewDetachedWindow(opts.Session, fmt.Sprintf("exec-%d", index), "", "")
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Pane = target
+	handle, err := canonicalPaneTarget(target)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	defer func() { _ = tmux.Kill(handle.ID) }()
+
+	run, waitErr, err := executeRun(handle.ID, buildRunCommand(command, opts.Cwd, opts.Env), runOptions{
+		Timeout:     opts.Timeout,
+		Lines:       opts.Lines,
+		ExitCode:    true,
+		ExitTag:     "__ARC_TMUX_EXIT:",
+		Segment:     true,
+		UntilMarker: true,
+	})
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	// The capture ends with the pane's blank rows below the prompt.
+	if trimmed := strings.TrimRight(run.Output, "\n"); trimmed != "" {
+		res.Output = trimmed + "\n"
+	}
+	res.ExitCode = run.ExitCode
+	if waitErr != nil {
+		res.Error = waitErr.Error()
+	} else if !run.ExitFound {
+		res.Error = "exit code not found"
+	}
+	return res
+}
+
+// execWriter streams results in the selected output format.
+type execWriter struct {
+	out        io.Writer
+	outputOpts output.OutputOptions
+	yamlEnc    *yaml.Encoder
+	err        error
+}
+
+func newExecWriter(out io.Writer, outputOpts output.OutputOptions) *execWriter {
+	w := &execWriter{out: out, outputOpts: outputOpts}
+	if outputOpts.Is(output.OutputYAML) {
+		w.yamlEnc = yaml.NewEncoder(out)
+	}
+	return w
+}
+
+func (w *execWriter) write(res execResult) {
+	if w.err != nil {
+		return
+	}
+	switch {
+	case w.outputOpts.Is(output.OutputJSON):
+		w.err = json.NewEncoder(w.out).Encode(res)
+	case w.outputOpts.Is(output.OutputYAML):
+		w.err = w.yamlEnc.Encode(res)
+	case w.outputOpts.Is(output.OutputQuiet):
+		code := "unknown"
+		if res.ExitCode != nil {
+			code = strconv.Itoa(*res.ExitCode)
+		}
+		_, w.err = fmt.Fprintf(w.out, "%d\t%s\n", res.Index, code)
+	default:
+		_, w.err = fmt.Fprintf(w.out, "==> [%d] %s (%s, %.1fs)\n", res.Index, res.Command, execExitLabel(res), res.DurationSeconds)
+		if w.err == nil && res.Output != "" {
+			_, w.err = fmt.Fprint(w.out, res.Output)
+		}
+		if w.err == nil && res.Error != "" {
+			_, w.err = fmt.Fprintf(w.out, "error: %s\n", res.Error)
+		}
+	}
+}
+
+func (w *execWriter) close() {
+	if w.yamlEnc != nil {
+		_ = w.yamlEnc.Close()
+	}
+}
+
+func execExitLabel(res execResult) string {
+	if res.ExitCode == nil {
+		return "exit unknown"
+	}
+	return fmt.Sprintf("exit %d", *res.ExitCode)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseExecCommands(t *testing.T) {
+	input := "# checks\ngo vet ./...\n\n  go test ./...  \n#skip\nnpm test\n"
+	got, err := parseExecCommands(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseExecCommands: %v", err)
+	}
+	want := []string{"go vet ./...", "go test ./...", "npm test"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestExecResultFailed(t *testing.T) {
+	zero, one := 0, 1
+	cases := []struct {
+		res  execResult
+		want bool
+	}{
+		{execResult{ExitCode: &zero}, false},
+		{execResult{ExitCode: &one}, true},
+		{execResult{}, true},
+		{execResult{ExitCode: &zero, Error: "timeout"}, true},
+	}
+	for i, tc := range cases {
+		if got := tc.res.failed(); got != tc.want {
+			t.Fatalf("case %d: failed() = %v, want %v", i, got, tc.want)
+		}
+	}
+}
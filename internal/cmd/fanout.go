@@ -0,0 +1,276 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultSSHOptions keep a fan-out from hanging on a password prompt or an
+// unreachable host.
+var defaultSSHOptions = []string{"BatchMode=yes", "ConnectTimeout=10"}
+
+type fanoutResult struct {
+	Session     string       `json:"session" yaml:"session"`
+	Window      string       `json:"window" yaml:"window"`
+	WindowIndex int          `json:"window_index" yaml:"window_index"`
+	Command     string       `json:"command" yaml:"command"`
+	Failed      int          `json:"failed" yaml:"failed"`
+	Hosts       []fanoutHost `json:"hosts" yaml:"hosts"`
+}
+
+type fanoutHost struct {
+	Host            string  `json:"host" yaml:"host"`
+	Pane            string  `json:"pane" yaml:"pane"`
+	ExitCode        *int    `json:"exit_code,omitempty" yaml:"exit_code,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds" yaml:"duration_seconds"`
+	Error           string  `json:"error,omitempty" yaml:"error,omitempty"`
+	Output          string  `json:"output" yaml:"output"`
+}
+
+func (h fanoutHost) failed() bool {
+	return h.Error != "" || h.ExitCode == nil || *h.ExitCode != 0
+}
+
+func newFanoutCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var hosts []string
+	var hostsFile string
+	var command string
+	var session string
+	var window string
+	var sshOptions []string
+	var timeout float64
+	var lines int
+	var closeWindow bool
+
+	cmd := &cobra.Command{
+		Use:   "fanout --hosts HOST,... --command COMMAND",
+		Short: "Run a command on several hosts over SSH, one pane each",
+		Long: `Open a window with one pane per host (titled with the host name), run
+the command on every host at once with ssh, wait for each to finish, and
+report every host's exit code and output: a lightweight cluster shell whose
+sessions stay open in tmux for a closer look.
+
+Hosts come from --hosts (comma-separated or repeated) and --hosts-file (one
+per line, # comments allowed; - for stdin), and may be user@host or any ssh
+config alias. ssh runs with BatchMode=yes and ConnectTimeout=10 unless
+--ssh-option overrides them, so a host asking for a password fails instead
+of hanging. --close removes the window once every host has finished.
+
+The exit status is ERR_COMMAND_EXIT when any host fails, exits non-zero, or
+times out, after the report is written.`,
+		Example: `  arc-tmux fanout --hosts web1,web2,db1 --command uptime
+  arc-tmux fanout --hosts-file hosts.txt --command "df -h /" -o json
+  arc-tmux fanout --hosts deploy@app1 --command "systemctl is-active app" --ssh-option ConnectTimeout=3 --close`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			command = strings.TrimSpace(command)
+			if command == "" {
+				return fmt.Errorf("--command is required")
+			}
+			var fromFile []string
+			if strings.TrimSpace(hostsFile) != "" {
+				var err error
+				if fromFile, err = readExecCommands(cmd, hostsFile); err != nil {
+					return err
+				}
+			}
+			targets := fanoutHosts(hosts, fromFile)
+			if len(targets) == 0 {
+				return fmt.Errorf("no hosts given; pass --hosts or --hosts-file")
+			}
+			sess, shouldStyle, err := resolveEnsureSession(session)
+			if err != nil {
+				return err
+			}
+			exists, err := tmux.HasSession(sess)
+			if err != nil {
+				return err
+			}
+			if err := tmux.EnsureSession(sess); err != nil {
+				return fmt.Errorf("failed to ensure session %q: %w", sess, err)
+			}
+			if !exists {
+				if err := applyAgentStyleIfNeeded(sess, shouldStyle); err != nil {
+					return err
+				}
+			}
+			panes, windowIndex, err := buildTiledWindow(sess, strings.TrimSpace(window), "", targets, make([]string, len(targets)))
+			if err != nil {
+				return err
+			}
+			if closeWindow {
+				defer func() {
+					for _, p := range panes {
+						_ = tmux.Kill(p.ID)
+					}
+				}()
+			}
+
+			result := fanoutResult{Session: sess, Window: strings.TrimSpace(window), WindowIndex: windowIndex, Command: command}
+			result.Hosts = runFanout(panes, command, sshOptions, timeout, lines)
+			for _, h := range result.Hosts {
+				if h.failed() {
+					result.Failed++
+				}
+			}
+			if err := writeFanoutResult(cmd, outputOpts, result); err != nil {
+				return err
+			}
+			if result.Failed > 0 {
+				return newCodedError(errCommandExit, fmt.Sprintf("%d of %d hosts failed", result.Failed, len(result.Hosts)), nil)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringSliceVar(&hosts, "hosts", nil, "Hosts to run on (comma-separated or repeated)")
+	cmd.Flags().StringVar(&hostsFile, "hosts-file", "", "Read hosts from this file, one per line (- for stdin)")
+	cmd.Flags().StringVar(&command, "command", "", "Command to run on every host")
+	cmd.Flags().StringVar(&session, "session", "", "Session name or selector (@current|@managed)")
+	cmd.Flags().StringVar(&window, "window", "fanout", "Name for the window")
+	cmd.Flags().StringArrayVar(&sshOptions, "ssh-option", nil, "Extra ssh -o option (KEY=VALUE). Repeatable.")
+	cmd.Flags().Float64Var(&timeout, "timeout", 120.0, "Maximum seconds to wait for each host")
+	cmd.Flags().IntVar(&lines, "lines", 0, "Limit each host's output to its last N lines (0 for full)")
+	cmd.Flags().BoolVar(&closeWindow, "close", false, "Remove the window once every host has finished")
+	return cmd
+}
+
+// fanoutHosts merges hosts from flags and file, dropping blanks and
+// duplicates while keeping the first occurrence's order.
+func fanoutHosts(flagHosts []string, fileHosts []string) []string {
+	seen := map[string]bool{}
+	var hosts []string
+	for _, h := range append(append([]string{}, flagHosts...), fileHosts...) {
+		h = strings.TrimSpace(h)
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		hosts = append(hosts, h)
+	}
+	return hosts
+}
+
+// sshCommand builds the ssh invocation for host. User options replace the
+// defaults with the same key.
+func sshCommand(host string, command string, options []string) string {
+	var opts []string
+	override := map[string]bool{}
+	for _, o := range options {
+		if key, _, ok := strings.Cut(o, "="); ok {
+			override[strings.ToLower(strings.TrimSpace(key))] = true
+		}
+	}
+	for _, o := range defaultSSHOptions {
+		key, _, _ := strings.Cut(o, "=")
+		if !override[strings.ToLower(key)] {
+			opts = append(opts, o)
+		}
+	}
+	opts = append(opts, options...)
+	parts := []string{"ssh"}
+	for _, o := range opts {
+		parts = append(parts, "-o", shellQuoteSingle(strings.TrimSpace(o)))
+	}
+	return strings.Join(append(parts, shellQuoteSingle(host), shellQuoteSingle(command)), " ")
+}
+
+// runFanout runs command over ssh in every pane at once and collects the
+// results in host order.
+func runFanout(panes []titledPane, command string, sshOptions []string, timeout float64, lines int) []fanoutHost {
+	results := make([]fanoutHost, len(panes))
+	var wg sync.WaitGroup
+	for i, p := range panes {
+		wg.Add(1)
+		go func(i int, p titledPane) {
+			defer wg.Done()
+			res := fanoutHost{Host: p.Title, Pane: p.PaneID}
+			start := time.Now()
+			run, waitErr, err := executeRun(p.ID, sshCommand(p.Title, command, sshOptions), runOptions{
+				Timeout:     timeout,
+				Lines:       lines,
+				ExitCode:    true,
+				ExitTag:     "__ARC_TMUX_EXIT:",
+				Segment:     true,
+				UntilMarker: true,
+			})
+			res.DurationSeconds = time.Since(start).Seconds()
+			defer func() { results[i] = res }()
+			if err != nil {
+				res.Error = err.Error()
+				return
+			}
+			if trimmed := strings.TrimRight(run.Output, "\n"); trimmed != "" {
+				res.Output = trimmed + "\n"
+			}
+			res.ExitCode = run.ExitCode
+			if waitErr != nil {
+				res.Error = waitErr.Error()
+			} else if !run.ExitFound {
+				res.Error = "exit code not found"
+			}
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
+
+func writeFanoutResult(cmd *cobra.Command, outputOpts output.OutputOptions, result fanoutResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, h := range result.Hosts {
+			code := "unknown"
+			if h.ExitCode != nil {
+				code = strconv.Itoa(*h.ExitCode)
+			}
+			_, _ = fmt.Fprintf(out, "%s\t%s\n", h.Host, code)
+		}
+		return nil
+	}
+	for _, h := range result.Hosts {
+		label := "exit unknown"
+		switch {
+		case h.ExitCode != nil:
+			label = "exit " + strconv.Itoa(*h.ExitCode)
+		case h.Error != "":
+			label = "error"
+		}
+		if _, err := fmt.Fprintf(out, "==> %s [%s] (%s, %.1fs)\n", h.Host, h.Pane, label, h.DurationSeconds); err != nil {
+			return err
+		}
+		if h.Output != "" {
+			_, _ = fmt.Fprint(out, h.Output)
+		}
+		if h.Error != "" {
+			_, _ = fmt.Fprintf(out, "error: %s\n", h.Error)
+		}
+	}
+	_, err := fmt.Fprintf(out, "%d of %d hosts succeeded.\n", len(result.Hosts)-result.Failed, len(result.Hosts))
+	return err
+}
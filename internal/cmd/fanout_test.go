@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFanoutHosts(t *testing.T) {
+	got := fanoutHosts([]string{"web1", " web2 ", ""}, []string{"web1", "db1"})
+	want := []string{"web1", "web2", "db1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("fanoutHosts = %v, want %v", got, want)
+	}
+}
+
+func TestSSHCommand(t *testing.T) {
+	got := sshCommand("deploy@app1", "echo 'hi'", nil)
+	want := `ssh -o 'BatchMode=yes' -o 'ConnectTimeout=10' 'deploy@app1' 'echo '"'"'hi'"'"''`
+	if got != want {
+		t.Fatalf("sshCommand = %q, want %q", got, want)
+	}
+	got = sshCommand("app1", "uptime", []string{"connecttimeout=3", "Port=2222"})
+	want = `ssh -o 'BatchMode=yes' -o 'connecttimeout=3' -o 'Port=2222' 'app1' 'uptime'`
+	if got != want {
+		t.Fatalf("sshCommand with options = %q, want %q", got, want)
+	}
+}
+
+func TestFanoutHostFailed(t *testing.T) {
+	zero, one := 0, 1
+	cases := []struct {
+		host fanoutHost
+		want bool
+	}{
+		{fanoutHost{ExitCode: &zero}, false},
+		{fanoutHost{ExitCode: &one}, true},
+		{fanoutHost{}, true},
+		{fanoutHost{ExitCode: &zero, Error: "timeout"}, true},
+	}
+	for _, c := range cases {
+		if got := c.host.failed(); got != c.want {
+			t.Errorf("failed(%+v) = %v, want %v", c.host, got, c.want)
+		}
+	}
+}
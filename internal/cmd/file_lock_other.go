@@ -0,0 +1,20 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+//go:build !unix
+
+package cmd
+
+import (
+	"os"
+)
+
+// lockFile only creates path: without flock, writers in other processes are
+// not excluded.
+func lockFile(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return func() { _ = f.Close() }, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fileStamp is what a change to a file is detected by.
+type fileStamp struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// snapshotFiles records every regular file under paths (files or
+// directories), skipping hidden directories and node_modules.
+func snapshotFiles(paths []string) (map[string]fileStamp, error) {
+	snap := map[string]fileStamp{}
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// Files may vanish between listing a directory and reading them.
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			snap[path] = fileStamp{ModTime: info.ModTime(), Size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snap, nil
+}
+
+// changedFiles lists the files added, modified, or removed between two
+// snapshots, sorted.
+func changedFiles(prev map[string]fileStamp, curr map[string]fileStamp) []string {
+	var changed []string
+	for path, stamp := range curr {
+		if old, ok := prev[path]; !ok || !old.ModTime.Equal(stamp.ModTime) || old.Size != stamp.Size {
+			changed = append(changed, path)
+		}
+	}
+	for path := range prev {
+		if _, ok := curr[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// resolveWatchPaths makes paths absolute against dir (or the working
+// directory when dir is empty), expanding ~, and checks they exist.
+func resolveWatchPaths(paths []string, dir string) ([]string, error) {
+	resolved := make([]string, 0, len(paths))
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "~" || strings.HasPrefix(p, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, err
+			}
+			p = filepath.Join(home, strings.TrimPrefix(p, "~"))
+		}
+		if !filepath.IsAbs(p) && dir != "" {
+			p = filepath.Join(dir, p)
+		}
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, abs)
+	}
+	return resolved, nil
+}
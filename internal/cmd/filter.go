@@ -0,0 +1,470 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// paneFilter is a compiled --filter expression evaluated against pane metadata.
+//
+// Grammar:
+//
+//	expr    = or
+//	or      = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | "(" expr ")" | compare
+//	compare = field [ op value ]
+//	op      = "==" | "!=" | "=~" | "!~" | ">" | ">=" | "<" | "<="
+//
+// Values are double-quoted strings, numbers, or bare words. A bare field
+// (e.g. "active") is true when the field is true, non-zero, or non-empty.
+type paneFilter struct {
+	root filterNode
+}
+
+type filterNode interface {
+	eval(p tmux.PaneDetails, now time.Time) (bool, error)
+}
+
+type filterAnd struct{ left, right filterNode }
+type filterOr struct{ left, right filterNode }
+type filterNot struct{ inner filterNode }
+
+type filterCompare struct {
+	field string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+func (n filterAnd) eval(p tmux.PaneDetails, now time.Time) (bool, error) {
+	ok, err := n.left.eval(p, now)
+	if err != nil || !ok {
+		return false, err
+	}
+	return n.right.eval(p, now)
+}
+
+func (n filterOr) eval(p tmux.PaneDetails, now time.Time) (bool, error) {
+	ok, err := n.left.eval(p, now)
+	if err != nil || ok {
+		return ok, err
+	}
+	return n.right.eval(p, now)
+}
+
+func (n filterNot) eval(p tmux.PaneDetails, now time.Time) (bool, error) {
+	ok, err := n.inner.eval(p, now)
+	return !ok, err
+}
+
+var filterFields = map[string]bool{
+	"session":       true,
+	"window":        true,
+	"window_name":   true,
+	"window_active": true,
+	"pane":          true,
+	"pane_id":       true,
+	"id":            true,
+	"active":        true,
+	"command":       true,
+	"title":         true,
+	"path":          true,
+	"pid":           true,
+	"idle":          true,
+}
+
+// paneFilterValue returns the field value as a string, float64, or bool.
+func paneFilterValue(p tmux.PaneDetails, field string, now time.Time) any {
+	switch field {
+	case "session":
+		return p.Session
+	case "window":
+		return float64(p.WindowIndex)
+	case "window_name":
+		return p.WindowName
+	case "window_active":
+		return p.WindowActive
+	case "pane":
+		return float64(p.PaneIndex)
+	case "pane_id":
+		return p.PaneID
+	case "id":
+		return formattedPaneID(&p)
+	case "active":
+		return p.Active
+	case "command":
+		return p.Command
+	case "title":
+		return p.Title
+	case "path":
+		return p.Path
+	case "pid":
+		return float64(p.PID)
+	case "idle":
+		if p.ActivityAt.IsZero() {
+			return float64(0)
+		}
+		return now.Sub(p.ActivityAt).Seconds()
+	}
+	return nil
+}
+
+func (n filterCompare) eval(p tmux.PaneDetails, now time.Time) (bool, error) {
+	raw := paneFilterValue(p, n.field, now)
+	if n.op == "" {
+		switch v := raw.(type) {
+		case bool:
+			return v, nil
+		case float64:
+			return v != 0, nil
+		case string:
+			return v != "", nil
+		}
+		return false, nil
+	}
+	switch n.op {
+	case "=~":
+		return n.re.MatchString(filterString(raw)), nil
+	case "!~":
+		return !n.re.MatchString(filterString(raw)), nil
+	}
+	switch v := raw.(type) {
+	case float64:
+		want, err := strconv.ParseFloat(n.value, 64)
+		if err != nil {
+			return false, fmt.Errorf("filter: %s expects a number, got %q", n.field, n.value)
+		}
+		switch n.op {
+		case "==":
+			return v == want, nil
+		case "!=":
+			return v != want, nil
+		case ">":
+			return v > want, nil
+		case ">=":
+			return v >= want, nil
+		case "<":
+			return v < want, nil
+		case "<=":
+			return v <= want, nil
+		}
+	case bool:
+		want, err := strconv.ParseBool(n.value)
+		if err != nil {
+			return false, fmt.Errorf("filter: %s expects true/false, got %q", n.field, n.value)
+		}
+		switch n.op {
+		case "==":
+			return v == want, nil
+		case "!=":
+			return v != want, nil
+		}
+	case string:
+		switch n.op {
+		case "==":
+			return v == n.value, nil
+		case "!=":
+			return v != n.value, nil
+		}
+	}
+	return false, fmt.Errorf("filter: operator %s is not supported for %s", n.op, n.field)
+}
+
+func filterString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	return ""
+}
+
+// Match reports whether the pane satisfies the filter.
+func (f *paneFilter) Match(p tmux.PaneDetails, now time.Time) (bool, error) {
+	if f == nil || f.root == nil {
+		return true, nil
+	}
+	return f.root.eval(p, now)
+}
+
+// parsePaneFilter compiles a filter expression; an empty expression matches everything.
+func parsePaneFilter(expr string) (*paneFilter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	parser := &filterParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return nil, fmt.Errorf("filter: unexpected %q", parser.tokens[parser.pos].text)
+	}
+	return &paneFilter{root: root}, nil
+}
+
+type filterToken struct {
+	kind string // "op", "ident", "string"
+	text string
+}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				b.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("filter: unterminated string")
+			}
+			tokens = append(tokens, filterToken{kind: "string", text: b.String()})
+			i = j + 1
+		case r == '(' || r == ')':
+			tokens = append(tokens, filterToken{kind: "op", text: string(r)})
+			i++
+		case strings.ContainsRune("&|=!<>~", r):
+			op := string(r)
+			if i+1 < len(runes) {
+				two := string(runes[i : i+2])
+				switch two {
+				case "&&", "||", "==", "!=", "=~", "!~", ">=", "<=":
+					op = two
+				}
+			}
+			if op == "&" || op == "|" || op == "=" || op == "~" {
+				return nil, fmt.Errorf("filter: unexpected %q", op)
+			}
+			tokens = append(tokens, filterToken{kind: "op", text: op})
+			i += len(op)
+		default:
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune("()&|=!<>~\"", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, filterToken{kind: "ident", text: string(runes[i:j])})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *filterParser) acceptOp(op string) bool {
+	if tok, ok := p.peek(); ok && tok.kind == "op" && tok.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = filterAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.acceptOp("!") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{inner: inner}, nil
+	}
+	if p.acceptOp("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptOp(")") {
+			return nil, fmt.Errorf("filter: missing )")
+		}
+		return inner, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *filterParser) parseCompare() (filterNode, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("filter: unexpected end of expression")
+	}
+	if tok.kind != "ident" {
+		return nil, fmt.Errorf("filter: expected field, got %q", tok.text)
+	}
+	field := strings.ToLower(tok.text)
+	if !filterFields[field] {
+		return nil, fmt.Errorf("filter: unknown field %q", tok.text)
+	}
+	p.pos++
+
+	opTok, ok := p.peek()
+	if !ok || opTok.kind != "op" {
+		return filterCompare{field: field}, nil
+	}
+	switch opTok.text {
+	case "==", "!=", "=~", "!~", ">", ">=", "<", "<=":
+	default:
+		return filterCompare{field: field}, nil
+	}
+	p.pos++
+
+	valTok, ok := p.peek()
+	if !ok || valTok.kind == "op" {
+		return nil, fmt.Errorf("filter: %s %s needs a value", field, opTok.text)
+	}
+	p.pos++
+	node := filterCompare{field: field, op: opTok.text, value: valTok.text}
+	if node.op == "=~" || node.op == "!~" {
+		re, err := regexp.Compile(node.value)
+		if err != nil {
+			return nil, fmt.Errorf("filter: invalid regex %q: %w", node.value, err)
+		}
+		node.re = re
+	}
+	return node, nil
+}
+
+// filterPanes returns panes matching the filter (all panes when f is nil).
+func filterPanes(panes []tmux.PaneDetails, f *paneFilter) ([]tmux.PaneDetails, error) {
+	if f == nil {
+		return panes, nil
+	}
+	now := time.Now()
+	matched := make([]tmux.PaneDetails, 0, len(panes))
+	for _, p := range panes {
+		ok, err := f.Match(p, now)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, p)
+		}
+	}
+	return matched, nil
+}
+
+// resolveFilterTargets lists handles for panes matching expr in session,
+// window, and pane order.
+func resolveFilterTargets(expr string) ([]tmux.PaneHandle, error) {
+	f, err := parsePaneFilter(expr)
+	if err != nil {
+		return nil, newCodedError(errInvalidFilter, err.Error(), err)
+	}
+	panes, err := tmux.ListPanesDetailed()
+	if err != nil {
+		return nil, err
+	}
+	matched, err := filterPanes(panes, f)
+	if err != nil {
+		return nil, newCodedError(errInvalidFilter, err.Error(), err)
+	}
+	sortPaneDetails(matched)
+	handles := make([]tmux.PaneHandle, 0, len(matched))
+	for i := range matched {
+		handles = append(handles, tmux.PaneHandle{ID: matched[i].PaneID, Target: formattedPaneID(&matched[i])})
+	}
+	return handles, nil
+}
+
+// sortPaneDetails orders panes by session, then window and pane index
+// numerically, so dev:2.0 sorts before dev:10.0.
+func sortPaneDetails(panes []tmux.PaneDetails) {
+	sort.Slice(panes, func(i, j int) bool {
+		if panes[i].Session != panes[j].Session {
+			return panes[i].Session < panes[j].Session
+		}
+		if panes[i].WindowIndex != panes[j].WindowIndex {
+			return panes[i].WindowIndex < panes[j].WindowIndex
+		}
+		return panes[i].PaneIndex < panes[j].PaneIndex
+	})
+}
+
+func handleTargets(handles []tmux.PaneHandle) []string {
+	targets := make([]string, 0, len(handles))
+	for _, h := range handles {
+		targets = append(targets, h.Target)
+	}
+	return targets
+}
+
+// resolveSingleFilterTarget requires expr to match exactly one pane.
+func resolveSingleFilterTarget(expr string) (tmux.PaneHandle, error) {
+	handles, err := resolveFilterTargets(expr)
+	if err != nil {
+		return tmux.PaneHandle{}, err
+	}
+	switch len(handles) {
+	case 0:
+		return tmux.PaneHandle{}, newCodedError(errInvalidPane, "no panes matched filter", nil)
+	case 1:
+		return handles[0], nil
+	default:
+		return tmux.PaneHandle{}, newCodedError(errAmbiguousPane, fmt.Sprintf("filter matched %d panes: %s", len(handles), strings.Join(handleTargets(handles), ", ")), nil)
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestPaneFilterMatch(t *testing.T) {
+	now := time.Unix(1700001000, 0)
+	pane := tmux.PaneDetails{
+		Session:     "arc-dev",
+		WindowIndex: 2,
+		PaneIndex:   1,
+		Command:     "node",
+		Title:       "server",
+		Active:      true,
+		ActivityAt:  time.Unix(1700000000, 0),
+	}
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{`session=~"^arc-" && command=="node" && idle>300`, true},
+		{`command=="bash" || title=="server"`, true},
+		{`!(active) || window>=3`, false},
+		{`active && pane==1 && id=="arc-dev:2.1"`, true},
+		{`path`, false},
+		{`command!~"^n"`, false},
+	}
+	for _, tc := range cases {
+		f, err := parsePaneFilter(tc.expr)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.expr, err)
+		}
+		got, err := f.Match(pane, now)
+		if err != nil {
+			t.Fatalf("eval %q: %v", tc.expr, err)
+		}
+		if got != tc.want {
+			t.Fatalf("filter %q: expected %t, got %t", tc.expr, tc.want, got)
+		}
+	}
+}
+
+func TestParsePaneFilterErrors(t *testing.T) {
+	for _, expr := range []string{`colour=="red"`, `command==`, `(active`, `command=="node`, `session=~"["`} {
+		if _, err := parsePaneFilter(expr); err == nil {
+			t.Fatalf("expected parse error for %q", expr)
+		}
+	}
+	f, err := parsePaneFilter(`pid>"abc"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if _, err := f.Match(tmux.PaneDetails{PID: 10}, time.Now()); err == nil {
+		t.Fatal("expected type error for numeric comparison")
+	}
+}
+
+func TestSortPaneDetailsIsNumeric(t *testing.T) {
+	panes := []tmux.PaneDetails{
+		{Session: "dev", WindowIndex: 10, PaneIndex: 0, PaneID: "%3"},
+		{Session: "dev", WindowIndex: 2, PaneIndex: 10, PaneID: "%2"},
+		{Session: "api", WindowIndex: 3, PaneIndex: 0, PaneID: "%4"},
+		{Session: "dev", WindowIndex: 2, PaneIndex: 1, PaneID: "%1"},
+	}
+	sortPaneDetails(panes)
+	var got []string
+	for _, p := range panes {
+		got = append(got, p.PaneID)
+	}
+	want := []string{"%4", "%1", "%2", "%3"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
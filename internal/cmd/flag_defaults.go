@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultableFlags may take their default from the config file's defaults or
+// the environment instead of the built-in value.
+var defaultableFlags = []string{"idle", "timeout", "lines", "forward-interrupt", "set-title"}
+
+// applyFlagDefaults fills --idle, --timeout, --lines, --forward-interrupt, and
+// --set-title when they were not given on the command line. Later sources win: the config's defaults
+// ("timeout", then "run.timeout"), ARC_TMUX_TIMEOUT, then ARC_TMUX_RUN_TIMEOUT.
+func applyFlagDefaults(cmd *cobra.Command) error {
+	cfg, _ := loadConfig(defaultConfigFile())
+	path := commandDefaultsPath(cmd)
+	for _, name := range defaultableFlags {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || f.Changed {
+			continue
+		}
+		value, source := "", ""
+		for _, key := range []string{name, path + "." + name} {
+			if v, ok := cfg.Defaults[key]; ok && strings.TrimSpace(v) != "" {
+				value, source = strings.TrimSpace(v), "config defaults."+key
+			}
+		}
+		for _, key := range []string{flagDefaultsEnv("", name), flagDefaultsEnv(path, name)} {
+			if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+				value, source = v, key
+			}
+		}
+		if source == "" {
+			continue
+		}
+		// Setting the value directly leaves Changed false, so commands still
+		// treat it as a default.
+		if err := f.Value.Set(value); err != nil {
+			return fmt.Errorf("%s: invalid value %q for --%s: %w", source, value, name, err)
+		}
+	}
+	return nil
+}
+
+// commandDefaultsPath names cmd for defaults keys: "run", "repl.eval".
+func commandDefaultsPath(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if root := cmd.Root(); root != nil {
+		path = strings.TrimPrefix(path, root.Name())
+	}
+	return strings.Join(strings.Fields(path), ".")
+}
+
+// flagDefaultsEnv returns the environment variable for a flag default, scoped
+// to a command path when given: ARC_TMUX_TIMEOUT, ARC_TMUX_REPL_EVAL_TIMEOUT.
+func flagDefaultsEnv(path string, flag string) string {
+	parts := []string{"ARC_TMUX"}
+	if path != "" {
+		parts = append(parts, path)
+	}
+	parts = append(parts, flag)
+	name := strings.Join(parts, "_")
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(name))
+}
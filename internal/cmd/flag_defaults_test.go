@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newFlagDefaultsTestCmd() (*cobra.Command, *cobra.Command) {
+	root := &cobra.Command{Use: "arc-tmux"}
+	repl := &cobra.Command{Use: "repl"}
+	eval := &cobra.Command{Use: "eval", Run: func(*cobra.Command, []string) {}}
+	eval.Flags().Float64("timeout", 60, "")
+	eval.Flags().Float64("idle", 2, "")
+	eval.Flags().Int("lines", 200, "")
+	repl.AddCommand(eval)
+	root.AddCommand(repl)
+	return root, eval
+}
+
+func TestFlagDefaultsEnv(t *testing.T) {
+	if got := flagDefaultsEnv("", "timeout"); got != "ARC_TMUX_TIMEOUT" {
+		t.Fatalf("unexpected env: %s", got)
+	}
+	if got := flagDefaultsEnv("repl.eval", "timeout"); got != "ARC_TMUX_REPL_EVAL_TIMEOUT" {
+		t.Fatalf("unexpected env: %s", got)
+	}
+}
+
+func TestApplyFlagDefaultsPrecedence(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	data := "defaults:\n  timeout: 90\n  repl.eval.idle: 4\n  lines: 50\n"
+	if err := os.WriteFile(config, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ARC_TMUX_CONFIG", config)
+	t.Setenv("ARC_TMUX_TIMEOUT", "120")
+	t.Setenv("ARC_TMUX_REPL_EVAL_TIMEOUT", "300")
+	t.Setenv("ARC_TMUX_IDLE", "")
+	t.Setenv("ARC_TMUX_LINES", "")
+
+	_, eval := newFlagDefaultsTestCmd()
+	if err := eval.ParseFlags([]string{"--lines", "10"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyFlagDefaults(eval); err != nil {
+		t.Fatalf("applyFlagDefaults: %v", err)
+	}
+	timeout, _ := eval.Flags().GetFloat64("timeout")
+	idle, _ := eval.Flags().GetFloat64("idle")
+	lines, _ := eval.Flags().GetInt("lines")
+	if timeout != 300 || idle != 4 || lines != 10 {
+		t.Fatalf("got timeout=%v idle=%v lines=%v", timeout, idle, lines)
+	}
+	if eval.Flags().Changed("timeout") {
+		t.Fatal("defaults must not mark flags as changed")
+	}
+}
+
+func TestApplyFlagDefaultsInvalid(t *testing.T) {
+	t.Setenv("ARC_TMUX_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Setenv("ARC_TMUX_TIMEOUT", "soon")
+	_, eval := newFlagDefaultsTestCmd()
+	if err := applyFlagDefaults(eval); err == nil {
+		t.Fatal("expected error for invalid ARC_TMUX_TIMEOUT")
+	}
+}
+
+func TestApplyFlagDefaultsForwardInterrupt(t *testing.T) {
+	t.Setenv("ARC_TMUX_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Setenv("ARC_TMUX_FORWARD_INTERRUPT", "true")
+	_, eval := newFlagDefaultsTestCmd()
+	eval.Flags().Bool("forward-interrupt", false, "")
+	if err := applyFlagDefaults(eval); err != nil {
+		t.Fatalf("applyFlagDefaults: %v", err)
+	}
+	if forward, _ := eval.Flags().GetBool("forward-interrupt"); !forward {
+		t.Fatal("expected ARC_TMUX_FORWARD_INTERRUPT to enable --forward-interrupt")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type fmtResult struct {
+	Pane string `json:"pane" yaml:"pane"`
+	// Values maps each format string to its expansion.
+	Values map[string]string `json:"values" yaml:"values"`
+}
+
+func newFmtCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var windowArg string
+
+	cmd := &cobra.Command{
+		Use:   "fmt <format>...",
+		Short: "Evaluate tmux format strings against a pane",
+		Long: `Expand raw tmux format strings against a pane and print the results, one
+per line in argument order: an escape hatch for fields arc-tmux does not
+expose. Formats are passed to tmux unchanged, so conditionals, modifiers
+(#{q:...}, #{t:...}), and session/window variables all work; see FORMATS in
+tmux(1). All formats are evaluated in a single tmux call.
+
+--output json or yaml returns an object keyed by format string.`,
+		Example: `  arc-tmux fmt --pane dev:2.0 '#{pane_width}x#{pane_height} #{cursor_x},#{cursor_y}'
+  arc-tmux fmt --window dev:api '#{pane_current_command}' '#{pane_tty}'
+  arc-tmux fmt --pane @current '#{session_name}' '#{?pane_in_mode,copy,normal}' -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			for _, f := range args {
+				if strings.TrimSpace(f) == "" {
+					return fmt.Errorf("format strings must not be empty")
+				}
+			}
+			handles, _, err := resolvePaneOrWindowTargets(cmd, paneArg, windowArg)
+			if err != nil {
+				return err
+			}
+			if len(handles) != 1 {
+				return fmt.Errorf("fmt evaluates against a single pane")
+			}
+			values, err := tmux.EvalFormats(handles[0].ID, args)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			result := fmtResult{Pane: handles[0].Target, Values: map[string]string{}}
+			for i, f := range args {
+				result.Values[f] = values[i]
+			}
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			}
+			for _, v := range values {
+				if _, err := fmt.Fprintln(out, v); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, @name)")
+	cmd.Flags().StringVar(&windowArg, "window", "", "Target the active pane of this window (SESSION:WINDOW, index or name)")
+	return cmd
+}
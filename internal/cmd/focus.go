@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// runFailed reports whether a run timed out or exited non-zero.
+func runFailed(result runResult, waitErr error) bool {
+	return waitErr != nil || (result.ExitCode != nil && *result.ExitCode != 0)
+}
+
+// runFailureMessage describes a failed run for the status line.
+func runFailureMessage(command string, target string, result runResult, waitErr error) string {
+	if r := []rune(command); len(r) > 40 {
+		command = string(r[:37]) + "..."
+	}
+	reason := "timed out"
+	if waitErr == nil && result.ExitCode != nil {
+		reason = fmt.Sprintf("exited %d", *result.ExitCode)
+	}
+	return fmt.Sprintf("arc-tmux: %q %s in %s", command, reason, target)
+}
+
+// focusAttachedClients switches the clients attached to the pane's session
+// to the pane, rings their bell, and shows message. Clients on other sessions
+// are left alone: whoever is watching them is working on something else. It
+// returns the clients that were focused; with no clients attached (or no
+// server) there is nothing to do.
+func focusAttachedClients(handle tmux.PaneHandle, message string) ([]string, error) {
+	pane, err := tmux.PaneDetailsForTarget(handle.ID)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := tmux.ListClients()
+	if err != nil {
+		if errors.Is(err, tmux.ErrNoTmuxServer) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var focused []string
+	var failures []string
+	for _, c := range clientsInSession(clients, pane.Session) {
+		if err := tmux.FocusPane(c.Name, handle.ID); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", c.Name, err))
+			continue
+		}
+		_ = tmux.RingBell(c)
+		_ = tmux.DisplayMessage(c.Name, message, tmux.MessageOptions{Style: messageStyles["error"]})
+		focused = append(focused, c.Name)
+	}
+	if len(failures) > 0 {
+		return focused, errors.New("focus clients: " + strings.Join(failures, "; "))
+	}
+	return focused, nil
+}
+
+// clientsInSession returns the clients attached to session.
+func clientsInSession(clients []tmux.Client, session string) []tmux.Client {
+	var matched []tmux.Client
+	for _, c := range clients {
+		if c.Session == session {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
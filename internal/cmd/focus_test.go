@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestRunFailed(t *testing.T) {
+	zero, two := 0, 2
+	if runFailed(runResult{ExitCode: &zero}, nil) {
+		t.Fatal("exit 0 should not count as failed")
+	}
+	if runFailed(runResult{}, nil) {
+		t.Fatal("unknown exit without a timeout should not count as failed")
+	}
+	if !runFailed(runResult{ExitCode: &two}, nil) {
+		t.Fatal("exit 2 should count as failed")
+	}
+	if !runFailed(runResult{}, errors.New("timeout")) {
+		t.Fatal("timeout should count as failed")
+	}
+}
+
+func TestRunFailureMessage(t *testing.T) {
+	two := 2
+	msg := runFailureMessage("npm test", "fe:2.0", runResult{ExitCode: &two}, nil)
+	if msg != `arc-tmux: "npm test" exited 2 in fe:2.0` {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+	long := runFailureMessage(strings.Repeat("x", 60), "fe:2.0", runResult{}, errors.New("timeout"))
+	if !strings.Contains(long, "...\" timed out") {
+		t.Fatalf("expected truncated command and timeout: %s", long)
+	}
+}
+
+func TestClientsInSession(t *testing.T) {
+	clients := []tmux.Client{
+		{Name: "/dev/pts/1", Session: "fe"},
+		{Name: "/dev/pts/2", Session: "ops"},
+		{Name: "/dev/pts/3", Session: "fe"},
+	}
+	got := clientsInSession(clients, "fe")
+	if len(got) != 2 || got[0].Name != "/dev/pts/1" || got[1].Name != "/dev/pts/3" {
+		t.Fatalf("clientsInSession = %+v", got)
+	}
+	if got := clientsInSession(clients, "api"); len(got) != 0 {
+		t.Fatalf("expected no clients, got %+v", got)
+	}
+}
@@ -18,6 +18,9 @@ import (
 type followEvent struct {
 	Time string `json:"time" yaml:"time"`
 	Line string `json:"line" yaml:"line"`
+	// Dropped is set on a drop marker: this many lines were discarded
+	// because the reader fell more than --buffer lines behind.
+	Dropped int `json:"dropped,omitempty" yaml:"dropped,omitempty"`
 }
 
 func newFollowCmd() *cobra.Command {
@@ -28,16 +31,37 @@ func newFollowCmd() *cobra.Command {
 	var fromStart bool
 	var duration float64
 	var once bool
+	var forwardInterrupt bool
+	var compact bool
+	var bufferSize int
+	var verbose bool
 
 	cmd := &cobra.Command{
 		Use:   "follow",
 		Short: "Follow output from a tmux pane",
-		Long:  "Continuously poll a tmux pane and stream any new output lines.",
+		Long: `Continuously poll a tmux pane and stream any new output lines.
+
+With --forward-interrupt, pressing Ctrl+C sends Ctrl+C to the pane as well:
+follow emits the pane's last lines and exits, so stopping the stream also
+stops the command producing it.
+
+--compact drops spinner frames and blank-line runs and collapses repeated
+lines into one plus a "[previous line repeated N more times]" note, which
+is emitted once a different line arrives or follow exits.
+
+Lines are queued for the reader in a ring of --buffer lines, so a slow
+consumer never stalls capturing. When the ring overflows the oldest lines are
+dropped and a marker takes their place: "[N lines dropped ...]" in text, an
+event with "dropped": N in JSON. --verbose reports the buffer's depth, peak,
+and drops on stderr as they happen and when follow exits.`,
 		Example: `  arc-tmux follow --pane=fe:2.0
   arc-tmux follow --pane=fe:2.0 --output json
   arc-tmux follow --pane=fe:2.0 --from-start
   arc-tmux follow --pane=fe:2.0 --duration 10
-  arc-tmux follow --pane=fe:2.0 --once`,
+  arc-tmux follow --pane=fe:2.0 --once
+  arc-tmux follow --pane=fe:2.0 --forward-interrupt
+  arc-tmux follow --pane=fe:2.0 --compact -o json
+  arc-tmux follow --pane=fe:2.0 -o json --buffer 5000 --verbose | slow-consumer`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
@@ -46,7 +70,8 @@ func newFollowCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
 				return err
 			}
 
@@ -71,7 +96,49 @@ func newFollowCmd() *cobra.Command {
 				defer func() { _ = yamlEnc.Close() }()
 			}
 
+			ring := newLineRing(bufferSize)
+			reportBuffer := func() {
+				if verbose {
+					st := ring.snapshot()
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "follow buffer: %d queued, peak %d/%d, %d written, %d dropped\n", st.Queued, st.Peak, st.Capacity, st.Written, st.Dropped)
+				}
+			}
+			writerDone := make(chan struct{})
+			go func() {
+				defer close(writerDone)
+				for {
+					events, dropped, ok := ring.pop()
+					if !ok {
+						return
+					}
+					if dropped > 0 {
+						reportBuffer()
+						marker := followEvent{Time: events[0].Time, Dropped: dropped}
+						if err := emitFollow(out, outputOpts, jsonEnc, yamlEnc, []followEvent{marker}); err != nil {
+							ring.written(0, err)
+							return
+						}
+					}
+					err := emitFollow(out, outputOpts, jsonEnc, yamlEnc, events)
+					ring.written(len(events), err)
+					if err != nil {
+						return
+					}
+				}
+			}()
+			// finish lets the writer drain the ring before follow returns.
+			finish := func(err error) error {
+				ring.close()
+				<-writerDone
+				reportBuffer()
+				if err != nil {
+					return err
+				}
+				return ring.failure()
+			}
+
 			var prev []string
+			var compactor outputCompactor
 			prevCount := 0
 			initialized := false
 			var deadline time.Time
@@ -80,11 +147,18 @@ func newFollowCmd() *cobra.Command {
 			}
 			ticker := time.NewTicker(time.Duration(interval * float64(time.Second)))
 			defer ticker.Stop()
+			var interrupted <-chan struct{}
+			stopping := false
+			if forwardInterrupt {
+				var stop func()
+				interrupted, stop = forwardInterrupts(handle, cmd.ErrOrStderr())
+				defer stop()
+			}
 
 			for {
-				capture, err := tmux.CaptureJoined(target, lines)
+				capture, err := tmux.CaptureJoined(handle.ID, lines)
 				if err != nil {
-					return err
+					return finish(err)
 				}
 				curr := splitLines(capture)
 				var emit []string
@@ -105,17 +179,32 @@ func newFollowCmd() *cobra.Command {
 					prev = curr
 				}
 
-				if err := emitFollow(out, outputOpts, jsonEnc, yamlEnc, emit); err != nil {
-					return err
+				done := once || stopping || (!deadline.IsZero() && time.Now().After(deadline))
+				if compact {
+					var kept []string
+					for _, line := range emit {
+						kept = append(kept, compactor.feed(line)...)
+					}
+					if done {
+						kept = append(kept, compactor.flush()...)
+					}
+					emit = kept
+				}
+				if err := ring.push(followEvents(emit)); err != nil {
+					return finish(err)
 				}
 
-				if once {
-					return nil
+				if done {
+					return finish(nil)
 				}
-				if !deadline.IsZero() && time.Now().After(deadline) {
-					return nil
+				select {
+				case <-ticker.C:
+				case <-interrupted:
+					// One more capture picks up what the pane printed as
+					// it was interrupted.
+					stopping = true
+					<-ticker.C
 				}
-				<-ticker.C
 			}
 		},
 	}
@@ -128,18 +217,30 @@ func newFollowCmd() *cobra.Command {
 	cmd.Flags().Float64Var(&duration, "duration", 0, "Stop after N seconds (0 to run indefinitely)")
 	cmd.Flags().Float64Var(&duration, "timeout", 0, "Alias for --duration")
 	cmd.Flags().BoolVar(&once, "once", false, "Capture once and exit")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().BoolVar(&compact, "compact", false, "Drop spinner frames and blank runs, and collapse repeated lines and prompts")
+	cmd.Flags().BoolVar(&forwardInterrupt, "forward-interrupt", false, "On Ctrl+C, send Ctrl+C to the pane too before exiting")
+	cmd.Flags().IntVar(&bufferSize, "buffer", defaultStreamBuffer, "Lines to queue for a slow reader before dropping the oldest")
+	cmd.Flags().BoolVar(&verbose, "verbose", false, "Report buffer depth and dropped lines on stderr")
 
 	return cmd
 }
 
-func emitFollow(out interface{ Write([]byte) (int, error) }, outputOpts output.OutputOptions, jsonEnc *json.Encoder, yamlEnc *yaml.Encoder, lines []string) error {
-	if len(lines) == 0 {
-		return nil
+// followEvents stamps lines with the time they were captured.
+func followEvents(lines []string) []followEvent {
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+	events := make([]followEvent, len(lines))
+	for i, line := range lines {
+		events[i] = followEvent{Time: ts, Line: line}
 	}
-	for _, line := range lines {
-		ts := time.Now().UTC().Format(time.RFC3339Nano)
-		event := followEvent{Time: ts, Line: line}
+	return events
+}
+
+func emitFollow(out interface{ Write([]byte) (int, error) }, outputOpts output.OutputOptions, jsonEnc *json.Encoder, yamlEnc *yaml.Encoder, events []followEvent) error {
+	for _, event := range events {
+		line := event.Line
+		if event.Dropped > 0 {
+			line = fmt.Sprintf("[%d lines dropped: output arrived faster than it was read]", event.Dropped)
+		}
 		switch {
 		case outputOpts.Is(output.OutputJSON):
 			if err := jsonEnc.Encode(event); err != nil {
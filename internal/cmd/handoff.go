@@ -0,0 +1,294 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type handoffReport struct {
+	Session     string          `json:"session" yaml:"session"`
+	GeneratedAt time.Time       `json:"generated_at" yaml:"generated_at"`
+	Windows     []handoffWindow `json:"windows" yaml:"windows"`
+	// Pending lists panes another agent should look at first: running a
+	// foreground program, recently busy, or waiting on a prompt.
+	Pending []string     `json:"pending" yaml:"pending"`
+	Aliases []aliasEntry `json:"aliases" yaml:"aliases"`
+}
+
+type handoffWindow struct {
+	Index  int           `json:"index" yaml:"index"`
+	Name   string        `json:"name" yaml:"name"`
+	Active bool          `json:"active" yaml:"active"`
+	Panes  []handoffPane `json:"panes" yaml:"panes"`
+}
+
+type handoffPane struct {
+	FormattedID     string         `json:"formatted_id" yaml:"formatted_id"`
+	PaneID          string         `json:"pane_id" yaml:"pane_id"`
+	Active          bool           `json:"active" yaml:"active"`
+	Command         string         `json:"command" yaml:"command"`
+	Title           string         `json:"title,omitempty" yaml:"title,omitempty"`
+	Path            string         `json:"path" yaml:"path"`
+	PID             int            `json:"pid" yaml:"pid"`
+	IdleSeconds     float64        `json:"idle_seconds" yaml:"idle_seconds"`
+	State           string         `json:"state" yaml:"state"`
+	DeadStatus      int            `json:"dead_status,omitempty" yaml:"dead_status,omitempty"`
+	ProgressPercent *float64       `json:"progress_percent,omitempty" yaml:"progress_percent,omitempty"`
+	Prompt          *pendingPrompt `json:"prompt,omitempty" yaml:"prompt,omitempty"`
+	Excerpt         string         `json:"excerpt" yaml:"excerpt"`
+}
+
+func newHandoffCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var lines int
+	var idle float64
+
+	cmd := &cobra.Command{
+		Use:   "handoff",
+		Short: "Summarize a session for whoever picks it up next",
+		Long: `Summarize a session so another agent or teammate can pick up where you left
+off: its windows and panes, what runs in each, the last lines of output,
+panes still running or waiting on a prompt, and the aliases pointing into it.
+
+The default output is Markdown, ready to paste into a ticket or chat.
+--output json or yaml gives the same report as structured data.`,
+		Example: `  arc-tmux handoff --session arc-dev
+  arc-tmux handoff --session arc-dev --lines 30 > HANDOFF.md
+  arc-tmux handoff --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			resolved, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			sess, err := defaultPaneSessionArg(resolved)
+			if err != nil {
+				return err
+			}
+			if idle <= 0 {
+				idle = 2
+			}
+			report, err := buildHandoffReport(sess, lines, idle)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(report)
+			case outputOpts.Is(output.OutputQuiet):
+				for _, id := range report.Pending {
+					_, _ = fmt.Fprintln(out, id)
+				}
+				return nil
+			}
+			return writeHandoffMarkdown(out, report)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Session to summarize (name, @current, or @managed; default: current or ARC_TMUX_SESSION)")
+	cmd.Flags().IntVar(&lines, "lines", 10, "Lines of recent output to include per pane (0 to omit)")
+	cmd.Flags().Float64Var(&idle, "idle", 2.0, "Seconds of inactivity before a pane counts as idle")
+	return cmd
+}
+
+func buildHandoffReport(session string, lines int, idle float64) (handoffReport, error) {
+	report := handoffReport{
+		Session:     session,
+		GeneratedAt: time.Now().UTC(),
+		Windows:     []handoffWindow{},
+		Pending:     []string{},
+		Aliases:     []aliasEntry{},
+	}
+	wins, err := tmux.ListWindows(session)
+	if err != nil {
+		return report, err
+	}
+	panes, err := tmux.ListPanesDetailed()
+	if err != nil {
+		return report, err
+	}
+	patterns, err := progressPatterns()
+	if err != nil {
+		return report, err
+	}
+	rules, err := promptRules()
+	if err != nil {
+		return report, err
+	}
+
+	sort.Slice(wins, func(i, j int) bool { return wins[i].WindowIndex < wins[j].WindowIndex })
+	paneIDs := map[string]bool{}
+	for _, w := range wins {
+		window := handoffWindow{Index: w.WindowIndex, Name: w.Name, Active: w.Active, Panes: []handoffPane{}}
+		for _, p := range panes {
+			if p.Session != session || p.WindowIndex != w.WindowIndex {
+				continue
+			}
+			pane := handoffPane{
+				FormattedID: fmt.Sprintf("%s:%d.%d", p.Session, p.WindowIndex, p.PaneIndex),
+				PaneID:      p.PaneID,
+				Active:      p.Active,
+				Command:     p.Command,
+				Title:       p.Title,
+				Path:        p.Path,
+				PID:         p.PID,
+				DeadStatus:  p.DeadStatus,
+			}
+			// Without pane_activity (tmux < 3.4) a shell pane counts as idle.
+			isIdle := true
+			if !p.ActivityAt.IsZero() {
+				pane.IdleSeconds = time.Since(p.ActivityAt).Seconds()
+				isIdle = pane.IdleSeconds >= idle
+			}
+			capture := ""
+			if lines > 0 {
+				if capture, err = tmux.Capture(p.PaneID, lines); err != nil {
+					return report, err
+				}
+				pane.Excerpt = tailLines(capture, lines)
+			}
+			pane.State = handoffPaneState(p, isIdle)
+			if percent, ok := detectProgress(capture, patterns); ok && !p.Dead {
+				pane.ProgressPercent = &percent
+			}
+			if prompt, ok := detectPendingPrompt(capture, rules); ok && !p.Dead {
+				pane.State = "prompt"
+				pane.Prompt = &prompt
+			}
+			if pane.State != "idle" && pane.State != "dead" {
+				report.Pending = append(report.Pending, pane.FormattedID)
+			}
+			paneIDs[p.PaneID] = true
+			window.Panes = append(window.Panes, pane)
+		}
+		report.Windows = append(report.Windows, window)
+	}
+
+	aliases, err := listAliases(aliasScopeAll, "", session)
+	if err != nil {
+		return report, err
+	}
+	for _, a := range aliases {
+		if a.Scope == aliasScopeSession || paneIDs[a.Target] || strings.HasPrefix(a.Target, session+":") {
+			report.Aliases = append(report.Aliases, a)
+		}
+	}
+	return report, nil
+}
+
+// handoffPaneState labels a pane dead, running (a foreground program other
+// than the shell), busy (a shell with recent output), or idle.
+func handoffPaneState(p tmux.PaneDetails, idle bool) string {
+	switch {
+	case p.Dead:
+		return "dead"
+	case !isShellCommand(p.Command):
+		return "running"
+	case !idle:
+		return "busy"
+	default:
+		return "idle"
+	}
+}
+
+// tailLines returns the last n lines of output, ignoring trailing blank lines.
+func tailLines(output string, n int) string {
+	all := strings.Split(strings.TrimRight(output, "\n \t"), "\n")
+	if len(all) > n {
+		all = all[len(all)-n:]
+	}
+	return strings.Join(all, "\n")
+}
+
+func writeHandoffMarkdown(out io.Writer, report handoffReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Handoff: %s\n\n", report.Session)
+	fmt.Fprintf(&b, "Generated %s.\n", report.GeneratedAt.Format(time.RFC3339))
+
+	b.WriteString("\n## Needs attention\n\n")
+	if len(report.Pending) == 0 {
+		b.WriteString("Nothing running or waiting on input.\n")
+	}
+	for _, w := range report.Windows {
+		for _, p := range w.Panes {
+			if p.State == "idle" || p.State == "dead" {
+				continue
+			}
+			fmt.Fprintf(&b, "- `%s` %s\n", p.FormattedID, handoffPaneSummary(p))
+		}
+	}
+
+	for _, w := range report.Windows {
+		active := ""
+		if w.Active {
+			active = " (active)"
+		}
+		fmt.Fprintf(&b, "\n## Window %d: %s%s\n", w.Index, w.Name, active)
+		for _, p := range w.Panes {
+			fmt.Fprintf(&b, "\n### `%s` (%s)\n\n", p.FormattedID, p.PaneID)
+			fmt.Fprintf(&b, "- Command: `%s` (pid %d)\n", p.Command, p.PID)
+			fmt.Fprintf(&b, "- State: %s\n", handoffPaneSummary(p))
+			fmt.Fprintf(&b, "- Path: `%s`\n", p.Path)
+			if p.Title != "" {
+				fmt.Fprintf(&b, "- Title: %s\n", p.Title)
+			}
+			if p.Excerpt != "" {
+				fmt.Fprintf(&b, "\n```\n%s\n```\n", p.Excerpt)
+			}
+		}
+	}
+
+	if len(report.Aliases) > 0 {
+		b.WriteString("\n## Aliases\n\n")
+		for _, a := range report.Aliases {
+			fmt.Fprintf(&b, "- `@%s` -> `%s` (%s)\n", a.Name, a.Target, a.Scope)
+		}
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func handoffPaneSummary(p handoffPane) string {
+	var summary string
+	switch p.State {
+	case "dead":
+		summary = fmt.Sprintf("dead (exit %d)", p.DeadStatus)
+	case "prompt":
+		summary = fmt.Sprintf("waiting on a %s prompt %q", p.Prompt.Kind, p.Prompt.Text)
+	case "running":
+		summary = fmt.Sprintf("running %s", p.Command)
+	default:
+		summary = p.State
+		if p.IdleSeconds > 0 {
+			summary += fmt.Sprintf(" (idle %s)", (time.Duration(p.IdleSeconds) * time.Second).String())
+		}
+	}
+	if p.ProgressPercent != nil {
+		summary += fmt.Sprintf(" at %.1f%%", *p.ProgressPercent)
+	}
+	return summary
+}
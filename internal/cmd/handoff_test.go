@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestHandoffPaneState(t *testing.T) {
+	cases := []struct {
+		pane tmux.PaneDetails
+		idle bool
+		want string
+	}{
+		{tmux.PaneDetails{Command: "bash", Dead: true}, true, "dead"},
+		{tmux.PaneDetails{Command: "node"}, true, "running"},
+		{tmux.PaneDetails{Command: "zsh"}, false, "busy"},
+		{tmux.PaneDetails{Command: "fish"}, true, "idle"},
+	}
+	for _, tc := range cases {
+		if got := handoffPaneState(tc.pane, tc.idle); got != tc.want {
+			t.Fatalf("handoffPaneState(%+v, %v) = %q, want %q", tc.pane, tc.idle, got, tc.want)
+		}
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	if got := tailLines("a\nb\nc\n\n\n", 2); got != "b\nc" {
+		t.Fatalf("tailLines = %q", got)
+	}
+}
+
+func TestWriteHandoffMarkdown(t *testing.T) {
+	report := handoffReport{
+		Session: "dev",
+		Windows: []handoffWindow{{Index: 0, Name: "api", Active: true, Panes: []handoffPane{
+			{FormattedID: "dev:0.0", PaneID: "%1", Command: "node", State: "running", Excerpt: "listening on :3000"},
+			{FormattedID: "dev:0.1", PaneID: "%2", Command: "bash", State: "idle"},
+		}}},
+		Pending: []string{"dev:0.0"},
+		Aliases: []aliasEntry{{Name: "api", Target: "%1", Scope: "session"}},
+	}
+	var buf bytes.Buffer
+	if err := writeHandoffMarkdown(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"# Handoff: dev", "- `dev:0.0` running node", "## Window 0: api (active)", "listening on :3000", "`@api` -> `%1` (session)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "- `dev:0.1`") {
+		t.Fatalf("idle pane listed as needing attention:\n%s", out)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type historyResult struct {
+	PaneID   string             `json:"pane_id" yaml:"pane_id"`
+	Commands []tmux.HookCommand `json:"commands" yaml:"commands"`
+}
+
+func newHistoryCmd() *cobra.Command {
+	var paneArg string
+	var limit int
+	var failed bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List recent commands run in a pane",
+		Long: `List the commands recently run in a pane's shell, oldest first, with when
+each finished and its exit status, so an agent can see what a person already
+tried there before stepping in.
+
+Commands are recorded by the shell hook (see shell-hook), which keeps the last
+100 per pane in pane options; panes without it fail with ERR_NO_SHELL_HOOK.
+Everything typed at the prompt is recorded, whether by a person or arc-tmux.`,
+		Example: `  arc-tmux history --pane @api
+  arc-tmux history --pane dev:1.0 --failed --limit 5
+  arc-tmux history --pane @current -o json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			commands, err := tmux.HookHistory(handle.ID)
+			if err != nil {
+				return err
+			}
+			if len(commands) == 0 {
+				if _, ok, err := tmux.PaneOption(handle.ID, tmux.ShellHookOption); err != nil {
+					return err
+				} else if !ok {
+					return newCodedError(errNoShellHook, fmt.Sprintf("pane %s has no shell hook; load it with: arc-tmux shell-hook install --pane %s", handle.Target, handle.Target), nil)
+				}
+			}
+			result := historyResult{PaneID: handle.Target, Commands: filterHistory(commands, failed, limit)}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				for _, c := range result.Commands {
+					_, _ = fmt.Fprintln(out, c.Command)
+				}
+				return nil
+			}
+
+			if len(result.Commands) == 0 {
+				_, _ = fmt.Fprintf(out, "No commands recorded in %s.\n", result.PaneID)
+				return nil
+			}
+			table := newTextTable("SEQ", "EXIT", "FINISHED", "COMMAND")
+			for _, c := range result.Commands {
+				exit := cell(strconv.Itoa(c.ExitCode))
+				if c.ExitCode != 0 {
+					exit = styledCell(strconv.Itoa(c.ExitCode), styleError)
+				}
+				table.addRow(cell(strconv.Itoa(c.Seq)), exit, cell(formatRelative(c.At)), cell(c.Command))
+			}
+			return table.render(out)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Show the last N commands (0 for all recorded)")
+	cmd.Flags().BoolVar(&failed, "failed", false, "Only show commands that exited non-zero")
+	return cmd
+}
+
+// filterHistory keeps the failed commands when failed is set, then the last
+// limit of them.
+func filterHistory(commands []tmux.HookCommand, failed bool, limit int) []tmux.HookCommand {
+	kept := make([]tmux.HookCommand, 0, len(commands))
+	for _, c := range commands {
+		if failed && c.ExitCode == 0 {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	if limit > 0 && len(kept) > limit {
+		kept = kept[len(kept)-limit:]
+	}
+	return kept
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestFilterHistory(t *testing.T) {
+	commands := []tmux.HookCommand{
+		{Seq: 1, ExitCode: 0, Command: "make"},
+		{Seq: 2, ExitCode: 2, Command: "make test"},
+		{Seq: 3, ExitCode: 0, Command: "git status"},
+		{Seq: 4, ExitCode: 130, Command: "npm start"},
+	}
+	cases := []struct {
+		name   string
+		failed bool
+		limit  int
+		want   []int
+	}{
+		{"all", false, 0, []int{1, 2, 3, 4}},
+		{"last two", false, 2, []int{3, 4}},
+		{"failed", true, 0, []int{2, 4}},
+		{"last failed", true, 1, []int{4}},
+	}
+	for _, tc := range cases {
+		got := filterHistory(commands, tc.failed, tc.limit)
+		seqs := make([]int, 0, len(got))
+		for _, c := range got {
+			seqs = append(seqs, c.Seq)
+		}
+		if len(seqs) != len(tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.name, seqs, tc.want)
+		}
+		for i := range seqs {
+			if seqs[i] != tc.want[i] {
+				t.Fatalf("%s: got %v, want %v", tc.name, seqs, tc.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// Markers delimiting the hook block arc-tmux manages inside tmux.conf.
+const (
+	hooksBlockStart = "# >>> arc-tmux hooks >>>"
+	hooksBlockEnd   = "# <<< arc-tmux hooks <<<"
+)
+
+// serverHookIndex is the hook array index arc-tmux uses, leaving the user's
+// own hooks on the same events untouched. Keep the hooks help in sync.
+const serverHookIndex = 90
+
+// serverHook is one global tmux hook installed by arc-tmux. Command is the
+// tmux command run when Event fires.
+type serverHook struct {
+	Event       string `json:"event" yaml:"event"`
+	Description string `json:"description" yaml:"description"`
+	Command     string `json:"command" yaml:"command"`
+}
+
+type hooksResult struct {
+	Action string       `json:"action" yaml:"action"`
+	Path   string       `json:"path,omitempty" yaml:"path,omitempty"`
+	Live   bool         `json:"live" yaml:"live"`
+	DryRun bool         `json:"dry_run" yaml:"dry_run"`
+	Hooks  []serverHook `json:"hooks" yaml:"hooks"`
+	Hint   string       `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+func newHooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Install tmux hooks that keep arc-tmux state in sync",
+		Long: `Manage server-wide tmux hooks that tell arc-tmux when panes, windows, and
+sessions go away, so aliases and default panes are cleaned up even when panes
+are closed outside arc-tmux (exit, kill-pane, closing a window):
+
+  pane-exited      a pane's command exited and the pane was closed
+  window-unlinked  a window was closed or moved out of a session
+  session-closed   a session was destroyed
+
+Each hook runs "arc-tmux _event" in the background. Hooks are written to a
+marked block in tmux.conf, so re-installing replaces them instead of appending
+duplicates; --live sets them on the running server without touching any file.
+They use hook index 90, so existing hooks on the same events keep running.`,
+		Example: `  arc-tmux hooks install
+  arc-tmux hooks install --live
+  arc-tmux hooks show >> ~/.tmux.conf
+  arc-tmux hooks uninstall --live`,
+	}
+
+	cmd.AddCommand(
+		newHooksInstallCmd(),
+		newHooksUninstallCmd(),
+		newHooksShowCmd(),
+	)
+
+	return cmd
+}
+
+func newHooksInstallCmd() *cobra.Command {
+	var tmuxConf string
+	var live bool
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Write the hooks to tmux.conf (or set them live)",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			hooks := defaultServerHooks(arcTmuxExecutable())
+			result := hooksResult{Action: "install", Live: live, DryRun: dryRun, Hooks: hooks}
+			if live {
+				if !dryRun {
+					for _, hook := range hooks {
+						if err := tmux.SetHook(hook.Event, serverHookIndex, hook.Command); err != nil {
+							return err
+						}
+					}
+				}
+				return writeHooksResult(cmd, outputOpts, result)
+			}
+
+			path, err := bindConfArg(tmuxConf)
+			if err != nil {
+				return err
+			}
+			result.Path = path
+			if !dryRun {
+				if err := writeConfBlock(path, hooksBlockStart, hooksBlockEnd, renderHooksBlock(hooks)); err != nil {
+					return err
+				}
+			}
+			result.Hint = "reload with: tmux source-file " + path
+			return writeHooksResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&tmuxConf, "tmux-conf", "", "tmux.conf to write (default: ~/.config/tmux/tmux.conf or ~/.tmux.conf)")
+	cmd.Flags().BoolVar(&live, "live", false, "Set hooks on the running tmux server instead of writing tmux.conf")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the hooks without writing or setting them")
+	return cmd
+}
+
+func newHooksUninstallCmd() *cobra.Command {
+	var tmuxConf string
+	var live bool
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the hooks from tmux.conf (or unset them live)",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			hooks := defaultServerHooks(arcTmuxExecutable())
+			result := hooksResult{Action: "uninstall", Live: live, DryRun: dryRun, Hooks: hooks}
+			if live {
+				if !dryRun {
+					for _, hook := range hooks {
+						if err := tmux.UnsetHook(hook.Event, serverHookIndex); err != nil {
+							return err
+						}
+					}
+				}
+				return writeHooksResult(cmd, outputOpts, result)
+			}
+
+			path, err := bindConfArg(tmuxConf)
+			if err != nil {
+				return err
+			}
+			result.Path = path
+			if !dryRun {
+				if err := writeConfBlock(path, hooksBlockStart, hooksBlockEnd, ""); err != nil {
+					return err
+				}
+			}
+			result.Hint = "hooks stay set on running servers until tmux restarts or 'arc-tmux hooks uninstall --live'"
+			return writeHooksResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&tmuxConf, "tmux-conf", "", "tmux.conf to edit (default: ~/.config/tmux/tmux.conf or ~/.tmux.conf)")
+	cmd.Flags().BoolVar(&live, "live", false, "Unset hooks on the running tmux server instead of editing tmux.conf")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the hooks without editing or unsetting them")
+	return cmd
+}
+
+func newHooksShowCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the tmux.conf snippet",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			hooks := defaultServerHooks(arcTmuxExecutable())
+			if outputOpts.Is(output.OutputTable) {
+				_, _ = fmt.Fprint(cmd.OutOrStdout(), renderHooksBlock(hooks))
+				return nil
+			}
+			return writeHooksResult(cmd, outputOpts, hooksResult{Action: "show", DryRun: true, Hooks: hooks})
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	return cmd
+}
+
+func writeHooksResult(cmd *cobra.Command, outputOpts output.OutputOptions, result hooksResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, hook := range result.Hooks {
+			_, _ = fmt.Fprintln(out, hook.Event)
+		}
+		return nil
+	}
+	for _, hook := range result.Hooks {
+		_, _ = fmt.Fprintf(out, "%-16s %s\n", hook.Event, hook.Description)
+	}
+	where := result.Path
+	if result.Live {
+		where = "running tmux server"
+	}
+	verb := "Installed in"
+	if result.Action == "uninstall" {
+		verb = "Removed from"
+	}
+	if result.DryRun {
+		verb = "Would update"
+	}
+	_, _ = fmt.Fprintf(out, "%s %s\n", verb, where)
+	if result.Hint != "" && !result.DryRun {
+		_, _ = fmt.Fprintln(out, result.Hint)
+	}
+	return nil
+}
+
+// defaultServerHooks returns the lifecycle hooks; exe is the arc-tmux binary
+// tmux should invoke. Output is discarded so run-shell never opens a view over
+// the user's pane.
+func defaultServerHooks(exe string) []serverHook {
+	event := func(name string, id string) string {
+		return fmt.Sprintf("run-shell -b \"%s _event %s %s >/dev/null 2>&1\"", exe, name, id)
+	}
+	return []serverHook{
+		{Event: "pane-exited", Description: "Forget aliases and default panes of exited panes", Command: event("pane-exited", "#{hook_pane}")},
+		{Event: "window-unlinked", Description: "Forget aliases and default panes of closed windows", Command: event("window-unlinked", "#{hook_window}")},
+		{Event: "session-closed", Description: "Forget aliases pointing into closed sessions", Command: event("session-closed", "#{hook_session_name}")},
+	}
+}
+
+// renderHooksBlock renders hooks as the marked tmux.conf block.
+func renderHooksBlock(hooks []serverHook) string {
+	var b strings.Builder
+	b.WriteString(hooksBlockStart + "\n")
+	b.WriteString("# Managed by arc-tmux; edits inside this block are overwritten.\n")
+	for _, hook := range hooks {
+		b.WriteString("# " + hook.Event + ": " + hook.Description + "\n")
+		name := fmt.Sprintf("%s[%d]", hook.Event, serverHookIndex)
+		b.WriteString(strings.Join([]string{"set-hook", "-g", tmuxConfQuote(name), tmuxConfQuote(hook.Command)}, " ") + "\n")
+	}
+	b.WriteString(hooksBlockEnd + "\n")
+	return b.String()
+}
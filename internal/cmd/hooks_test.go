@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHooksBlock(t *testing.T) {
+	block := renderHooksBlock(defaultServerHooks("/usr/local/bin/arc-tmux"))
+	want := `set-hook -g pane-exited[90] 'run-shell -b "/usr/local/bin/arc-tmux _event pane-exited #{hook_pane} >/dev/null 2>&1"'`
+	if !strings.Contains(block, want+"\n") {
+		t.Fatalf("expected %q in block:\n%s", want, block)
+	}
+	conf := replaceConfBlock("set -g mouse on\n", hooksBlockStart, hooksBlockEnd, block)
+	bound := replaceBindBlock(conf, renderBindBlock(defaultBindings("arc-tmux", "arc-tmux")))
+	if removed := replaceConfBlock(bound, hooksBlockStart, hooksBlockEnd, ""); strings.Contains(removed, "set-hook") || !strings.Contains(removed, bindBlockStart) {
+		t.Fatalf("removing hooks should keep the bindings block:\n%s", removed)
+	}
+}
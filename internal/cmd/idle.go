@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type idleReport struct {
+	Session string     `json:"session,omitempty" yaml:"session,omitempty"`
+	Idle    float64    `json:"idle_threshold_seconds" yaml:"idle_threshold_seconds"`
+	Busy    int        `json:"busy" yaml:"busy"`
+	Panes   []idlePane `json:"panes" yaml:"panes"`
+}
+
+type idlePane struct {
+	Pane        string  `json:"pane" yaml:"pane"`
+	PaneID      string  `json:"pane_id" yaml:"pane_id"`
+	Command     string  `json:"command" yaml:"command"`
+	State       string  `json:"state" yaml:"state"`
+	IdleSeconds float64 `json:"idle_seconds" yaml:"idle_seconds"`
+	// Source is "pane" or "window": tmux before 3.4 has no pane_activity,
+	// so the window's last activity stands in for each of its panes.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+func newIdleCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var idle float64
+
+	cmd := &cobra.Command{
+		Use:   "idle",
+		Short: "Report which panes are idle and for how long",
+		Long: `Report, for every pane (or those in --session), whether it is idle and how
+long since it last produced output, from a single tmux list-panes call. A pane
+is busy when it produced output within the last --idle seconds; dead panes
+are reported as dead.
+
+tmux before 3.4 does not track activity per pane, so the window's last
+activity is used for each of its panes (source "window").
+
+--output quiet prints only the busy panes, one per line, so an empty result
+means everything is quiet: the check to run before broadcasting keys.`,
+		Example: `  arc-tmux idle --session dev
+  arc-tmux idle --session dev --idle 10 -o quiet
+  arc-tmux idle --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if idle <= 0 {
+				return errors.New("--idle must be > 0")
+			}
+			resolved, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			panes, err := tmux.ListPanesDetailed()
+			if err != nil && !errors.Is(err, tmux.ErrNoTmuxServer) {
+				return err
+			}
+			report := buildIdleReport(panes, resolved, idle, time.Now())
+			// A mistyped session must not read as "nothing busy".
+			if resolved != "" && len(report.Panes) == 0 {
+				return newCodedError(errInvalidSession, fmt.Sprintf("session %q not found", resolved), nil)
+			}
+			return writeIdleReport(cmd, outputOpts, report)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Only report panes in this session (name, @current, or @managed)")
+	cmd.Flags().Float64Var(&idle, "idle", 2.0, "Seconds of inactivity before a pane counts as idle")
+	return cmd
+}
+
+// buildIdleReport classifies panes in session (all when empty) as idle, busy,
+// or dead as of now.
+func buildIdleReport(panes []tmux.PaneDetails, session string, idle float64, now time.Time) idleReport {
+	report := idleReport{Session: session, Idle: idle, Panes: []idlePane{}}
+	for _, p := range panes {
+		if session != "" && p.Session != session {
+			continue
+		}
+		pane := idlePane{
+			Pane:    formattedPaneID(&p),
+			PaneID:  p.PaneID,
+			Command: p.Command,
+			State:   "idle",
+		}
+		activity, source := p.ActivityAt, "pane"
+		if activity.IsZero() {
+			activity, source = p.WindowActivityAt, "window"
+		}
+		if !activity.IsZero() {
+			pane.Source = source
+			pane.IdleSeconds = math.Max(0, now.Sub(activity).Seconds())
+		}
+		switch {
+		case p.Dead:
+			pane.State = "dead"
+		case pane.Source != "" && pane.IdleSeconds < idle:
+			pane.State = "busy"
+			report.Busy++
+		}
+		report.Panes = append(report.Panes, pane)
+	}
+	return report
+}
+
+func writeIdleReport(cmd *cobra.Command, outputOpts output.OutputOptions, report idleReport) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(report)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, p := range report.Panes {
+			if p.State == "busy" {
+				_, _ = fmt.Fprintln(out, p.Pane)
+			}
+		}
+		return nil
+	}
+	if len(report.Panes) == 0 {
+		_, _ = fmt.Fprintln(out, "No panes found.")
+		return nil
+	}
+	table := newTextTable("PANE", "COMMAND", "STATE", "IDLE")
+	for _, p := range report.Panes {
+		state := styledCell(p.State, styleMuted)
+		switch p.State {
+		case "busy":
+			state = styledCell(p.State, styleActive)
+		case "dead":
+			state = styledCell(p.State, styleError)
+		}
+		idleFor := "-"
+		if p.Source != "" {
+			idleFor = formatActivitySeconds(p.IdleSeconds)
+		}
+		table.addRow(cell(p.Pane), cell(p.Command), state, cell(idleFor))
+	}
+	return table.render(out)
+}
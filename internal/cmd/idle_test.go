@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestBuildIdleReport(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	panes := []tmux.PaneDetails{
+		{Session: "dev", WindowIndex: 1, PaneIndex: 0, PaneID: "%1", Command: "bash", ActivityAt: now.Add(-time.Second)},
+		{Session: "dev", WindowIndex: 1, PaneIndex: 1, PaneID: "%2", Command: "bash", ActivityAt: now.Add(-time.Minute)},
+		{Session: "dev", WindowIndex: 2, PaneIndex: 0, PaneID: "%3", Command: "node", WindowActivityAt: now.Add(-500 * time.Millisecond)},
+		{Session: "dev", WindowIndex: 3, PaneIndex: 0, PaneID: "%4", Command: "bash", Dead: true, ActivityAt: now},
+		{Session: "dev", WindowIndex: 4, PaneIndex: 0, PaneID: "%5", Command: "vim"},
+		{Session: "ops", WindowIndex: 0, PaneIndex: 0, PaneID: "%6", Command: "bash", ActivityAt: now},
+	}
+	report := buildIdleReport(panes, "dev", 2, now)
+	if len(report.Panes) != 5 || report.Busy != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	want := []struct {
+		state, source string
+	}{
+		{"busy", "pane"},
+		{"idle", "pane"},
+		{"busy", "window"},
+		{"dead", "pane"},
+		{"idle", ""},
+	}
+	for i, w := range want {
+		got := report.Panes[i]
+		if got.State != w.state || got.Source != w.source {
+			t.Fatalf("pane %s: got state %q source %q, want %q %q", got.Pane, got.State, got.Source, w.state, w.source)
+		}
+	}
+	if report.Panes[1].Pane != "dev:1.1" || report.Panes[1].IdleSeconds != 60 {
+		t.Fatalf("unexpected idle pane: %+v", report.Panes[1])
+	}
+	if all := buildIdleReport(panes, "", 2, now); len(all.Panes) != 6 {
+		t.Fatalf("expected all panes without --session, got %d", len(all.Panes))
+	}
+}
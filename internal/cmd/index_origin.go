@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+const (
+	indexOriginTmux = "tmux"
+	indexOriginZero = "0"
+)
+
+func addIndexOriginFlag(root *cobra.Command) {
+	root.PersistentFlags().String("index-origin", "", `Window/pane index origin: "tmux" follows base-index and pane-base-index, "0" always counts from zero (env ARC_TMUX_INDEX_ORIGIN)`)
+}
+
+// applyIndexOrigin picks the index origin from --index-origin, the
+// environment, or the config file, in that order.
+func applyIndexOrigin(cmd *cobra.Command) error {
+	cfg, _ := loadConfig(defaultConfigFile())
+	origin := strings.TrimSpace(cfg.IndexOrigin)
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_INDEX_ORIGIN")); env != "" {
+		origin = env
+	}
+	if f := cmd.Flags().Lookup("index-origin"); f != nil && f.Changed {
+		origin = strings.TrimSpace(f.Value.String())
+	}
+	zero, err := parseIndexOrigin(origin)
+	if err != nil {
+		return err
+	}
+	tmux.SetZeroBasedIndexes(zero)
+	return nil
+}
+
+// parseIndexOrigin reports whether origin selects zero-based indexes.
+func parseIndexOrigin(origin string) (bool, error) {
+	switch strings.ToLower(origin) {
+	case "", indexOriginTmux, "native":
+		return false, nil
+	case indexOriginZero, "zero":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid index origin %q (want tmux or 0)", origin)
+	}
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import "testing"
+
+func TestParseIndexOrigin(t *testing.T) {
+	for _, origin := range []string{"", "tmux", "native"} {
+		if zero, err := parseIndexOrigin(origin); err != nil || zero {
+			t.Fatalf("parseIndexOrigin(%q) = %v, %v", origin, zero, err)
+		}
+	}
+	for _, origin := range []string{"0", "zero", "ZERO"} {
+		if zero, err := parseIndexOrigin(origin); err != nil || !zero {
+			t.Fatalf("parseIndexOrigin(%q) = %v, %v", origin, zero, err)
+		}
+	}
+	if _, err := parseIndexOrigin("1"); err == nil {
+		t.Fatalf("expected error for origin 1")
+	}
+}
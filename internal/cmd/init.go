@@ -0,0 +1,233 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type initResult struct {
+	ConfigPath  string     `json:"config_path" yaml:"config_path"`
+	Session     string     `json:"session" yaml:"session"`
+	TmuxVersion string     `json:"tmux_version,omitempty" yaml:"tmux_version,omitempty"`
+	DryRun      bool       `json:"dry_run" yaml:"dry_run"`
+	Steps       []initStep `json:"steps" yaml:"steps"`
+}
+
+type initStep struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+const (
+	initStepOK      = "ok"
+	initStepDone    = "done"
+	initStepSkipped = "skipped"
+	initStepWarning = "warning"
+	initStepDryRun  = "dry_run"
+)
+
+func newInitCmd() *cobra.Command {
+	var yes bool
+	var session string
+	var configFile string
+	var shell string
+	var tmuxConf string
+	var noCompletions bool
+	var noBindings bool
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Set up config, completions, and tmux keybindings",
+		Long: `Guided first-run setup. init checks the tmux version, writes the config file
+with the managed session name, and offers to install shell completions and the
+arc-tmux tmux keybindings (prefix+A opens a menu for the current pane).
+
+Questions are asked on stderr; --yes accepts every default so init can run from
+scripts. Re-running init is safe: the config is updated in place and the
+keybinding block in tmux.conf is replaced rather than duplicated.`,
+		Example: `  arc-tmux init
+  arc-tmux init --yes --session agents
+  arc-tmux init --yes --no-bindings --dry-run`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			yes = yes || assumeYes
+			if !yes && nonInteractive {
+				return confirmationRequired("init asks questions in non-interactive mode")
+			}
+			prompt := &initPrompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr(), yes: yes}
+			path := configFile
+			if path == "" {
+				path = defaultConfigFile()
+			}
+			result := initResult{ConfigPath: path, DryRun: dryRun}
+			stepStatus := func(done string) string {
+				if dryRun {
+					return initStepDryRun
+				}
+				return done
+			}
+
+			if v, err := tmux.ClientVersion(); err != nil {
+				result.Steps = append(result.Steps, initStep{Name: "tmux", Status: initStepWarning, Detail: err.Error()})
+			} else {
+				result.TmuxVersion = v.String()
+				step := initStep{Name: "tmux", Status: initStepOK, Detail: "tmux " + v.String()}
+				if !tmux.FeaturesFor(v).Popups {
+					step.Status = initStepWarning
+					step.Detail += " (3.2+ needed for the popup menu items)"
+				}
+				result.Steps = append(result.Steps, step)
+			}
+
+			cfg, err := loadConfig(path)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(session) == "" {
+				def := strings.TrimSpace(cfg.Session)
+				if def == "" {
+					def = resolveManagedSession()
+				}
+				session = prompt.ask("Managed session name", def)
+			}
+			session = strings.TrimSpace(session)
+			if err := tmux.ValidateSessionName(session); err != nil {
+				return newCodedError(errInvalidSession, err.Error(), nil)
+			}
+			cfg.Session = session
+			result.Session = session
+			if !dryRun {
+				if err := saveConfig(path, cfg); err != nil {
+					return err
+				}
+			}
+			result.Steps = append(result.Steps, initStep{Name: "config", Status: stepStatus(initStepDone), Detail: path})
+
+			if noCompletions || !prompt.confirm("Install shell completions?", true) {
+				result.Steps = append(result.Steps, initStep{Name: "completions", Status: initStepSkipped})
+			} else if res, err := installCompletions(cmd.Root(), shell, "", dryRun); err != nil {
+				result.Steps = append(result.Steps, initStep{Name: "completions", Status: initStepWarning, Detail: err.Error()})
+			} else {
+				detail := res.Shell + ": " + res.Path
+				if res.Hint != "" {
+					detail += " (" + res.Hint + ")"
+				}
+				result.Steps = append(result.Steps, initStep{Name: "completions", Status: stepStatus(initStepDone), Detail: detail})
+			}
+
+			confPath := tmuxConf
+			if confPath == "" {
+				if confPath, err = defaultTmuxConf(); err != nil {
+					return err
+				}
+			}
+			if noBindings || !prompt.confirm(fmt.Sprintf("Install tmux keybindings (prefix+A menu) in %s?", confPath), true) {
+				result.Steps = append(result.Steps, initStep{Name: "bindings", Status: initStepSkipped})
+			} else {
+				if !dryRun {
+					if err := writeBindBlock(confPath, renderBindBlock(defaultBindings(arcTmuxExecutable(), session))); err != nil {
+						return err
+					}
+				}
+				detail := fmt.Sprintf("%s (reload with: tmux source-file %s)", confPath, confPath)
+				result.Steps = append(result.Steps, initStep{Name: "bindings", Status: stepStatus(initStepDone), Detail: detail})
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				_, _ = fmt.Fprintln(out, result.ConfigPath)
+				return nil
+			}
+			for _, step := range result.Steps {
+				_, _ = fmt.Fprintf(out, "%-12s %-8s %s\n", step.Name, step.Status, step.Detail)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Accept all defaults without prompting")
+	cmd.Flags().StringVar(&session, "session", "", "Managed session name to store in the config")
+	cmd.Flags().StringVar(&configFile, "config", "", "Config file path (default: ARC_TMUX_CONFIG or config dir)")
+	cmd.Flags().StringVar(&shell, "shell", "", "Shell to install completions for (default: from $SHELL)")
+	cmd.Flags().StringVar(&tmuxConf, "tmux-conf", "", "tmux.conf to add keybindings to (default: ~/.config/tmux/tmux.conf or ~/.tmux.conf)")
+	cmd.Flags().BoolVar(&noCompletions, "no-completions", false, "Skip installing shell completions")
+	cmd.Flags().BoolVar(&noBindings, "no-bindings", false, "Skip installing tmux keybindings")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be written without changing any files")
+	return cmd
+}
+
+// initPrompter asks questions on out and reads answers from in. With yes set,
+// or when input is exhausted, defaults are used.
+type initPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	yes bool
+}
+
+func (p *initPrompter) ask(question string, def string) string {
+	if p.yes {
+		return def
+	}
+	_, _ = fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	line, err := p.in.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if err != nil && line == "" {
+		_, _ = fmt.Fprintln(p.out)
+		return def
+	}
+	if line == "" {
+		return def
+	}
+	return line
+}
+
+func (p *initPrompter) confirm(question string, def bool) bool {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+	if p.yes {
+		return def
+	}
+	_, _ = fmt.Fprintf(p.out, "%s [%s]: ", question, choices)
+	line, err := p.in.ReadString('\n')
+	line = strings.ToLower(strings.TrimSpace(line))
+	if err != nil && line == "" {
+		_, _ = fmt.Fprintln(p.out)
+		return def
+	}
+	switch line {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return def
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestInitPrompter(t *testing.T) {
+	p := &initPrompter{in: bufio.NewReader(strings.NewReader("agents\nn\n\n")), out: io.Discard}
+	if got := p.ask("Managed session name", "arc-tmux"); got != "agents" {
+		t.Fatalf("expected typed answer, got %q", got)
+	}
+	if p.confirm("Install shell completions?", true) {
+		t.Fatalf("expected explicit no to win over default")
+	}
+	if !p.confirm("Install tmux keybindings?", true) {
+		t.Fatalf("expected empty answer to use default")
+	}
+	if got := p.ask("Managed session name", "arc-tmux"); got != "arc-tmux" {
+		t.Fatalf("expected default at EOF, got %q", got)
+	}
+
+	yes := &initPrompter{in: bufio.NewReader(strings.NewReader("n\n")), out: io.Discard, yes: true}
+	if !yes.confirm("Install shell completions?", true) {
+		t.Fatalf("expected --yes to accept the default")
+	}
+}
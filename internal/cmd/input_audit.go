@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// auditTextLimit caps the text copied into each input audit record.
+const auditTextLimit = 1024
+
+// inputAuditEntry is one JSON line in the input audit log: something arc-tmux
+// typed into a pane, and who asked it to.
+type inputAuditEntry struct {
+	Time  time.Time `json:"time" yaml:"time"`
+	Actor string    `json:"actor" yaml:"actor"`
+	// Pane is the pane ID (%N), which stays the same however the pane was
+	// addressed.
+	Pane    string   `json:"pane" yaml:"pane"`
+	Command string   `json:"command" yaml:"command"`
+	Text    string   `json:"text,omitempty" yaml:"text,omitempty"`
+	Keys    []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+}
+
+// currentActor is who this invocation acts for, set by applyActor.
+var currentActor string
+
+var auditMu sync.Mutex
+
+func addActorFlag(root *cobra.Command) {
+	root.PersistentFlags().String("actor", "", "Name to record input sent to panes under, e.g. an agent's name (env ARC_TMUX_ACTOR; default: the OS user)")
+}
+
+// applyActor resolves the acting identity from --actor, ARC_TMUX_ACTOR, or
+// the OS user, and, when the audit log is on, records every input the command
+// sends in it.
+func applyActor(cmd *cobra.Command) {
+	currentActor = resolveActor(cmd)
+	if cmd.Name() == "__complete" {
+		return
+	}
+	path := defaultAuditFile()
+	if path == "" {
+		return
+	}
+	command := cmd.CommandPath()
+	// The rotation policy and pane IDs are resolved once per invocation, not
+	// for every input sent.
+	var policyOnce sync.Once
+	var policy rotationPolicy
+	var policyErr error
+	panes := map[string]string{}
+	tmux.SetInputRecorder(func(target string, text string, keys []string) {
+		policyOnce.Do(func() { policy, policyErr = loadRotationPolicy() })
+		err := policyErr
+		if err == nil {
+			pane, ok := panes[target]
+			if !ok {
+				pane = auditPaneID(target)
+				panes[target] = pane
+			}
+			err = appendAuditEntry(path, policy, inputAuditEntry{
+				Time:    time.Now().UTC(),
+				Actor:   currentActor,
+				Pane:    pane,
+				Command: command,
+				Text:    truncateAuditText(text),
+				Keys:    keys,
+			})
+		}
+		if err != nil {
+			_, _ = io.WriteString(os.Stderr, "warning: audit log: "+err.Error()+"\n")
+		}
+	})
+}
+
+func resolveActor(cmd *cobra.Command) string {
+	if f := cmd.Flags().Lookup("actor"); f != nil && f.Changed && strings.TrimSpace(f.Value.String()) != "" {
+		return strings.TrimSpace(f.Value.String())
+	}
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_ACTOR")); env != "" {
+		return env
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if env := strings.TrimSpace(os.Getenv("USER")); env != "" {
+		return env
+	}
+	return "unknown"
+}
+
+// defaultAuditFile is the input audit log, which is off ("") unless
+// ARC_TMUX_AUDIT_LOG or the config's audit_log names a file; "on" picks
+// input.jsonl under the user cache directory and "off" disables it.
+func defaultAuditFile() string {
+	cfg, _ := loadConfig(defaultConfigFile())
+	path := strings.TrimSpace(cfg.AuditLog)
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_AUDIT_LOG")); env != "" {
+		path = env
+	}
+	switch path {
+	case "", "off":
+		return ""
+	case "on":
+	default:
+		return path
+	}
+	if dir, err := os.UserCacheDir(); err == nil && strings.TrimSpace(dir) != "" {
+		return filepath.Join(dir, "arc-tmux", "input.jsonl")
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".arc-tmux-input.jsonl")
+	}
+	return "input.jsonl"
+}
+
+// auditPaneID turns a send target into a pane ID; targets are usually
+// already IDs, since commands send to resolved handles.
+func auditPaneID(target string) string {
+	if strings.HasPrefix(target, "%") {
+		return target
+	}
+	if handle, err := tmux.ResolveTarget(target); err == nil {
+		return handle.ID
+	}
+	return target
+}
+
+func truncateAuditText(text string) string {
+	if len(text) <= auditTextLimit {
+		return text
+	}
+	return headBytes(text, auditTextLimit) + "..."
+}
+
+func appendAuditEntry(path string, policy rotationPolicy, entry inputAuditEntry) error {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	f, err := openRotatingFile(path, policy)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// loadAuditEntries reads the entries for pane recorded at or after since,
+// including those in rotated copies of the log, oldest first. A missing log
+// yields no entries; malformed lines are skipped.
+func loadAuditEntries(path string, pane string, since time.Time) ([]inputAuditEntry, error) {
+	var entries []inputAuditEntry
+	rotated, err := rotatedLogs(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, r := range rotated {
+		if r.Rotated.Before(since) {
+			continue
+		}
+		if entries, err = readAuditEntries(r.Path, pane, since, entries); err != nil {
+			return nil, err
+		}
+	}
+	return readAuditEntries(path, pane, since, entries)
+}
+
+func readAuditEntries(path string, pane string, since time.Time, entries []inputAuditEntry) ([]inputAuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		defer func() { _ = zr.Close() }()
+		r = zr
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e inputAuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if e.Pane != pane || e.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
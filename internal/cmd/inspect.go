@@ -38,11 +38,12 @@ func newInspectCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
 				return err
 			}
 
-			pane, err := tmux.PaneDetailsForTarget(target)
+			pane, err := tmux.PaneDetailsForTarget(handle.ID)
 			if err != nil {
 				return err
 			}
@@ -104,6 +105,5 @@ func newInspectCmd() *cobra.Command {
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
 	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
-	_ = cmd.MarkFlagRequired("pane")
 	return cmd
 }
@@ -6,6 +6,14 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/yourorg/arc-sdk/output"
@@ -13,50 +21,185 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// maxInterruptTimes caps --times so a runaway agent cannot flood a pane.
+const maxInterruptTimes = 10
+
 func newInterruptCmd() *cobra.Command {
 	var paneArg string
+	var times int
+	var interval float64
+	var force bool
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
-		Use:     "interrupt",
-		Short:   "Send Ctrl+C to a pane",
-		Long:    "Gracefully stop the foreground program in a pane by sending Ctrl+C.",
-		Example: `  arc-tmux interrupt --pane=fe:api.0`,
+		Use:   "interrupt",
+		Short: "Send Ctrl+C to a pane",
+		Long: `Gracefully stop the foreground program in a pane by sending Ctrl+C.
+
+Panes running a protected command are refused (ERR_PANE_PROTECTED) unless
+--force is given. Protected commands are regular expressions matched against
+each command line in the pane's process tree, configured with
+protected_commands in the config file; none are protected by default.`,
+		Example: `  arc-tmux interrupt --pane=fe:api.0
+  arc-tmux interrupt --pane=fe:api.0 --times 3 --interval 0.5
+  arc-tmux panes --session dev -o quiet | arc-tmux interrupt --pane -`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-			target, err := resolvePaneTarget(paneArg)
+			if times < 1 || times > maxInterruptTimes {
+				return fmt.Errorf("--times must be between 1 and %d", maxInterruptTimes)
+			}
+			if interval < 0 {
+				return fmt.Errorf("--interval must not be negative")
+			}
+			handles, bulk, err := resolvePaneTargets(cmd, paneArg)
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
-				return err
+			if !force {
+				patterns := protectedCommandPatterns(cmd.ErrOrStderr())
+				for _, h := range handles {
+					if command, pattern, ok := paneProtectedCommand(h.ID, patterns); ok {
+						return newCodedError(errPaneProtected, fmt.Sprintf("pane %s is running %q (protected by %q); pass --force to interrupt anyway", h.Target, command, pattern), nil)
+					}
+				}
 			}
-			if err := tmux.Interrupt(target); err != nil {
-				return err
+			results := make([]actionResult, 0, len(handles))
+			for _, h := range handles {
+				for i := 0; i < times; i++ {
+					if i > 0 {
+						time.Sleep(time.Duration(interval * float64(time.Second)))
+					}
+					if err := tmux.Interrupt(h.ID); err != nil {
+						return err
+					}
+				}
+				result := actionResult{PaneID: h.Target, Action: "interrupt"}
+				if times > 1 {
+					result.Times = times
+				}
+				results = append(results, result)
+			}
+			if bulk {
+				return writeActionResults(cmd, outputOpts, results, "Sent Ctrl+C")
 			}
-			result := actionResult{PaneID: target, Action: "interrupt"}
-			return writeActionResult(cmd, outputOpts, result, "Sent Ctrl+C")
+			return writeActionResult(cmd, outputOpts, results[0], "Sent Ctrl+C")
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
-	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, - for stdin)")
+	cmd.Flags().IntVar(&times, "times", 1, fmt.Sprintf("Number of Ctrl+C presses (max %d)", maxInterruptTimes))
+	cmd.Flags().Float64Var(&interval, "interval", 0.5, "Seconds between presses when --times > 1")
+	cmd.Flags().BoolVar(&force, "force", false, "Interrupt even if the pane runs a protected command")
 
 	return cmd
 }
 
+// protectedCommandPatterns compiles protected_commands from the config file.
+// A config that cannot be read, or a pattern that does not compile, is
+// reported on stderr and skipped rather than blocking the keystroke.
+func protectedCommandPatterns(stderr io.Writer) []*regexp.Regexp {
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "warning: protected_commands: %v\n", err)
+		return nil
+	}
+	patterns := make([]*regexp.Regexp, 0, len(cfg.ProtectedCommands))
+	for _, expr := range cfg.ProtectedCommands {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "warning: protected_commands: invalid pattern %q: %v\n", expr, err)
+			continue
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns
+}
+
+// paneProtectedCommand reports the first command line in the pane's process
+// tree that matches a protected pattern.
+func paneProtectedCommand(paneID string, patterns []*regexp.Regexp) (string, string, bool) {
+	if len(patterns) == 0 {
+		return "", "", false
+	}
+	pane, err := tmux.PaneDetailsForTarget(paneID)
+	if err != nil || pane.Dead {
+		return "", "", false
+	}
+	commands := []string{pane.Command}
+	if pane.PID > 0 {
+		if tree, err := tmux.ProcessTree(pane.PID); err == nil {
+			for _, node := range tree {
+				commands = append(commands, node.Command)
+			}
+		}
+	}
+	return matchProtectedCommand(commands, patterns)
+}
+
+// matchProtectedCommand matches command lines with the program reduced to its
+// base name, so "^vim" also matches "/usr/bin/vim file.go".
+func matchProtectedCommand(commands []string, patterns []*regexp.Regexp) (string, string, bool) {
+	for _, command := range commands {
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = filepath.Base(strings.TrimPrefix(fields[0], "-"))
+		line := strings.Join(fields, " ")
+		for _, re := range patterns {
+			if re.MatchString(line) {
+				return line, re.String(), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// forwardInterrupts relays the first Ctrl+C (SIGINT) sent to arc-tmux to the
+// pane, so cancelling the wrapper cancels the command it is watching. The
+// returned channel is closed once an interrupt has been forwarded; a second
+// Ctrl+C gets the default behaviour and ends arc-tmux at once. Call stop when
+// done watching the pane.
+func forwardInterrupts(handle tmux.PaneHandle, stderr io.Writer) (forwarded <-chan struct{}, stop func()) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	sent := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigs:
+			signal.Stop(sigs)
+			if err := tmux.Interrupt(handle.ID); err != nil {
+				_, _ = fmt.Fprintf(stderr, "warning: forwarding Ctrl+C to %s: %v\n", handle.Target, err)
+			} else {
+				_, _ = fmt.Fprintf(stderr, "Forwarded Ctrl+C to %s.\n", handle.Target)
+			}
+			close(sent)
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	return sent, func() {
+		once.Do(func() {
+			signal.Stop(sigs)
+			close(done)
+		})
+	}
+}
+
 func newEscapeCmd() *cobra.Command {
 	var paneArg string
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
-		Use:     "escape",
-		Short:   "Send Escape key to a pane",
-		Long:    "Inject a literal Escape keystroke.",
-		Example: `  arc-tmux escape --pane=fe:2.0`,
+		Use:        "escape",
+		Short:      "Send Escape key to a pane",
+		Deprecated: "use \"arc-tmux key Escape\" instead",
+		Long:       "Inject a literal Escape keystroke.",
+		Example:    `  arc-tmux escape --pane=fe:2.0`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
@@ -65,10 +208,12 @@ func newEscapeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
 				return err
 			}
-			if err := tmux.Escape(target); err != nil {
+			target = handle.Target
+			if err := tmux.Escape(handle.ID); err != nil {
 				return err
 			}
 			result := actionResult{PaneID: target, Action: "escape"}
@@ -78,7 +223,6 @@ func newEscapeCmd() *cobra.Command {
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
 	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
-	_ = cmd.MarkFlagRequired("pane")
 
 	return cmd
 }
@@ -86,6 +230,7 @@ func newEscapeCmd() *cobra.Command {
 type actionResult struct {
 	PaneID string `json:"pane_id" yaml:"pane_id"`
 	Action string `json:"action" yaml:"action"`
+	Times  int    `json:"times,omitempty" yaml:"times,omitempty"`
 }
 
 func writeActionResult(cmd *cobra.Command, outputOpts output.OutputOptions, result actionResult, message string) error {
@@ -105,3 +250,23 @@ func writeActionResult(cmd *cobra.Command, outputOpts output.OutputOptions, resu
 	_, _ = fmt.Fprintln(out, message)
 	return nil
 }
+
+func writeActionResults(cmd *cobra.Command, outputOpts output.OutputOptions, results []actionResult, message string) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(results)
+	case outputOpts.Is(output.OutputQuiet):
+		return nil
+	}
+	for _, result := range results {
+		_, _ = fmt.Fprintf(out, "%s (%s)\n", message, result.PaneID)
+	}
+	return nil
+}
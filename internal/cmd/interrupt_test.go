@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMatchProtectedCommand(t *testing.T) {
+	patterns := []*regexp.Regexp{regexp.MustCompile(`^(vi|vim|nvim)\b`), regexp.MustCompile(`^ssh .*prod`)}
+	cases := []struct {
+		commands []string
+		want     bool
+	}{
+		{[]string{"zsh", "-zsh", "/usr/bin/vim main.go"}, true},
+		{[]string{"ssh", "ssh deploy@prod-db-1"}, true},
+		{[]string{"ssh", "ssh staging"}, false},
+		{[]string{"node", "node vimrc-server.js"}, false},
+	}
+	for _, tc := range cases {
+		_, _, got := matchProtectedCommand(tc.commands, patterns)
+		if got != tc.want {
+			t.Fatalf("matchProtectedCommand(%q) = %v, want %v", tc.commands, got, tc.want)
+		}
+	}
+}
+
+func TestProtectedCommandPatternsConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	t.Setenv("ARC_TMUX_CONFIG", path)
+
+	var stderr bytes.Buffer
+	if patterns := protectedCommandPatterns(&stderr); len(patterns) != 0 || stderr.Len() != 0 {
+		t.Fatalf("without a config: %d patterns, stderr %q", len(patterns), stderr.String())
+	}
+
+	if err := os.WriteFile(path, []byte("protected_commands:\n  - '^vim'\n  - '('\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	patterns := protectedCommandPatterns(&stderr)
+	if len(patterns) != 1 || patterns[0].String() != "^vim" {
+		t.Fatalf("patterns = %v", patterns)
+	}
+	if !strings.Contains(stderr.String(), "invalid pattern") {
+		t.Fatalf("expected a warning, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if err := os.WriteFile(path, []byte("protected_commands: [\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if patterns := protectedCommandPatterns(&stderr); len(patterns) != 0 || !strings.Contains(stderr.String(), "warning:") {
+		t.Fatalf("broken config: %d patterns, stderr %q", len(patterns), stderr.String())
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type keyResult struct {
+	PaneID    string   `json:"pane_id" yaml:"pane_id"`
+	Keys      []string `json:"keys" yaml:"keys"`
+	Repeat    int      `json:"repeat" yaml:"repeat"`
+	DelaySecs float64  `json:"delay_secs" yaml:"delay_secs"`
+	KeysSent  int      `json:"keys_sent" yaml:"keys_sent"`
+}
+
+// namedKeys maps accepted key names (lowercased) to tmux key names.
+var namedKeys = map[string]string{
+	"escape": "Escape", "esc": "Escape",
+	"enter": "Enter", "return": "Enter",
+	"tab": "Tab", "btab": "BTab", "backtab": "BTab",
+	"space":  "Space",
+	"bspace": "BSpace", "backspace": "BSpace",
+	"up": "Up", "down": "Down", "left": "Left", "right": "Right",
+	"home": "Home", "end": "End",
+	"pageup": "PageUp", "pgup": "PageUp", "ppage": "PageUp",
+	"pagedown": "PageDown", "pgdn": "PageDown", "npage": "PageDown",
+	"insert": "Insert", "ic": "Insert",
+	"delete": "Delete", "del": "Delete", "dc": "Delete",
+}
+
+const maxKeyRepeat = 100
+
+func newKeyCmd() *cobra.Command {
+	var paneArg string
+	var repeat int
+	var delay float64
+	var force bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "key <key>...",
+		Short: "Send named special keys to a pane",
+		Long: `Send special keys to a pane for TUI automation.
+
+Keys are tmux key names, matched case-insensitively: Escape (Esc), Enter, Tab,
+BTab, Space, BSpace, Up, Down, Left, Right, Home, End, PageUp (PgUp),
+PageDown (PgDn), Insert, Delete, F1-F12, or a single character. Prefix C-, M-,
+or S- for Ctrl, Meta/Alt, and Shift chords (e.g. C-x, M-Left, C-M-a).
+
+The key sequence is sent --repeat times, waiting --delay seconds between keys.
+C-c is checked against protected commands like "interrupt" unless --force.`,
+		Example: `  arc-tmux key Escape --pane=fe:2.0
+  arc-tmux key Down --repeat 5 --delay 0.1 --pane=@current
+  arc-tmux key C-x C-s --pane=@editor
+  arc-tmux key F5 --pane -`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			keys := make([]string, 0, len(args))
+			for _, arg := range args {
+				key, err := normalizeKeyName(arg)
+				if err != nil {
+					return err
+				}
+				keys = append(keys, key)
+			}
+			if repeat < 1 || repeat > maxKeyRepeat {
+				return fmt.Errorf("--repeat must be between 1 and %d", maxKeyRepeat)
+			}
+			if delay < 0 {
+				return fmt.Errorf("--delay must not be negative")
+			}
+			handles, bulk, err := resolvePaneTargets(cmd, paneArg)
+			if err != nil {
+				return err
+			}
+			if !force && containsKey(keys, "C-c") {
+				patterns := protectedCommandPatterns(cmd.ErrOrStderr())
+				for _, h := range handles {
+					if command, pattern, ok := paneProtectedCommand(h.ID, patterns); ok {
+						return newCodedError(errPaneProtected, fmt.Sprintf("pane %s is running %q (protected by %q); pass --force to send C-c anyway", h.Target, command, pattern), nil)
+					}
+				}
+			}
+
+			pause := time.Duration(delay * float64(time.Second))
+			results := make([]keyResult, 0, len(handles))
+			for _, h := range handles {
+				sent, err := sendKeySequence(h.ID, keys, repeat, pause)
+				if err != nil {
+					return err
+				}
+				results = append(results, keyResult{PaneID: h.Target, Keys: keys, Repeat: repeat, DelaySecs: delay, KeysSent: sent})
+			}
+
+			var doc any = results[0]
+			if bulk {
+				doc = results
+			}
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(doc)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(doc)
+			case outputOpts.Is(output.OutputQuiet):
+				return nil
+			}
+			for _, result := range results {
+				times := ""
+				if result.Repeat > 1 {
+					times = fmt.Sprintf(" x%d", result.Repeat)
+				}
+				_, _ = fmt.Fprintf(out, "Sent %s%s (%s)\n", strings.Join(result.Keys, " "), times, result.PaneID)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, - for stdin)")
+	cmd.Flags().IntVar(&repeat, "repeat", 1, fmt.Sprintf("Send the key sequence this many times (max %d)", maxKeyRepeat))
+	cmd.Flags().Float64Var(&delay, "delay", 0, "Seconds to wait between keys")
+	cmd.Flags().BoolVar(&force, "force", false, "Send C-c even if the pane runs a protected command")
+	cmd.ValidArgsFunction = completeKeyNames
+
+	return cmd
+}
+
+// sendKeySequence sends keys repeat times. Without a delay each repetition is
+// one send-keys call; with a delay every key is sent separately.
+func sendKeySequence(paneID string, keys []string, repeat int, pause time.Duration) (int, error) {
+	sent := 0
+	for i := 0; i < repeat; i++ {
+		if pause == 0 {
+			if err := tmux.SendKeys(paneID, keys); err != nil {
+				return sent, err
+			}
+			sent += len(keys)
+			continue
+		}
+		for _, key := range keys {
+			if sent > 0 {
+				time.Sleep(pause)
+			}
+			if err := tmux.SendKeys(paneID, []string{key}); err != nil {
+				return sent, err
+			}
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// normalizeKeyName converts a user key name into tmux's spelling, rejecting
+// names tmux would otherwise type out literally.
+func normalizeKeyName(raw string) (string, error) {
+	name := strings.TrimSpace(raw)
+	if name == "" {
+		return "", fmt.Errorf("empty key name")
+	}
+	var mods []string
+	for len(name) > 2 && name[1] == '-' {
+		switch name[0] {
+		case 'C', 'c':
+			mods = append(mods, "C-")
+		case 'M', 'm':
+			mods = append(mods, "M-")
+		case 'S', 's':
+			mods = append(mods, "S-")
+		default:
+			return "", fmt.Errorf("invalid key %q: unknown modifier %q", raw, name[:2])
+		}
+		name = name[2:]
+	}
+	base, ok := namedKeys[strings.ToLower(name)]
+	switch {
+	case ok:
+	case utf8.RuneCountInString(name) == 1:
+		base = name
+	case isFunctionKey(name):
+		base = "F" + name[1:]
+	default:
+		return "", fmt.Errorf("unknown key %q (see arc-tmux key --help)", raw)
+	}
+	return strings.Join(mods, "") + base, nil
+}
+
+func isFunctionKey(name string) bool {
+	if len(name) < 2 || (name[0] != 'F' && name[0] != 'f') {
+		return false
+	}
+	n, err := strconv.Atoi(name[1:])
+	return err == nil && n >= 1 && n <= 12
+}
+
+func containsKey(keys []string, want string) bool {
+	for _, key := range keys {
+		if key == want {
+			return true
+		}
+	}
+	return false
+}
+
+func completeKeyNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, name := range namedKeys {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for i := 1; i <= 12; i++ {
+		names = append(names, "F"+strconv.Itoa(i))
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import "testing"
+
+func TestNormalizeKeyName(t *testing.T) {
+	cases := map[string]string{
+		"esc":       "Escape",
+		"ENTER":     "Enter",
+		"pgdn":      "PageDown",
+		"f5":        "F5",
+		"c-c":       "C-c",
+		"C-x":       "C-x",
+		"M-Left":    "M-Left",
+		"C-M-a":     "C-M-a",
+		"S-tab":     "S-Tab",
+		"q":         "q",
+		"backspace": "BSpace",
+	}
+	for in, want := range cases {
+		got, err := normalizeKeyName(in)
+		if err != nil {
+			t.Fatalf("normalizeKeyName(%q) error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("normalizeKeyName(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "hello", "F13", "X-a", "C-"} {
+		if _, err := normalizeKeyName(bad); err == nil {
+			t.Fatalf("normalizeKeyName(%q) expected error", bad)
+		}
+	}
+}
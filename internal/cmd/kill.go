@@ -4,13 +4,12 @@
 package cmd
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
-	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
-	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 	"github.com/yourorg/arc-sdk/output"
 	"github.com/yourorg/arc-tmux/pkg/tmux"
@@ -21,35 +20,107 @@ func newKillCmd() *cobra.Command {
 	var paneArg string
 	var yes bool
 	var dryRun bool
+	var filterExpr string
+	var force bool
+	var checkBusy bool
+	var keepAliases bool
+	var graceful bool
+	var idle, timeout float64
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
 		Use:   "kill",
 		Short: "Kill a tmux pane (safe by default)",
-		Long:  "Kill a pane after confirming the target.",
+		Long: `Kill a pane after confirming the target.
+
+Before killing, kill inspects the pane's process tree. If a program other than
+the shell is in the foreground or the shell has child processes, the prompt
+lists what will die. With --check-busy, --yes refuses such panes
+(ERR_PANE_BUSY) instead, unless --graceful is given to interrupt them first;
+--force skips the inspection. --graceful sends Ctrl+C and waits for the pane to
+go idle before the kill. Aliases pointing at a killed pane are removed unless
+--keep-aliases is given; use "alias gc" for panes closed outside arc-tmux.`,
 		Example: `  # Preview which pane would be killed
   arc-tmux kill --pane=fe:2.0 --dry-run
 
   # Kill without prompting (useful in scripts)
-  arc-tmux kill --pane=fe:2.0 --yes`,
+  arc-tmux kill --pane=fe:2.0 --yes
+
+  # Interrupt a dev server, give it time to exit, then kill the pane
+  arc-tmux kill --pane=fe:2.0 --graceful --timeout 15
+
+  # Kill every pane running a command (stdin targets require --yes)
+  arc-tmux locate --field command htop -o quiet | arc-tmux kill --pane - --yes
+
+  # Kill idle shells in agent sessions
+  arc-tmux kill --filter 'session=~"^arc-" && command=="sh" && idle>3600' --dry-run`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-			target, err := resolvePaneTarget(paneArg)
-			if err != nil {
-				return err
+			var err error
+			var handles []tmux.PaneHandle
+			var bulk bool
+			if strings.TrimSpace(filterExpr) != "" {
+				if strings.TrimSpace(paneArg) != "" {
+					return fmt.Errorf("use either --pane or --filter, not both")
+				}
+				handles, err = resolveFilterTargets(filterExpr)
+				if err != nil {
+					return err
+				}
+				if len(handles) == 0 {
+					return writeKillResults(cmd, outputOpts, []killResult{}, "")
+				}
+				bulk = true
+			} else {
+				handles, bulk, err = resolvePaneTargets(cmd, paneArg)
+				if err != nil {
+					return err
+				}
+				if bulk && !yes && !assumeYes && !dryRun {
+					return confirmationRequired("stdin is used for pane targets, so kill cannot prompt; use --dry-run to preview")
+				}
 			}
-			if err := validatePaneTarget(target); err != nil {
-				return err
+
+			results := killResultsFor(handleTargets(handles), dryRun)
+			if !force {
+				for i, h := range handles {
+					results[i].Processes = paneKillVictims(h.ID)
+					results[i].Busy = len(results[i].Processes) > 0
+				}
+			}
+			var busy []killResult
+			for _, r := range results {
+				if r.Busy {
+					busy = append(busy, r)
+				}
 			}
 
 			if dryRun {
-				return writeKillResult(cmd, outputOpts, killResult{PaneID: target, DryRun: true}, "[dry-run] Would kill tmux pane")
+				if !keepAliases {
+					for i, h := range handles {
+						results[i].RemovedAliases = forgetPaneAliases(cmd, h, true)
+					}
+				}
+				if bulk {
+					return writeKillResults(cmd, outputOpts, results, "[dry-run] Would kill tmux pane")
+				}
+				return writeKillResult(cmd, outputOpts, results[0], "[dry-run] Would kill tmux pane")
 			}
 
+			if checkBusy && !graceful && len(busy) > 0 && (yes || assumeYes) {
+				return newCodedError(errPaneBusy, fmt.Sprintf("%s; pass --graceful to interrupt it first or --force to kill anyway", describeBusyPanes(busy)), nil)
+			}
 			if !yes {
-				confirmed, err := confirmPrompt(cmd, fmt.Sprintf("Kill tmux pane %s? [y/N]: ", target))
+				for _, r := range busy {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Pane %s is running %s\n", r.PaneID, describeProcesses(r.Processes))
+				}
+				prompt := fmt.Sprintf("Kill tmux pane %s? [y/N]: ", handles[0].Target)
+				if len(handles) > 1 {
+					prompt = fmt.Sprintf("Kill %d tmux panes (%s)? [y/N]: ", len(handles), strings.Join(handleTargets(handles), ", "))
+				}
+				confirmed, err := confirmPrompt(cmd, prompt)
 				if err != nil {
 					return err
 				}
@@ -59,75 +130,196 @@ func newKillCmd() *cobra.Command {
 				}
 			}
 
-			if err := tmux.Kill(target); err != nil {
-				return err
+			for i, h := range handles {
+				if graceful {
+					if err := tmux.Interrupt(h.ID); err != nil {
+						return err
+					}
+					results[i].Interrupted = true
+					waitErr := tmux.WaitIdle(h.ID, time.Duration(idle*float64(time.Second)), time.Duration(timeout*float64(time.Second)))
+					if waitErr != nil && !isTimeout(waitErr) {
+						return waitErr
+					}
+				}
+				if err := tmux.Kill(h.ID); err != nil {
+					return err
+				}
+				if !keepAliases {
+					results[i].RemovedAliases = forgetPaneAliases(cmd, h, false)
+				}
+			}
+			if bulk {
+				return writeKillResults(cmd, outputOpts, results, "Killed tmux pane")
 			}
-			return writeKillResult(cmd, outputOpts, killResult{PaneID: target, Killed: true}, "Killed tmux pane")
+			return writeKillResult(cmd, outputOpts, results[0], "Killed tmux pane")
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
-	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, - for stdin)")
 	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview without killing")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().StringVar(&filterExpr, "filter", "", "Kill all panes matching a filter expression (see panes --filter)")
+	cmd.Flags().BoolVar(&checkBusy, "check-busy", false, "With --yes, refuse panes running a program or with child processes (unless --graceful)")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip inspecting the pane's processes")
+	cmd.Flags().BoolVar(&keepAliases, "keep-aliases", false, "Keep aliases that point at the killed pane")
+	cmd.Flags().BoolVar(&graceful, "graceful", false, "Send Ctrl+C and wait for idle before killing")
+	cmd.Flags().Float64Var(&idle, "idle", 2.0, "Seconds of inactivity to consider idle (with --graceful)")
+	cmd.Flags().Float64Var(&timeout, "timeout", 10.0, "Maximum seconds to wait for idle before killing (with --graceful)")
 
 	return cmd
 }
 
-func confirmPrompt(cmd *cobra.Command, prompt string) (bool, error) {
-	in := cmd.InOrStdin()
-	if f, ok := in.(*os.File); ok {
-		if !isatty.IsTerminal(f.Fd()) {
-			return false, fmt.Errorf("confirmation required; run in interactive terminal or pass --yes")
-		}
+type killResult struct {
+	PaneID      string             `json:"pane_id" yaml:"pane_id"`
+	DryRun      bool               `json:"dry_run" yaml:"dry_run"`
+	Killed      bool               `json:"killed" yaml:"killed"`
+	Busy        bool               `json:"busy,omitempty" yaml:"busy,omitempty"`
+	Processes   []tmux.ProcessNode `json:"processes,omitempty" yaml:"processes,omitempty"`
+	Interrupted bool               `json:"interrupted,omitempty" yaml:"interrupted,omitempty"`
+	// RemovedAliases names the aliases that pointed at the pane.
+	RemovedAliases []string `json:"removed_aliases,omitempty" yaml:"removed_aliases,omitempty"`
+}
+
+func writeKillResult(cmd *cobra.Command, outputOpts output.OutputOptions, result killResult, message string) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		return nil
 	}
+	_, _ = fmt.Fprintf(out, "%s %s%s\n", message, result.PaneID, killResultSuffix(result))
+	return nil
+}
 
-	reader := bufio.NewReader(in)
-	for {
-		if _, err := fmt.Fprint(cmd.OutOrStdout(), prompt); err != nil {
-			return false, err
-		}
-		response, err := reader.ReadString('\n')
-		if err != nil {
-			return false, err
-		}
-		resp := strings.TrimSpace(strings.ToLower(response))
-		switch resp {
-		case "y", "yes":
-			return true, nil
-		case "", "n", "no":
-			return false, nil
-		default:
-			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Please answer 'y' or 'n'.")
-		}
+func killResultSuffix(result killResult) string {
+	var suffix string
+	if result.Busy {
+		suffix += " (running " + describeProcesses(result.Processes) + ")"
 	}
+	if len(result.RemovedAliases) > 0 {
+		suffix += "; removed aliases " + strings.Join(result.RemovedAliases, ", ")
+	}
+	return suffix
 }
 
-type killResult struct {
-	PaneID string `json:"pane_id" yaml:"pane_id"`
-	DryRun bool   `json:"dry_run" yaml:"dry_run"`
-	Killed bool   `json:"killed" yaml:"killed"`
+func killResultsFor(targets []string, dryRun bool) []killResult {
+	results := make([]killResult, 0, len(targets))
+	for _, target := range targets {
+		results = append(results, killResult{PaneID: target, DryRun: dryRun, Killed: !dryRun})
+	}
+	return results
 }
 
-func writeKillResult(cmd *cobra.Command, outputOpts output.OutputOptions, result killResult, message string) error {
+func writeKillResults(cmd *cobra.Command, outputOpts output.OutputOptions, results []killResult, message string) error {
 	out := cmd.OutOrStdout()
 	switch {
 	case outputOpts.Is(output.OutputJSON):
 		enc := json.NewEncoder(out)
 		enc.SetIndent("", "  ")
-		return enc.Encode(result)
+		return enc.Encode(results)
 	case outputOpts.Is(output.OutputYAML):
 		enc := yaml.NewEncoder(out)
 		defer func() { _ = enc.Close() }()
-		return enc.Encode(result)
+		return enc.Encode(results)
 	case outputOpts.Is(output.OutputQuiet):
 		return nil
 	}
-	if result.DryRun {
-		_, _ = fmt.Fprintf(out, "%s %s\n", message, result.PaneID)
+	if len(results) == 0 {
+		_, _ = fmt.Fprintln(out, "No panes matched filter.")
 		return nil
 	}
-	_, _ = fmt.Fprintf(out, "%s %s\n", message, result.PaneID)
+	for _, result := range results {
+		_, _ = fmt.Fprintf(out, "%s %s%s\n", message, result.PaneID, killResultSuffix(result))
+	}
 	return nil
 }
+
+// paneKillVictims returns the processes that would die with the pane: the
+// whole process tree, minus the root when it is an idle shell. Dead panes
+// have none.
+func paneKillVictims(paneID string) []tmux.ProcessNode {
+	pane, err := tmux.PaneDetailsForTarget(paneID)
+	if err != nil || pane.Dead || pane.PID <= 0 {
+		return nil
+	}
+	tree, err := tmux.ProcessTree(pane.PID)
+	if err != nil {
+		if isShellCommand(pane.Command) {
+			return nil
+		}
+		return []tmux.ProcessNode{{PID: pane.PID, Command: pane.Command}}
+	}
+	return killVictims(tree)
+}
+
+func killVictims(tree []tmux.ProcessNode) []tmux.ProcessNode {
+	victims := make([]tmux.ProcessNode, 0, len(tree))
+	for _, node := range tree {
+		if node.Depth == 0 && isShellCommand(node.Command) {
+			continue
+		}
+		victims = append(victims, node)
+	}
+	if len(victims) == 0 {
+		return nil
+	}
+	return victims
+}
+
+// isShellCommand reports whether a ps command line is an interactive shell
+// (login shells are reported with a leading dash).
+func isShellCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	name := filepath.Base(strings.TrimPrefix(fields[0], "-"))
+	switch name {
+	case "sh", "bash", "zsh", "fish", "dash", "ksh", "mksh", "tcsh", "csh", "nu", "elvish", "xonsh", "pwsh":
+		return true
+	}
+	return false
+}
+
+func describeProcesses(nodes []tmux.ProcessNode) string {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		parts = append(parts, fmt.Sprintf("%s (pid %d)", commandProgram(node.Command), node.PID))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeBusyPanes(busy []killResult) string {
+	parts := make([]string, 0, len(busy))
+	for _, r := range busy {
+		parts = append(parts, fmt.Sprintf("pane %s is running %s", r.PaneID, describeProcesses(r.Processes)))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// forgetPaneAliases removes the aliases pointing at pane h (by target or pane
+// id) and returns their names. The kill has already happened, so failures are
+// reported as warnings rather than errors.
+func forgetPaneAliases(cmd *cobra.Command, h tmux.PaneHandle, dryRun bool) []string {
+	session, _, _ := strings.Cut(h.Target, ":")
+	match := func(target string) (bool, error) {
+		return target == h.Target || target == h.ID, nil
+	}
+	removed, err := pruneAliases(aliasStores("", session), match, dryRun)
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: alias cleanup for %s: %v\n", h.Target, err)
+	}
+	names := make([]string, 0, len(removed))
+	for _, entry := range removed {
+		names = append(names, "@"+entry.Name)
+	}
+	return names
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestKillVictims(t *testing.T) {
+	idle := []tmux.ProcessNode{{PID: 10, Command: "-zsh", Depth: 0}}
+	if got := killVictims(idle); got != nil {
+		t.Fatalf("expected idle shell to have no victims, got %+v", got)
+	}
+
+	busy := []tmux.ProcessNode{
+		{PID: 10, Command: "/bin/bash --login", Depth: 0},
+		{PID: 11, PPID: 10, Command: "npm run dev", Depth: 1},
+		{PID: 12, PPID: 11, Command: "node server.js", Depth: 2},
+	}
+	got := killVictims(busy)
+	if len(got) != 2 || got[0].PID != 11 || got[1].PID != 12 {
+		t.Fatalf("expected npm and node as victims, got %+v", got)
+	}
+	if desc := describeProcesses(got); desc != "npm (pid 11), node (pid 12)" {
+		t.Fatalf("unexpected description %q", desc)
+	}
+
+	direct := []tmux.ProcessNode{{PID: 20, Command: "htop", Depth: 0}}
+	if got := killVictims(direct); len(got) != 1 || got[0].PID != 20 {
+		t.Fatalf("expected non-shell root to be a victim, got %+v", got)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// linkedSelectorPrefix starts a selector naming a linked pane.
+const linkedSelectorPrefix = "@linked:"
+
+// defaultLinkCooldown applies to links that do not set cooldown; it is short
+// so saving twice in a row still reruns the linked command.
+const defaultLinkCooldown = 5
+
+// paneLink is a links config entry: Target is linked to Pane under Name, so
+// @linked:NAME resolves to Target. watch sends Command to Target when Pane
+// prints a line matching Trigger or when files under Paths change.
+type paneLink struct {
+	Name     string   `json:"name" yaml:"name"`
+	Pane     string   `json:"pane" yaml:"pane"`
+	Target   string   `json:"target" yaml:"target"`
+	Command  string   `json:"command,omitempty" yaml:"command,omitempty"`
+	Trigger  string   `json:"trigger,omitempty" yaml:"trigger,omitempty"`
+	Paths    []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Cooldown float64  `json:"cooldown,omitempty" yaml:"cooldown,omitempty"`
+}
+
+// resolveLinkedPane resolves @linked:NAME to the target of the link called
+// NAME. When several links share the name, the one whose pane is the current
+// pane (or, outside tmux, the default pane) wins.
+func resolveLinkedPane(selector string) (string, error) {
+	name := strings.TrimSpace(strings.TrimPrefix(selector, linkedSelectorPrefix))
+	if name == "" {
+		return "", newCodedError(errUnknownSelector, fmt.Sprintf("invalid linked selector: %s", selector), nil)
+	}
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		return "", err
+	}
+	var links []paneLink
+	for _, link := range cfg.Links {
+		if link.Name == name {
+			links = append(links, link)
+		}
+	}
+	if len(links) == 0 {
+		return "", newCodedError(errUnknownSelector, fmt.Sprintf("no link named %q in the config", name), nil)
+	}
+	link := links[0]
+	if len(links) > 1 {
+		if link, err = linkFromCurrentPane(name, links); err != nil {
+			return "", err
+		}
+	}
+	if strings.HasPrefix(strings.TrimSpace(link.Target), linkedSelectorPrefix) {
+		return "", newCodedError(errUnknownSelector, fmt.Sprintf("link %q: target cannot be another linked selector", name), nil)
+	}
+	return resolvePaneTarget(link.Target)
+}
+
+func linkFromCurrentPane(name string, links []paneLink) (paneLink, error) {
+	current, err := tmux.CurrentPaneID()
+	if err != nil || strings.TrimSpace(current) == "" {
+		if current, err = defaultPaneTarget(); err != nil {
+			return paneLink{}, newCodedError(errAmbiguousPane, fmt.Sprintf("%d links are named %q; run from one of their panes", len(links), name), nil)
+		}
+	}
+	here, err := canonicalPaneTarget(current)
+	if err != nil {
+		return paneLink{}, err
+	}
+	for _, link := range links {
+		target, err := resolvePaneTarget(link.Pane)
+		if err != nil {
+			continue
+		}
+		if handle, err := tmux.ResolveTarget(target); err == nil && handle.ID == here.ID {
+			return link, nil
+		}
+	}
+	return paneLink{}, newCodedError(errAmbiguousPane, fmt.Sprintf("%d links are named %q and none is from %s", len(links), name, here.Target), nil)
+}
+
+// linkWatchRules turns the links that have a trigger or paths into watch
+// rules.
+func linkWatchRules(links []paneLink) []watchRule {
+	var rules []watchRule
+	for _, link := range links {
+		if strings.TrimSpace(link.Trigger) == "" && len(link.Paths) == 0 {
+			continue
+		}
+		cooldown := link.Cooldown
+		if cooldown <= 0 {
+			cooldown = defaultLinkCooldown
+		}
+		rules = append(rules, watchRule{
+			Name:     link.Name,
+			Pane:     link.Pane,
+			Match:    link.Trigger,
+			Paths:    link.Paths,
+			Target:   link.Target,
+			Send:     link.Command,
+			Cooldown: cooldown,
+		})
+	}
+	return rules
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResolveLinkedPane(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	data := `links:
+  - name: tests
+    pane: dev:0.0
+    target: dev:1.0
+    command: go test ./...
+    paths: [.]
+  - name: loop
+    pane: dev:0.0
+    target: "@linked:tests"
+`
+	if err := os.WriteFile(config, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ARC_TMUX_CONFIG", config)
+
+	got, err := resolvePaneTarget("@linked:tests")
+	if err != nil || got != "dev:1.0" {
+		t.Fatalf("resolvePaneTarget(@linked:tests) = %q, %v", got, err)
+	}
+	if _, err := resolvePaneTarget("@linked:missing"); err == nil {
+		t.Fatal("expected error for unknown link")
+	}
+	if _, err := resolvePaneTarget("@linked:loop"); err == nil {
+		t.Fatal("expected error for a link targeting another link")
+	}
+}
+
+func TestLinkWatchRules(t *testing.T) {
+	rules := linkWatchRules([]paneLink{
+		{Name: "tests", Pane: "@editor", Target: "@tests", Command: "go test ./...", Paths: []string{"."}},
+		{Name: "plain", Pane: "@editor", Target: "@repl"},
+		{Name: "build", Pane: "@editor", Target: "@build", Command: "make", Trigger: "written$", Cooldown: 20},
+	})
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %+v", rules)
+	}
+	if rules[0].Send != "go test ./..." || rules[0].Target != "@tests" || rules[0].Cooldown != defaultLinkCooldown {
+		t.Fatalf("unexpected tests rule: %+v", rules[0])
+	}
+	if rules[1].Match != "written$" || rules[1].Cooldown != 20 {
+		t.Fatalf("unexpected build rule: %+v", rules[1])
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.go")
+	b := filepath.Join(dir, "b.go")
+	for _, p := range []string{a, b, filepath.Join(dir, ".git", "HEAD")} {
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before, err := snapshotFiles([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 2 {
+		t.Fatalf("expected hidden directories to be skipped, got %v", before)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(a, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(b); err != nil {
+		t.Fatal(err)
+	}
+	c := filepath.Join(dir, "c.go")
+	if err := os.WriteFile(c, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	after, err := snapshotFiles([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := changedFiles(before, after); !equalSlice(got, []string{a, b, c}) {
+		t.Fatalf("changedFiles = %q", got)
+	}
+	if got := changedFiles(after, after); len(got) != 0 {
+		t.Fatalf("expected no changes, got %q", got)
+	}
+}
@@ -24,6 +24,7 @@ type paneInfo struct {
 func newListCmd() *cobra.Command {
 	var flat bool
 	var outputOpts output.OutputOptions
+	var porcelain bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -36,11 +37,16 @@ func newListCmd() *cobra.Command {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
+			if err := checkPorcelain(cmd, porcelain); err != nil {
+				return err
+			}
 
 			rawPanes, err := tmux.ListPanes()
 			if err != nil {
 				if err == tmux.ErrNoTmuxServer {
-					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
+					if !porcelain {
+						_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
+					}
 					return nil
 				}
 				return err
@@ -60,6 +66,8 @@ func newListCmd() *cobra.Command {
 			out := cmd.OutOrStdout()
 
 			switch {
+			case porcelain:
+				return writePorcelain(out, paneInfoPorcelainRows(panes))
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
@@ -140,6 +148,7 @@ func newListCmd() *cobra.Command {
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	addPorcelainFlag(cmd, "list", &porcelain)
 	cmd.Flags().BoolVar(&flat, "flat", false, "Print a flat list instead of grouping by window")
 
 	return cmd
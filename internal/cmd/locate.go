@@ -23,7 +23,8 @@ func newLocateCmd() *cobra.Command {
 	var useRegex bool
 	var fuzzy bool
 	var session string
-	var window int
+	var window string
+	var porcelain bool
 
 	cmd := &cobra.Command{
 		Use:   "locate [query]",
@@ -38,6 +39,9 @@ func newLocateCmd() *cobra.Command {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
+			if err := checkPorcelain(cmd, porcelain); err != nil {
+				return err
+			}
 
 			q := strings.TrimSpace(query)
 			if q == "" && len(args) > 0 {
@@ -76,7 +80,9 @@ func newLocateCmd() *cobra.Command {
 			panes, err := tmux.ListPanesDetailed()
 			if err != nil {
 				if err == tmux.ErrNoTmuxServer {
-					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
+					if !porcelain {
+						_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
+					}
 					return nil
 				}
 				return err
@@ -87,7 +93,7 @@ func newLocateCmd() *cobra.Command {
 				if session != "" && p.Session != session {
 					continue
 				}
-				if window >= 0 && p.WindowIndex != window {
+				if !matchesWindow(p, window) {
 					continue
 				}
 				if !locateMatches(p, field, q, re, fuzzy) {
@@ -108,6 +114,8 @@ func newLocateCmd() *cobra.Command {
 
 			out := cmd.OutOrStdout()
 			switch {
+			case porcelain:
+				return writePorcelain(out, paneSnapshotPorcelainRows(items))
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
@@ -139,12 +147,13 @@ func newLocateCmd() *cobra.Command {
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	addPorcelainFlag(cmd, "locate", &porcelain)
 	cmd.Flags().StringVar(&query, "query", "", "Query string to match")
 	cmd.Flags().StringVar(&field, "field", "any", "Field to search: any|command|title|path")
 	cmd.Flags().BoolVar(&useRegex, "regex", false, "Interpret query as regex")
 	cmd.Flags().BoolVar(&fuzzy, "fuzzy", false, "Use fuzzy matching instead of substring matching")
 	cmd.Flags().StringVar(&session, "session", "", "Filter by session name or selector (@current|@managed)")
-	cmd.Flags().IntVar(&window, "window", -1, "Filter by window index")
+	cmd.Flags().StringVar(&window, "window", "", "Filter by window index or name")
 	return cmd
 }
 
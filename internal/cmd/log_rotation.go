@@ -0,0 +1,282 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rotatedStampFormat is the timestamp put in rotated file names.
+const rotatedStampFormat = "20060102T150405.000Z"
+
+// logRotation is the log_rotation config section. It applies to every file
+// arc-tmux appends to: transcripts, the activity file, and ship --file logs.
+type logRotation struct {
+	// MaxSize rotates a file before a write would take it past this size
+	// ("10MB", "512KB", or bytes).
+	MaxSize string `yaml:"max_size,omitempty"`
+	// Every rotates a file at its first write after each interval boundary
+	// in UTC ("24h", "1d", "1h").
+	Every string `yaml:"every,omitempty"`
+	// Keep is how many rotated files to keep per log; 0 keeps them all.
+	Keep int `yaml:"keep,omitempty"`
+	// MaxAge removes rotated files older than this ("72h", "14d").
+	MaxAge string `yaml:"max_age,omitempty"`
+	// Compress gzips rotated files.
+	Compress bool `yaml:"compress,omitempty"`
+}
+
+// rotationPolicy is a parsed logRotation; the zero value never rotates.
+type rotationPolicy struct {
+	MaxSize  int64
+	Every    time.Duration
+	Keep     int
+	MaxAge   time.Duration
+	Compress bool
+}
+
+// loadRotationPolicy reads log_rotation from the config file.
+func loadRotationPolicy() (rotationPolicy, error) {
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		return rotationPolicy{}, err
+	}
+	if cfg.LogRotation == nil {
+		return rotationPolicy{}, nil
+	}
+	return parseLogRotation(*cfg.LogRotation)
+}
+
+func parseLogRotation(cfg logRotation) (rotationPolicy, error) {
+	policy := rotationPolicy{Keep: cfg.Keep, Compress: cfg.Compress}
+	if cfg.Keep < 0 {
+		return policy, fmt.Errorf("log_rotation.keep must be >= 0")
+	}
+	if strings.TrimSpace(cfg.MaxSize) != "" {
+		size, err := parseByteSize(cfg.MaxSize)
+		if err != nil {
+			return policy, fmt.Errorf("log_rotation.max_size: %w", err)
+		}
+		policy.MaxSize = size
+	}
+	for _, d := range []struct {
+		name string
+		raw  string
+		dst  *time.Duration
+	}{
+		{"every", cfg.Every, &policy.Every},
+		{"max_age", cfg.MaxAge, &policy.MaxAge},
+	} {
+		if strings.TrimSpace(d.raw) == "" {
+			continue
+		}
+		parsed, err := parseSince(d.raw)
+		if err != nil {
+			return policy, fmt.Errorf("log_rotation.%s: invalid duration %q (e.g. 12h, 7d)", d.name, d.raw)
+		}
+		*d.dst = parsed
+	}
+	return policy, nil
+}
+
+// parseByteSize parses a size such as "512", "64KB", "10MB", or "1GiB";
+// K, M, and G count in 1024s.
+func parseByteSize(raw string) (int64, error) {
+	trimmed := strings.ToUpper(strings.TrimSpace(raw))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		scale  int64
+	}{
+		{"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10},
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+	} {
+		if rest, ok := strings.CutSuffix(trimmed, unit.suffix); ok {
+			trimmed, multiplier = strings.TrimSpace(rest), unit.scale
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q (e.g. 10MB, 512KB)", raw)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// due reports whether a file of size, last written at lastWrite, must be
+// rotated before n more bytes are written at now.
+func (p rotationPolicy) due(size int64, lastWrite time.Time, n int, now time.Time) bool {
+	if size == 0 {
+		return false
+	}
+	if p.MaxSize > 0 && size+int64(n) > p.MaxSize {
+		return true
+	}
+	return p.Every > 0 && lastWrite.UTC().Truncate(p.Every).Before(now.UTC().Truncate(p.Every))
+}
+
+// rotatingFile appends to a log file, rotating it by policy before writes.
+type rotatingFile struct {
+	path      string
+	policy    rotationPolicy
+	f         *os.File
+	size      int64
+	lastWrite time.Time
+	now       func() time.Time
+}
+
+func openRotatingFile(path string, policy rotationPolicy) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	r := &rotatingFile{path: path, policy: policy, now: time.Now}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	// Logs hold commands, pane output, and typed input: owner-only.
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.f, r.size, r.lastWrite = f, info.Size(), info.ModTime()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	now := r.now()
+	if r.policy.due(r.size, r.lastWrite, len(p), now) {
+		if err := r.f.Close(); err != nil {
+			return 0, err
+		}
+		if err := rotateLogFile(r.path, r.policy, now); err != nil {
+			return 0, err
+		}
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	r.lastWrite = now
+	return n, err
+}
+
+func (r *rotatingFile) Close() error {
+	return r.f.Close()
+}
+
+// rotateLogFile moves path aside as STEM-STAMP.EXT (gzipped when the policy
+// compresses), then prunes rotated files beyond Keep or older than MaxAge.
+func rotateLogFile(path string, policy rotationPolicy, now time.Time) error {
+	ext := filepath.Ext(path)
+	var rotated string
+	// Stamps have millisecond resolution; step past any name already taken.
+	for stamp := now.UTC(); ; stamp = stamp.Add(time.Millisecond) {
+		rotated = strings.TrimSuffix(path, ext) + "-" + stamp.Format(rotatedStampFormat) + ext
+		if !fileExists(rotated) && !fileExists(rotated+".gz") {
+			break
+		}
+	}
+	if err := os.Rename(path, rotated); err != nil {
+		return err
+	}
+	if policy.Compress {
+		if err := gzipFile(rotated); err != nil {
+			return err
+		}
+	}
+	return pruneRotatedLogs(path, policy, now)
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// rotatedLog is a rotated copy of a log file.
+type rotatedLog struct {
+	Path    string
+	Rotated time.Time
+}
+
+// rotatedLogs lists the rotated copies of path, oldest first.
+func rotatedLogs(path string) ([]rotatedLog, error) {
+	dir, base := filepath.Split(path)
+	ext := filepath.Ext(base)
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(strings.TrimSuffix(base, ext)) + `-(\d{8}T\d{6}\.\d{3}Z)` + regexp.QuoteMeta(ext) + `(\.gz)?$`)
+	entries, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return nil, err
+	}
+	var logs []rotatedLog
+	for _, e := range entries {
+		m := pattern.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		stamp, err := time.Parse(rotatedStampFormat, m[1])
+		if err != nil {
+			continue
+		}
+		logs = append(logs, rotatedLog{Path: filepath.Join(dir, e.Name()), Rotated: stamp})
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].Rotated.Before(logs[j].Rotated) })
+	return logs, nil
+}
+
+func pruneRotatedLogs(path string, policy rotationPolicy, now time.Time) error {
+	logs, err := rotatedLogs(path)
+	if err != nil {
+		return err
+	}
+	for i, l := range logs {
+		expired := policy.MaxAge > 0 && now.Sub(l.Rotated) > policy.MaxAge
+		surplus := policy.Keep > 0 && i < len(logs)-policy.Keep
+		if expired || surplus {
+			if err := os.Remove(l.Path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]int64{
+		"512":   512,
+		"64KB":  64 << 10,
+		"10mb":  10 << 20,
+		"1GiB":  1 << 30,
+		"1.5 M": 3 << 19,
+	}
+	for raw, want := range cases {
+		got, err := parseByteSize(raw)
+		if err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d", raw, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "MB", "-1", "ten"} {
+		if _, err := parseByteSize(bad); err == nil {
+			t.Errorf("parseByteSize(%q) accepted an invalid size", bad)
+		}
+	}
+}
+
+func TestParseLogRotation(t *testing.T) {
+	policy, err := parseLogRotation(logRotation{MaxSize: "1MB", Every: "1d", Keep: 3, MaxAge: "72h", Compress: true})
+	if err != nil {
+		t.Fatalf("parseLogRotation: %v", err)
+	}
+	want := rotationPolicy{MaxSize: 1 << 20, Every: 24 * time.Hour, Keep: 3, MaxAge: 72 * time.Hour, Compress: true}
+	if policy != want {
+		t.Fatalf("policy = %+v, want %+v", policy, want)
+	}
+	if _, err := parseLogRotation(logRotation{Every: "soon"}); err == nil || !strings.Contains(err.Error(), "log_rotation.every") {
+		t.Fatalf("expected log_rotation.every error, got %v", err)
+	}
+}
+
+func TestRotationPolicyDue(t *testing.T) {
+	now := time.Date(2026, 3, 2, 0, 5, 0, 0, time.UTC)
+	daily := rotationPolicy{Every: 24 * time.Hour}
+	if !daily.due(10, now.Add(-10*time.Minute), 1, now) {
+		t.Fatalf("daily rotation not due across midnight")
+	}
+	if daily.due(10, now.Add(-time.Minute), 1, now) {
+		t.Fatalf("daily rotation due within the same day")
+	}
+	sized := rotationPolicy{MaxSize: 100}
+	if sized.due(0, now, 500, now) {
+		t.Fatalf("an empty file must not rotate")
+	}
+	if !sized.due(90, now, 20, now) || sized.due(90, now, 10, now) {
+		t.Fatalf("size rotation threshold wrong")
+	}
+}
+
+func TestRotatingFileKeepsAndCompresses(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "agent.log")
+	f, err := openRotatingFile(path, rotationPolicy{MaxSize: 10, Keep: 2, Compress: true})
+	if err != nil {
+		t.Fatalf("openRotatingFile: %v", err)
+	}
+	clock := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	f.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := f.Write([]byte("0123456789\n")); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	rotated, err := rotatedLogs(path)
+	if err != nil {
+		t.Fatalf("rotatedLogs: %v", err)
+	}
+	if len(rotated) != 2 {
+		t.Fatalf("kept %d rotated files, want 2: %+v", len(rotated), rotated)
+	}
+	for _, r := range rotated {
+		if !strings.HasSuffix(r.Path, ".log.gz") {
+			t.Fatalf("rotated file %s not compressed", r.Path)
+		}
+	}
+	if !rotated[0].Rotated.Before(rotated[1].Rotated) {
+		t.Fatalf("rotated files not oldest first: %+v", rotated)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "0123456789\n" {
+		t.Fatalf("current file = %q, %v", data, err)
+	}
+}
+
+func TestPruneRotatedLogsByAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "activity.jsonl")
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	old := filepath.Join(dir, "activity-"+now.Add(-10*24*time.Hour).Format(rotatedStampFormat)+".jsonl")
+	recent := filepath.Join(dir, "activity-"+now.Add(-time.Hour).Format(rotatedStampFormat)+".jsonl.gz")
+	other := filepath.Join(dir, "activity-notes.jsonl")
+	for _, p := range []string{old, recent, other} {
+		if err := os.WriteFile(p, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pruneRotatedLogs(path, rotationPolicy{MaxAge: 7 * 24 * time.Hour}, now); err != nil {
+		t.Fatalf("pruneRotatedLogs: %v", err)
+	}
+	if fileExists(old) {
+		t.Fatalf("expired file %s kept", old)
+	}
+	if !fileExists(recent) || !fileExists(other) {
+		t.Fatalf("recent or unrelated file removed")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// messageStyles maps --style names to tmux styles; any other value is used
+// as a tmux style as-is.
+var messageStyles = map[string]string{
+	"info":    "",
+	"success": "fg=black,bg=green",
+	"warning": "fg=black,bg=yellow",
+	"error":   "fg=white,bg=red,bold",
+}
+
+// defaultPopupSeconds is how long a popup stays open without --duration.
+const defaultPopupSeconds = 5
+
+type messageResult struct {
+	Message string   `json:"message" yaml:"message"`
+	Session string   `json:"session,omitempty" yaml:"session,omitempty"`
+	Popup   bool     `json:"popup" yaml:"popup"`
+	Clients []string `json:"clients" yaml:"clients"`
+}
+
+func newMessageCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var client string
+	var duration float64
+	var style string
+	var popup bool
+
+	cmd := &cobra.Command{
+		Use:   "message <text>",
+		Short: "Show a notification to attached clients",
+		Long: `Show a transient notification to whoever is attached, so automation can
+tell the human watching what happened. By default the text appears in the
+status line of every attached client (display-message); --session limits it
+to clients viewing that session and --client to one client. --popup opens a
+centered popup instead (tmux 3.2+); the command returns once the popups
+close.
+
+--duration sets how long the message stays up in seconds (default: the
+display-time option, or 5 seconds for a popup). --style is info, success,
+warning, error, or any tmux style such as "fg=white,bg=blue".
+
+With no client attached, nothing is shown and the result lists no clients.`,
+		Example: `  arc-tmux message --session dev "deploy finished"
+  arc-tmux message --style error --duration 10 "migration failed: see dev:2.0"
+  arc-tmux message --popup "tests passed"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			text := strings.TrimSpace(strings.Join(args, " "))
+			if text == "" {
+				return errors.New("message text is required")
+			}
+			if duration < 0 {
+				return errors.New("--duration must be >= 0")
+			}
+			resolved, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			styleValue := strings.TrimSpace(style)
+			if named, ok := messageStyles[strings.ToLower(styleValue)]; ok {
+				styleValue = named
+			}
+			if popup {
+				server, err := tmux.ServerVersion()
+				if err != nil {
+					return err
+				}
+				if !tmux.FeaturesFor(server).Popups {
+					return fmt.Errorf("--popup requires tmux 3.2 or newer (server is %s)", server)
+				}
+				if !server.AtLeast(3, 3) {
+					styleValue = ""
+				}
+			}
+
+			clients, err := messageClients(resolved, strings.TrimSpace(client))
+			if err != nil {
+				return err
+			}
+			result := messageResult{Message: text, Session: resolved, Popup: popup, Clients: []string{}}
+			for _, c := range clients {
+				if popup {
+					seconds := duration
+					if seconds <= 0 {
+						seconds = defaultPopupSeconds
+					}
+					err = tmux.DisplayPopup(c.Name, popupCommand(text, seconds), popupSize(text, styleValue))
+				} else {
+					err = tmux.DisplayMessage(c.Name, text, tmux.MessageOptions{
+						Duration: time.Duration(duration * float64(time.Second)),
+						Style:    styleValue,
+					})
+				}
+				if err != nil {
+					return fmt.Errorf("client %s: %w", c.Name, err)
+				}
+				result.Clients = append(result.Clients, c.Name)
+			}
+			return writeMessageResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Only notify clients viewing this session (name, @current, or @managed)")
+	cmd.Flags().StringVar(&client, "client", "", "Only notify this client (name as shown by tmux list-clients)")
+	cmd.Flags().Float64Var(&duration, "duration", 0, "Seconds to show the message (0 for the display-time option; popups default to 5)")
+	cmd.Flags().StringVar(&style, "style", "", "info, success, warning, error, or a tmux style")
+	cmd.Flags().BoolVar(&popup, "popup", false, "Show the message in a popup instead of the status line")
+	return cmd
+}
+
+// messageClients returns the attached clients to notify, filtered by session
+// and client name when given.
+func messageClients(session string, name string) ([]tmux.Client, error) {
+	clients, err := tmux.ListClients()
+	if err != nil {
+		if errors.Is(err, tmux.ErrNoTmuxServer) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var selected []tmux.Client
+	for _, c := range clients {
+		if session != "" && c.Session != session {
+			continue
+		}
+		if name != "" && c.Name != name {
+			continue
+		}
+		selected = append(selected, c)
+	}
+	if name != "" && len(selected) == 0 {
+		return nil, fmt.Errorf("no attached client %q", name)
+	}
+	return selected, nil
+}
+
+// popupCommand prints text and keeps the popup open for seconds.
+func popupCommand(text string, seconds float64) string {
+	return fmt.Sprintf("printf '%%s\\n' %s; sleep %g", shellQuoteSingle(text), seconds)
+}
+
+// popupSize fits the popup to the text, leaving room for the border.
+func popupSize(text string, style string) tmux.PopupOptions {
+	width := 0
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		if n := len([]rune(line)); n > width {
+			width = n
+		}
+	}
+	return tmux.PopupOptions{Width: width + 4, Height: len(lines) + 2, Style: style}
+}
+
+func writeMessageResult(cmd *cobra.Command, outputOpts output.OutputOptions, result messageResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, c := range result.Clients {
+			_, _ = fmt.Fprintln(out, c)
+		}
+		return nil
+	}
+	if len(result.Clients) == 0 {
+		_, _ = fmt.Fprintln(out, "No clients attached; nothing shown.")
+		return nil
+	}
+	_, _ = fmt.Fprintf(out, "Notified %d client(s): %s\n", len(result.Clients), strings.Join(result.Clients, ", "))
+	return nil
+}
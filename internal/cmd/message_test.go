@@ -0,0 +1,18 @@
+package cmd
+
+import "testing"
+
+func TestPopupCommand(t *testing.T) {
+	got := popupCommand("it's done", 2.5)
+	want := `printf '%s\n' 'it'"'"'s done'; sleep 2.5`
+	if got != want {
+		t.Fatalf("popupCommand = %q, want %q", got, want)
+	}
+}
+
+func TestPopupSize(t *testing.T) {
+	opts := popupSize("short\na longer line", "fg=red")
+	if opts.Width != 17 || opts.Height != 4 || opts.Style != "fg=red" {
+		t.Fatalf("unexpected popup size: %+v", opts)
+	}
+}
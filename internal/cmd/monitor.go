@@ -4,10 +4,9 @@
 package cmd
 
 import (
-	"crypto/sha1"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -31,6 +30,30 @@ type monitorSnapshot struct {
 	Idle         bool      `json:"idle" yaml:"idle"`
 	OutputHash   string    `json:"output_hash" yaml:"output_hash"`
 	LinesChecked int       `json:"lines_checked" yaml:"lines_checked"`
+	// CursorX/CursorY, ScrollPosition, HistorySize, and AlternateScreen
+	// describe the visible region; AlternateScreen means a full-screen
+	// program (vim, less, htop) rather than a shell prompt owns the pane.
+	CursorX         int  `json:"cursor_x" yaml:"cursor_x"`
+	CursorY         int  `json:"cursor_y" yaml:"cursor_y"`
+	ScrollPosition  int  `json:"scroll_position" yaml:"scroll_position"`
+	HistorySize     int  `json:"history_size" yaml:"history_size"`
+	AlternateScreen bool `json:"alternate_screen" yaml:"alternate_screen"`
+	// LastCommand is the last command the pane's shell finished, with its
+	// exit status, when the shell loaded the arc-tmux shell hook.
+	LastCommand *tmux.HookCommand `json:"last_command,omitempty" yaml:"last_command,omitempty"`
+	// ProgressPercent is the completion shown by a progress indicator near
+	// the bottom of the output, when one is recognised.
+	ProgressPercent *float64 `json:"progress_percent,omitempty" yaml:"progress_percent,omitempty"`
+	// OutputRate is set with --rate: bytes and lines per second over the
+	// sampled intervals.
+	OutputRate *outputRate `json:"output_rate,omitempty" yaml:"output_rate,omitempty"`
+	// State is "busy", "idle", or "prompt" when the pane waits on input.
+	State  string         `json:"state" yaml:"state"`
+	Prompt *pendingPrompt `json:"prompt,omitempty" yaml:"prompt,omitempty"`
+	// Changed is set with --until-changed: true once the output hash moved
+	// off PreviousHash, false when the timeout hit first.
+	Changed      *bool  `json:"changed,omitempty" yaml:"changed,omitempty"`
+	PreviousHash string `json:"previous_hash,omitempty" yaml:"previous_hash,omitempty"`
 }
 
 func newMonitorCmd() *cobra.Command {
@@ -38,13 +61,50 @@ func newMonitorCmd() *cobra.Command {
 	var paneArg string
 	var idle float64
 	var lines int
+	var answerPrompts bool
+	var untilChanged bool
+	var prevHash string
+	var timeout float64
+	var rateIntervals int
+	var rateInterval float64
+	var floodLines float64
 
 	cmd := &cobra.Command{
 		Use:   "monitor",
 		Short: "Snapshot pane activity and output hash",
-		Long:  "Return a single snapshot of pane activity, idle state, and output hash.",
+		Long: `Return a single snapshot of pane activity, idle state, and output hash.
+
+When the last lines of output show a progress indicator (45%, 12/40, pip or
+cargo bars, or a progress_patterns entry in the config), progress_percent
+reports it.
+
+A pane whose last line is an interactive prompt ([y/N], Password:, "Are you
+sure?") is reported with state "prompt". With --answer-prompts, prompts
+matching a prompt_policies entry with an answer are answered; password prompts
+are only ever reported.
+
+--until-changed blocks until the output hash differs from --hash (a value from
+an earlier snapshot), or, without --hash, until the output changes at all, and
+then reports the new snapshot. If --timeout passes first, the snapshot has
+changed=false and the command fails with a timeout (structured output still
+prints the snapshot and exits 0, as wait does).
+
+--rate N samples the output N times, --rate-interval apart, before taking the
+snapshot and reports output_rate: bytes and lines per second over those
+intervals and an activity of "idle" (nothing printed), "progressing", or
+"flooding" (at least --flood-lines lines per second). This tells a slow but
+working command from a stuck one, and either from one printing faster than a
+follow can usefully keep up with. Each sample captures the last 1000 lines;
+saturated is set when an interval printed more, making the rates lower bounds.`,
 		Example: `  arc-tmux monitor --pane=fe:2.0
-  arc-tmux monitor --pane=@current --idle 5 --lines 200 --output json`,
+  arc-tmux monitor --pane=@current --idle 5 --lines 200 --output json
+  arc-tmux monitor --pane=@deploy --answer-prompts
+
+  # Bytes and lines per second over the last five seconds
+  arc-tmux monitor --pane=@build --rate 5 -o json
+
+  # Block until something new appears after a known snapshot
+  arc-tmux monitor --pane=@deploy --until-changed --hash "$HASH" --timeout 300 -o json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
@@ -53,27 +113,64 @@ func newMonitorCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			target = handle.Target
+			if strings.TrimSpace(prevHash) != "" && !untilChanged {
+				return fmt.Errorf("--hash requires --until-changed")
+			}
+
+			var rate *outputRate
+			if rateIntervals > 0 {
+				r, err := sampleOutputRate(handle.ID, rateIntervals, time.Duration(rateInterval*float64(time.Second)), floodLines)
+				if err != nil {
+					return err
+				}
+				rate = &r
+			}
+
+			// The change wait runs before the snapshot so that activity and
+			// prompt state describe the changed output.
+			var capture string
+			var changeErr error
+			if untilChanged {
+				if timeout <= 0 {
+					timeout = 60
+				}
+				capture, changeErr = tmux.WaitOutputChange(handle.ID, lines, strings.ToLower(strings.TrimSpace(prevHash)), time.Duration(timeout*float64(time.Second)))
+				if changeErr != nil && !isTimeout(changeErr) {
+					return changeErr
+				}
+			} else if capture, err = tmux.Capture(handle.ID, lines); err != nil {
 				return err
 			}
 
-			pane, err := tmux.PaneDetailsForTarget(target)
+			pane, err := tmux.PaneDetailsForTarget(handle.ID)
 			if err != nil {
 				return err
 			}
 
 			snapshot := monitorSnapshot{
-				PaneID:       target,
-				Session:      pane.Session,
-				WindowIndex:  pane.WindowIndex,
-				PaneIndex:    pane.PaneIndex,
-				Active:       pane.Active,
-				Command:      pane.Command,
-				Title:        pane.Title,
-				Path:         pane.Path,
-				PID:          pane.PID,
-				ActivityAt:   pane.ActivityAt,
-				LinesChecked: lines,
+				PaneID:          target,
+				Session:         pane.Session,
+				WindowIndex:     pane.WindowIndex,
+				PaneIndex:       pane.PaneIndex,
+				Active:          pane.Active,
+				Command:         pane.Command,
+				Title:           pane.Title,
+				Path:            pane.Path,
+				PID:             pane.PID,
+				ActivityAt:      pane.ActivityAt,
+				LinesChecked:    lines,
+				CursorX:         pane.CursorX,
+				CursorY:         pane.CursorY,
+				ScrollPosition:  pane.ScrollPosition,
+				HistorySize:     pane.HistorySize,
+				AlternateScreen: pane.AlternateScreen,
+				LastCommand:     pane.LastCommand,
+				OutputRate:      rate,
 			}
 
 			if idle <= 0 {
@@ -84,12 +181,36 @@ func newMonitorCmd() *cobra.Command {
 				snapshot.Idle = snapshot.IdleSeconds >= idle
 			}
 
-			capture, err := tmux.Capture(target, lines)
+			snapshot.OutputHash = tmux.OutputHash(capture)
+			if untilChanged {
+				changed := changeErr == nil
+				snapshot.Changed = &changed
+				snapshot.PreviousHash = strings.ToLower(strings.TrimSpace(prevHash))
+			}
+			patterns, err := progressPatterns()
+			if err != nil {
+				return err
+			}
+			if percent, ok := detectProgress(capture, patterns); ok {
+				snapshot.ProgressPercent = &percent
+			}
+			rules, err := promptRules()
 			if err != nil {
 				return err
 			}
-			hash := sha1.Sum([]byte(capture))
-			snapshot.OutputHash = hex.EncodeToString(hash[:])
+			snapshot.State = "busy"
+			if snapshot.Idle {
+				snapshot.State = "idle"
+			}
+			if prompt, ok := detectPendingPrompt(capture, rules); ok {
+				if answerPrompts {
+					if err := answerPendingPrompt(handle.ID, &prompt); err != nil {
+						return err
+					}
+				}
+				snapshot.State = "prompt"
+				snapshot.Prompt = &prompt
+			}
 
 			out := cmd.OutOrStdout()
 			switch {
@@ -102,17 +223,38 @@ func newMonitorCmd() *cobra.Command {
 				defer func() { _ = enc.Close() }()
 				return enc.Encode(snapshot)
 			case outputOpts.Is(output.OutputQuiet):
-				if snapshot.Idle {
-					_, _ = fmt.Fprintln(out, "idle")
-					return nil
+				if untilChanged {
+					_, _ = fmt.Fprintln(out, snapshot.OutputHash)
+					return changeErr
 				}
-				_, _ = fmt.Fprintln(out, "busy")
+				_, _ = fmt.Fprintln(out, snapshot.State)
 				return nil
 			}
+			if changeErr != nil {
+				_, _ = fmt.Fprintf(out, "Pane %s output did not change in time. hash=%s\n", target, snapshot.OutputHash)
+				return changeErr
+			}
 
-			status := "busy"
-			if snapshot.Idle {
-				status = "idle"
+			status := snapshot.State
+			if snapshot.Prompt != nil {
+				status = fmt.Sprintf("waiting on a %s prompt %q", snapshot.Prompt.Kind, snapshot.Prompt.Text)
+				if snapshot.Prompt.Action == "answered" {
+					status += fmt.Sprintf(" (answered %q)", snapshot.Prompt.Answer)
+				}
+			}
+			if snapshot.ProgressPercent != nil {
+				status = fmt.Sprintf("%s at %.1f%%", status, *snapshot.ProgressPercent)
+			}
+			if snapshot.AlternateScreen {
+				status += fmt.Sprintf(" in full-screen %s", snapshot.Command)
+			}
+			if r := snapshot.OutputRate; r != nil && r.Activity == "idle" {
+				status += ", printing nothing"
+			} else if r != nil {
+				status += fmt.Sprintf(", %s at %.1f lines/s (%.0f B/s)", r.Activity, r.LinesPerSecond, r.BytesPerSecond)
+				if r.Saturated {
+					status += " or more"
+				}
 			}
 			_, _ = fmt.Fprintf(out, "Pane %s is %s (idle %.1fs). hash=%s\n", target, status, snapshot.IdleSeconds, snapshot.OutputHash)
 			return nil
@@ -123,6 +265,12 @@ func newMonitorCmd() *cobra.Command {
 	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, @name)")
 	cmd.Flags().Float64Var(&idle, "idle", 2.0, "Seconds of inactivity to consider idle")
 	cmd.Flags().IntVar(&lines, "lines", 200, "Limit capture to last N lines for hashing (0 for full)")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().BoolVar(&answerPrompts, "answer-prompts", false, "Answer pending prompts that match a prompt_policies answer")
+	cmd.Flags().BoolVar(&untilChanged, "until-changed", false, "Block until the output hash changes, then report")
+	cmd.Flags().StringVar(&prevHash, "hash", "", "With --until-changed, wait for the output hash to differ from this value")
+	cmd.Flags().Float64Var(&timeout, "timeout", 60.0, "With --until-changed, maximum seconds to wait")
+	cmd.Flags().IntVar(&rateIntervals, "rate", 0, "Measure output rate over N intervals before the snapshot (0 to skip)")
+	cmd.Flags().Float64Var(&rateInterval, "rate-interval", 1.0, "With --rate, seconds per interval")
+	cmd.Flags().Float64Var(&floodLines, "flood-lines", defaultFloodLines, "With --rate, lines per second at which output counts as flooding")
 	return cmd
 }
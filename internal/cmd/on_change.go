@@ -0,0 +1,243 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// onChangeEvent is one NDJSON line emitted each time on-change sends.
+type onChangeEvent struct {
+	Time    string   `json:"time" yaml:"time"`
+	Pane    string   `json:"pane" yaml:"pane"`
+	Command string   `json:"command,omitempty" yaml:"command,omitempty"`
+	Keys    []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Files   []string `json:"files" yaml:"files"`
+	// Action is "sent" or "dry_run".
+	Action string `json:"action" yaml:"action"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func newOnChangeCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var paths []string
+	var ignore []string
+	var keys []string
+	var debounce float64
+	var interval float64
+	var duration float64
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "on-change [command]",
+		Short: "Send a command to a pane when files change",
+		Long: `Watch files and send a command to a pane when they change, e.g. rerun the
+tests in the test pane whenever you save.
+
+Files under each --path (default: the current directory) are polled every
+--interval seconds; hidden directories and node_modules are skipped, and
+--ignore drops files whose name, relative path, or any directory on the way
+matches a glob. Changes are debounced: the command is sent once no file has
+changed for --debounce seconds, so saving several files at once runs it
+once. --key sends keys first (e.g. C-c to stop the previous run).
+
+Each send is printed as it happens; with --output json as NDJSON events.`,
+		Example: `  arc-tmux on-change "go test ./..." --pane @tests
+  arc-tmux on-change "npm test" --pane dev:1.0 --path src --path test --ignore '*.snap'
+  arc-tmux on-change "cargo run" --pane @server --key C-c --debounce 1`,
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 && len(keys) == 0 {
+				return fmt.Errorf("requires a command or at least one --key")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if debounce < 0 {
+				return errors.New("--debounce must be >= 0")
+			}
+			for _, pattern := range ignore {
+				if _, err := filepath.Match(pattern, ""); err != nil {
+					return fmt.Errorf("invalid --ignore %q: %w", pattern, err)
+				}
+			}
+			normalized := make([]string, 0, len(keys))
+			for _, key := range keys {
+				name, err := normalizeKeyName(key)
+				if err != nil {
+					return err
+				}
+				normalized = append(normalized, name)
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			if len(paths) == 0 {
+				paths = []string{"."}
+			}
+			roots, err := resolveWatchPaths(paths, "")
+			if err != nil {
+				return err
+			}
+			if interval <= 0 {
+				interval = 0.5
+			}
+			text := strings.Join(args, " ")
+
+			out := cmd.OutOrStdout()
+			var yamlEnc *yaml.Encoder
+			if outputOpts.Is(output.OutputYAML) {
+				yamlEnc = yaml.NewEncoder(out)
+				defer func() { _ = yamlEnc.Close() }()
+			}
+			emit := func(event onChangeEvent) error {
+				switch {
+				case outputOpts.Is(output.OutputJSON):
+					return json.NewEncoder(out).Encode(event)
+				case outputOpts.Is(output.OutputYAML):
+					return yamlEnc.Encode(event)
+				case outputOpts.Is(output.OutputQuiet):
+					_, err := fmt.Fprintf(out, "%s\t%d\n", event.Action, len(event.Files))
+					return err
+				}
+				line := fmt.Sprintf("%s [%s] %s", event.Time, event.Action, describeChangedFiles(event.Files))
+				if event.Error != "" {
+					line += " (error: " + event.Error + ")"
+				}
+				_, err := fmt.Fprintln(out, line)
+				return err
+			}
+
+			var deadline time.Time
+			if duration > 0 {
+				deadline = time.Now().Add(time.Duration(duration * float64(time.Second)))
+			}
+			files, err := snapshotFiles(roots)
+			if err != nil {
+				return err
+			}
+			wait := time.Duration(debounce * float64(time.Second))
+			pending := map[string]bool{}
+			var lastChange time.Time
+			ticker := time.NewTicker(time.Duration(interval * float64(time.Second)))
+			defer ticker.Stop()
+			for {
+				if !deadline.IsZero() && time.Now().After(deadline) {
+					return nil
+				}
+				<-ticker.C
+				curr, err := snapshotFiles(roots)
+				if err != nil {
+					return err
+				}
+				for _, file := range ignoreFiles(changedFiles(files, curr), roots, ignore) {
+					pending[file] = true
+					lastChange = time.Now()
+				}
+				files = curr
+				if len(pending) == 0 || time.Since(lastChange) < wait {
+					continue
+				}
+				changed := make([]string, 0, len(pending))
+				for file := range pending {
+					changed = append(changed, file)
+				}
+				sort.Strings(changed)
+				event := onChangeEvent{
+					Time:    time.Now().UTC().Format(time.RFC3339),
+					Pane:    handle.Target,
+					Command: text,
+					Keys:    normalized,
+					Files:   changed,
+					Action:  "sent",
+				}
+				pending = map[string]bool{}
+				if dryRun {
+					event.Action = "dry_run"
+				} else if err := sendOnChange(handle.ID, normalized, text); err != nil {
+					event.Error = err.Error()
+				}
+				if err := emit(event); err != nil {
+					return err
+				}
+			}
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().StringArrayVar(&paths, "path", nil, "File or directory to watch (repeatable; default: .)")
+	cmd.Flags().StringArrayVar(&ignore, "ignore", nil, "Glob of files or directories to ignore (repeatable, e.g. '*.log', dist)")
+	cmd.Flags().StringArrayVar(&keys, "key", nil, "Special key to send before the command (repeatable, e.g. C-c)")
+	cmd.Flags().Float64Var(&debounce, "debounce", 0.3, "Seconds without further changes before sending")
+	cmd.Flags().Float64Var(&interval, "interval", 0.5, "Polling interval in seconds")
+	cmd.Flags().Float64Var(&duration, "duration", 0, "Stop after N seconds (0 to run until interrupted)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report changes without sending")
+	return cmd
+}
+
+func sendOnChange(target string, keys []string, text string) error {
+	if len(keys) > 0 {
+		if err := tmux.SendKeys(target, keys); err != nil {
+			return err
+		}
+	}
+	if text == "" {
+		return nil
+	}
+	return tmux.SendLiteral(target, text, true, 0)
+}
+
+// ignoreFiles drops files whose base name, path relative to their root, or
+// any directory on that path matches one of patterns.
+func ignoreFiles(files []string, roots []string, patterns []string) []string {
+	if len(patterns) == 0 {
+		return files
+	}
+	var kept []string
+	for _, file := range files {
+		if !ignoredFile(file, roots, patterns) {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
+
+func ignoredFile(file string, roots []string, patterns []string) bool {
+	rel := filepath.Base(file)
+	for _, root := range roots {
+		if r, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(r, "..") {
+			rel = r
+			break
+		}
+	}
+	candidates := append([]string{rel, filepath.Base(file)}, strings.Split(filepath.Dir(rel), string(filepath.Separator))...)
+	for _, pattern := range patterns {
+		for _, c := range candidates {
+			if ok, _ := filepath.Match(pattern, c); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import "testing"
+
+func TestIgnoreFiles(t *testing.T) {
+	root := "/src/app"
+	files := []string{
+		"/src/app/main.go",
+		"/src/app/server.log",
+		"/src/app/dist/bundle.js",
+		"/src/app/web/__snapshots__/a.snap",
+	}
+	got := ignoreFiles(files, []string{root}, []string{"*.log", "dist", "web/__snapshots__/*"})
+	if !equalSlice(got, []string{"/src/app/main.go"}) {
+		t.Fatalf("ignoreFiles = %q", got)
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// fileRefPattern matches a FILE:LINE[:COL] reference anywhere in a line. The
+// file needs an extension, which keeps times and host:port pairs out.
+var fileRefPattern = regexp.MustCompile(`(?:^|[\s'"(\[=])(?P<file>(?:~|\.{1,2})?/?(?:[\w.@+-]+/)*[\w@+-][\w.@+-]*\.\w+):(?P<line>\d+)(?::(?P<col>\d+))?`)
+
+type openRefResult struct {
+	From   string `json:"from" yaml:"from"`
+	To     string `json:"to" yaml:"to"`
+	File   string `json:"file" yaml:"file"`
+	Line   int    `json:"line" yaml:"line"`
+	Column int    `json:"column,omitempty" yaml:"column,omitempty"`
+	// Source is "error" for a triage finding or "reference" for any other
+	// FILE:LINE in the output.
+	Source  string `json:"source" yaml:"source"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	Editor  string `json:"editor" yaml:"editor"`
+	// Command is the text typed into the editor pane.
+	Command string `json:"command" yaml:"command"`
+	DryRun  bool   `json:"dry_run" yaml:"dry_run"`
+}
+
+// fileRef is a reference found in a pane's output.
+type fileRef struct {
+	File    string
+	Line    int
+	Column  int
+	Source  string
+	Message string
+}
+
+func (r fileRef) String() string {
+	return triageFinding{File: r.File, Line: r.Line, Column: r.Column}.ref()
+}
+
+func newOpenRefCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var fromArg string
+	var toArg string
+	var lines int
+	var editor string
+	var template string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "open-ref",
+		Short: "Open the latest file:line from one pane in an editor pane",
+		Long: `Find the most recent file reference in one pane's output and open it in the
+editor running in another pane.
+
+The newest error found by the triage signatures wins; without one, the
+newest FILE:LINE[:COL] anywhere in the output is used. Relative paths are
+resolved against the --from pane's directory, and references to files that
+do not exist there are skipped.
+
+When the --to pane is running vim or nvim, arc-tmux leaves insert mode and
+types :edit +LINE FILE. When it is at a shell prompt, it runs the editor
+named by --editor: vim, nvim, or code (code -g FILE:LINE:COL). The default,
+auto, uses $VISUAL or $EDITOR and falls back to vim. --command gives your
+own command instead, with {file}, {line}, and {col} filled in.`,
+		Example: `  arc-tmux open-ref --from @server --to @editor
+  arc-tmux open-ref --from @tests --to dev:0.0 --editor code
+  arc-tmux open-ref --from @build --to @editor --command 'hx {file}:{line}:{col}'`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if strings.TrimSpace(toArg) == "" {
+				return errors.New("--to is required")
+			}
+			switch editor {
+			case "auto", "vim", "nvim", "code":
+			default:
+				return fmt.Errorf("invalid --editor %q (auto|vim|nvim|code)", editor)
+			}
+			fromTarget, err := resolvePaneTarget(fromArg)
+			if err != nil {
+				return err
+			}
+			from, err := canonicalPaneTarget(fromTarget)
+			if err != nil {
+				return err
+			}
+			toTarget, err := resolvePaneTarget(toArg)
+			if err != nil {
+				return err
+			}
+			to, err := canonicalPaneTarget(toTarget)
+			if err != nil {
+				return err
+			}
+			source, err := tmux.PaneDetailsForTarget(from.ID)
+			if err != nil {
+				return err
+			}
+			dest, err := tmux.PaneDetailsForTarget(to.ID)
+			if err != nil {
+				return err
+			}
+			patterns, err := triagePatterns()
+			if err != nil {
+				return err
+			}
+			capture, err := tmux.CaptureJoined(from.ID, lines)
+			if err != nil {
+				return err
+			}
+			ref, ok := latestFileRef(splitLines(capture), patterns, source.Path)
+			if !ok {
+				return newCodedError(errNoMatch, fmt.Sprintf("no file:line reference to an existing file in the last %d lines of %s", lines, from.Target), nil)
+			}
+
+			result := openRefResult{
+				From:    from.Target,
+				To:      to.Target,
+				File:    ref.File,
+				Line:    ref.Line,
+				Column:  ref.Column,
+				Source:  ref.Source,
+				Message: ref.Message,
+				DryRun:  dryRun,
+			}
+			inEditor := isVimCommand(dest.Command)
+			switch {
+			case strings.TrimSpace(template) != "":
+				result.Editor = "command"
+				result.Command = expandRefTemplate(template, ref)
+			case inEditor:
+				result.Editor = filepath.Base(strings.Fields(dest.Command)[0])
+				result.Command = vimEditCommand(ref)
+			default:
+				result.Editor = shellEditor(editor)
+				result.Command = editorShellCommand(result.Editor, ref)
+			}
+			if !inEditor && !isShellCommand(dest.Command) {
+				return newCodedError(errPaneBusy, fmt.Sprintf("pane %s is running %q; open-ref needs vim, nvim, or a shell prompt", to.Target, dest.Command), nil)
+			}
+			if !dryRun {
+				if inEditor {
+					if err := tmux.Escape(to.ID); err != nil {
+						return err
+					}
+				}
+				if err := tmux.SendLiteral(to.ID, result.Command, true, 0); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				_, _ = fmt.Fprintln(out, ref.String())
+				return nil
+			}
+			if dryRun {
+				_, _ = fmt.Fprintf(out, "Would send to %s: %s\n", result.To, result.Command)
+				return nil
+			}
+			_, _ = fmt.Fprintf(out, "Opened %s in %s (%s)\n", ref.String(), result.To, result.Editor)
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&fromArg, "from", "", "Pane to search for a file reference (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().StringVar(&toArg, "to", "", "Pane running the editor, or a shell to start it in")
+	cmd.Flags().IntVar(&lines, "lines", 500, "Search the last N lines (0 for full)")
+	cmd.Flags().StringVar(&editor, "editor", "auto", "Editor to start from a shell prompt: auto, vim, nvim, or code")
+	cmd.Flags().StringVar(&template, "command", "", "Command to send instead, with {file}, {line}, and {col} placeholders")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the command without sending it")
+	return cmd
+}
+
+// latestFileRef returns the newest triage finding with a location, or else
+// the newest FILE:LINE reference, whose file exists relative to dir.
+func latestFileRef(lines []string, patterns []triagePattern, dir string) (fileRef, bool) {
+	findings := triageOutput(lines, patterns, 0)
+	for i := len(findings) - 1; i >= 0; i-- {
+		f := findings[i]
+		if f.File == "" || f.Line == 0 {
+			continue
+		}
+		if path, ok := resolveRefPath(f.File, dir); ok {
+			return fileRef{File: path, Line: f.Line, Column: f.Column, Source: "error", Message: f.Message}, true
+		}
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		matches := fileRefPattern.FindAllStringSubmatch(lines[i], -1)
+		for j := len(matches) - 1; j >= 0; j-- {
+			file, line, col := triageLocation(fileRefPattern, matches[j])
+			if line == 0 {
+				continue
+			}
+			if path, ok := resolveRefPath(file, dir); ok {
+				return fileRef{File: path, Line: line, Column: col, Source: "reference"}, true
+			}
+		}
+	}
+	return fileRef{}, false
+}
+
+// resolveRefPath makes file absolute against dir and reports whether it
+// names an existing regular file.
+func resolveRefPath(file string, dir string) (string, bool) {
+	if file == "~" || strings.HasPrefix(file, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		file = filepath.Join(home, strings.TrimPrefix(file, "~"))
+	}
+	if !filepath.IsAbs(file) {
+		if dir == "" {
+			return "", false
+		}
+		file = filepath.Join(dir, file)
+	}
+	info, err := os.Stat(file)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	return filepath.Clean(file), true
+}
+
+func isVimCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	switch filepath.Base(fields[0]) {
+	case "vim", "nvim", "vi", "view", "gvim":
+		return true
+	}
+	return false
+}
+
+// shellEditor picks the editor to start for --editor auto from $VISUAL or
+// $EDITOR, falling back to vim.
+func shellEditor(editor string) string {
+	if editor != "auto" {
+		return editor
+	}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		fields := strings.Fields(os.Getenv(env))
+		if len(fields) == 0 {
+			continue
+		}
+		switch name := filepath.Base(fields[0]); name {
+		case "vim", "nvim", "code":
+			return name
+		case "vi":
+			return "vim"
+		}
+	}
+	return "vim"
+}
+
+func editorShellCommand(editor string, ref fileRef) string {
+	if editor == "code" {
+		return "code -g " + shellQuoteSingle(ref.String())
+	}
+	return editor + " " + shellQuoteSingle(vimPosition(ref)) + " " + shellQuoteSingle(ref.File)
+}
+
+// vimEditCommand is the ex command that opens ref in a running vim.
+func vimEditCommand(ref fileRef) string {
+	return ":edit " + strings.ReplaceAll(vimPosition(ref), " ", `\ `) + " " + vimEscapePath(ref.File)
+}
+
+// vimPosition is the +cmd that puts the cursor on ref's line and column.
+func vimPosition(ref fileRef) string {
+	if ref.Column > 0 {
+		return fmt.Sprintf("+call cursor(%d,%d)", ref.Line, ref.Column)
+	}
+	return "+" + strconv.Itoa(ref.Line)
+}
+
+// vimEscapePath escapes the characters an ex command line treats specially
+// in a file name.
+func vimEscapePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(` \%#|"`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// expandRefTemplate fills {file}, {line}, and {col} in a --command template;
+// the file is shell-quoted and a missing column becomes 1.
+func expandRefTemplate(template string, ref fileRef) string {
+	col := ref.Column
+	if col == 0 {
+		col = 1
+	}
+	return strings.NewReplacer(
+		"{file}", shellQuoteSingle(ref.File),
+		"{line}", strconv.Itoa(ref.Line),
+		"{col}", strconv.Itoa(col),
+	).Replace(template)
+}
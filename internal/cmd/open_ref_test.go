@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLatestFileRef(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "util.go", "pkg/log.go"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	patterns := builtinTriagePatterns(t)
+
+	lines := []string{
+		"./main.go:12:5: undefined: frob",
+		"./missing.go:3:1: undefined: other",
+		"2025/01/02 10:00:00 pkg/log.go:40: listening on example.com:8080",
+	}
+	ref, ok := latestFileRef(lines, patterns, dir)
+	if !ok {
+		t.Fatal("expected a reference")
+	}
+	want := fileRef{File: filepath.Join(dir, "main.go"), Line: 12, Column: 5, Source: "error", Message: "undefined: frob"}
+	if ref != want {
+		t.Fatalf("error ref = %+v, want %+v", ref, want)
+	}
+
+	lines = []string{
+		"see util.go:7 for details",
+		"2025/01/02 10:00:00 pkg/log.go:40: listening on example.com:8080",
+	}
+	ref, ok = latestFileRef(lines, patterns, dir)
+	if !ok {
+		t.Fatal("expected a reference")
+	}
+	want = fileRef{File: filepath.Join(dir, "pkg/log.go"), Line: 40, Source: "reference"}
+	if ref != want {
+		t.Fatalf("plain ref = %+v, want %+v", ref, want)
+	}
+
+	if _, ok := latestFileRef([]string{"GET example.com:8080 12:30:01"}, patterns, dir); ok {
+		t.Fatal("expected no reference")
+	}
+}
+
+func TestEditorCommands(t *testing.T) {
+	ref := fileRef{File: "/srv/my app/main.go", Line: 12, Column: 5}
+	cases := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"vim", editorShellCommand("vim", ref), `vim '+call cursor(12,5)' '/srv/my app/main.go'`},
+		{"code", editorShellCommand("code", ref), `code -g '/srv/my app/main.go:12:5'`},
+		{"running vim", vimEditCommand(ref), `:edit +call\ cursor(12,5) /srv/my\ app/main.go`},
+		{"running vim no column", vimEditCommand(fileRef{File: "/srv/a%b.go", Line: 3}), `:edit +3 /srv/a\%b.go`},
+		{"template", expandRefTemplate("hx {file}:{line}:{col}", fileRef{File: "/srv/main.go", Line: 3}), `hx '/srv/main.go':3:1`},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, tc.got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// rateCaptureLines is how many lines of history each rate sample captures; a
+// pane printing more than this between samples is reported as saturated.
+const rateCaptureLines = 1000
+
+// progressRateIntervals is how many samples the rates on progress events
+// cover.
+const progressRateIntervals = 5
+
+// defaultFloodLines is the lines per second above which output counts as
+// flooding.
+const defaultFloodLines = 100
+
+// rateSample is the output a pane printed during one interval.
+type rateSample struct {
+	Seconds float64 `json:"seconds" yaml:"seconds"`
+	Bytes   int     `json:"bytes" yaml:"bytes"`
+	Lines   int     `json:"lines" yaml:"lines"`
+}
+
+// outputRate summarizes the last intervals of a pane's output.
+type outputRate struct {
+	BytesPerSecond float64 `json:"bytes_per_second" yaml:"bytes_per_second"`
+	LinesPerSecond float64 `json:"lines_per_second" yaml:"lines_per_second"`
+	// Activity is "idle" (nothing printed), "progressing", or "flooding"
+	// (at least the flood threshold in lines per second).
+	Activity string `json:"activity" yaml:"activity"`
+	// Saturated is set when an interval printed more than a capture holds,
+	// so the rates are lower bounds.
+	Saturated bool         `json:"saturated,omitempty" yaml:"saturated,omitempty"`
+	Intervals []rateSample `json:"intervals" yaml:"intervals"`
+}
+
+// rateTracker measures how fast a pane prints from successive captures of
+// its last lines, keeping the most recent window intervals.
+type rateTracker struct {
+	window    int
+	prev      []string
+	last      time.Time
+	samples   []rateSample
+	saturated []bool
+}
+
+func newRateTracker(window int) *rateTracker {
+	if window <= 0 {
+		window = 1
+	}
+	return &rateTracker{window: window}
+}
+
+// feed records the lines printed since the previous capture; the first
+// capture only sets the baseline.
+func (t *rateTracker) feed(capture string, now time.Time) {
+	curr := splitLines(strings.TrimRight(capture, "\n"))
+	if t.last.IsZero() {
+		t.prev, t.last = curr, now
+		return
+	}
+	added, saturated := newOutputLines(t.prev, curr)
+	sample := rateSample{Seconds: now.Sub(t.last).Seconds(), Lines: len(added)}
+	for _, line := range added {
+		sample.Bytes += len(line) + 1
+	}
+	t.samples = append(t.samples, sample)
+	t.saturated = append(t.saturated, saturated)
+	if len(t.samples) > t.window {
+		t.samples = t.samples[len(t.samples)-t.window:]
+		t.saturated = t.saturated[len(t.saturated)-t.window:]
+	}
+	t.prev, t.last = curr, now
+}
+
+// newOutputLines returns the lines of curr printed since prev. The last line
+// of prev may have been rewritten in place (a progress bar, a prompt being
+// typed), so it only anchors the overlap when it is unchanged; a rewritten
+// last line counts as output. saturated is set when a capture at least as
+// long as prev shares no lines with it.
+func newOutputLines(prev []string, curr []string) ([]string, bool) {
+	if equalSlice(prev, curr) {
+		return nil, false
+	}
+	if len(prev) == 0 {
+		return curr, false
+	}
+	if added := diffLines(prev, curr); len(added) < len(curr) {
+		return added, false
+	}
+	added := diffLines(prev[:len(prev)-1], curr)
+	return added, len(prev) > 1 && len(added) == len(curr) && len(curr) >= len(prev)
+}
+
+// rate sums the kept intervals; flood is the flooding threshold in lines per
+// second (defaultFloodLines when <= 0).
+func (t *rateTracker) rate(flood float64) outputRate {
+	if flood <= 0 {
+		flood = defaultFloodLines
+	}
+	r := outputRate{Activity: "idle", Intervals: append([]rateSample{}, t.samples...)}
+	var seconds float64
+	var bytes, lines int
+	for i, s := range t.samples {
+		seconds += s.Seconds
+		bytes += s.Bytes
+		lines += s.Lines
+		r.Saturated = r.Saturated || t.saturated[i]
+	}
+	if seconds > 0 {
+		r.BytesPerSecond = roundSeconds(float64(bytes) / seconds)
+		r.LinesPerSecond = roundSeconds(float64(lines) / seconds)
+	}
+	switch {
+	case r.LinesPerSecond >= flood:
+		r.Activity = "flooding"
+	case lines > 0:
+		r.Activity = "progressing"
+	}
+	return r
+}
+
+// sampleOutputRate captures a pane intervals+1 times, every interval apart,
+// and returns its output rate over those intervals.
+func sampleOutputRate(paneID string, intervals int, interval time.Duration, flood float64) (outputRate, error) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	tracker := newRateTracker(intervals)
+	for i := 0; i <= intervals; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		capture, err := tmux.Capture(paneID, rateCaptureLines)
+		if err != nil {
+			return outputRate{}, err
+		}
+		tracker.feed(capture, time.Now())
+	}
+	return tracker.rate(flood), nil
+}
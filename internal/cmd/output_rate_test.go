@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func numberedLines(from int, to int) string {
+	var b strings.Builder
+	for i := from; i <= to; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func TestRateTrackerCountsNewLines(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tracker := newRateTracker(2)
+	tracker.feed(numberedLines(1, 10), start)
+	tracker.feed(numberedLines(1, 14), start.Add(time.Second))
+	tracker.feed(numberedLines(5, 20), start.Add(2*time.Second))
+
+	r := tracker.rate(0)
+	if len(r.Intervals) != 2 || r.Intervals[0].Lines != 4 || r.Intervals[1].Lines != 6 {
+		t.Fatalf("unexpected intervals: %+v", r.Intervals)
+	}
+	if r.LinesPerSecond != 5 || r.Activity != "progressing" || r.Saturated {
+		t.Fatalf("unexpected rate: %+v", r)
+	}
+	if r.Intervals[1].Bytes != len(numberedLines(15, 20)) {
+		t.Fatalf("expected bytes of the new lines, got %d", r.Intervals[1].Bytes)
+	}
+
+	tracker.feed(numberedLines(5, 20), start.Add(3*time.Second))
+	tracker.feed(numberedLines(5, 20), start.Add(4*time.Second))
+	if r := tracker.rate(0); r.Activity != "idle" || r.LinesPerSecond != 0 {
+		t.Fatalf("expected idle after unchanged captures, got %+v", r)
+	}
+}
+
+func TestRateTrackerRewrittenLastLine(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tracker := newRateTracker(1)
+	tracker.feed("building\n[#---] 25%\n", start)
+	tracker.feed("building\n[##--] 50%\n", start.Add(time.Second))
+	r := tracker.rate(0)
+	if r.Intervals[0].Lines != 1 || r.Saturated {
+		t.Fatalf("expected the rewritten line to count once, got %+v", r)
+	}
+}
+
+func TestRateTrackerFloodingAndSaturated(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tracker := newRateTracker(1)
+	tracker.feed(numberedLines(1, 500), start)
+	tracker.feed(numberedLines(2001, 2500), start.Add(time.Second))
+	r := tracker.rate(100)
+	if r.Activity != "flooding" || !r.Saturated || r.LinesPerSecond != 500 {
+		t.Fatalf("expected saturated flooding, got %+v", r)
+	}
+}
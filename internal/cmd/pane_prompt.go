@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// pendingPrompt is an interactive prompt a pane appears to be blocked on.
+type pendingPrompt struct {
+	Text string `json:"text" yaml:"text"`
+	Kind string `json:"kind" yaml:"kind"`
+	// Action is "answered" when a prompt policy replied, otherwise "alert".
+	Action string `json:"action" yaml:"action"`
+	Answer string `json:"answer,omitempty" yaml:"answer,omitempty"`
+	Policy string `json:"policy,omitempty" yaml:"policy,omitempty"`
+}
+
+// promptPolicy is a prompt_policies config entry: prompts matching Match are
+// answered with Answer followed by Enter, or only reported when Answer is empty.
+type promptPolicy struct {
+	Match  string `yaml:"match"`
+	Answer string `yaml:"answer,omitempty"`
+}
+
+type promptRule struct {
+	kind   string
+	re     *regexp.Regexp
+	answer string
+	policy string
+}
+
+// passwordPrompt matches secret prompts, which are never answered by policy.
+var passwordPrompt = regexp.MustCompile(`(?i)\b(password|passphrase|passcode|pin)\b[^:]*:\s*$`)
+
+// defaultPromptRules recognise common blocking prompts on the last line.
+var defaultPromptRules = []promptRule{
+	{kind: "password", re: passwordPrompt},
+	{kind: "confirm", re: regexp.MustCompile(`(?i)[\[(]\s*y(es)?\s*/\s*no?\s*[\])]|[\[(]\s*no?\s*/\s*y(es)?\s*[\])]|\(yes/no(/\[fingerprint\])?\)`)},
+	{kind: "confirm", re: regexp.MustCompile(`(?i)\b(are you sure|do you want to continue|proceed|continue)\b.*\?\s*$`)},
+	{kind: "continue", re: regexp.MustCompile(`(?i)\bpress (any key|enter|return)\b`)},
+}
+
+// promptRules compiles prompt_policies from the config file ahead of the
+// built-in rules, so policies can also recognise tool-specific prompts.
+func promptRules() ([]promptRule, error) {
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]promptRule, 0, len(cfg.PromptPolicies)+len(defaultPromptRules))
+	for _, policy := range cfg.PromptPolicies {
+		re, err := regexp.Compile(policy.Match)
+		if err != nil {
+			return nil, fmt.Errorf("prompt_policies: invalid match %q: %w", policy.Match, err)
+		}
+		rules = append(rules, promptRule{kind: "policy", re: re, answer: policy.Answer, policy: policy.Match})
+	}
+	return append(rules, defaultPromptRules...), nil
+}
+
+// detectPendingPrompt checks the last non-empty line of output. Password
+// prompts are never answered automatically, whatever the policy says.
+func detectPendingPrompt(output string, rules []promptRule) (pendingPrompt, bool) {
+	line := lastNonEmptyLine(output)
+	if line == "" {
+		return pendingPrompt{}, false
+	}
+	password := passwordPrompt.MatchString(line)
+	for _, rule := range rules {
+		if !rule.re.MatchString(line) {
+			continue
+		}
+		prompt := pendingPrompt{Text: line, Kind: rule.kind, Action: "alert", Policy: rule.policy}
+		if rule.kind == "policy" {
+			prompt.Kind = "confirm"
+			if password {
+				prompt.Kind = "password"
+			}
+		}
+		if rule.answer != "" && !password {
+			prompt.Answer = rule.answer
+		}
+		return prompt, true
+	}
+	return pendingPrompt{}, false
+}
+
+// answerPendingPrompt sends the policy answer for prompt, marking it answered.
+func answerPendingPrompt(paneID string, prompt *pendingPrompt) error {
+	if prompt.Answer == "" {
+		return nil
+	}
+	if err := tmux.SendLiteral(paneID, prompt.Answer, true, 0); err != nil {
+		return err
+	}
+	prompt.Action = "answered"
+	return nil
+}
+
+func lastNonEmptyLine(output string) string {
+	lines := splitLines(output)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
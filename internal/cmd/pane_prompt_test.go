@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestDetectPendingPrompt(t *testing.T) {
+	cases := []struct {
+		output string
+		kind   string
+		ok     bool
+	}{
+		{"Removing 3 packages\nDo you want to continue? [Y/n] ", "confirm", true},
+		{"Are you sure you want to delete prod?\n", "confirm", true},
+		{"The authenticity of host can't be established.\nAre you sure you want to continue connecting (yes/no/[fingerprint])? ", "confirm", true},
+		{"[sudo] password for dev: ", "password", true},
+		{"Enter passphrase for key '/home/dev/.ssh/id_ed25519': ", "password", true},
+		{"Press ENTER to continue\n", "continue", true},
+		{"Compiling...\nspinning up: done\n$ ", "", false},
+		{"password updated\n$ ", "", false},
+	}
+	for _, tc := range cases {
+		prompt, ok := detectPendingPrompt(tc.output, defaultPromptRules)
+		if ok != tc.ok || prompt.Kind != tc.kind {
+			t.Fatalf("detectPendingPrompt(%q) = %+v, %v; want kind %q, %v", tc.output, prompt, ok, tc.kind, tc.ok)
+		}
+		if ok && (prompt.Action != "alert" || prompt.Answer != "") {
+			t.Fatalf("built-in rules must only alert: %+v", prompt)
+		}
+	}
+}
+
+func TestDetectPendingPromptPolicy(t *testing.T) {
+	rules := append([]promptRule{
+		{kind: "policy", re: regexp.MustCompile(`Overwrite .*\?`), answer: "y", policy: `Overwrite .*\?`},
+		{kind: "policy", re: regexp.MustCompile(`(?i)password`), answer: "hunter2", policy: `(?i)password`},
+	}, defaultPromptRules...)
+
+	prompt, ok := detectPendingPrompt("Overwrite config.yaml? ", rules)
+	if !ok || prompt.Answer != "y" || prompt.Kind != "confirm" || prompt.Policy == "" {
+		t.Fatalf("expected policy answer, got %+v (%v)", prompt, ok)
+	}
+	prompt, ok = detectPendingPrompt("Password: ", rules)
+	if !ok || prompt.Kind != "password" || prompt.Answer != "" {
+		t.Fatalf("password prompts must never be answered, got %+v (%v)", prompt, ok)
+	}
+}
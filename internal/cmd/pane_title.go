@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// commandTitleMax caps how much of a command a pane title shows.
+const commandTitleMax = 60
+
+// commandTitle is a pane retitled with --set-title, and what to put back.
+type commandTitle struct {
+	pane     string
+	previous string
+	title    string
+}
+
+// setCommandTitle titles a pane with command, the way a shell with a title
+// escape sequence in its prompt would, so title filters match what the pane
+// is running. With keep, a pane with a title of its own (anything but tmux's
+// default, the hostname) is left alone and nil is returned.
+func setCommandTitle(paneID string, command string, keep bool) (*commandTitle, error) {
+	details, err := tmux.PaneDetailsForTarget(paneID)
+	if err != nil {
+		return nil, err
+	}
+	hostname, _ := os.Hostname()
+	if keep && !isDefaultPaneTitle(details.Title, hostname) {
+		return nil, nil
+	}
+	t := &commandTitle{pane: paneID, previous: details.Title, title: commandTitleText(command)}
+	if err := tmux.SetPaneTitle(paneID, t.title); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// restore puts the previous title back, unless the pane was retitled since,
+// typically by its program through an escape sequence; that title is kept.
+func (t *commandTitle) restore() {
+	if t == nil {
+		return
+	}
+	details, err := tmux.PaneDetailsForTarget(t.pane)
+	if err != nil || details.Title != t.title {
+		return
+	}
+	_ = tmux.SetPaneTitle(t.pane, t.previous)
+}
+
+// commandTitleText is the first line of command, shortened to
+// commandTitleMax runes.
+func commandTitleText(command string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(command), "\n")
+	line = strings.TrimSpace(line)
+	if r := []rune(line); len(r) > commandTitleMax {
+		line = string(r[:commandTitleMax-1]) + "…"
+	}
+	return line
+}
+
+// isDefaultPaneTitle reports whether title is tmux's default pane title, the
+// hostname, or empty.
+func isDefaultPaneTitle(title string, hostname string) bool {
+	short, _, _ := strings.Cut(hostname, ".")
+	return title == "" || title == hostname || title == short
+}
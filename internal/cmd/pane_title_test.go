@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandTitleText(t *testing.T) {
+	if got := commandTitleText("  make test\necho done"); got != "make test" {
+		t.Fatalf("title = %q", got)
+	}
+	long := strings.Repeat("x", commandTitleMax+10)
+	got := []rune(commandTitleText(long))
+	if len(got) != commandTitleMax || got[len(got)-1] != '…' {
+		t.Fatalf("long title = %q", string(got))
+	}
+}
+
+func TestIsDefaultPaneTitle(t *testing.T) {
+	for _, title := range []string{"", "build-host", "build-host.example.com"} {
+		if !isDefaultPaneTitle(title, "build-host.example.com") {
+			t.Fatalf("%q should be the default title", title)
+		}
+	}
+	if isDefaultPaneTitle("server", "build-host.example.com") {
+		t.Fatalf("server should be a title of its own")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 )
 
 type paneSnapshot struct {
+	Socket       string    `json:"socket,omitempty" yaml:"socket,omitempty"`
 	Session      string    `json:"session" yaml:"session"`
 	WindowIndex  int       `json:"window_index" yaml:"window_index"`
 	WindowName   string    `json:"window_name" yaml:"window_name"`
@@ -30,16 +32,37 @@ type paneSnapshot struct {
 	Path         string    `json:"path" yaml:"path"`
 	PID          int       `json:"pid" yaml:"pid"`
 	ActivityAt   time.Time `json:"activity_at" yaml:"activity_at"`
+	Dead         bool      `json:"dead,omitempty" yaml:"dead,omitempty"`
+	DeadStatus   int       `json:"dead_status,omitempty" yaml:"dead_status,omitempty"`
+	// ExitCode is the finished command's exit status (pane_dead_status), set
+	// only for dead panes so a clean exit reports 0 rather than nothing.
+	ExitCode     *int   `json:"exit_code,omitempty" yaml:"exit_code,omitempty"`
+	StartCommand string `json:"start_command,omitempty" yaml:"start_command,omitempty"`
+	// Screen state: AlternateScreen is set while a full-screen program
+	// (vim, less, htop) owns the pane instead of a shell prompt.
+	CursorX         int  `json:"cursor_x" yaml:"cursor_x"`
+	CursorY         int  `json:"cursor_y" yaml:"cursor_y"`
+	ScrollPosition  int  `json:"scroll_position" yaml:"scroll_position"`
+	HistorySize     int  `json:"history_size" yaml:"history_size"`
+	AlternateScreen bool `json:"alternate_screen" yaml:"alternate_screen"`
+	// ShellHook and LastCommand are set in panes whose shell loaded the
+	// arc-tmux shell hook (see shell-hook).
+	ShellHook   string            `json:"shell_hook,omitempty" yaml:"shell_hook,omitempty"`
+	LastCommand *tmux.HookCommand `json:"last_command,omitempty" yaml:"last_command,omitempty"`
 }
 
 func newPanesCmd() *cobra.Command {
 	var outputOpts output.OutputOptions
 	var session string
-	var window int
+	var window string
 	var command string
 	var title string
 	var path string
 	var fuzzy bool
+	var filterExpr string
+	var porcelain bool
+	var allServers bool
+	var deadOnly bool
 
 	cmd := &cobra.Command{
 		Use:   "panes",
@@ -49,11 +72,20 @@ func newPanesCmd() *cobra.Command {
   arc-tmux panes --session fe --window 2
   arc-tmux panes --command node --path /srv
   arc-tmux panes --command ndsr --fuzzy
-  arc-tmux panes --output json`,
+  arc-tmux panes --filter 'session=~"^arc-" && command=="node" && idle>300'
+  arc-tmux panes --output json
+  arc-tmux panes --all-servers --command node
+  arc-tmux panes --dead --output json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
+			if err := checkPorcelain(cmd, porcelain); err != nil {
+				return err
+			}
+			if err := checkAllServers(allServers, porcelain); err != nil {
+				return err
+			}
 
 			resolvedSession, err := resolveSessionTarget(session)
 			if err != nil {
@@ -61,36 +93,75 @@ func newPanesCmd() *cobra.Command {
 			}
 			session = resolvedSession
 
-			panes, err := tmux.ListPanesDetailed()
+			filter, err := parsePaneFilter(filterExpr)
 			if err != nil {
-				if err == tmux.ErrNoTmuxServer {
-					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
-					return nil
-				}
-				return err
+				return newCodedError(errInvalidFilter, err.Error(), err)
 			}
 
-			items := make([]paneSnapshot, 0, len(panes))
-			for _, p := range panes {
-				if session != "" && p.Session != session {
-					continue
+			collect := func(socket string) ([]paneSnapshot, error) {
+				panes, err := tmux.ListPanesDetailed()
+				if err != nil {
+					return nil, err
 				}
-				if window >= 0 && p.WindowIndex != window {
-					continue
+				panes, err = filterPanes(panes, filter)
+				if err != nil {
+					return nil, newCodedError(errInvalidFilter, err.Error(), err)
 				}
-				if !matchesFilter(p.Command, command, fuzzy) {
-					continue
+				var found []paneSnapshot
+				for _, p := range panes {
+					if session != "" && p.Session != session {
+						continue
+					}
+					if deadOnly && !p.Dead {
+						continue
+					}
+					if !matchesWindow(p, window) {
+						continue
+					}
+					if !matchesFilter(p.Command, command, fuzzy) {
+						continue
+					}
+					if !matchesFilter(p.Title, title, fuzzy) {
+						continue
+					}
+					if !matchesFilter(p.Path, path, fuzzy) {
+						continue
+					}
+					snap := toPaneSnapshot(p)
+					snap.Socket = socket
+					found = append(found, snap)
 				}
-				if !matchesFilter(p.Title, title, fuzzy) {
-					continue
+				return found, nil
+			}
+
+			items := make([]paneSnapshot, 0)
+			if allServers {
+				err := forEachServer(func(socket string) error {
+					found, err := collect(socket)
+					items = append(items, found...)
+					return err
+				})
+				if err != nil {
+					return err
 				}
-				if !matchesFilter(p.Path, path, fuzzy) {
-					continue
+			} else {
+				found, err := collect("")
+				if err != nil {
+					if err == tmux.ErrNoTmuxServer {
+						if !porcelain {
+							_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
+						}
+						return nil
+					}
+					return err
 				}
-				items = append(items, toPaneSnapshot(p))
+				items = append(items, found...)
 			}
 
 			sort.Slice(items, func(i, j int) bool {
+				if items[i].Socket != items[j].Socket {
+					return items[i].Socket < items[j].Socket
+				}
 				if items[i].Session != items[j].Session {
 					return items[i].Session < items[j].Session
 				}
@@ -102,6 +173,8 @@ func newPanesCmd() *cobra.Command {
 
 			out := cmd.OutOrStdout()
 			switch {
+			case porcelain:
+				return writePorcelain(out, paneSnapshotPorcelainRows(items))
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
@@ -124,44 +197,47 @@ func newPanesCmd() *cobra.Command {
 				return nil
 			}
 
-			_, _ = fmt.Fprintln(out, "Panes:")
+			headers := []string{"PANE", "ID", "STATE", "PID", "COMMAND", "WINDOW", "ACTIVITY", "TITLE", "PATH"}
+			if allServers {
+				headers = append([]string{"SERVER"}, headers...)
+			}
+			table := newTextTable(headers...)
 			for _, p := range items {
-				active := "inactive"
-				if p.Active {
-					active = "active"
-				}
-				winActive := "inactive"
+				window := p.WindowName
 				if p.WindowActive {
-					winActive = "active"
+					window += "*"
+				}
+				row := []tableCell{
+					cell(p.FormattedID),
+					cell(p.PaneID),
+					paneStateCell(p),
+					cell(strconv.Itoa(p.PID)),
+					cell(p.Command),
+					cell(window),
+					cell(formatRelative(p.ActivityAt)),
+					cell(p.Title),
+					cell(p.Path),
 				}
-				windowLabel := fmt.Sprintf("%s:%d", p.Session, p.WindowIndex)
-				if strings.TrimSpace(p.WindowName) != "" {
-					windowLabel = fmt.Sprintf("%s (%s)", windowLabel, p.WindowName)
+				if allServers {
+					row = append([]tableCell{cell(serverLabel(p.Socket))}, row...)
 				}
-				_, _ = fmt.Fprintf(out, "  %s  %s  pane=%d  pid=%d  cmd=%s  path=%s  title=%s  win=%s (%s)  activity=%s\n",
-					p.FormattedID,
-					active,
-					p.PaneIndex,
-					p.PID,
-					p.Command,
-					p.Path,
-					p.Title,
-					windowLabel,
-					winActive,
-					formatRelative(p.ActivityAt),
-				)
+				table.addRow(row...)
 			}
-			return nil
+			return table.render(out)
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	addPorcelainFlag(cmd, "panes", &porcelain)
+	addAllServersFlag(cmd, &allServers)
 	cmd.Flags().StringVar(&session, "session", "", "Filter by session name or selector (@current|@managed)")
-	cmd.Flags().IntVar(&window, "window", -1, "Filter by window index")
+	cmd.Flags().StringVar(&window, "window", "", "Filter by window index or name")
 	cmd.Flags().StringVar(&command, "command", "", "Filter by current command (substring)")
 	cmd.Flags().StringVar(&title, "title", "", "Filter by pane title (substring)")
 	cmd.Flags().StringVar(&path, "path", "", "Filter by pane path (substring)")
 	cmd.Flags().BoolVar(&fuzzy, "fuzzy", false, "Use fuzzy matching for command/title/path filters")
+	cmd.Flags().BoolVar(&deadOnly, "dead", false, "Only list dead panes (remain-on-exit) with their exit codes")
+	cmd.Flags().StringVar(&filterExpr, "filter", "", "Filter expression (e.g., 'command==\"node\" && idle>300')")
 	return cmd
 }
 
@@ -175,20 +251,47 @@ func matchesFilter(value string, filter string, fuzzy bool) bool {
 	return strings.Contains(strings.ToLower(value), strings.ToLower(filter))
 }
 
+// paneStateCell labels a pane active, inactive, or dead (with exit status).
+func paneStateCell(p paneSnapshot) tableCell {
+	switch {
+	case p.Dead:
+		return styledCell(fmt.Sprintf("dead(%d)", p.DeadStatus), styleError)
+	case p.Active:
+		return styledCell("active", styleActive)
+	default:
+		return styledCell("inactive", styleMuted)
+	}
+}
+
 func toPaneSnapshot(p tmux.PaneDetails) paneSnapshot {
-	return paneSnapshot{
-		Session:      p.Session,
-		WindowIndex:  p.WindowIndex,
-		WindowName:   p.WindowName,
-		WindowActive: p.WindowActive,
-		PaneIndex:    p.PaneIndex,
-		PaneID:       p.PaneID,
-		FormattedID:  fmt.Sprintf("%s:%d.%d", p.Session, p.WindowIndex, p.PaneIndex),
-		Active:       p.Active,
-		Command:      p.Command,
-		Title:        p.Title,
-		Path:         p.Path,
-		PID:          p.PID,
-		ActivityAt:   p.ActivityAt,
+	snap := paneSnapshot{
+		Session:         p.Session,
+		WindowIndex:     p.WindowIndex,
+		WindowName:      p.WindowName,
+		WindowActive:    p.WindowActive,
+		PaneIndex:       p.PaneIndex,
+		PaneID:          p.PaneID,
+		FormattedID:     fmt.Sprintf("%s:%d.%d", p.Session, p.WindowIndex, p.PaneIndex),
+		Active:          p.Active,
+		Command:         p.Command,
+		Title:           p.Title,
+		Path:            p.Path,
+		PID:             p.PID,
+		ActivityAt:      p.ActivityAt,
+		Dead:            p.Dead,
+		DeadStatus:      p.DeadStatus,
+		StartCommand:    p.StartCommand,
+		CursorX:         p.CursorX,
+		CursorY:         p.CursorY,
+		ScrollPosition:  p.ScrollPosition,
+		HistorySize:     p.HistorySize,
+		AlternateScreen: p.AlternateScreen,
+		ShellHook:       p.ShellHook,
+		LastCommand:     p.LastCommand,
+	}
+	if p.Dead {
+		code := p.ExitCode()
+		snap.ExitCode = &code
 	}
+	return snap
 }
@@ -0,0 +1,548 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// pipelineSpec is the YAML document accepted by "arc-tmux pipeline".
+type pipelineSpec struct {
+	Name     string           `yaml:"name"`
+	Defaults pipelineDefaults `yaml:"defaults"`
+	Steps    []pipelineStep   `yaml:"steps"`
+}
+
+type pipelineDefaults struct {
+	Pane    string   `yaml:"pane"`
+	Cwd     string   `yaml:"cwd"`
+	Env     []string `yaml:"env"`
+	Idle    float64  `yaml:"idle"`
+	Timeout float64  `yaml:"timeout"`
+	Retries int      `yaml:"retries"`
+	Lines   int      `yaml:"lines"`
+}
+
+type pipelineStep struct {
+	Name    string         `yaml:"name"`
+	Pane    string         `yaml:"pane"`
+	Run     string         `yaml:"run"`
+	Needs   []string       `yaml:"needs"`
+	Cwd     string         `yaml:"cwd"`
+	Env     []string       `yaml:"env"`
+	Idle    float64        `yaml:"idle"`
+	Timeout float64        `yaml:"timeout"`
+	Retries *int           `yaml:"retries"`
+	Lines   int            `yaml:"lines"`
+	Expect  pipelineExpect `yaml:"expect"`
+}
+
+type pipelineExpect struct {
+	ExitCode *int   `yaml:"exit_code"`
+	Match    string `yaml:"match"`
+	NotMatch string `yaml:"not_match"`
+}
+
+type pipelineReport struct {
+	Name             string               `json:"name,omitempty" yaml:"name,omitempty"`
+	Success          bool                 `json:"success" yaml:"success"`
+	StartedAt        time.Time            `json:"started_at" yaml:"started_at"`
+	DurationSeconds  float64              `json:"duration_seconds" yaml:"duration_seconds"`
+	DeadlineExceeded bool                 `json:"deadline_exceeded,omitempty" yaml:"deadline_exceeded,omitempty"`
+	Steps            []pipelineStepResult `json:"steps" yaml:"steps"`
+}
+
+type pipelineStepResult struct {
+	Name            string  `json:"name" yaml:"name"`
+	Pane            string  `json:"pane" yaml:"pane"`
+	Status          string  `json:"status" yaml:"status"`
+	Attempts        int     `json:"attempts" yaml:"attempts"`
+	ExitCode        *int    `json:"exit_code,omitempty" yaml:"exit_code,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds" yaml:"duration_seconds"`
+	Error           string  `json:"error,omitempty" yaml:"error,omitempty"`
+	Output          string  `json:"output,omitempty" yaml:"output,omitempty"`
+}
+
+const (
+	stepSucceeded = "succeeded"
+	stepFailed    = "failed"
+	stepSkipped   = "skipped"
+)
+
+func newPipelineCmd() *cobra.Command {
+	var dryRun bool
+	var deadline float64
+	var progressOpts progressOptions
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "pipeline <file.yaml|->",
+		Short: "Run a DAG of commands across panes",
+		Long: `Execute a pipeline of steps defined in YAML. Each step runs a command in a
+pane (like "run --exit-code"), may depend on other steps via "needs", and
+succeeds when its exit code and output match "expect". Steps whose
+dependencies are satisfied run concurrently; steps sharing a pane run one at a
+time. Steps depending on a failed step are skipped. A step's "timeout" bounds
+each attempt; --deadline bounds the whole pipeline, and steps not started
+before it passes are skipped.
+
+  name: release
+  defaults:
+    pane: dev:1.0
+    timeout: 300
+  steps:
+    - name: deps
+      run: npm ci
+    - name: test
+      run: npm test
+      needs: [deps]
+      retries: 1
+      expect:
+        exit_code: 0
+        not_match: "FAIL"
+    - name: lint
+      pane: dev:1.1
+      run: npm run lint
+      needs: [deps]`,
+		Example: `  arc-tmux pipeline release.yaml
+  arc-tmux pipeline release.yaml --dry-run
+  arc-tmux pipeline release.yaml --deadline 600
+  arc-tmux pipeline - --output json < release.yaml`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return err
+			}
+			spec, err := parsePipeline(data)
+			if err != nil {
+				return newCodedError(errInvalidPipeline, err.Error(), nil)
+			}
+			waves, err := pipelineWaves(spec.Steps)
+			if err != nil {
+				return newCodedError(errInvalidPipeline, err.Error(), nil)
+			}
+
+			if dryRun {
+				return writePipelinePlan(cmd, outputOpts, spec, waves)
+			}
+
+			if deadline > 0 {
+				tmux.SetDeadline(time.Now().Add(time.Duration(deadline * float64(time.Second))))
+				defer tmux.SetDeadline(time.Time{})
+			}
+			progress, err := progressOpts.open(cmd)
+			if err != nil {
+				return err
+			}
+			report, err := executePipeline(spec, waves, progress)
+			if err != nil {
+				return err
+			}
+			if err := writePipelineReport(cmd, outputOpts, report); err != nil {
+				return err
+			}
+			if report.DeadlineExceeded {
+				return newCodedError(errDeadlineExceeded, "pipeline did not finish before --deadline", nil)
+			}
+			if !report.Success {
+				return newCodedError(errPipelineFailed, "one or more pipeline steps failed", nil)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the pipeline and print the execution order")
+	progressOpts.addFlags(cmd)
+	cmd.Flags().Float64Var(&deadline, "deadline", 0, "Maximum seconds for the whole pipeline; unstarted steps are skipped when hit (0 for none)")
+	return cmd
+}
+
+// parsePipeline decodes and validates a pipeline document.
+func parsePipeline(data []byte) (pipelineSpec, error) {
+	var spec pipelineSpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		return pipelineSpec{}, fmt.Errorf("parse pipeline: %w", err)
+	}
+	if len(spec.Steps) == 0 {
+		return pipelineSpec{}, fmt.Errorf("pipeline has no steps")
+	}
+	if spec.Defaults.Retries < 0 {
+		return pipelineSpec{}, fmt.Errorf("defaults: retries must be >= 0")
+	}
+	seen := make(map[string]bool, len(spec.Steps))
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		step.Name = strings.TrimSpace(step.Name)
+		if step.Name == "" {
+			return pipelineSpec{}, fmt.Errorf("step %d: name is required", i+1)
+		}
+		if seen[step.Name] {
+			return pipelineSpec{}, fmt.Errorf("step %q: duplicate name", step.Name)
+		}
+		seen[step.Name] = true
+		if strings.TrimSpace(step.Run) == "" {
+			return pipelineSpec{}, fmt.Errorf("step %q: run is required", step.Name)
+		}
+		if step.Retries != nil && *step.Retries < 0 {
+			return pipelineSpec{}, fmt.Errorf("step %q: retries must be >= 0", step.Name)
+		}
+		applyPipelineDefaults(step, spec.Defaults)
+		if strings.TrimSpace(step.Pane) == "" {
+			return pipelineSpec{}, fmt.Errorf("step %q: pane is required (set it on the step or in defaults)", step.Name)
+		}
+		for _, pattern := range []string{step.Expect.Match, step.Expect.NotMatch} {
+			if pattern == "" {
+				continue
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return pipelineSpec{}, fmt.Errorf("step %q: invalid regex %q: %w", step.Name, pattern, err)
+			}
+		}
+	}
+	return spec, nil
+}
+
+func applyPipelineDefaults(step *pipelineStep, defaults pipelineDefaults) {
+	if step.Pane == "" {
+		step.Pane = defaults.Pane
+	}
+	if step.Cwd == "" {
+		step.Cwd = defaults.Cwd
+	}
+	step.Env = append(append([]string(nil), defaults.Env...), step.Env...)
+	if step.Idle <= 0 {
+		step.Idle = defaults.Idle
+	}
+	if step.Idle <= 0 {
+		step.Idle = 2
+	}
+	if step.Timeout <= 0 {
+		step.Timeout = defaults.Timeout
+	}
+	if step.Retries == nil {
+		retries := defaults.Retries
+		step.Retries = &retries
+	}
+	if step.Lines == 0 {
+		step.Lines = defaults.Lines
+	}
+}
+
+// pipelineWaves groups steps into dependency levels; every step in a wave
+// depends only on steps from earlier waves. Cycles and unknown needs are errors.
+func pipelineWaves(steps []pipelineStep) ([][]string, error) {
+	known := make(map[string]bool, len(steps))
+	for _, s := range steps {
+		known[s.Name] = true
+	}
+	remaining := make(map[string][]string, len(steps))
+	for _, s := range steps {
+		for _, dep := range s.Needs {
+			if !known[dep] {
+				return nil, fmt.Errorf("step %q needs unknown step %q", s.Name, dep)
+			}
+		}
+		remaining[s.Name] = s.Needs
+	}
+	done := make(map[string]bool, len(steps))
+	var waves [][]string
+	for len(remaining) > 0 {
+		var wave []string
+		for name, needs := range remaining {
+			ready := true
+			for _, dep := range needs {
+				if !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				wave = append(wave, name)
+			}
+		}
+		if len(wave) == 0 {
+			var stuck []string
+			for name := range remaining {
+				stuck = append(stuck, name)
+			}
+			sort.Strings(stuck)
+			return nil, fmt.Errorf("dependency cycle among steps: %s", strings.Join(stuck, ", "))
+		}
+		sort.Strings(wave)
+		for _, name := range wave {
+			done[name] = true
+			delete(remaining, name)
+		}
+		waves = append(waves, wave)
+	}
+	return waves, nil
+}
+
+func executePipeline(spec pipelineSpec, waves [][]string, progress *progressWriter) (pipelineReport, error) {
+	byName := make(map[string]pipelineStep, len(spec.Steps))
+	panes := make(map[string]string, len(spec.Steps))
+	for _, s := range spec.Steps {
+		byName[s.Name] = s
+		raw, err := resolvePaneTarget(s.Pane)
+		if err != nil {
+			return pipelineReport{}, fmt.Errorf("step %q: %w", s.Name, err)
+		}
+		handle, err := canonicalPaneTarget(raw)
+		if err != nil {
+			return pipelineReport{}, fmt.Errorf("step %q: %w", s.Name, err)
+		}
+		panes[s.Name] = handle.ID
+	}
+
+	report := pipelineReport{Name: spec.Name, Success: true, StartedAt: time.Now()}
+	results := make(map[string]pipelineStepResult, len(spec.Steps))
+	paneLocks := make(map[string]*sync.Mutex)
+	for _, id := range panes {
+		if paneLocks[id] == nil {
+			paneLocks[id] = &sync.Mutex{}
+		}
+	}
+
+	var mu sync.Mutex
+	for _, wave := range waves {
+		var wg sync.WaitGroup
+		for _, name := range wave {
+			step := byName[name]
+			mu.Lock()
+			reason := ""
+			if blocker := failedDependency(step, results); blocker != "" {
+				reason = fmt.Sprintf("dependency %q did not succeed", blocker)
+			} else if tmux.DeadlineExceeded() {
+				reason = "deadline exceeded"
+			}
+			if reason != "" {
+				results[name] = pipelineStepResult{
+					Name:   name,
+					Pane:   step.Pane,
+					Status: stepSkipped,
+					Error:  reason,
+				}
+				progress.emit(progressEvent{Event: "step_finished", Step: name, Pane: step.Pane, Status: stepSkipped, Error: reason})
+			}
+			mu.Unlock()
+			if reason != "" {
+				continue
+			}
+			wg.Add(1)
+			go func(step pipelineStep, paneID string) {
+				defer wg.Done()
+				lock := paneLocks[paneID]
+				lock.Lock()
+				res := runPipelineStep(step, paneID, progress.with(step.Name, step.Pane))
+				lock.Unlock()
+				mu.Lock()
+				results[step.Name] = res
+				mu.Unlock()
+			}(step, panes[name])
+		}
+		wg.Wait()
+	}
+
+	for _, s := range spec.Steps {
+		res := results[s.Name]
+		if res.Status != stepSucceeded {
+			report.Success = false
+		}
+		report.Steps = append(report.Steps, res)
+	}
+	report.DurationSeconds = time.Since(report.StartedAt).Seconds()
+	report.DeadlineExceeded = tmux.DeadlineExceeded()
+	status := stepSucceeded
+	if !report.Success {
+		status = stepFailed
+	}
+	progress.emit(progressEvent{Event: "pipeline_finished", Status: status})
+	return report, nil
+}
+
+func failedDependency(step pipelineStep, results map[string]pipelineStepResult) string {
+	for _, dep := range step.Needs {
+		if results[dep].Status != stepSucceeded {
+			return dep
+		}
+	}
+	return ""
+}
+
+func runPipelineStep(step pipelineStep, paneID string, progress *progressWriter) (res pipelineStepResult) {
+	res = pipelineStepResult{Name: step.Name, Pane: step.Pane}
+	start := time.Now()
+	defer func() {
+		res.DurationSeconds = time.Since(start).Seconds()
+		progress.emit(progressEvent{Event: "step_finished", Attempt: res.Attempts, Status: res.Status, ExitCode: res.ExitCode, Error: res.Error})
+	}()
+
+	envPairs, err := parseEnvVars(step.Env)
+	if err != nil {
+		res.Status = stepFailed
+		res.Error = err.Error()
+		return res
+	}
+	text := buildRunCommand(step.Run, strings.TrimSpace(step.Cwd), envPairs)
+	opts := runOptions{
+		Idle:     step.Idle,
+		Timeout:  step.Timeout,
+		Lines:    step.Lines,
+		ExitCode: true,
+		ExitTag:  "__ARC_TMUX_EXIT:",
+		Segment:  true,
+		// Idle detection can fire before a fast command prints anything;
+		// the end sentinel is authoritative.
+		UntilMarker: true,
+		Progress:    progress,
+	}
+
+	for attempt := 0; attempt <= *step.Retries; attempt++ {
+		res.Attempts = attempt + 1
+		progress.emit(progressEvent{Event: "step_started", Attempt: res.Attempts})
+		run, waitErr, err := executeRun(paneID, text, opts)
+		if err != nil {
+			res.Status = stepFailed
+			res.Error = err.Error()
+			if tmux.DeadlineExceeded() {
+				// A tmux call killed at the deadline reports a context error.
+				res.Error = tmux.ErrDeadlineExceeded.Error()
+			}
+			return res
+		}
+		res.Output = run.Output
+		res.ExitCode = run.ExitCode
+		if msg := checkPipelineStep(step.Expect, run, waitErr); msg != "" {
+			res.Status = stepFailed
+			res.Error = msg
+			if tmux.DeadlineExceeded() {
+				return res
+			}
+			continue
+		}
+		res.Status = stepSucceeded
+		res.Error = ""
+		return res
+	}
+	return res
+}
+
+// checkPipelineStep returns a failure reason, or "" when the run meets expect.
+func checkPipelineStep(expect pipelineExpect, run runResult, waitErr error) string {
+	if waitErr != nil {
+		return "timeout: " + waitErr.Error()
+	}
+	want := 0
+	if expect.ExitCode != nil {
+		want = *expect.ExitCode
+	}
+	if !run.ExitFound || run.ExitCode == nil {
+		return "exit code not found"
+	}
+	if *run.ExitCode != want {
+		return fmt.Sprintf("exit code %d (expected %d)", *run.ExitCode, want)
+	}
+	if expect.Match != "" && !regexp.MustCompile(expect.Match).MatchString(run.Output) {
+		return fmt.Sprintf("output did not match %q", expect.Match)
+	}
+	if expect.NotMatch != "" && regexp.MustCompile(expect.NotMatch).MatchString(run.Output) {
+		return fmt.Sprintf("output matched %q", expect.NotMatch)
+	}
+	return ""
+}
+
+func writePipelinePlan(cmd *cobra.Command, outputOpts output.OutputOptions, spec pipelineSpec, waves [][]string) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(waves)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(waves)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, wave := range waves {
+			_, _ = fmt.Fprintln(out, strings.Join(wave, " "))
+		}
+		return nil
+	}
+	title := spec.Name
+	if title == "" {
+		title = "pipeline"
+	}
+	_, _ = fmt.Fprintf(out, "[dry-run] %s: %d steps in %d waves\n", title, len(spec.Steps), len(waves))
+	for i, wave := range waves {
+		_, _ = fmt.Fprintf(out, "  %d. %s\n", i+1, strings.Join(wave, ", "))
+	}
+	return nil
+}
+
+func writePipelineReport(cmd *cobra.Command, outputOpts output.OutputOptions, report pipelineReport) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(report)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, s := range report.Steps {
+			_, _ = fmt.Fprintf(out, "%s %s\n", s.Name, s.Status)
+		}
+		return nil
+	}
+	for _, s := range report.Steps {
+		line := fmt.Sprintf("%-9s %s (%s", s.Status, s.Name, s.Pane)
+		if s.Attempts > 0 {
+			line += fmt.Sprintf(", %d attempt(s), %.1fs", s.Attempts, s.DurationSeconds)
+		}
+		line += ")"
+		if s.Error != "" {
+			line += ": " + s.Error
+		}
+		_, _ = fmt.Fprintln(out, line)
+	}
+	status := "succeeded"
+	if !report.Success {
+		status = "failed"
+	}
+	if report.DeadlineExceeded {
+		status = "hit its deadline"
+	}
+	_, _ = fmt.Fprintf(out, "Pipeline %s in %.1fs.\n", status, report.DurationSeconds)
+	return nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePipelineDefaults(t *testing.T) {
+	spec, err := parsePipeline([]byte(`
+name: ci
+defaults:
+  pane: dev:1.0
+  timeout: 120
+  retries: 2
+  env: [CI=1]
+steps:
+  - name: build
+    run: make
+  - name: test
+    run: make test
+    pane: dev:1.1
+    retries: 0
+    env: [VERBOSE=1]
+    needs: [build]
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	build, test := spec.Steps[0], spec.Steps[1]
+	if build.Pane != "dev:1.0" || build.Timeout != 120 || *build.Retries != 2 || build.Idle != 2 {
+		t.Fatalf("defaults not applied: %+v", build)
+	}
+	if test.Pane != "dev:1.1" || *test.Retries != 0 {
+		t.Fatalf("step overrides lost: %+v", test)
+	}
+	if !reflect.DeepEqual(test.Env, []string{"CI=1", "VERBOSE=1"}) {
+		t.Fatalf("unexpected env: %v", test.Env)
+	}
+}
+
+func TestParsePipelineErrors(t *testing.T) {
+	cases := map[string]string{
+		"steps: []":                  "no steps",
+		"steps: [{name: a}]":         "run is required",
+		"steps: [{name: a, run: x}]": "pane is required",
+		"steps: [{name: a, run: x, pane: p}, {name: a, run: y, pane: p}]": "duplicate",
+		"steps: [{name: a, run: x, pane: p, bogus: 1}]":                   "bogus",
+		"steps: [{name: a, run: x, pane: p, expect: {match: '('}}]":       "invalid regex",
+		"steps: [{name: a, run: x, pane: p, retries: -1}]":                `step "a": retries must be >= 0`,
+		"defaults: {retries: -2}\nsteps: [{name: a, run: x, pane: p}]":    "defaults: retries must be >= 0",
+	}
+	for doc, want := range cases {
+		if _, err := parsePipeline([]byte(doc)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%s: expected error containing %q, got %v", doc, want, err)
+		}
+	}
+}
+
+func TestPipelineWaves(t *testing.T) {
+	steps := []pipelineStep{
+		{Name: "deploy", Needs: []string{"test", "lint"}},
+		{Name: "lint", Needs: []string{"deps"}},
+		{Name: "deps"},
+		{Name: "test", Needs: []string{"deps"}},
+	}
+	waves, err := pipelineWaves(steps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]string{{"deps"}, {"lint", "test"}, {"deploy"}}
+	if !reflect.DeepEqual(waves, want) {
+		t.Fatalf("expected %v, got %v", want, waves)
+	}
+
+	if _, err := pipelineWaves([]pipelineStep{{Name: "a", Needs: []string{"b"}}, {Name: "b", Needs: []string{"a"}}}); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	if _, err := pipelineWaves([]pipelineStep{{Name: "a", Needs: []string{"missing"}}}); err == nil {
+		t.Fatal("expected unknown dependency error")
+	}
+}
+
+func TestCheckPipelineStep(t *testing.T) {
+	zero, one := 0, 1
+	ok := runResult{Output: "all tests passed\n", ExitCode: &zero, ExitFound: true}
+	if msg := checkPipelineStep(pipelineExpect{Match: "passed"}, ok, nil); msg != "" {
+		t.Fatalf("expected success, got %q", msg)
+	}
+	if msg := checkPipelineStep(pipelineExpect{NotMatch: "passed"}, ok, nil); msg == "" {
+		t.Fatal("expected not_match failure")
+	}
+	if msg := checkPipelineStep(pipelineExpect{}, runResult{ExitCode: &one, ExitFound: true}, nil); !strings.Contains(msg, "exit code 1") {
+		t.Fatalf("expected exit code failure, got %q", msg)
+	}
+	if msg := checkPipelineStep(pipelineExpect{ExitCode: &one}, runResult{ExitCode: &one, ExitFound: true}, nil); msg != "" {
+		t.Fatalf("expected custom exit code to pass, got %q", msg)
+	}
+	if msg := checkPipelineStep(pipelineExpect{}, ok, errors.New("timed out")); !strings.HasPrefix(msg, "timeout") {
+		t.Fatalf("expected timeout failure, got %q", msg)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// porcelainFields is the stable --porcelain contract: one tab-separated line
+// per item with these fields in this order. Fields may be appended in later
+// releases but are never removed or reordered.
+var porcelainFields = map[string][]string{
+	"alias list": {"name", "target", "scope"},
+	"list":       {"formatted_id", "active", "command", "title"},
+	"locate":     {"formatted_id", "pane_id", "session", "window_index", "pane_index", "active", "pid", "command", "path", "title"},
+	"panes":      {"formatted_id", "pane_id", "session", "window_index", "pane_index", "active", "pid", "command", "path", "title"},
+	"sessions":   {"name", "windows", "attached", "created_at", "activity_at"},
+	"windows":    {"session", "window_index", "active", "name"},
+}
+
+func addPorcelainFlag(cmd *cobra.Command, command string, porcelain *bool) {
+	usage := fmt.Sprintf("Stable tab-separated output (fields: %s)", strings.Join(porcelainFields[command], ", "))
+	cmd.Flags().BoolVar(porcelain, "porcelain", false, usage)
+}
+
+// checkPorcelain rejects --porcelain combined with an explicit --output.
+func checkPorcelain(cmd *cobra.Command, porcelain bool) error {
+	if f := cmd.Flags().Lookup("output"); porcelain && f != nil && f.Changed {
+		return fmt.Errorf("use either --porcelain or --output, not both")
+	}
+	return nil
+}
+
+// writePorcelain writes rows as tab-separated lines. Empty values are written
+// as "-"; tabs, newlines, and backslashes inside values are escaped.
+func writePorcelain(out io.Writer, rows [][]string) error {
+	escape := strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+	var b strings.Builder
+	for _, row := range rows {
+		for i, value := range row {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			if value == "" {
+				value = "-"
+			}
+			b.WriteString(escape.Replace(value))
+		}
+		b.WriteByte('\n')
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func porcelainBool(v bool) string {
+	if v {
+		return "1"
+	}
+	return "0"
+}
+
+func porcelainTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func paneSnapshotPorcelainRows(items []paneSnapshot) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, p := range items {
+		rows = append(rows, []string{
+			p.FormattedID, p.PaneID, p.Session, strconv.Itoa(p.WindowIndex), strconv.Itoa(p.PaneIndex),
+			porcelainBool(p.Active), strconv.Itoa(p.PID), p.Command, p.Path, p.Title,
+		})
+	}
+	return rows
+}
+
+func sessionPorcelainRows(items []sessionInfo) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, s := range items {
+		rows = append(rows, []string{
+			s.Name, strconv.Itoa(s.Windows), strconv.Itoa(s.Attached), porcelainTime(s.CreatedAt), porcelainTime(s.ActivityAt),
+		})
+	}
+	return rows
+}
+
+func windowPorcelainRows(wins []tmux.Window) [][]string {
+	rows := make([][]string, 0, len(wins))
+	for _, w := range wins {
+		rows = append(rows, []string{w.Session, strconv.Itoa(w.WindowIndex), porcelainBool(w.Active), w.Name})
+	}
+	return rows
+}
+
+func paneInfoPorcelainRows(panes []paneInfo) [][]string {
+	rows := make([][]string, 0, len(panes))
+	for _, p := range panes {
+		rows = append(rows, []string{p.FormattedID, porcelainBool(p.Active), p.Command, p.Title})
+	}
+	return rows
+}
+
+func aliasPorcelainRows(entries []aliasEntry) [][]string {
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{e.Name, e.Target, e.Scope})
+	}
+	return rows
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestWritePorcelain(t *testing.T) {
+	var buf strings.Builder
+	rows := [][]string{{"dev:0.1", "", "tab\there", `back\slash`, "two\nlines"}}
+	if err := writePorcelain(&buf, rows); err != nil {
+		t.Fatalf("writePorcelain: %v", err)
+	}
+	want := "dev:0.1\t-\ttab\\there\tback\\\\slash\ttwo\\nlines\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPorcelainRowsMatchFields(t *testing.T) {
+	rows := map[string][][]string{
+		"alias list": aliasPorcelainRows([]aliasEntry{{Name: "api", Target: "dev:0.1", Scope: "global"}}),
+		"list":       paneInfoPorcelainRows([]paneInfo{{FormattedID: "dev:0.1"}}),
+		"locate":     paneSnapshotPorcelainRows([]paneSnapshot{{FormattedID: "dev:0.1"}}),
+		"panes":      paneSnapshotPorcelainRows([]paneSnapshot{{FormattedID: "dev:0.1"}}),
+		"sessions":   sessionPorcelainRows([]sessionInfo{{Name: "dev", CreatedAt: time.Unix(0, 0)}}),
+		"windows":    windowPorcelainRows([]tmux.Window{{Session: "dev"}}),
+	}
+	for command, fields := range porcelainFields {
+		got, ok := rows[command]
+		if !ok {
+			t.Fatalf("no row builder checked for %q", command)
+		}
+		if len(got[0]) != len(fields) {
+			t.Fatalf("%s: %d values for %d documented fields", command, len(got[0]), len(fields))
+		}
+	}
+	if got := rows["sessions"][0][3]; got != "1970-01-01T00:00:00Z" {
+		t.Fatalf("expected RFC3339 UTC time, got %q", got)
+	}
+}
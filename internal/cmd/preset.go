@@ -0,0 +1,454 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// windowPreset describes a multi-pane window: the first slot is the window's
+// initial pane and each later slot splits an earlier one.
+type windowPreset struct {
+	Name        string       `json:"name" yaml:"name"`
+	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	Slots       []presetSlot `json:"slots" yaml:"slots"`
+	// Layout, when set, is a tmux layout applied after all splits.
+	Layout string `json:"layout,omitempty" yaml:"layout,omitempty"`
+	Source string `json:"source" yaml:"source,omitempty"`
+}
+
+type presetSlot struct {
+	Name string `json:"name" yaml:"name"`
+	// From is the slot this one splits; empty means the previous slot.
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+	// Split is h (side by side) or v (stacked, the default).
+	Split string `json:"split,omitempty" yaml:"split,omitempty"`
+	// Percent sizes the new pane relative to the pane it splits.
+	Percent int    `json:"percent,omitempty" yaml:"percent,omitempty"`
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+type presetResult struct {
+	Session     string           `json:"session" yaml:"session"`
+	Window      string           `json:"window" yaml:"window"`
+	WindowIndex int              `json:"window_index" yaml:"window_index"`
+	Preset      string           `json:"preset" yaml:"preset"`
+	Slots       []presetSlotPane `json:"slots" yaml:"slots"`
+}
+
+// presetSlotPane maps a preset slot to the pane created for it.
+type presetSlotPane struct {
+	Slot    string `json:"slot" yaml:"slot"`
+	PaneID  string `json:"pane_id" yaml:"pane_id"`
+	ID      string `json:"id" yaml:"id"`
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+func builtinPresets() []windowPreset {
+	return []windowPreset{
+		{
+			Name:        "ide",
+			Description: "Editor on top, server and logs side by side below",
+			Slots: []presetSlot{
+				{Name: "editor"},
+				{Name: "server", From: "editor", Split: "v", Percent: 30},
+				{Name: "logs", From: "server", Split: "h", Percent: 50},
+			},
+		},
+		{
+			Name:        "quad",
+			Description: "Four equal panes",
+			Slots: []presetSlot{
+				{Name: "top-left"},
+				{Name: "top-right", From: "top-left", Split: "h", Percent: 50},
+				{Name: "bottom-left", From: "top-left", Split: "v", Percent: 50},
+				{Name: "bottom-right", From: "top-right", Split: "v", Percent: 50},
+			},
+		},
+		{
+			Name:        "main-vertical",
+			Description: "A large main pane with two stacked panes on the right",
+			Slots: []presetSlot{
+				{Name: "main"},
+				{Name: "side-top", From: "main", Split: "h", Percent: 35},
+				{Name: "side-bottom", From: "side-top", Split: "v", Percent: 50},
+			},
+		},
+		{
+			Name:        "dev",
+			Description: "Editor on top; server, tests, and a shell side by side below",
+			Slots: []presetSlot{
+				{Name: "editor"},
+				{Name: "server", From: "editor", Split: "v", Percent: 35},
+				{Name: "test", From: "server", Split: "h", Percent: 67},
+				{Name: "shell", From: "test", Split: "h", Percent: 50},
+			},
+		},
+	}
+}
+
+// allPresets returns built-in presets overridden by the config's presets.
+func allPresets() ([]windowPreset, error) {
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]windowPreset{}
+	for _, p := range builtinPresets() {
+		p.Source = recipeSourceBuiltin
+		byName[p.Name] = p
+	}
+	for _, p := range cfg.Presets {
+		p.Name = strings.TrimSpace(p.Name)
+		p.Source = recipeSourceConfig
+		if err := validatePreset(p); err != nil {
+			return nil, newCodedError(errInvalidPreset, err.Error(), err)
+		}
+		byName[p.Name] = p
+	}
+	presets := make([]windowPreset, 0, len(byName))
+	for _, p := range byName {
+		presets = append(presets, p)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets, nil
+}
+
+func findPreset(name string) (windowPreset, error) {
+	presets, err := allPresets()
+	if err != nil {
+		return windowPreset{}, err
+	}
+	trimmed := strings.TrimSpace(name)
+	names := make([]string, 0, len(presets))
+	for _, p := range presets {
+		if p.Name == trimmed {
+			return p, nil
+		}
+		names = append(names, p.Name)
+	}
+	return windowPreset{}, newCodedError(errInvalidPreset, fmt.Sprintf("unknown preset %q (available: %s)", trimmed, strings.Join(names, ", ")), nil)
+}
+
+func validatePreset(p windowPreset) error {
+	if p.Name == "" {
+		return fmt.Errorf("preset name is required")
+	}
+	if len(p.Slots) == 0 {
+		return fmt.Errorf("preset %q has no slots", p.Name)
+	}
+	seen := map[string]bool{}
+	for i, slot := range p.Slots {
+		name := strings.TrimSpace(slot.Name)
+		if name == "" {
+			return fmt.Errorf("preset %q: slot %d has no name", p.Name, i+1)
+		}
+		if seen[name] {
+			return fmt.Errorf("preset %q: duplicate slot %q", p.Name, name)
+		}
+		if i == 0 && (slot.From != "" || slot.Split != "" || slot.Percent != 0) {
+			return fmt.Errorf("preset %q: first slot %q is the window's pane and cannot split", p.Name, name)
+		}
+		if slot.From != "" && !seen[slot.From] {
+			return fmt.Errorf("preset %q: slot %q splits %q, which is not an earlier slot", p.Name, name, slot.From)
+		}
+		if slot.Split != "" && slot.Split != "h" && slot.Split != "v" {
+			return fmt.Errorf("preset %q: slot %q has invalid split %q (use h or v)", p.Name, name, slot.Split)
+		}
+		if slot.Percent < 0 || slot.Percent > 99 {
+			return fmt.Errorf("preset %q: slot %q percent must be between 1 and 99", p.Name, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// parseSlotCommands parses repeated slot=command flags.
+func parseSlotCommands(raw []string, preset windowPreset) (map[string]string, error) {
+	known := map[string]bool{}
+	for _, slot := range preset.Slots {
+		known[slot.Name] = true
+	}
+	commands := map[string]string{}
+	for _, item := range raw {
+		slot, command, ok := strings.Cut(item, "=")
+		slot = strings.TrimSpace(slot)
+		if !ok || slot == "" {
+			return nil, fmt.Errorf("invalid --cmd %q; expected SLOT=COMMAND", item)
+		}
+		if !known[slot] {
+			return nil, newCodedError(errInvalidPreset, fmt.Sprintf("preset %q has no slot %q", preset.Name, slot), nil)
+		}
+		commands[slot] = command
+	}
+	return commands, nil
+}
+
+// buildPresetWindow creates window in session and splits it per the preset,
+// titling each pane with its slot name. commands override slot commands.
+func buildPresetWindow(session string, window string, preset windowPreset, commands map[string]string, cwd string, env []envVar) (presetResult, error) {
+	result := presetResult{Session: session, Window: window, Preset: preset.Name, Slots: []presetSlotPane{}}
+	ids := map[string]string{}
+	// Slots start as shells and get their commands once every split exists,
+	// so a command that exits at once cannot take its pane out from under a
+	// later split.
+	shellCommand := buildRunCommand("", "", env)
+	for i, slot := range preset.Slots {
+		var target string
+		var err error
+		if i == 0 {
+			target, err = tmux.NewWindowDir(session, window, shellCommand, cwd)
+		} else {
+			from := slot.From
+			if from == "" {
+				from = preset.Slots[i-1].Name
+			}
+			split := slot.Split
+			if split == "" {
+				split = "v"
+			}
+			target, err = tmux.SplitWindowPercent(ids[from], split, slot.Percent, shellCommand, cwd)
+		}
+		if err != nil {
+			return result, fmt.Errorf("slot %q: %w", slot.Name, err)
+		}
+		// Later splits renumber panes, so keep the stable pane id.
+		handle, err := canonicalPaneTarget(target)
+		if err != nil {
+			return result, err
+		}
+		if err := tmux.SetPaneTitle(handle.ID, slot.Name); err != nil {
+			return result, err
+		}
+		ids[slot.Name] = handle.ID
+		command := slot.Command
+		if override, ok := commands[slot.Name]; ok {
+			command = override
+		}
+		result.Slots = append(result.Slots, presetSlotPane{Slot: slot.Name, ID: handle.ID, Command: strings.TrimSpace(command)})
+	}
+
+	if len(result.Slots) > 0 && isAgentSessionName(session) {
+		pane, err := tmux.PaneDetailsForTarget(result.Slots[0].ID)
+		if err != nil {
+			return result, err
+		}
+		if err := tmux.ApplyAgentWindowStyle(session, pane.WindowIndex); err != nil {
+			return result, err
+		}
+	}
+	if preset.Layout != "" {
+		first := result.Slots[0].ID
+		if err := selectLayoutUndoable(first, preset.Layout); err != nil {
+			return result, err
+		}
+	}
+	for i := range result.Slots {
+		pane, err := tmux.PaneDetailsForTarget(result.Slots[i].ID)
+		if err != nil {
+			return result, err
+		}
+		result.Slots[i].PaneID = formattedPaneID(&pane)
+		result.WindowIndex = pane.WindowIndex
+	}
+	for _, slot := range result.Slots {
+		if slot.Command != "" {
+			if err := tmux.RespawnPaneDir(slot.ID, buildRunCommand(slot.Command, "", env), cwd); err != nil {
+				return result, fmt.Errorf("slot %q: %w", slot.Slot, err)
+			}
+		}
+	}
+	if len(result.Slots) > 0 {
+		_ = tmux.SelectPane(result.Slots[0].ID)
+	}
+	return result, nil
+}
+
+// matchPresetSlots maps slots to existing panes titled with the slot name,
+// skipping slots with no such pane.
+func matchPresetSlots(preset windowPreset, panes []tmux.PaneDetails) []presetSlotPane {
+	var slots []presetSlotPane
+	for _, slot := range preset.Slots {
+		if match := findPaneByTitle(panes, slot.Name); match != nil {
+			slots = append(slots, presetSlotPane{Slot: slot.Name, PaneID: formattedPaneID(match), ID: match.PaneID})
+		}
+	}
+	return slots
+}
+
+func newPresetCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var window string
+	var cwd string
+	var envVars []string
+	var slotCommands []string
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "preset <name>",
+		Short: "Build a window from a multi-pane preset",
+		Long: `Create a window split into named slots from a preset. Built-in presets:
+
+  ide            editor on top, server and logs side by side below
+  quad           four equal panes
+  main-vertical  a large main pane with two stacked panes on the right
+  dev            editor on top; server, test, and shell below (used by "dev")
+
+Each pane is titled with its slot name, so "alias set-from-window" can turn
+the slots into aliases; the slot-to-pane mapping is also printed. --cmd
+SLOT=COMMAND starts a command in a slot (repeatable).
+
+Presets are read from the "presets" list in the config file and override
+built-ins of the same name:
+
+  presets:
+    - name: api
+      layout: main-horizontal
+      slots:
+        - name: editor
+          command: nvim .
+        - name: server
+          split: v
+          percent: 30
+          command: npm run dev
+        - name: shell
+          from: server
+          split: h
+
+Each slot after the first splits the slot named by "from" (default: the
+previous slot), "h" side by side or "v" stacked (default), with the new pane
+taking "percent" of it.`,
+		Example: `  arc-tmux preset ide --session dev --cmd editor="nvim ." --cmd server="npm run dev"
+  arc-tmux preset quad --window checks --output json
+  arc-tmux preset --list`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if list {
+				presets, err := allPresets()
+				if err != nil {
+					return err
+				}
+				return writePresetList(cmd, outputOpts, presets)
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("preset name is required (see --list)")
+			}
+			preset, err := findPreset(args[0])
+			if err != nil {
+				return err
+			}
+			commands, err := parseSlotCommands(slotCommands, preset)
+			if err != nil {
+				return err
+			}
+			envPairs, err := parseEnvVars(envVars)
+			if err != nil {
+				return newCodedError(errInvalidEnv, err.Error(), err)
+			}
+			startDir, err := resolveStartDir(cwd)
+			if err != nil {
+				return err
+			}
+			sess, shouldStyle, err := resolveEnsureSession(session)
+			if err != nil {
+				return err
+			}
+			exists, err := tmux.HasSession(sess)
+			if err != nil {
+				return err
+			}
+			if err := tmux.EnsureSession(sess); err != nil {
+				return fmt.Errorf("failed to ensure session %q: %w", sess, err)
+			}
+			if !exists {
+				if err := applyAgentStyleIfNeeded(sess, shouldStyle); err != nil {
+					return err
+				}
+			}
+			if strings.TrimSpace(window) == "" {
+				window = preset.Name
+			}
+			result, err := buildPresetWindow(sess, strings.TrimSpace(window), preset, commands, startDir, envPairs)
+			if err != nil {
+				return err
+			}
+			return writePresetResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Session name or selector (@current|@managed)")
+	cmd.Flags().StringVar(&window, "window", "", "Name for the new window (default: the preset name)")
+	cmd.Flags().StringVar(&cwd, "cwd", "", "Working directory for the new panes")
+	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Set environment variables for the new panes (KEY=VAL). Repeatable.")
+	cmd.Flags().StringArrayVar(&slotCommands, "cmd", nil, "Command for a slot (SLOT=COMMAND). Repeatable.")
+	cmd.Flags().BoolVar(&list, "list", false, "List available presets")
+	return cmd
+}
+
+func writePresetResult(cmd *cobra.Command, outputOpts output.OutputOptions, result presetResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, s := range result.Slots {
+			_, _ = fmt.Fprintf(out, "%s\t%s\n", s.Slot, s.PaneID)
+		}
+		return nil
+	}
+	_, _ = fmt.Fprintf(out, "Created window %q (index %d) in session %q from preset %s.\n", result.Window, result.WindowIndex, result.Session, result.Preset)
+	table := newTextTable("SLOT", "PANE", "ID", "COMMAND")
+	for _, s := range result.Slots {
+		table.addRow(cell(s.Slot), cell(s.PaneID), cell(s.ID), cell(s.Command))
+	}
+	return table.render(out)
+}
+
+func writePresetList(cmd *cobra.Command, outputOpts output.OutputOptions, presets []windowPreset) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(presets)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(presets)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, p := range presets {
+			_, _ = fmt.Fprintln(out, p.Name)
+		}
+		return nil
+	}
+	table := newTextTable("NAME", "SLOTS", "SOURCE", "DESCRIPTION")
+	for _, p := range presets {
+		slots := make([]string, 0, len(p.Slots))
+		for _, s := range p.Slots {
+			slots = append(slots, s.Name)
+		}
+		table.addRow(cell(p.Name), cell(strconv.Itoa(len(slots))+": "+strings.Join(slots, ",")), cell(p.Source), cell(p.Description))
+	}
+	return table.render(out)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestBuiltinPresetsAreValid(t *testing.T) {
+	for _, p := range builtinPresets() {
+		if err := validatePreset(p); err != nil {
+			t.Fatalf("builtin preset %s: %v", p.Name, err)
+		}
+	}
+}
+
+func TestValidatePresetErrors(t *testing.T) {
+	cases := map[string]windowPreset{
+		"no slots":        {Name: "x"},
+		"duplicate slot":  {Name: "x", Slots: []presetSlot{{Name: "a"}, {Name: "a"}}},
+		"first splits":    {Name: "x", Slots: []presetSlot{{Name: "a", Split: "h"}}},
+		"unknown from":    {Name: "x", Slots: []presetSlot{{Name: "a"}, {Name: "b", From: "c"}}},
+		"bad split":       {Name: "x", Slots: []presetSlot{{Name: "a"}, {Name: "b", Split: "x"}}},
+		"percent too big": {Name: "x", Slots: []presetSlot{{Name: "a"}, {Name: "b", Percent: 100}}},
+	}
+	for name, p := range cases {
+		if err := validatePreset(p); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseSlotCommands(t *testing.T) {
+	preset := builtinPresets()[0]
+	got, err := parseSlotCommands([]string{"editor=nvim .", "server=npm run dev -- --port=3000"}, preset)
+	if err != nil {
+		t.Fatalf("parseSlotCommands: %v", err)
+	}
+	if got["editor"] != "nvim ." || got["server"] != "npm run dev -- --port=3000" {
+		t.Fatalf("unexpected commands: %v", got)
+	}
+	if _, err := parseSlotCommands([]string{"nope=ls"}, preset); err == nil || !strings.Contains(err.Error(), errInvalidPreset) {
+		t.Fatalf("expected ERR_INVALID_PRESET, got %v", err)
+	}
+	if _, err := parseSlotCommands([]string{"editor"}, preset); err == nil {
+		t.Fatal("expected error for missing =")
+	}
+}
+
+func TestMatchPresetSlots(t *testing.T) {
+	panes := []tmux.PaneDetails{
+		{Session: "dev", WindowIndex: 1, PaneIndex: 0, PaneID: "%1", Title: "editor"},
+		{Session: "dev", WindowIndex: 1, PaneIndex: 1, PaneID: "%2", Title: "logs"},
+	}
+	slots := matchPresetSlots(builtinPresets()[0], panes)
+	if len(slots) != 2 || slots[0].Slot != "editor" || slots[0].ID != "%1" || slots[1].PaneID != "dev:1.1" {
+		t.Fatalf("unexpected slots: %+v", slots)
+	}
+}
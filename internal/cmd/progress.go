@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// progressEvent is one NDJSON line emitted by --progress.
+type progressEvent struct {
+	Event            string    `json:"event"`
+	Time             time.Time `json:"time"`
+	Step             string    `json:"step,omitempty"`
+	Pane             string    `json:"pane,omitempty"`
+	Attempt          int       `json:"attempt,omitempty"`
+	Status           string    `json:"status,omitempty"`
+	Bytes            int       `json:"bytes,omitempty"`
+	IdleSeconds      float64   `json:"idle_seconds,omitempty"`
+	ProgressPercent  *float64  `json:"progress_percent,omitempty"`
+	BytesPerSecond   float64   `json:"bytes_per_second,omitempty"`
+	LinesPerSecond   float64   `json:"lines_per_second,omitempty"`
+	Activity         string    `json:"activity,omitempty"`
+	RemainingSeconds float64   `json:"remaining_seconds,omitempty"`
+	ExitCode         *int      `json:"exit_code,omitempty"`
+	Error            string    `json:"error,omitempty"`
+}
+
+type progressOptions struct {
+	enabled bool
+	fd      int
+}
+
+func (o *progressOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.enabled, "progress", false, "Emit NDJSON progress events on stderr")
+	cmd.Flags().IntVar(&o.fd, "progress-fd", -1, "Emit NDJSON progress events on this file descriptor instead of stderr")
+}
+
+// open returns the writer selected by the flags, or nil when progress is off.
+func (o *progressOptions) open(cmd *cobra.Command) (*progressWriter, error) {
+	if o.fd < 0 {
+		if !o.enabled {
+			return nil, nil
+		}
+		return newProgressWriter(cmd.ErrOrStderr()), nil
+	}
+	f := os.NewFile(uintptr(o.fd), "progress")
+	if f == nil {
+		return nil, fmt.Errorf("invalid --progress-fd %d", o.fd)
+	}
+	if _, err := f.Stat(); err != nil {
+		return nil, fmt.Errorf("invalid --progress-fd %d: %w", o.fd, err)
+	}
+	return newProgressWriter(f), nil
+}
+
+// progressWriter serializes events from concurrent steps. A nil writer
+// discards everything, so callers need not check whether progress is on.
+type progressWriter struct {
+	mu   *sync.Mutex
+	enc  *json.Encoder
+	step string
+	pane string
+	// outputPane and patterns enable progress_percent on idle events; rate
+	// measures outputPane's output for the rate fields.
+	outputPane string
+	patterns   []*regexp.Regexp
+	rate       *rateTracker
+}
+
+func newProgressWriter(w io.Writer) *progressWriter {
+	return &progressWriter{mu: &sync.Mutex{}, enc: json.NewEncoder(w)}
+}
+
+// with returns a writer that tags events with step and pane.
+func (p *progressWriter) with(step string, pane string) *progressWriter {
+	if p == nil {
+		return nil
+	}
+	scoped := *p
+	if step != "" {
+		scoped.step = step
+	}
+	if pane != "" {
+		scoped.pane = pane
+	}
+	return &scoped
+}
+
+// withOutputProgress returns a writer that adds progress_percent, detected
+// from paneID's recent output, and the pane's output rate to idle events.
+// Patterns may be nil to report only the rate.
+func (p *progressWriter) withOutputProgress(paneID string, patterns []*regexp.Regexp) *progressWriter {
+	if p == nil {
+		return nil
+	}
+	scoped := *p
+	scoped.outputPane = paneID
+	scoped.patterns = patterns
+	scoped.rate = newRateTracker(progressRateIntervals)
+	return &scoped
+}
+
+// observe fills in ev's progress_percent and output rate from a capture of
+// the pane's recent output.
+func (p *progressWriter) observe(ev *progressEvent, capture string) {
+	if p == nil {
+		return
+	}
+	ev.ProgressPercent = p.percent(capture)
+	if p.rate == nil {
+		return
+	}
+	p.rate.feed(capture, time.Now())
+	if len(p.rate.samples) == 0 {
+		return
+	}
+	r := p.rate.rate(0)
+	ev.BytesPerSecond = r.BytesPerSecond
+	ev.LinesPerSecond = r.LinesPerSecond
+	ev.Activity = r.Activity
+}
+
+// percent detects progress in output, returning nil when none is shown or
+// detection is off.
+func (p *progressWriter) percent(output string) *float64 {
+	if p == nil || len(p.patterns) == 0 {
+		return nil
+	}
+	if percent, ok := detectProgress(output, p.patterns); ok {
+		return &percent
+	}
+	return nil
+}
+
+func (p *progressWriter) emit(ev progressEvent) {
+	if p == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	if ev.Step == "" {
+		ev.Step = p.step
+	}
+	if ev.Pane == "" {
+		ev.Pane = p.pane
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_ = p.enc.Encode(ev)
+}
+
+// idleFunc returns a WaitIdleFunc callback emitting at most one "idle" event
+// per second, or nil when progress is off.
+func (p *progressWriter) idleFunc() func(tmux.IdleStatus) {
+	if p == nil {
+		return nil
+	}
+	var last time.Time
+	return func(st tmux.IdleStatus) {
+		if time.Since(last) < time.Second {
+			return
+		}
+		last = time.Now()
+		remaining := st.Remaining.Seconds()
+		if remaining < 0 {
+			remaining = 0
+		}
+		ev := progressEvent{
+			Event:            "idle",
+			IdleSeconds:      roundSeconds(st.Idle.Seconds()),
+			RemainingSeconds: roundSeconds(remaining),
+		}
+		if p.outputPane != "" {
+			if capture, err := tmux.Capture(p.outputPane, rateCaptureLines); err == nil {
+				p.observe(&ev, capture)
+			}
+		}
+		p.emit(ev)
+	}
+}
+
+func roundSeconds(v float64) float64 {
+	return float64(int64(v*10+0.5)) / 10
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// progressScanLines is how many trailing non-empty lines are searched for a
+// progress indicator; bars are redrawn in place at the bottom of the pane.
+const progressScanLines = 5
+
+// defaultProgressPatterns recognise common indicators. Each pattern captures
+// either "percent" or "done" and "total".
+var defaultProgressPatterns = []string{
+	// "45%", "12.5 %" (curl, wget, pytest, docker, rsync)
+	`(?P<percent>\d{1,3}(?:\.\d+)?)\s?%`,
+	// pip/rich download bars: "12.3/45.6 MB"
+	`(?P<done>\d+(?:\.\d+)?)/(?P<total>\d+(?:\.\d+)?)\s*(?:[kKMGT]i?B|bytes)\b`,
+	// cargo/ninja/make counters: "Building [=====> ] 45/120", "[3/10]"
+	`(?:^|[\s\[(])(?P<done>\d+)/(?P<total>\d+)(?:$|[\s\]):])`,
+}
+
+// progressPatterns compiles progress_patterns from the config file followed by
+// the built-in patterns, so configured patterns win.
+func progressPatterns() ([]*regexp.Regexp, error) {
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		return nil, err
+	}
+	patterns := make([]*regexp.Regexp, 0, len(cfg.ProgressPatterns)+len(defaultProgressPatterns))
+	for _, expr := range cfg.ProgressPatterns {
+		re, err := compileProgressPattern(expr)
+		if err != nil {
+			return nil, fmt.Errorf("progress_patterns: %w", err)
+		}
+		patterns = append(patterns, re)
+	}
+	for _, expr := range defaultProgressPatterns {
+		patterns = append(patterns, regexp.MustCompile(expr))
+	}
+	return patterns, nil
+}
+
+func compileProgressPattern(expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", expr, err)
+	}
+	if re.SubexpIndex("percent") < 0 && (re.SubexpIndex("done") < 0 || re.SubexpIndex("total") < 0) {
+		return nil, fmt.Errorf("pattern %q must capture (?P<percent>...) or (?P<done>...) and (?P<total>...)", expr)
+	}
+	return re, nil
+}
+
+// detectProgress returns the completion percentage shown nearest the bottom
+// of output, rounded to one decimal.
+func detectProgress(output string, patterns []*regexp.Regexp) (float64, bool) {
+	lines := splitLines(output)
+	scanned := 0
+	for i := len(lines) - 1; i >= 0 && scanned < progressScanLines; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		scanned++
+		for _, re := range patterns {
+			if percent, ok := matchProgress(re, line); ok {
+				return math.Round(percent*10) / 10, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// matchProgress uses the last match on the line, since bars are often
+// preceded by other numbers.
+func matchProgress(re *regexp.Regexp, line string) (float64, bool) {
+	matches := re.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	m := matches[len(matches)-1]
+	if i := re.SubexpIndex("percent"); i >= 0 && m[i] != "" {
+		percent, err := strconv.ParseFloat(m[i], 64)
+		if err != nil || percent > 100 {
+			return 0, false
+		}
+		return percent, true
+	}
+	di, ti := re.SubexpIndex("done"), re.SubexpIndex("total")
+	if di < 0 || ti < 0 {
+		return 0, false
+	}
+	done, err := strconv.ParseFloat(m[di], 64)
+	if err != nil {
+		return 0, false
+	}
+	total, err := strconv.ParseFloat(m[ti], 64)
+	if err != nil || total <= 0 || done > total {
+		return 0, false
+	}
+	return done / total * 100, true
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestDetectProgress(t *testing.T) {
+	t.Setenv("ARC_TMUX_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	patterns, err := progressPatterns()
+	if err != nil {
+		t.Fatalf("progressPatterns: %v", err)
+	}
+	cases := []struct {
+		output string
+		want   float64
+		ok     bool
+	}{
+		{"Downloading...\n 45% |#####     | ETA 0:10\n", 45, true},
+		{"   ━━━━━━━━━━━━━━╸━━━━━━━ 12.3/49.2 MB 3.1 MB/s eta 0:00:12\n", 25, true},
+		{"   Compiling serde v1.0\n    Building [=======>     ] 45/120: serde\n", 37.5, true},
+		{"[3/10] Linking CXX executable app\n", 30, true},
+		{"old 10%\nline\nline\nline\nline\nline\n$ \n", 0, false},
+		{"see src/app/main.go\nbuilt 2024/10/01\n", 0, false},
+		{"ratio 9/3\n", 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := detectProgress(tc.output, patterns)
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("detectProgress(%q) = %v, %v; want %v, %v", tc.output, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestCompileProgressPattern(t *testing.T) {
+	re, err := compileProgressPattern(`step (?P<done>\d+) of (?P<total>\d+)`)
+	if err != nil {
+		t.Fatalf("compileProgressPattern: %v", err)
+	}
+	if got, ok := detectProgress("step 3 of 4\n", []*regexp.Regexp{re}); !ok || got != 75 {
+		t.Fatalf("custom pattern = %v, %v; want 75", got, ok)
+	}
+	if _, err := compileProgressPattern(`(\d+)%`); err == nil {
+		t.Fatalf("expected error for pattern without named groups")
+	}
+}
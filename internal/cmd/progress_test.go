@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestProgressWriterScopesEvents(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgressWriter(&buf)
+	step := p.with("build", "dev:1.0")
+	step.emit(progressEvent{Event: "step_started", Attempt: 1})
+	p.emit(progressEvent{Event: "pipeline_finished", Status: "succeeded"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 NDJSON lines, got %d: %q", len(lines), buf.String())
+	}
+	var first, second progressEvent
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if first.Step != "build" || first.Pane != "dev:1.0" || first.Attempt != 1 || first.Time.IsZero() {
+		t.Fatalf("unexpected scoped event: %+v", first)
+	}
+	if second.Step != "" || second.Pane != "" {
+		t.Fatalf("parent writer should not inherit scope: %+v", second)
+	}
+}
+
+func TestProgressWriterNil(t *testing.T) {
+	var p *progressWriter
+	p.with("a", "b").emit(progressEvent{Event: "run_started"})
+	if p.idleFunc() != nil {
+		t.Fatalf("expected nil idle callback when progress is off")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// Prompt policy for this invocation, set from --assume-yes/--non-interactive
+// or ARC_TMUX_ASSUME_YES/ARC_TMUX_NON_INTERACTIVE.
+var (
+	assumeYes      bool
+	nonInteractive bool
+)
+
+func addPromptFlags(root *cobra.Command) {
+	root.PersistentFlags().Bool("assume-yes", false, "Answer yes to every confirmation prompt (env ARC_TMUX_ASSUME_YES)")
+	root.PersistentFlags().Bool("non-interactive", false, "Fail instead of prompting (env ARC_TMUX_NON_INTERACTIVE)")
+}
+
+// applyPromptMode resolves the prompt flags, falling back to the environment.
+func applyPromptMode(cmd *cobra.Command) {
+	assumeYes = boolFlagOrEnv(cmd, "assume-yes", "ARC_TMUX_ASSUME_YES")
+	nonInteractive = boolFlagOrEnv(cmd, "non-interactive", "ARC_TMUX_NON_INTERACTIVE")
+}
+
+func boolFlagOrEnv(cmd *cobra.Command, flag string, env string) bool {
+	if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
+		return f.Value.String() == "true"
+	}
+	v, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(env)))
+	return err == nil && v
+}
+
+func confirmationRequired(what string) error {
+	return newCodedError(errConfirmationRequired, what+"; pass --yes or --assume-yes (or set ARC_TMUX_ASSUME_YES=1)", nil)
+}
+
+// confirmPrompt asks a yes/no question. It returns true without asking under
+// --assume-yes, and fails with ERR_CONFIRMATION_REQUIRED when prompting is
+// disabled or stdin is not a terminal.
+func confirmPrompt(cmd *cobra.Command, prompt string) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if nonInteractive {
+		return false, confirmationRequired("confirmation required in non-interactive mode")
+	}
+	in := cmd.InOrStdin()
+	if f, ok := in.(*os.File); ok {
+		if !isatty.IsTerminal(f.Fd()) {
+			return false, confirmationRequired("confirmation required but stdin is not a terminal")
+		}
+	}
+
+	reader := bufio.NewReader(in)
+	for {
+		if _, err := fmt.Fprint(cmd.OutOrStdout(), prompt); err != nil {
+			return false, err
+		}
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return false, err
+		}
+		resp := strings.TrimSpace(strings.ToLower(response))
+		switch resp {
+		case "y", "yes":
+			return true, nil
+		case "", "n", "no":
+			return false, nil
+		default:
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Please answer 'y' or 'n'.")
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestConfirmPromptPolicy(t *testing.T) {
+	defer func() { assumeYes, nonInteractive = false, false }()
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("n\n"))
+	cmd.SetOut(&strings.Builder{})
+
+	assumeYes, nonInteractive = true, true
+	if ok, err := confirmPrompt(cmd, "Kill? "); err != nil || !ok {
+		t.Fatalf("expected assume-yes to confirm, got %v %v", ok, err)
+	}
+
+	assumeYes = false
+	_, err := confirmPrompt(cmd, "Kill? ")
+	var coded *codedError
+	if !errors.As(err, &coded) || coded.Code != errConfirmationRequired {
+		t.Fatalf("expected ERR_CONFIRMATION_REQUIRED, got %v", err)
+	}
+
+	nonInteractive = false
+	if ok, err := confirmPrompt(cmd, "Kill? "); err != nil || ok {
+		t.Fatalf("expected typed answer to decline, got %v %v", ok, err)
+	}
+}
+
+func TestApplyPromptModeFromEnv(t *testing.T) {
+	defer func() { assumeYes, nonInteractive = false, false }()
+	t.Setenv("ARC_TMUX_ASSUME_YES", "1")
+	t.Setenv("ARC_TMUX_NON_INTERACTIVE", "")
+	root := NewRootCmd()
+	applyPromptMode(root)
+	if !assumeYes || nonInteractive {
+		t.Fatalf("expected assume-yes from env only, got %v %v", assumeYes, nonInteractive)
+	}
+}
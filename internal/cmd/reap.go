@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type reapResult struct {
+	DryRun bool         `json:"dry_run" yaml:"dry_run"`
+	Reaped []reapedPane `json:"reaped" yaml:"reaped"`
+	// Failed counts reaped panes whose command exited non-zero.
+	Failed int `json:"failed" yaml:"failed"`
+}
+
+type reapedPane struct {
+	PaneID   string `json:"pane_id" yaml:"pane_id"`
+	ID       string `json:"id" yaml:"id"`
+	Command  string `json:"command,omitempty" yaml:"command,omitempty"`
+	ExitCode int    `json:"exit_code" yaml:"exit_code"`
+	// Signal is the signal that killed the command, whose ExitCode is then
+	// 128 plus the signal number.
+	Signal         int      `json:"signal,omitempty" yaml:"signal,omitempty"`
+	RemovedAliases []string `json:"removed_aliases,omitempty" yaml:"removed_aliases,omitempty"`
+}
+
+func newReapCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var dryRun bool
+	var keepAliases bool
+
+	cmd := &cobra.Command{
+		Use:   "reap",
+		Short: "Collect exit codes from dead panes and remove them",
+		Long: `Collect the exit codes of dead panes and kill them.
+
+With remain-on-exit on, a pane whose command finishes stays open as a dead
+pane holding the exit status. reap reports each one's exit code (128 plus
+the signal number for a command killed by a signal) and removes it, giving
+batch-style semantics without sentinels: launch commands, wait, then reap. Unless --dry-run, the exit status is ERR_COMMAND_EXIT when any
+reaped pane's command failed. Aliases pointing at reaped panes are removed
+unless --keep-aliases is given.`,
+		Example: `  arc-tmux reap --session batch
+  arc-tmux reap --dry-run --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			resolved, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			panes, err := tmux.ListPanesDetailed()
+			if err != nil {
+				return err
+			}
+
+			result := reapResult{DryRun: dryRun, Reaped: []reapedPane{}}
+			for _, p := range panes {
+				if !p.Dead || (resolved != "" && p.Session != resolved) {
+					continue
+				}
+				h := tmux.PaneHandle{ID: p.PaneID, Target: fmt.Sprintf("%s:%d.%d", p.Session, p.WindowIndex, p.PaneIndex)}
+				reaped := newReapedPane(p, h)
+				if !dryRun {
+					if err := tmux.Kill(h.ID); err != nil {
+						return fmt.Errorf("kill %s: %w", h.Target, err)
+					}
+				}
+				if !keepAliases {
+					reaped.RemovedAliases = forgetPaneAliases(cmd, h, dryRun)
+				}
+				if reaped.ExitCode != 0 {
+					result.Failed++
+				}
+				result.Reaped = append(result.Reaped, reaped)
+			}
+
+			if err := writeReapResult(cmd, outputOpts, result); err != nil {
+				return err
+			}
+			if result.Failed > 0 && !dryRun {
+				return newCodedError(errCommandExit, fmt.Sprintf("%d of %d dead panes exited non-zero", result.Failed, len(result.Reaped)), nil)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Only reap panes in this session (name, @current, or @managed)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report dead panes without killing them")
+	cmd.Flags().BoolVar(&keepAliases, "keep-aliases", false, "Keep aliases that point at reaped panes")
+	return cmd
+}
+
+// newReapedPane reports dead pane p, counting a command killed by a signal
+// (for which tmux's exit status is 0) as failed.
+func newReapedPane(p tmux.PaneDetails, h tmux.PaneHandle) reapedPane {
+	return reapedPane{PaneID: h.Target, ID: h.ID, Command: p.StartCommand, ExitCode: p.ExitCode(), Signal: p.DeadSignal}
+}
+
+func writeReapResult(cmd *cobra.Command, outputOpts output.OutputOptions, result reapResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, p := range result.Reaped {
+			_, _ = fmt.Fprintf(out, "%s\t%d\n", p.PaneID, p.ExitCode)
+		}
+		return nil
+	}
+	if len(result.Reaped) == 0 {
+		_, _ = fmt.Fprintln(out, "No dead panes.")
+		return nil
+	}
+	table := newTextTable("PANE", "ID", "EXIT", "COMMAND")
+	for _, p := range result.Reaped {
+		exitText := strconv.Itoa(p.ExitCode)
+		if p.Signal > 0 {
+			exitText += fmt.Sprintf(" (signal %d)", p.Signal)
+		}
+		exit := styledCell(exitText, styleActive)
+		if p.ExitCode != 0 {
+			exit.Style = styleError
+		}
+		table.addRow(cell(p.PaneID), cell(p.ID), exit, cell(p.Command))
+	}
+	if err := table.render(out); err != nil {
+		return err
+	}
+	verb := "Reaped"
+	if result.DryRun {
+		verb = "[dry-run] Would reap"
+	}
+	_, _ = fmt.Fprintf(out, "%s %d dead pane(s), %d failed.\n", verb, len(result.Reaped), result.Failed)
+	return nil
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestNewReapedPane(t *testing.T) {
+	h := tmux.PaneHandle{ID: "%3", Target: "batch:1.0"}
+	exited := newReapedPane(tmux.PaneDetails{Dead: true, DeadStatus: 2, StartCommand: "make"}, h)
+	if exited.ExitCode != 2 || exited.Signal != 0 || exited.Command != "make" || exited.PaneID != "batch:1.0" {
+		t.Fatalf("exited pane = %+v", exited)
+	}
+	// A command killed by a signal has a dead status of 0 in tmux.
+	signaled := newReapedPane(tmux.PaneDetails{Dead: true, DeadSignal: 9}, h)
+	if signaled.ExitCode != 137 || signaled.Signal != 9 {
+		t.Fatalf("signaled pane = %+v", signaled)
+	}
+}
@@ -6,9 +6,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/yourorg/arc-sdk/output"
 	"gopkg.in/yaml.v3"
 )
@@ -16,24 +19,52 @@ import (
 type recipe struct {
 	Name        string `json:"name" yaml:"name"`
 	Description string `json:"description" yaml:"description"`
-	Command     string `json:"command" yaml:"command"`
+	Command     string `json:"command,omitempty" yaml:"command,omitempty"`
+	// Commands runs several arc-tmux commands in order instead of Command.
+	Commands []string `json:"commands,omitempty" yaml:"commands,omitempty"`
+	// Params maps {{name}} placeholders to default values; "" means required.
+	Params map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+	Source string            `json:"source,omitempty" yaml:"source,omitempty"`
 }
 
+const (
+	recipeSourceBuiltin = "builtin"
+	recipeSourceConfig  = "config"
+)
+
 func newRecipesCmd() *cobra.Command {
+	var configFile string
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
 		Use:   "recipes",
 		Short: "Common workflows",
-		Long:  "Show common arc-tmux workflows for agents and developers.",
+		Long: `Show common arc-tmux workflows for agents and developers.
+
+User recipes are read from the "recipes" list in the config file
+(ARC_TMUX_CONFIG or <config dir>/arc-tmux/config.yaml) and override built-in
+recipes of the same name:
+
+  recipes:
+    - name: test-api
+      description: Run the API tests
+      params:
+        pane: "@api"
+        target: ""
+      command: arc-tmux run "npm test -- {{target}}" --pane={{pane}} --exit-code
+
+Run one with "arc-tmux recipes run <name> --param key=value".`,
 		Example: `  arc-tmux recipes
-  arc-tmux recipes --output json`,
+  arc-tmux recipes --output json
+  arc-tmux recipes run test-api --param target=auth -o json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-			recipes := defaultRecipes()
-			sort.Slice(recipes, func(i, j int) bool { return recipes[i].Name < recipes[j].Name })
+			recipes, err := allRecipes(configFile)
+			if err != nil {
+				return err
+			}
 			out := cmd.OutOrStdout()
 			switch {
 			case outputOpts.Is(output.OutputJSON):
@@ -57,16 +88,230 @@ func newRecipesCmd() *cobra.Command {
 			}
 			_, _ = fmt.Fprintln(out, "Recipes:")
 			for _, r := range recipes {
-				_, _ = fmt.Fprintf(out, "  %s\n    %s\n    %s\n", r.Name, r.Description, r.Command)
+				_, _ = fmt.Fprintf(out, "  %s\n    %s\n", r.Name, r.Description)
+				for _, line := range recipeCommands(r) {
+					_, _ = fmt.Fprintf(out, "    %s\n", line)
+				}
 			}
 			return nil
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file path (default: ARC_TMUX_CONFIG or config dir)")
+	cmd.AddCommand(newRecipesRunCmd(&configFile))
 	return cmd
 }
 
+func newRecipesRunCmd(configFile *string) *cobra.Command {
+	var params []string
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "run <name>",
+		Short: "Run a recipe with parameters",
+		Long: `Substitute --param values into a recipe's {{placeholders}} and run its
+arc-tmux command(s) in order, stopping at the first failure. --output and the
+global flags given to recipes run (--assume-yes, --actor, and so on) are passed
+through to each command.`,
+		Example: `  arc-tmux recipes run test-api --param pane=@api
+  arc-tmux recipes run graceful-stop --dry-run`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeRecipeNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			recipes, err := allRecipes(*configFile)
+			if err != nil {
+				return err
+			}
+			var selected *recipe
+			for i := range recipes {
+				if recipes[i].Name == args[0] {
+					selected = &recipes[i]
+					break
+				}
+			}
+			if selected == nil {
+				return fmt.Errorf("recipe %q not found", args[0])
+			}
+			values, err := parseRecipeParams(params)
+			if err != nil {
+				return err
+			}
+			passthrough := globalFlagArgs(cmd)
+			if f := cmd.Flags().Lookup("output"); f != nil && f.Changed {
+				passthrough = append(passthrough, "--output", f.Value.String())
+			}
+			invocations, err := expandRecipe(*selected, values, passthrough)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if dryRun {
+				for _, words := range invocations {
+					quoted := make([]string, len(words))
+					for i, w := range words {
+						quoted[i] = shellQuoteSingle(w)
+					}
+					_, _ = fmt.Fprintln(out, "arc-tmux "+strings.Join(quoted, " "))
+				}
+				return nil
+			}
+			for _, words := range invocations {
+				sub := NewRootCmd()
+				sub.SetArgs(words)
+				sub.SetIn(cmd.InOrStdin())
+				sub.SetOut(out)
+				sub.SetErr(cmd.ErrOrStderr())
+				if err := sub.Execute(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringArrayVar(&params, "param", nil, "Set a recipe parameter (KEY=VAL). Repeatable.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the expanded commands without running them")
+	return cmd
+}
+
+// globalFlagArgs returns the root's persistent flags set on cmd's command
+// line, as arguments for a nested invocation: each invocation parses its own
+// flags, so without them a recipe step would lose --assume-yes and the like.
+func globalFlagArgs(cmd *cobra.Command) []string {
+	global := cmd.Root().PersistentFlags()
+	var args []string
+	cmd.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed && global.Lookup(f.Name) != nil {
+			args = append(args, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	return args
+}
+
+// allRecipes returns built-in recipes overridden by config recipes, by name.
+func allRecipes(configFile string) ([]recipe, error) {
+	path := configFile
+	if path == "" {
+		path = defaultConfigFile()
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]recipe)
+	for _, r := range defaultRecipes() {
+		r.Source = recipeSourceBuiltin
+		byName[r.Name] = r
+	}
+	for _, r := range cfg.Recipes {
+		if strings.TrimSpace(r.Name) == "" {
+			return nil, fmt.Errorf("config %s: recipe without a name", path)
+		}
+		if strings.TrimSpace(r.Command) == "" && len(r.Commands) == 0 {
+			return nil, fmt.Errorf("config %s: recipe %q has no command", path, r.Name)
+		}
+		r.Source = recipeSourceConfig
+		byName[r.Name] = r
+	}
+	recipes := make([]recipe, 0, len(byName))
+	for _, r := range byName {
+		recipes = append(recipes, r)
+	}
+	sort.Slice(recipes, func(i, j int) bool { return recipes[i].Name < recipes[j].Name })
+	return recipes, nil
+}
+
+func recipeCommands(r recipe) []string {
+	if len(r.Commands) > 0 {
+		return r.Commands
+	}
+	return []string{r.Command}
+}
+
+func parseRecipeParams(raw []string) (map[string]string, error) {
+	values := make(map[string]string, len(raw))
+	for _, item := range raw {
+		key, value, ok := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --param %q; expected KEY=VAL", item)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+var recipePlaceholder = regexp.MustCompile(`\{\{([A-Za-z0-9_-]+)\}\}`)
+
+// expandRecipe splits each recipe command into words, substitutes parameters
+// word by word (so values never need quoting), and strips the leading
+// "arc-tmux". extra is appended to every invocation.
+func expandRecipe(r recipe, values map[string]string, extra []string) ([][]string, error) {
+	used := make(map[string]bool)
+	var invocations [][]string
+	for _, line := range recipeCommands(r) {
+		words, err := splitCommandLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("recipe %q: %w", r.Name, err)
+		}
+		if len(words) == 0 || words[0] != "arc-tmux" {
+			return nil, fmt.Errorf("recipe %q: commands must start with arc-tmux: %q", r.Name, line)
+		}
+		if len(words) > 2 && words[1] == "recipes" && words[2] == "run" {
+			return nil, fmt.Errorf("recipe %q: recipes cannot run other recipes", r.Name)
+		}
+		var missing string
+		for i, w := range words {
+			words[i] = recipePlaceholder.ReplaceAllStringFunc(w, func(m string) string {
+				name := recipePlaceholder.FindStringSubmatch(m)[1]
+				used[name] = true
+				if v, ok := values[name]; ok {
+					return v
+				}
+				if v := r.Params[name]; v != "" {
+					return v
+				}
+				if missing == "" {
+					missing = name
+				}
+				return m
+			})
+		}
+		if missing != "" {
+			return nil, fmt.Errorf("recipe %q: missing parameter %q (use --param %s=...)", r.Name, missing, missing)
+		}
+		invocations = append(invocations, append(words[1:], extra...))
+	}
+	for name := range values {
+		if !used[name] {
+			return nil, fmt.Errorf("recipe %q has no parameter %q", r.Name, name)
+		}
+	}
+	return invocations, nil
+}
+
+func completeRecipeNames(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	recipes, err := allRecipes("")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(recipes))
+	for _, r := range recipes {
+		names = append(names, fmt.Sprintf("%s\t%s", r.Name, r.Description))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
 func defaultRecipes() []recipe {
 	return []recipe{
 		{
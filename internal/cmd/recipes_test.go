@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExpandRecipe(t *testing.T) {
+	r := recipe{
+		Name:     "test",
+		Commands: []string{`arc-tmux run "npm test -- {{target}}" --pane={{pane}}`, "arc-tmux capture --pane={{pane}}"},
+		Params:   map[string]string{"pane": "@api", "target": ""},
+	}
+	got, err := expandRecipe(r, map[string]string{"target": "auth; rm -rf /"}, []string{"--output", "json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 invocations, got %d", len(got))
+	}
+	if strings.Join(got[0], "|") != "run|npm test -- auth; rm -rf /|--pane=@api|--output|json" {
+		t.Fatalf("unexpected first invocation: %q", got[0])
+	}
+	if strings.Join(got[1], "|") != "capture|--pane=@api|--output|json" {
+		t.Fatalf("unexpected second invocation: %q", got[1])
+	}
+
+	if _, err := expandRecipe(r, nil, nil); err == nil || !strings.Contains(err.Error(), `"target"`) {
+		t.Fatalf("expected missing target error, got %v", err)
+	}
+	if _, err := expandRecipe(r, map[string]string{"target": "x", "bogus": "1"}, nil); err == nil {
+		t.Fatalf("expected unknown parameter error")
+	}
+	if _, err := expandRecipe(recipe{Name: "sh", Command: "rm -rf /tmp/x"}, nil, nil); err == nil {
+		t.Fatalf("expected non arc-tmux command to be rejected")
+	}
+}
+
+func TestGlobalFlagArgs(t *testing.T) {
+	root := NewRootCmd()
+	run, _, err := root.Find([]string{"recipes", "run"})
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if err := run.ParseFlags([]string{"--assume-yes", "--actor", "bot", "--config", "/tmp/c.yaml", "-o", "json"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := strings.Join(globalFlagArgs(run), " ")
+	if got != "--actor=bot --assume-yes=true" {
+		t.Fatalf("globalFlagArgs = %q", got)
+	}
+}
+
+func TestRecipeSourceOmittedWhenEmpty(t *testing.T) {
+	data, err := json.Marshal(recipe{Name: "x", Description: "y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "source") {
+		t.Fatalf("empty source marshalled: %s", data)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// replDialect describes how an interactive interpreter prompts and reports
+// errors. Prompts are matched against captured lines, which tmux returns
+// with trailing spaces trimmed.
+type replDialect struct {
+	name string
+	// prompt matches a primary prompt, with the input typed after it.
+	prompt *regexp.Regexp
+	// continuation matches lines echoing the rest of multi-line input.
+	continuation *regexp.Regexp
+	// result is stripped from the first output line (ipython's Out[n]:).
+	result *regexp.Regexp
+	// failure marks output lines that report an error.
+	failure *regexp.Regexp
+	// closeBlock sends an empty line after multi-line input.
+	closeBlock bool
+}
+
+var replDialects = []replDialect{
+	{
+		name:         "ipython",
+		prompt:       regexp.MustCompile(`^In \[\d+\]:( |$)`),
+		continuation: regexp.MustCompile(`^\s+\.\.\.:( |$)`),
+		result:       regexp.MustCompile(`^Out\[\d+\]: ?`),
+		failure:      regexp.MustCompile(`^(Traceback \(most recent call last\)|\w+(Error|Exception)\b)`),
+		closeBlock:   true,
+	},
+	{
+		name:         "python",
+		prompt:       regexp.MustCompile(`^>>>( |$)`),
+		continuation: regexp.MustCompile(`^\.\.\.( |$)`),
+		failure:      regexp.MustCompile(`^(Traceback \(most recent call last\):|\w+(Error|Exception): )`),
+		closeBlock:   true,
+	},
+	{
+		name:         "node",
+		prompt:       regexp.MustCompile(`^>( |$)`),
+		continuation: regexp.MustCompile(`^(\.\.\.|\| )( |$)`),
+		failure:      regexp.MustCompile(`^(Uncaught |\w+Error: )`),
+	},
+	{
+		name:         "psql",
+		prompt:       regexp.MustCompile(`^[^\s=]+=[#>]( |$)`),
+		continuation: regexp.MustCompile(`^[^\s=]+[-'"(*][#>]( |$)`),
+		failure:      regexp.MustCompile(`^(ERROR|FATAL):`),
+	},
+}
+
+func replDialectNamed(name string) (replDialect, bool) {
+	for _, d := range replDialects {
+		if d.name == name {
+			return d, true
+		}
+	}
+	return replDialect{}, false
+}
+
+// detectReplDialect identifies the interpreter from the prompt on the last
+// non-empty line, so the pane must be waiting for input.
+func detectReplDialect(capture string) (replDialect, bool) {
+	line := lastNonEmptyLine(capture)
+	for _, d := range replDialects {
+		if d.prompt.MatchString(line) && strings.TrimSpace(d.prompt.ReplaceAllString(line, "")) == "" {
+			return d, true
+		}
+	}
+	return replDialect{}, false
+}
+
+type replEvalResult struct {
+	PaneID string `json:"pane_id" yaml:"pane_id"`
+	REPL   string `json:"repl" yaml:"repl"`
+	Input  string `json:"input" yaml:"input"`
+	Output string `json:"output" yaml:"output"`
+	Error  bool   `json:"error" yaml:"error"`
+}
+
+func newReplCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repl",
+		Short: "Evaluate input in interactive interpreters",
+		Long: `Drive interactive interpreters (python, ipython, node, psql) running in a pane.
+
+The interpreter is recognised from its prompt, so the pane must be idle at a
+prompt; --repl overrides detection.`,
+		Example: `  arc-tmux repl eval --pane=@py "sum(range(10))"
+  arc-tmux repl eval --pane=@db --repl psql "select count(*) from users;" --output json`,
+	}
+	cmd.AddCommand(newReplEvalCmd())
+	return cmd
+}
+
+func newReplEvalCmd() *cobra.Command {
+	var paneArg string
+	var replName string
+	var timeout float64
+	var lines int
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "eval <input>",
+		Short: "Evaluate input and return only its result",
+		Long: `Send input to a REPL, wait for the prompt to return, and print only the
+output the input produced, without the prompt and echoed input.
+
+Multi-line input is sent line by line; python and ipython get a closing blank
+line so blocks run. error is true when the output looks like an exception or
+ERROR: message.`,
+		Example: `  arc-tmux repl eval --pane=@py "import sys; sys.version"
+  arc-tmux repl eval --pane=@node "[1,2,3].map(x => x * 2)" --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			input := strings.TrimRight(args[0], "\n")
+			if strings.TrimSpace(input) == "" {
+				return fmt.Errorf("input is required")
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			before, err := tmux.CaptureJoined(handle.ID, lines)
+			if err != nil {
+				return err
+			}
+			dialect, ok := detectReplDialect(before)
+			if replName != "" {
+				if dialect, ok = replDialectNamed(replName); !ok {
+					return fmt.Errorf("unknown --repl %q (expected python, ipython, node, or psql)", replName)
+				}
+			} else if !ok {
+				return newCodedError(errReplNotReady, fmt.Sprintf("pane %s is not at a recognised REPL prompt (last line %q); pass --repl or wait for it to finish", handle.Target, lastNonEmptyLine(before)), nil)
+			}
+
+			inputLines := strings.Split(input, "\n")
+			for _, line := range inputLines {
+				if err := tmux.SendLiteral(handle.ID, line, true, 0); err != nil {
+					return err
+				}
+			}
+			if len(inputLines) > 1 && dialect.closeBlock {
+				if err := tmux.SendKeys(handle.ID, []string{"Enter"}); err != nil {
+					return err
+				}
+			}
+
+			out, found, err := waitForReplResult(handle.ID, dialect, before, inputLines[0], lines, time.Duration(timeout*float64(time.Second)))
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("echoed input not found in the last %d lines of %s; raise --lines", lines, handle.Target)
+			}
+			result := replEvalResult{
+				PaneID: handle.Target,
+				REPL:   dialect.name,
+				Input:  input,
+				Output: out,
+				Error:  replOutputFailed(dialect, out),
+			}
+
+			w := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(w)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				return nil
+			}
+			if result.Output != "" {
+				_, _ = fmt.Fprintln(w, result.Output)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&replName, "repl", "", "Interpreter dialect: python, ipython, node, or psql (default: detect from prompt)")
+	cmd.Flags().Float64Var(&timeout, "timeout", 30, "Maximum seconds to wait for the prompt to return")
+	cmd.Flags().IntVar(&lines, "lines", 500, "History lines searched for the echoed input and output")
+	return cmd
+}
+
+// waitForReplResult polls until the prompt returns below the echoed input and
+// extracts the output in between. Captures identical to before are skipped so
+// an earlier evaluation of the same input is not mistaken for this one.
+func waitForReplResult(paneID string, dialect replDialect, before string, firstLine string, lines int, timeout time.Duration) (string, bool, error) {
+	deadline := time.Now().Add(tmux.BoundTimeout(timeout))
+	for {
+		time.Sleep(100 * time.Millisecond)
+		capture, err := tmux.CaptureJoined(paneID, lines)
+		if err != nil {
+			return "", false, err
+		}
+		if capture != before {
+			if out, done, found := extractReplOutput(capture, dialect, firstLine); done {
+				return out, found, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			if tmux.DeadlineExceeded() {
+				return "", false, tmux.ErrDeadlineExceeded
+			}
+			return "", false, errors.New("timeout waiting for the REPL prompt to return")
+		}
+	}
+}
+
+// extractReplOutput returns the lines between the last echo of firstLine and
+// the trailing empty prompt. done is false while the interpreter is busy.
+func extractReplOutput(capture string, dialect replDialect, firstLine string) (out string, done bool, found bool) {
+	all := splitLines(capture)
+	end := len(all) - 1
+	for end >= 0 && strings.TrimSpace(all[end]) == "" {
+		end--
+	}
+	if end < 0 || !dialect.prompt.MatchString(all[end]) || strings.TrimSpace(dialect.prompt.ReplaceAllString(all[end], "")) != "" {
+		return "", false, false
+	}
+	want := strings.TrimSpace(firstLine)
+	start := -1
+	for i := end - 1; i >= 0; i-- {
+		line := all[i]
+		if dialect.prompt.MatchString(line) && strings.TrimSpace(dialect.prompt.ReplaceAllString(line, "")) == want {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return "", true, false
+	}
+	body := all[start+1 : end]
+	for len(body) > 0 && dialect.continuation != nil && dialect.continuation.MatchString(body[0]) {
+		body = body[1:]
+	}
+	if len(body) > 0 && dialect.result != nil {
+		body[0] = dialect.result.ReplaceAllString(body[0], "")
+	}
+	return strings.Trim(strings.Join(body, "\n"), "\n"), true, true
+}
+
+func replOutputFailed(dialect replDialect, out string) bool {
+	if dialect.failure == nil {
+		return false
+	}
+	for _, line := range splitLines(out) {
+		if dialect.failure.MatchString(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import "testing"
+
+func TestDetectReplDialect(t *testing.T) {
+	cases := map[string]string{
+		"Python 3.12.1\n>>> \n":             "python",
+		"In [3]: \n":                        "ipython",
+		"Welcome to Node.js v20.\n> \n":     "node",
+		"psql (16.1)\napp=# \n":             "psql",
+		"app=> \n":                          "psql",
+		"$ python3 script.py\nrunning...\n": "",
+		">>> print('busy')\n":               "",
+	}
+	for capture, want := range cases {
+		d, ok := detectReplDialect(capture)
+		if got := d.name; ok != (want != "") || got != want {
+			t.Fatalf("detectReplDialect(%q) = %q, %v; want %q", capture, got, ok, want)
+		}
+	}
+}
+
+func TestExtractReplOutput(t *testing.T) {
+	python, _ := replDialectNamed("python")
+	capture := ">>> x = 1\n>>> for i in range(2):\n...     print(i)\n...\n0\n1\n>>>\n"
+	out, done, found := extractReplOutput(capture, python, "for i in range(2):")
+	if !done || !found || out != "0\n1" {
+		t.Fatalf("python output = %q (done=%v found=%v)", out, done, found)
+	}
+	if _, done, _ := extractReplOutput(">>> import time; time.sleep(5)\n", python, "import time; time.sleep(5)"); done {
+		t.Fatalf("expected busy interpreter to be not done")
+	}
+
+	ipython, _ := replDialectNamed("ipython")
+	out, _, found = extractReplOutput("In [1]: 21*2\nOut[1]: 42\n\nIn [2]:\n", ipython, "21*2")
+	if !found || out != "42" {
+		t.Fatalf("ipython output = %q", out)
+	}
+
+	psql, _ := replDialectNamed("psql")
+	out, _, _ = extractReplOutput("app=# select 1 as n;\n n\n---\n 1\n(1 row)\n\napp=#\n", psql, "select 1 as n;")
+	if out != " n\n---\n 1\n(1 row)" {
+		t.Fatalf("psql output = %q", out)
+	}
+	if !replOutputFailed(psql, "ERROR:  relation \"x\" does not exist") {
+		t.Fatalf("expected psql ERROR to be reported")
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// activityRecordTick is one NDJSON line emitted by "report record" per sample.
+type activityRecordTick struct {
+	Time  time.Time `json:"time" yaml:"time"`
+	Panes int       `json:"panes" yaml:"panes"`
+	Busy  int       `json:"busy" yaml:"busy"`
+}
+
+func newReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Record pane activity and report on it",
+		Long: `Sample pane activity over time and summarize it, so teams can see which
+agent sessions are actually doing work.
+
+"report record" appends one sample per pane every --interval seconds to an
+activity file (ARC_TMUX_ACTIVITY, default under the user cache directory); a
+pane is busy when it produced output since the previous sample. Run it in the
+background or a spare pane. "report activity" totals busy time per pane and
+renders an hour-by-hour heatmap.`,
+		Example: `  arc-tmux report record --interval 60 &
+  arc-tmux report activity --since 24h
+  arc-tmux report activity --session dev --since 7d --output json`,
+	}
+
+	cmd.AddCommand(
+		newReportRecordCmd(),
+		newReportActivityCmd(),
+	)
+
+	return cmd
+}
+
+func newReportRecordCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var file string
+	var interval float64
+	var duration float64
+	var once bool
+
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Append pane activity samples to the activity file",
+		Long: `Sample every pane (or those in --session) every --interval seconds and
+append the samples to the activity file. --once takes a single sample, for
+running from cron with the same --interval as the schedule.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if interval <= 0 {
+				return errors.New("--interval must be > 0")
+			}
+			resolved, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			path := file
+			if path == "" {
+				path = defaultActivityFile()
+			}
+
+			out := cmd.OutOrStdout()
+			var yamlEnc *yaml.Encoder
+			if outputOpts.Is(output.OutputYAML) {
+				yamlEnc = yaml.NewEncoder(out)
+				defer func() { _ = yamlEnc.Close() }()
+			}
+			var deadline time.Time
+			if duration > 0 {
+				deadline = time.Now().Add(time.Duration(duration * float64(time.Second)))
+			}
+			ticker := time.NewTicker(time.Duration(interval * float64(time.Second)))
+			defer ticker.Stop()
+			for {
+				tick, err := recordActivitySamples(path, resolved, interval, time.Now())
+				if err != nil {
+					return err
+				}
+				switch {
+				case outputOpts.Is(output.OutputJSON):
+					err = json.NewEncoder(out).Encode(tick)
+				case outputOpts.Is(output.OutputYAML):
+					err = yamlEnc.Encode(tick)
+				case outputOpts.Is(output.OutputQuiet):
+				default:
+					_, err = fmt.Fprintf(out, "%s recorded %d panes (%d busy)\n", tick.Time.Format(time.RFC3339), tick.Panes, tick.Busy)
+				}
+				if err != nil {
+					return err
+				}
+				if once || (!deadline.IsZero() && time.Now().After(deadline)) {
+					return nil
+				}
+				<-ticker.C
+			}
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Only sample panes in this session (name, @current, or @managed)")
+	cmd.Flags().StringVar(&file, "file", "", "Activity file (default: ARC_TMUX_ACTIVITY or the user cache directory)")
+	cmd.Flags().Float64Var(&interval, "interval", 60, "Seconds between samples")
+	cmd.Flags().Float64Var(&duration, "duration", 0, "Stop after N seconds (0 to run until interrupted)")
+	cmd.Flags().BoolVar(&once, "once", false, "Take a single sample and exit")
+	return cmd
+}
+
+// recordActivitySamples samples the panes in session (all when empty) and
+// appends the samples to path. A pane is busy when its last activity falls
+// within the interval before now; without pane_activity the window's
+// activity is used.
+func recordActivitySamples(path string, session string, interval float64, now time.Time) (activityRecordTick, error) {
+	tick := activityRecordTick{Time: now.UTC()}
+	panes, err := tmux.ListPanesDetailed()
+	if err != nil {
+		if errors.Is(err, tmux.ErrNoTmuxServer) {
+			return tick, nil
+		}
+		return tick, err
+	}
+	window := time.Duration(interval * float64(time.Second))
+	var samples []activitySample
+	for _, p := range panes {
+		if session != "" && p.Session != session {
+			continue
+		}
+		activity := p.ActivityAt
+		if activity.IsZero() {
+			activity = p.WindowActivityAt
+		}
+		busy := !activity.IsZero() && now.Sub(activity) < window
+		samples = append(samples, activitySample{
+			Time:     tick.Time,
+			PaneID:   p.PaneID,
+			Pane:     fmt.Sprintf("%s:%d.%d", p.Session, p.WindowIndex, p.PaneIndex),
+			Session:  p.Session,
+			Command:  p.Command,
+			Busy:     busy,
+			Interval: interval,
+		})
+		if busy {
+			tick.Busy++
+		}
+	}
+	tick.Panes = len(samples)
+	return tick, appendActivitySamples(path, samples)
+}
+
+func newReportActivityCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var file string
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "activity",
+		Short: "Summarize recorded pane activity with an hourly heatmap",
+		Long: `Total the busy time of each pane recorded by "report record" over --since
+(e.g. 90m, 24h, 7d) and render an hour-by-hour heatmap, busiest panes first.
+Each heatmap cell shades one hour from · (idle) to █ (busy all hour).`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			window, err := parseSince(since)
+			if err != nil {
+				return err
+			}
+			resolved, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			path := file
+			if path == "" {
+				path = defaultActivityFile()
+			}
+			until := time.Now()
+			samples, err := loadActivitySamples(path, until.Add(-window))
+			if err != nil {
+				return err
+			}
+			if resolved != "" {
+				filtered := samples[:0]
+				for _, s := range samples {
+					if s.Session == resolved {
+						filtered = append(filtered, s)
+					}
+				}
+				samples = filtered
+			}
+			report := buildActivityReport(samples, until.Add(-window), until)
+			return writeActivityReport(cmd, outputOpts, report)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Only report panes in this session (name, @current, or @managed)")
+	cmd.Flags().StringVar(&file, "file", "", "Activity file (default: ARC_TMUX_ACTIVITY or the user cache directory)")
+	cmd.Flags().StringVar(&since, "since", "24h", "Report window (e.g. 90m, 24h, 7d)")
+	return cmd
+}
+
+func writeActivityReport(cmd *cobra.Command, outputOpts output.OutputOptions, report activityReport) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(report)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, p := range report.Panes {
+			_, _ = fmt.Fprintf(out, "%s\t%.0f\n", p.Pane, p.BusySeconds)
+		}
+		return nil
+	}
+	if len(report.Panes) == 0 {
+		_, _ = fmt.Fprintln(out, "No activity recorded in this window; run 'arc-tmux report record' to collect samples.")
+		return nil
+	}
+	heatmap := "HOURS"
+	if len(report.Hours) > 0 {
+		heatmap = fmt.Sprintf("HOURS %s–%s", report.Hours[0].Local().Format("01-02 15:00"), report.Until.Local().Format("01-02 15:04"))
+	}
+	table := newTextTable("PANE", "COMMAND", "BUSY", "SAMPLED", heatmap)
+	for _, p := range report.Panes {
+		table.addRow(
+			cell(p.Pane),
+			cell(p.Command),
+			cell(formatActivitySeconds(p.BusySeconds)),
+			cell(formatActivitySeconds(p.SampledSeconds)),
+			cell(activityHeatmap(p.HourlyBusySeconds)),
+		)
+	}
+	return table.render(out)
+}
+
+func formatActivitySeconds(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second).String()
+}
@@ -23,59 +23,164 @@ Common subcommands:
   list      List available tmux panes
   locate    Locate panes by metadata
   alias     Manage pane aliases
+  default   Set the pane used when --pane is omitted
   recipes   Show common workflows
   send      Send text to a pane
+  key       Send special keys (Escape, arrows, C-x chords)
   capture   Capture pane output
+  copy-mode Search and copy pane history via copy mode
+  scroll    Scroll a pane's view through its history
   follow    Stream pane output
+  ship      Stream pane output to files, syslog, or a log shipper
+  watch     Act on pane output matching rules
+  on-change Send a command to a pane when files change
+  diff      Diff pane output against a checkpoint or another pane
+  checkpoint Save and compare named snapshots of pane state
+  annotate  Print a timestamped marker into a pane and checkpoint it
   run       Send -> wait for idle -> capture
+  repl      Evaluate input in python/node/psql REPLs
+  setenv    Export variables into a pane's running shell
+  shell-hook Record exit statuses through a shell prompt hook
+  cd        Change a pane's directory and verify it
+  pipeline  Run a DAG of commands across panes
+  exec      Run commands in temporary panes (--pool for concurrency)
+  fanout    Run a command on several hosts over SSH
   monitor   Snapshot pane activity/output hash
   signal    Send a signal to a pane PID
   stop      Interrupt then kill on timeout
   wait      Block until a pane quiets down
+  then      Send a command once a pane finishes its current work
+  idle      Show which panes are idle and for how long
+  history   List recent commands run in a pane
+  blame     Show who sent recent input to a pane
+  triage    Find errors in a pane's recent output
+  open-ref  Open the latest file:line from a pane in an editor pane
   kill      Safely kill a pane
+  reap      Collect exit codes from dead panes and remove them
   ensure    Ensure session/window/pane exist
+  preset    Build a window from a multi-pane preset
+  dev       Bring up a dev window for the current project
+  compose   Open a pane per Docker Compose service
+  scale     Keep N panes running a command
   attach    Attach to a session
+  undo      Revert recent trash, layout, alias, and default-pane changes
   launch    Open a new pane/window
   windows   List windows for a session
   inspect   Inspect a pane and process tree
-  status    Show current tmux location`,
+  fmt       Evaluate raw tmux format strings against a pane
+  status    Show current tmux location
+  handoff   Summarize a session for the next agent
+  report    Record pane activity and report busy time
+  message   Show a notification to attached clients
+  version   Show arc-tmux/tmux versions and features
+  init      Set up config, completions, and keybindings
+  bind      Install tmux keybindings for arc-tmux
+  hooks     Install tmux hooks that keep aliases in sync
+  schema    Emit JSON Schemas for --output json
+  completion Generate or install shell completions`,
 		Example: `  arc-tmux list
   arc-tmux send "npm test" --pane=fe:2.0
   arc-tmux run "make lint" --pane=fe:2.0 --timeout 90s
   arc-tmux wait --pane=fe:2.0 --idle 2s --timeout 60s`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			applyColor(cmd)
+			applyPromptMode(cmd)
+			applyShellMode(cmd)
+			applyActor(cmd)
+			applyUndoJournal(cmd)
+			startTranscript(cmd)
+			if err := applyIndexOrigin(cmd); err != nil {
+				return err
+			}
+			if err := applyFlagDefaults(cmd); err != nil {
+				return err
+			}
+			return applyAPIVersion(cmd)
+		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
 		},
 	}
 
+	addAPIVersionFlag(root)
+	addColorFlag(root)
+	addPromptFlags(root)
+	addShellFlags(root)
+	addIndexOriginFlag(root)
+	addTranscriptFlag(root)
+	addActorFlag(root)
+
 	root.AddCommand(
 		newListCmd(),
 		newPanesCmd(),
 		newSessionsCmd(),
 		newLocateCmd(),
 		newAliasCmd(),
+		newDefaultCmd(),
 		newRecipesCmd(),
 		newSendCmd(),
 		newCaptureCmd(),
 		newWaitCmd(),
+		newThenCmd(),
 		newRunCmd(),
+		newPipelineCmd(),
+		newExecCmd(),
+		newFanoutCmd(),
 		newMonitorCmd(),
 		newSignalCmd(),
 		newStopCmd(),
 		newInterruptCmd(),
 		newEscapeCmd(),
+		newKeyCmd(),
+		newCopyModeCmd(),
+		newScrollCmd(),
+		newReplCmd(),
+		newSetenvCmd(),
+		newShellHookCmd(),
+		newHistoryCmd(),
+		newBlameCmd(),
+		newCdCmd(),
 		newKillCmd(),
+		newReapCmd(),
 		newEnsureCmd(),
+		newPresetCmd(),
+		newDevCmd(),
+		newComposeCmd(),
+		newScaleCmd(),
 		newInspectCmd(),
+		newFmtCmd(),
 		newFollowCmd(),
+		newShipCmd(),
+		newWatchCmd(),
+		newOnChangeCmd(),
+		newDiffCmd(),
+		newCheckpointCmd(),
+		newAnnotateCmd(),
 		newAttachCmd(),
 		newCleanupCmd(),
+		newUndoCmd(),
 		newLaunchCmd(),
 		newWindowsCmd(),
 		newStatusCmd(),
+		newHandoffCmd(),
+		newIdleCmd(),
+		newTriageCmd(),
+		newOpenRefCmd(),
+		newReportCmd(),
+		newMessageCmd(),
+		newVersionCmd(),
+		newInitCmd(),
+		newBindCmd(),
+		newHooksCmd(),
+		newEventCmd(),
+		newShipFilterCmd(),
+		newSchemaCmd(),
+		newCompletionCmd(),
 	)
 
+	registerDynamicCompletions(root)
+
 	return root
 }
@@ -7,9 +7,12 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -20,6 +23,7 @@ import (
 
 func newRunCmd() *cobra.Command {
 	var paneArg string
+	var windowArg string
 	var idle, timeout float64
 	var lines int
 	var exitCode bool
@@ -28,6 +32,18 @@ func newRunCmd() *cobra.Command {
 	var segment bool
 	var cwd string
 	var envVars []string
+	var filterExpr string
+	var teePane string
+	var focusOnFail bool
+	var setTitle bool
+	var keepTitle bool
+	var screenBoundary bool
+	var forwardInterrupt bool
+	var maxRuntime float64
+	var killAfter float64
+	var heartbeat float64
+	var outputLimit outputCap
+	var progressOpts progressOptions
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
@@ -44,91 +60,155 @@ func newRunCmd() *cobra.Command {
   arc-tmux run "npm test" --pane=fe:2.0 --cwd /srv/app --env NODE_ENV=development
 
   # Capture output and exit code in JSON
-  arc-tmux run "npm test" --pane=fe:2.0 --exit-code --output json`,
+  arc-tmux run "npm test" --pane=fe:2.0 --exit-code --output json
+
+  # Mirror the command's output into a console pane an operator is watching
+  arc-tmux run "make deploy" --pane=ops:1.0 --tee-pane=ops:0.0
+
+  # Keep only the first and last 100 lines of a noisy command
+  arc-tmux run "make build" --pane=fe:2.0 --max-lines 200 --keep both --output json
+
+  # Bring whoever is attached to the pane if the tests fail
+  arc-tmux run "npm test" --pane=fe:2.0 --exit-code --focus-on-fail
+
+  # Ctrl+C stops the command in the pane too; the output so far is still printed
+  arc-tmux run "make test" --pane=fe:2.0 --exit-code --forward-interrupt
+
+  # Stop a command that is still going after 10 minutes, killing it if Ctrl+C is ignored
+  arc-tmux run "./soak.sh" --pane=fe:2.0 --max-runtime 600 --kill-after 10 --output json
+
+  # Treat a deploy that goes silent for a minute while still running as hung
+  arc-tmux run "./deploy.sh" --pane=ops:1.0 --timeout 1800 --require-output-every 60 --kill-after 10
+
+  # Title the pane "make release" while it runs, so locate --title finds it
+  arc-tmux run "make release" --pane=ops:1.0 --timeout 900 --set-title
+
+  # Target the single pane matching a filter
+  arc-tmux run "npm test" --filter 'session=="fe" && title=="tests"'`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-			target, err := resolvePaneTarget(paneArg)
+			if err := outputLimit.validate(); err != nil {
+				return err
+			}
+			if maxRuntime < 0 || killAfter < 0 || heartbeat < 0 {
+				return fmt.Errorf("--max-runtime, --kill-after, and --require-output-every must be >= 0")
+			}
+			if killAfter > 0 && maxRuntime == 0 && heartbeat == 0 {
+				return fmt.Errorf("--kill-after requires --max-runtime or --require-output-every")
+			}
+			if heartbeat > 0 && screenBoundary {
+				return fmt.Errorf("--require-output-every cannot be combined with --screen-boundary")
+			}
+			// The cap replaces the default wait so a long command is stopped
+			// rather than left running when --timeout runs out first.
+			if maxRuntime > 0 && !cmd.Flags().Changed("timeout") {
+				timeout = maxRuntime
+			}
+			var handle tmux.PaneHandle
+			var err error
+			if strings.TrimSpace(filterExpr) != "" {
+				if strings.TrimSpace(paneArg) != "" || strings.TrimSpace(windowArg) != "" {
+					return fmt.Errorf("use only one of --pane, --window, or --filter")
+				}
+				handle, err = resolveSingleFilterTarget(filterExpr)
+			} else if strings.TrimSpace(windowArg) != "" {
+				var handles []tmux.PaneHandle
+				if handles, _, err = resolvePaneOrWindowTargets(cmd, paneArg, windowArg); err == nil {
+					handle = handles[0]
+				}
+			} else {
+				var raw string
+				raw, err = resolvePaneTarget(paneArg)
+				if err == nil {
+					handle, err = canonicalPaneTarget(raw)
+				}
+			}
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			progress, err := progressOpts.open(cmd)
+			if err != nil {
 				return err
 			}
+			var tee tmux.PaneHandle
+			if strings.TrimSpace(teePane) != "" {
+				raw, err := resolvePaneTarget(teePane)
+				if err != nil {
+					return err
+				}
+				tee, err = canonicalPaneTarget(raw)
+				if err != nil {
+					return err
+				}
+				if tee.ID == handle.ID {
+					return fmt.Errorf("--tee-pane must differ from the target pane")
+				}
+				if err := tmux.TeePane(handle.ID, tee.ID); err != nil {
+					return err
+				}
+				defer func() { _ = tmux.StopTee(handle.ID) }()
+			}
 
 			envPairs, err := parseEnvVars(envVars)
 			if err != nil {
 				return newCodedError(errInvalidEnv, err.Error(), err)
 			}
 
-			text := strings.Join(args, " ")
-			text = buildRunCommand(text, strings.TrimSpace(cwd), envPairs)
-			var startTag string
-			var endTag string
-			if exitCode || segment {
-				runID := newRunID()
-				startTag = fmt.Sprintf("__ARC_TMUX_RUN_START:%s__", runID)
-				endTag = fmt.Sprintf("__ARC_TMUX_RUN_END:%s__", runID)
-				text = wrapCommandForRun(text, startTag, endTag, exitTag, exitCode)
-			}
-
-			if err := tmux.SendLiteral(target, text, true, 0); err != nil {
-				return err
+			command := strings.Join(args, " ")
+			text := buildRunCommand(command, strings.TrimSpace(cwd), envPairs)
+			if setTitle {
+				title, err := setCommandTitle(handle.ID, command, keepTitle)
+				if err != nil {
+					return err
+				}
+				defer title.restore()
 			}
-
-			if timeout <= 0 {
-				timeout = 60
+			var interrupted <-chan struct{}
+			if forwardInterrupt {
+				var stop func()
+				interrupted, stop = forwardInterrupts(handle, cmd.ErrOrStderr())
+				defer stop()
 			}
-
-			waitErr := tmux.WaitIdle(target, time.Duration(idle*float64(time.Second)), time.Duration(timeout*float64(time.Second)))
-
-			s, err := tmux.Capture(target, lines)
+			result, waitErr, err := executeRun(handle.ID, text, runOptions{
+				Idle:           idle,
+				Timeout:        timeout,
+				Lines:          lines,
+				ExitCode:       exitCode,
+				ExitTag:        exitTag,
+				Segment:        segment,
+				ScreenBoundary: screenBoundary,
+				MaxRuntime:     maxRuntime,
+				KillAfter:      killAfter,
+				Heartbeat:      heartbeat,
+				Progress:       progress.with("", handle.Target),
+			})
 			if err != nil {
 				return err
 			}
-
-			capture := s
-			var codePtr *int
-			var found bool
-			if exitCode || segment {
-				clean, code, ok, windowFound := extractRunWindow(capture, startTag, endTag, exitTag, exitCode)
-				if !windowFound && lines > 0 {
-					if full, err := tmux.Capture(target, 0); err == nil {
-						clean, code, ok, windowFound = extractRunWindow(full, startTag, endTag, exitTag, exitCode)
-					}
-				}
-				if windowFound {
-					capture = clean
-					codePtr = code
-					found = ok
-				}
-				if exitCode && !found {
-					hadTrailingNewline := strings.HasSuffix(capture, "\n")
-					cleanLines, code, ok := extractExitFromLines(splitLines(capture), exitTag)
-					if ok {
-						capture = strings.Join(cleanLines, "\n")
-						if hadTrailingNewline {
-							capture += "\n"
-						}
-						codePtr = code
-						found = true
-					}
+			result.TeePane = tee.Target
+			select {
+			case <-interrupted:
+				result.Interrupted = true
+			default:
+			}
+			result.Output, result.Truncated = outputLimit.apply(result.Output)
+			if focusOnFail && runFailed(result, waitErr) {
+				focused, err := focusAttachedClients(handle, runFailureMessage(command, handle.Target, result, waitErr))
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
 				}
+				result.FocusedClients = focused
 			}
+			capture := result.Output
+			codePtr := result.ExitCode
+			found := result.ExitFound
 
 			out := cmd.OutOrStdout()
 			switch {
 			case outputOpts.Is(output.OutputJSON):
-				result := runResult{
-					Output:    capture,
-					ExitCode:  codePtr,
-					ExitFound: found,
-				}
-				if waitErr != nil {
-					result.WaitError = waitErr.Error()
-				}
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
 				if err := enc.Encode(result); err != nil {
@@ -137,14 +217,6 @@ func newRunCmd() *cobra.Command {
 				return combineRunErrors(waitErr, exitPropagate, exitCode, codePtr, found)
 
 			case outputOpts.Is(output.OutputYAML):
-				result := runResult{
-					Output:    capture,
-					ExitCode:  codePtr,
-					ExitFound: found,
-				}
-				if waitErr != nil {
-					result.WaitError = waitErr.Error()
-				}
 				enc := yaml.NewEncoder(out)
 				defer func() { _ = enc.Close() }()
 				if err := enc.Encode(result); err != nil {
@@ -162,6 +234,9 @@ func newRunCmd() *cobra.Command {
 			if _, err := fmt.Fprint(out, capture); err != nil {
 				return err
 			}
+			if result.AlternateScreen && waitErr == nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s is showing a full-screen program; the output is its screen\n", handle.Target)
+			}
 			if exitCode {
 				if codePtr != nil {
 					_, _ = fmt.Fprintf(out, "\nExit code: %d\n", *codePtr)
@@ -175,16 +250,28 @@ func newRunCmd() *cobra.Command {
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
 	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&windowArg, "window", "", "Target the active pane of this window (SESSION:WINDOW, index or name)")
 	cmd.Flags().Float64Var(&idle, "idle", 2.0, "Seconds of inactivity to consider idle")
 	cmd.Flags().Float64Var(&timeout, "timeout", 60.0, "Maximum seconds to wait")
 	cmd.Flags().IntVar(&lines, "lines", 200, "Limit capture to last N lines (0 for full)")
 	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "Emit and parse a sentinel exit code")
 	cmd.Flags().StringVar(&exitTag, "exit-tag", "__ARC_TMUX_EXIT:", "Sentinel tag for exit code parsing")
 	cmd.Flags().BoolVar(&exitPropagate, "exit-propagate", false, "Return a non-zero exit when the parsed exit code is non-zero")
-	cmd.Flags().BoolVar(&segment, "segment", false, "Capture only output for this command by inserting sentinel markers (runs via sh -lc)")
+	cmd.Flags().BoolVar(&segment, "segment", false, "Capture only output for this command by inserting sentinel markers (runs via the wrapper shell)")
 	cmd.Flags().StringVar(&cwd, "cwd", "", "Run the command from this working directory")
 	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Set environment variables for the command (KEY=VAL). Repeatable.")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().StringVar(&filterExpr, "filter", "", "Select the target pane by filter expression (must match exactly one pane)")
+	cmd.Flags().StringVar(&teePane, "tee-pane", "", "Mirror the pane's new output into this pane while the command runs")
+	addOutputCapFlags(cmd, &outputLimit)
+	cmd.Flags().BoolVar(&screenBoundary, "screen-boundary", false, "Stop waiting when the pane enters or leaves the alternate screen (vim, less, htop)")
+	cmd.Flags().BoolVar(&forwardInterrupt, "forward-interrupt", false, "On Ctrl+C, send Ctrl+C to the pane and report the output so far (Ctrl+C again to abort)")
+	cmd.Flags().Float64Var(&maxRuntime, "max-runtime", 0, "Interrupt the command with Ctrl+C once it has run this many seconds (0 for no cap)")
+	cmd.Flags().Float64Var(&heartbeat, "require-output-every", 0, "Treat the command as hung and interrupt it if a running program prints nothing for this many seconds (0 to disable)")
+	cmd.Flags().Float64Var(&killAfter, "kill-after", 0, "With --max-runtime or --require-output-every, kill the command's processes if they are still running this many seconds after the Ctrl+C")
+	cmd.Flags().BoolVar(&setTitle, "set-title", false, "Title the pane with the command while it runs, then restore its title")
+	cmd.Flags().BoolVar(&keepTitle, "keep-title", false, "With --set-title, leave panes that already have a title of their own alone")
+	cmd.Flags().BoolVar(&focusOnFail, "focus-on-fail", false, "On a timeout or non-zero exit (with --exit-code), switch clients attached to the pane's session to the pane, ring the bell, and show a message")
+	progressOpts.addFlags(cmd)
 
 	return cmd
 }
@@ -193,7 +280,414 @@ type runResult struct {
 	Output    string `json:"output" yaml:"output"`
 	ExitCode  *int   `json:"exit_code,omitempty" yaml:"exit_code,omitempty"`
 	ExitFound bool   `json:"exit_found" yaml:"exit_found"`
-	WaitError string `json:"wait_error,omitempty" yaml:"wait_error,omitempty"`
+	// ExitSource is "sentinel" when --exit-code parsed a sentinel, or
+	// "shell_hook" when the pane's shell hook recorded the status.
+	ExitSource string `json:"exit_source,omitempty" yaml:"exit_source,omitempty"`
+	WaitError  string `json:"wait_error,omitempty" yaml:"wait_error,omitempty"`
+	TeePane    string `json:"tee_pane,omitempty" yaml:"tee_pane,omitempty"`
+	// Truncated is set when --max-bytes or --max-lines cut the output.
+	Truncated bool `json:"truncated" yaml:"truncated"`
+	// FocusedClients lists the clients switched to the pane by --focus-on-fail.
+	FocusedClients []string `json:"focused_clients,omitempty" yaml:"focused_clients,omitempty"`
+	// AlternateScreen is set when the pane was showing a full-screen program
+	// (vim, less, htop) as the wait ended, so Output is that program's screen.
+	AlternateScreen bool `json:"alternate_screen" yaml:"alternate_screen"`
+	// ScreenSwitched is set when --screen-boundary ended the wait.
+	ScreenSwitched bool `json:"screen_switched,omitempty" yaml:"screen_switched,omitempty"`
+	// Interrupted is set when --forward-interrupt relayed a Ctrl+C to the pane.
+	Interrupted bool `json:"interrupted,omitempty" yaml:"interrupted,omitempty"`
+	// KilledDueToRuntime is set when --max-runtime stopped the command, Hung
+	// when --require-output-every did, and ForceKilled when --kill-after had
+	// to SIGKILL it as well.
+	KilledDueToRuntime bool `json:"killed_due_to_runtime" yaml:"killed_due_to_runtime"`
+	Hung               bool `json:"hung,omitempty" yaml:"hung,omitempty"`
+	ForceKilled        bool `json:"force_killed,omitempty" yaml:"force_killed,omitempty"`
+}
+
+// runOptions controls a single send/wait/capture cycle.
+type runOptions struct {
+	Idle     float64
+	Timeout  float64
+	Lines    int
+	ExitCode bool
+	ExitTag  string
+	Segment  bool
+	// UntilMarker waits for the end sentinel instead of idle detection;
+	// it requires ExitCode or Segment.
+	UntilMarker bool
+	// ScreenBoundary ends the wait when the pane enters or leaves the
+	// alternate screen.
+	ScreenBoundary bool
+	// MaxRuntime interrupts the command once it has run this many seconds,
+	// and Heartbeat once a program other than the shell has printed nothing
+	// for that long; KillAfter then SIGKILLs it if it is still running that
+	// much later.
+	MaxRuntime float64
+	Heartbeat  float64
+	KillAfter  float64
+	// Progress receives run_started/output/idle/run_finished events.
+	Progress *progressWriter
+}
+
+// executeRun sends text to the pane, waits for it to go idle, and captures the
+// output, parsing sentinel markers when requested. waitErr reports an idle
+// timeout; err reports a failure talking to tmux.
+func executeRun(paneID string, text string, opts runOptions) (result runResult, waitErr error, err error) {
+	// With the shell hook loaded in the shell at the prompt, the exit status
+	// comes from the hook, so --exit-code needs no sentinel wrapper.
+	var hooked bool
+	var hookSeq int
+	if !opts.Segment && !opts.UntilMarker {
+		if pane, err := tmux.PaneDetailsForTarget(paneID); err == nil && pane.ShellHook != "" && pane.Command == pane.ShellHook {
+			hooked = true
+			if pane.LastCommand != nil {
+				hookSeq = pane.LastCommand.Seq
+			}
+		}
+	}
+	var startTag string
+	var endTag string
+	if (opts.ExitCode && !hooked) || opts.Segment {
+		runID := newRunID()
+		startTag = fmt.Sprintf("__ARC_TMUX_RUN_START:%s__", runID)
+		endTag = fmt.Sprintf("__ARC_TMUX_RUN_END:%s__", runID)
+		text = wrapCommandForRun(text, startTag, endTag, opts.ExitTag, opts.ExitCode)
+	}
+
+	if opts.Progress != nil {
+		patterns, err := progressPatterns()
+		if err != nil {
+			return runResult{}, nil, err
+		}
+		opts.Progress = opts.Progress.withOutputProgress(paneID, patterns)
+	}
+
+	var initialScreen bool
+	if opts.ScreenBoundary {
+		if initialScreen, err = tmux.AlternateScreen(paneID); err != nil {
+			return runResult{}, nil, err
+		}
+	}
+	if err := tmux.SendLiteral(paneID, text, true, 0); err != nil {
+		return runResult{}, nil, err
+	}
+	sent := time.Now()
+	opts.Progress.emit(progressEvent{Event: "run_started"})
+
+	timeout := runWaitTimeout(opts.Timeout, opts.MaxRuntime)
+	switch {
+	case opts.UntilMarker && endTag != "":
+		var altScreen *bool
+		if opts.ScreenBoundary {
+			altScreen = &initialScreen
+		}
+		waitErr = waitForRunMarker(paneID, endTag, time.Duration(timeout*float64(time.Second)), altScreen, opts.Progress)
+	case opts.Heartbeat > 0:
+		waitErr = waitWithHeartbeat(paneID, time.Duration(opts.Idle*float64(time.Second)), time.Duration(opts.Heartbeat*float64(time.Second)), time.Duration(timeout*float64(time.Second)), opts.Progress)
+	case opts.ScreenBoundary:
+		waitErr = tmux.WaitIdleOrScreenSwitch(paneID, time.Duration(opts.Idle*float64(time.Second)), time.Duration(timeout*float64(time.Second)), initialScreen, opts.Progress.idleFunc())
+	default:
+		waitErr = tmux.WaitIdleFunc(paneID, time.Duration(opts.Idle*float64(time.Second)), time.Duration(timeout*float64(time.Second)), opts.Progress.idleFunc())
+	}
+	screenSwitched := errors.Is(waitErr, tmux.ErrScreenSwitched)
+	if screenSwitched {
+		waitErr = nil
+	}
+	var overRuntime, hung, forceKilled bool
+	switch {
+	case ranPastMaxRuntime(opts.MaxRuntime, waitErr, time.Since(sent)):
+		overRuntime = true
+		if forceKilled, err = stopRunawayCommand(paneID, opts.Idle, opts.KillAfter); err != nil {
+			return runResult{}, waitErr, err
+		}
+		waitErr = newCodedError(errMaxRuntime, fmt.Sprintf("command ran past --max-runtime %gs and was %s", opts.MaxRuntime, stopAction(forceKilled)), nil)
+	case errors.Is(waitErr, errNoHeartbeat):
+		hung = true
+		if forceKilled, err = stopRunawayCommand(paneID, opts.Idle, opts.KillAfter); err != nil {
+			return runResult{}, waitErr, err
+		}
+		waitErr = newCodedError(errCommandHung, fmt.Sprintf("no output for --require-output-every %gs; the command was %s", opts.Heartbeat, stopAction(forceKilled)), nil)
+	}
+	altScreen, screenCommand := paneScreenState(paneID)
+	waitErr = explainScreenWait(waitErr, altScreen, screenCommand)
+
+	capture, err := tmux.Capture(paneID, opts.Lines)
+	if err != nil {
+		return runResult{}, waitErr, err
+	}
+
+	var codePtr *int
+	var found bool
+	if startTag != "" {
+		clean, code, ok, windowFound := extractRunWindow(capture, startTag, endTag, opts.ExitTag, opts.ExitCode)
+		if !windowFound && opts.Lines > 0 {
+			if full, err := tmux.Capture(paneID, 0); err == nil {
+				clean, code, ok, windowFound = extractRunWindow(full, startTag, endTag, opts.ExitTag, opts.ExitCode)
+			}
+		}
+		if windowFound {
+			capture = clean
+			codePtr = code
+			found = ok
+		}
+		if opts.ExitCode && !found {
+			hadTrailingNewline := strings.HasSuffix(capture, "\n")
+			cleanLines, code, ok := extractExitFromLines(splitLines(capture), opts.ExitTag)
+			if ok {
+				capture = strings.Join(cleanLines, "\n")
+				if hadTrailingNewline {
+					capture += "\n"
+				}
+				codePtr = code
+				found = true
+			}
+		}
+	}
+
+	var exitSource string
+	if found {
+		exitSource = "sentinel"
+	}
+	if hooked {
+		if pane, err := tmux.PaneDetailsForTarget(paneID); err == nil && pane.LastCommand != nil && pane.LastCommand.Seq != hookSeq {
+			code := pane.LastCommand.ExitCode
+			codePtr, found, exitSource = &code, true, "shell_hook"
+		}
+	}
+
+	result = runResult{
+		Output:             capture,
+		ExitCode:           codePtr,
+		ExitFound:          found,
+		ExitSource:         exitSource,
+		AlternateScreen:    altScreen,
+		ScreenSwitched:     screenSwitched,
+		KilledDueToRuntime: overRuntime,
+		Hung:               hung,
+		ForceKilled:        forceKilled,
+	}
+	if waitErr != nil {
+		result.WaitError = waitErr.Error()
+	}
+	opts.Progress.emit(progressEvent{Event: "run_finished", Bytes: len(capture), ExitCode: codePtr, Error: result.WaitError})
+	return result, waitErr, nil
+}
+
+// errNoHeartbeat ends a --require-output-every wait whose command went silent.
+var errNoHeartbeat = errors.New("no output within the heartbeat interval")
+
+// waitWithHeartbeat waits like WaitIdle, except that a command still running
+// under the pane's shell keeps the wait going however quiet it is; once such a
+// command has printed nothing for heartbeat, it returns errNoHeartbeat.
+// Whether the command still runs comes from the pane's process tree, as for
+// kill, so it does not depend on what pane_current_command shows. Output is
+// detected by hashing the visible capture, which also works on servers
+// without pane_activity.
+func waitWithHeartbeat(paneID string, idle time.Duration, heartbeat time.Duration, timeout time.Duration, progress *progressWriter) error {
+	deadline := time.Now().Add(tmux.BoundTimeout(timeout))
+	lastHash := ""
+	lastOutput := time.Now()
+	report := progress.idleFunc()
+	for {
+		capture, err := tmux.Capture(paneID, 200)
+		if err != nil {
+			return err
+		}
+		if hash := tmux.OutputHash(capture); hash != lastHash {
+			lastHash = hash
+			lastOutput = time.Now()
+		}
+		quiet := time.Since(lastOutput)
+		switch heartbeatState(len(paneKillVictims(paneID)) > 0, quiet, idle, heartbeat) {
+		case heartbeatFinished:
+			return nil
+		case heartbeatHung:
+			return errNoHeartbeat
+		}
+		if report != nil {
+			report(tmux.IdleStatus{Idle: quiet, Remaining: idle - quiet})
+		}
+		if time.Now().After(deadline) {
+			if tmux.DeadlineExceeded() {
+				return tmux.ErrDeadlineExceeded
+			}
+			return errors.New("timeout waiting for idle")
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+}
+
+// heartbeatVerdict is the outcome of one --require-output-every poll.
+type heartbeatVerdict int
+
+const (
+	heartbeatWaiting heartbeatVerdict = iota
+	heartbeatFinished
+	heartbeatHung
+)
+
+// heartbeatState decides a --require-output-every poll: with nothing left
+// running under the shell, the command has finished once the pane is quiet
+// for idle; a command still running is hung once it is quiet for heartbeat.
+func heartbeatState(running bool, quiet time.Duration, idle time.Duration, heartbeat time.Duration) heartbeatVerdict {
+	switch {
+	case !running && quiet >= idle:
+		return heartbeatFinished
+	case running && quiet >= heartbeat:
+		return heartbeatHung
+	}
+	return heartbeatWaiting
+}
+
+// runWaitTimeout is how many seconds run waits for the command: --timeout
+// (60 when unset), cut short by --max-runtime.
+func runWaitTimeout(timeout float64, maxRuntime float64) float64 {
+	if timeout <= 0 {
+		timeout = 60
+	}
+	if maxRuntime > 0 && maxRuntime < timeout {
+		timeout = maxRuntime
+	}
+	return timeout
+}
+
+// ranPastMaxRuntime reports whether a wait that ended with waitErr after
+// elapsed hit --max-runtime, rather than finishing or hitting a shorter
+// --timeout.
+func ranPastMaxRuntime(maxRuntime float64, waitErr error, elapsed time.Duration) bool {
+	return maxRuntime > 0 && isTimeout(waitErr) && elapsed.Seconds() >= maxRuntime
+}
+
+// stopTimings returns how long stopRunawayCommand gives the command to exit
+// after Ctrl+C (--kill-after, or 5 seconds) and how long the pane must stay
+// quiet to count as settled: --idle, unless it does not fit in the grace
+// period, then a second (or the whole grace period when shorter).
+func stopTimings(idle float64, killAfter float64) (grace float64, settle float64) {
+	grace = killAfter
+	if grace <= 0 {
+		grace = 5
+	}
+	settle = idle
+	if settle <= 0 || settle > grace {
+		settle = math.Min(1, grace)
+	}
+	return grace, settle
+}
+
+func stopAction(killed bool) string {
+	if killed {
+		return "killed"
+	}
+	return "interrupted"
+}
+
+// stopRunawayCommand sends Ctrl+C to a command that ran past --max-runtime or
+// went silent past --require-output-every, and waits for the pane's shell to
+// come back. With killAfter set, processes still running under the shell
+// that long after the Ctrl+C are SIGKILLed, reported by killed.
+func stopRunawayCommand(paneID string, idle float64, killAfter float64) (killed bool, err error) {
+	if err := tmux.Interrupt(paneID); err != nil {
+		return false, err
+	}
+	grace, idle := stopTimings(idle, killAfter)
+	deadline := time.Now().Add(time.Duration(grace * float64(time.Second)))
+	for time.Now().Before(deadline) {
+		if err := tmux.WaitIdle(paneID, time.Duration(idle*float64(time.Second)), time.Until(deadline)); err != nil {
+			break
+		}
+		pane, err := tmux.PaneDetailsForTarget(paneID)
+		if err != nil {
+			return false, err
+		}
+		if isShellCommand(pane.Command) {
+			return false, nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	if killAfter <= 0 {
+		return false, nil
+	}
+	pane, err := tmux.PaneDetailsForTarget(paneID)
+	if err != nil {
+		return false, err
+	}
+	nodes, err := tmux.ProcessTree(pane.PID)
+	if err != nil {
+		return false, err
+	}
+	signalTree(nodes, syscall.SIGKILL, true)
+	// Give the shell a moment to print its prompt before the capture.
+	_ = tmux.WaitIdle(paneID, 300*time.Millisecond, 2*time.Second)
+	return true, nil
+}
+
+// waitForRunMarker polls the pane until the end sentinel is printed on its own
+// line (the echoed command line also contains the tag, but never alone).
+// An "output" event is emitted whenever the captured size changes. When
+// altScreen is set, the wait ends with tmux.ErrScreenSwitched once the pane's
+// alternate-screen state differs from it.
+func waitForRunMarker(paneID string, endTag string, timeout time.Duration, altScreen *bool, progress *progressWriter) error {
+	deadline := time.Now().Add(tmux.BoundTimeout(timeout))
+	lastBytes := -1
+	for {
+		if altScreen != nil {
+			if current, err := tmux.AlternateScreen(paneID); err == nil && current != *altScreen {
+				return tmux.ErrScreenSwitched
+			}
+		}
+		capture, err := tmux.Capture(paneID, 0)
+		if err != nil {
+			return err
+		}
+		if hasMarkerLine(capture, endTag) {
+			return nil
+		}
+		if len(capture) != lastBytes {
+			lastBytes = len(capture)
+			ev := progressEvent{Event: "output", Bytes: lastBytes}
+			progress.observe(&ev, capture)
+			progress.emit(ev)
+		}
+		if time.Now().After(deadline) {
+			if tmux.DeadlineExceeded() {
+				return tmux.ErrDeadlineExceeded
+			}
+			return errors.New("timeout waiting for command to finish")
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// paneScreenState reports whether the pane is showing the alternate screen and,
+// if so, the program that put it there.
+func paneScreenState(paneID string) (bool, string) {
+	details, err := tmux.PaneDetailsForTarget(paneID)
+	if err != nil || !details.AlternateScreen {
+		return false, ""
+	}
+	return true, details.Command
+}
+
+// explainScreenWait adds the full-screen program to a wait timeout, since a
+// program such as less or vim never prints the sentinel or goes quiet on its
+// own; other errors are returned unchanged.
+func explainScreenWait(err error, altScreen bool, command string) error {
+	if err == nil || !altScreen || !(isTimeout(err) || errors.Is(err, tmux.ErrDeadlineExceeded)) {
+		return err
+	}
+	if command == "" {
+		command = "unknown"
+	}
+	return fmt.Errorf("%w: pane is showing a full-screen program (%s) in the alternate screen", err, command)
+}
+
+func hasMarkerLine(output string, tag string) bool {
+	for _, line := range splitLines(output) {
+		if strings.TrimSpace(line) == tag {
+			return true
+		}
+	}
+	return false
 }
 
 func wrapCommandForRun(command string, startTag string, endTag string, exitTag string, includeExit bool) string {
@@ -211,7 +705,7 @@ func wrapCommandForRun(command string, startTag string, endTag string, exitTag s
 		inner += fmt.Sprintf(" printf \"\\n%s%%d\\n\" \"$status\";", exitTag)
 	}
 	inner += fmt.Sprintf(" printf \"\\n%s\\n\"", endTag)
-	return "sh -lc " + shellQuoteSingle(inner)
+	return segmentShell() + " " + shellQuoteSingle(inner)
 }
 
 func extractRunWindow(output string, startTag string, endTag string, exitTag string, parseExit bool) (string, *int, bool, bool) {
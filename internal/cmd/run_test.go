@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
 )
 
 func TestShellQuoteSingle(t *testing.T) {
@@ -32,3 +36,135 @@ func TestExtractRunWindow(t *testing.T) {
 		t.Fatalf("unexpected clean output: %q", clean)
 	}
 }
+
+func TestHasMarkerLine(t *testing.T) {
+	tag := "__ARC_TMUX_RUN_END:abc__"
+	echoed := "$ sh -lc 'printf \"\\n__ARC_TMUX_RUN_END:abc__\\n\"'\n"
+	if hasMarkerLine(echoed, tag) {
+		t.Fatal("echoed command line must not count as the marker")
+	}
+	if !hasMarkerLine(echoed+"done\n"+tag+"\n$ ", tag) {
+		t.Fatal("expected marker line to be detected")
+	}
+}
+
+func TestExplainScreenWait(t *testing.T) {
+	timeout := errors.New("timeout waiting for command to finish")
+	err := explainScreenWait(timeout, true, "less")
+	if !errors.Is(err, timeout) || !isTimeout(err) {
+		t.Fatalf("expected wrapped timeout, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "full-screen program (less)") {
+		t.Fatalf("expected program in error, got %q", err)
+	}
+	if got := explainScreenWait(timeout, false, ""); got != timeout {
+		t.Fatalf("expected timeout unchanged outside the alternate screen, got %v", got)
+	}
+	other := errors.New("tmux display-message: exit status 1")
+	if got := explainScreenWait(other, true, "vim"); got != other {
+		t.Fatalf("expected non-timeout error unchanged, got %v", got)
+	}
+	if explainScreenWait(nil, true, "vim") != nil {
+		t.Fatal("expected nil error to stay nil")
+	}
+}
+
+func TestSegmentShell(t *testing.T) {
+	defer tmux.SetCommandShell("sh", true)
+	cases := []struct {
+		shell string
+		login bool
+		want  string
+	}{
+		{"sh", true, "sh -lc"},
+		{"/bin/zsh", false, "/bin/zsh -c"},
+		{"/usr/bin/fish", true, "sh -lc"},
+	}
+	for _, tc := range cases {
+		tmux.SetCommandShell(tc.shell, tc.login)
+		if got := segmentShell(); got != tc.want {
+			t.Fatalf("segmentShell(%s, %v) = %q, want %q", tc.shell, tc.login, got, tc.want)
+		}
+	}
+}
+
+func TestRunWaitTimeout(t *testing.T) {
+	cases := []struct {
+		timeout, maxRuntime, want float64
+	}{
+		{0, 0, 60},
+		{30, 0, 30},
+		{30, 10, 10},
+		{10, 30, 10},
+		{0, 90, 60},
+	}
+	for _, c := range cases {
+		if got := runWaitTimeout(c.timeout, c.maxRuntime); got != c.want {
+			t.Fatalf("runWaitTimeout(%g, %g) = %g, want %g", c.timeout, c.maxRuntime, got, c.want)
+		}
+	}
+}
+
+func TestRanPastMaxRuntime(t *testing.T) {
+	timeout := errors.New("timeout waiting for idle")
+	cases := []struct {
+		maxRuntime float64
+		waitErr    error
+		elapsed    time.Duration
+		want       bool
+	}{
+		{10, timeout, 10 * time.Second, true},
+		{10, timeout, 11 * time.Second, true},
+		// A --timeout shorter than --max-runtime ran out first.
+		{10, timeout, 5 * time.Second, false},
+		// The command finished in time.
+		{10, nil, 10 * time.Second, false},
+		{10, errors.New("pane not found"), 10 * time.Second, false},
+		{0, timeout, time.Minute, false},
+	}
+	for _, c := range cases {
+		if got := ranPastMaxRuntime(c.maxRuntime, c.waitErr, c.elapsed); got != c.want {
+			t.Fatalf("ranPastMaxRuntime(%g, %v, %s) = %v, want %v", c.maxRuntime, c.waitErr, c.elapsed, got, c.want)
+		}
+	}
+}
+
+func TestStopTimings(t *testing.T) {
+	cases := []struct {
+		idle, killAfter     float64
+		wantGrace, wantIdle float64
+	}{
+		{2, 0, 5, 2},
+		{2, 10, 10, 2},
+		{8, 0, 5, 1},
+		{0, 0, 5, 1},
+		{2, 0.5, 0.5, 0.5},
+	}
+	for _, c := range cases {
+		grace, settle := stopTimings(c.idle, c.killAfter)
+		if grace != c.wantGrace || settle != c.wantIdle {
+			t.Fatalf("stopTimings(%g, %g) = %g, %g; want %g, %g", c.idle, c.killAfter, grace, settle, c.wantGrace, c.wantIdle)
+		}
+	}
+}
+
+func TestHeartbeatState(t *testing.T) {
+	idle, heartbeat := 2*time.Second, 30*time.Second
+	cases := []struct {
+		running bool
+		quiet   time.Duration
+		want    heartbeatVerdict
+	}{
+		// Finished: nothing runs under the shell and the pane settled.
+		{false, 3 * time.Second, heartbeatFinished},
+		{false, time.Second, heartbeatWaiting},
+		// Quiet but still running: keep waiting until the heartbeat lapses.
+		{true, 10 * time.Second, heartbeatWaiting},
+		{true, 30 * time.Second, heartbeatHung},
+	}
+	for _, c := range cases {
+		if got := heartbeatState(c.running, c.quiet, idle, heartbeat); got != c.want {
+			t.Fatalf("heartbeatState(%v, %s) = %d, want %d", c.running, c.quiet, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type scaleResult struct {
+	Session     string   `json:"session" yaml:"session"`
+	Window      string   `json:"window" yaml:"window"`
+	WindowIndex int      `json:"window_index" yaml:"window_index"`
+	Command     string   `json:"command" yaml:"command"`
+	Count       int      `json:"count" yaml:"count"`
+	Running     int      `json:"running" yaml:"running"`
+	Added       []string `json:"added" yaml:"added"`
+	Removed     []string `json:"removed" yaml:"removed"`
+	DryRun      bool     `json:"dry_run" yaml:"dry_run"`
+}
+
+func newScaleCmd() *cobra.Command {
+	var session string
+	var window string
+	var command string
+	var count int
+	var layout string
+	var split string
+	var cwd string
+	var envVars []string
+	var dryRun bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "scale",
+		Short: "Keep exactly N panes running a command in a window",
+		Long: `Create or kill panes in a window until exactly --count panes run --command.
+
+Panes are matched by their foreground command and process tree (see ensure
+--reconcile). Dead panes do not count as running. When scaling down, the
+highest-indexed matching panes are killed first; panes running other commands
+are left alone.`,
+		Example: `  # Keep five queue workers running
+  arc-tmux scale --session workers --window queue --command "npm run worker" --count 5
+
+  # Preview scaling down to two
+  arc-tmux scale --session workers --window queue --command "npm run worker" --count 2 --dry-run`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			window = strings.TrimSpace(window)
+			if window == "" {
+				return errors.New("--window is required")
+			}
+			command = strings.TrimSpace(command)
+			if command == "" {
+				return errors.New("--command is required")
+			}
+			if count < 0 {
+				return errors.New("--count must be >= 0")
+			}
+
+			envPairs, err := parseEnvVars(envVars)
+			if err != nil {
+				return newCodedError(errInvalidEnv, err.Error(), err)
+			}
+			startDir, err := resolveStartDir(cwd)
+			if err != nil {
+				return err
+			}
+			paneCommand := buildRunCommand(command, "", envPairs)
+
+			sess, shouldStyle, err := resolveEnsureSession(session)
+			if err != nil {
+				return err
+			}
+			result := scaleResult{
+				Session: sess,
+				Window:  window,
+				Command: command,
+				Count:   count,
+				Added:   []string{},
+				Removed: []string{},
+				DryRun:  dryRun,
+			}
+
+			exists, err := tmux.HasSession(sess)
+			if err != nil {
+				return err
+			}
+			var running []tmux.PaneDetails
+			var total int
+			win, found := tmux.Window{}, false
+			if exists {
+				wins, err := tmux.ListWindows(sess)
+				if err != nil {
+					return err
+				}
+				win, found = findWindowByName(wins, window)
+			}
+			if found {
+				result.WindowIndex = win.WindowIndex
+				panes, err := panesForWindow(sess, win.WindowIndex)
+				if err != nil {
+					return err
+				}
+				total = len(panes)
+				running = runningCommandPanes(panes, command)
+			}
+			result.Running = len(running)
+
+			add, remove := planScale(running, count)
+			if dryRun {
+				for i := 0; i < add; i++ {
+					result.Added = append(result.Added, "(new)")
+				}
+				for i := range remove {
+					result.Removed = append(result.Removed, formattedPaneID(&remove[i]))
+				}
+				return writeScaleResult(cmd, outputOpts, result)
+			}
+
+			if add > 0 && !found {
+				if err := tmux.EnsureSession(sess); err != nil {
+					return fmt.Errorf("failed to ensure session %q: %w", sess, err)
+				}
+				if !exists {
+					if err := applyAgentStyleIfNeeded(sess, shouldStyle); err != nil {
+						return err
+					}
+				}
+				paneID, err := tmux.NewWindowDir(sess, window, paneCommand, startDir)
+				if err != nil {
+					return err
+				}
+				pane, err := tmux.PaneDetailsForTarget(strings.TrimSpace(paneID))
+				if err != nil {
+					return err
+				}
+				result.WindowIndex = pane.WindowIndex
+				result.Added = append(result.Added, strings.TrimSpace(paneID))
+				if isAgentSessionName(sess) {
+					if err := tmux.ApplyAgentWindowStyle(sess, pane.WindowIndex); err != nil {
+						return err
+					}
+				}
+				add--
+			}
+
+			windowTarget := tmux.WindowTarget(sess, result.WindowIndex)
+			for i := 0; i < add; i++ {
+				paneID, err := tmux.SplitWindowDir(windowTarget, split, paneCommand, startDir)
+				if err != nil {
+					return err
+				}
+				result.Added = append(result.Added, strings.TrimSpace(paneID))
+				// Re-tile as we go so repeated splits don't run out of space.
+				if layout != "" {
+					_ = tmux.SelectLayout(windowTarget, layout)
+				}
+			}
+			for i := range remove {
+				if err := tmux.Kill(remove[i].PaneID); err != nil {
+					return err
+				}
+				result.Removed = append(result.Removed, formattedPaneID(&remove[i]))
+			}
+			changed := len(result.Added) > 0 || len(remove) > 0
+			if layout != "" && changed && total+len(result.Added)-len(remove) > 0 {
+				if err := selectLayoutUndoable(windowTarget, layout); err != nil {
+					return err
+				}
+			}
+			return writeScaleResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Session name or selector (@current|@managed)")
+	cmd.Flags().StringVar(&window, "window", "", "Window name holding the workers")
+	cmd.Flags().StringVar(&command, "command", "", "Command each worker pane runs")
+	cmd.Flags().IntVar(&count, "count", 1, "Number of panes that should run the command")
+	cmd.Flags().StringVar(&layout, "layout", "tiled", "Layout applied after scaling (empty to skip)")
+	cmd.Flags().StringVar(&split, "split", "", "Split direction when creating panes (h|v)")
+	cmd.Flags().StringVar(&cwd, "cwd", "", "Working directory for new worker panes")
+	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Set environment variables for new worker panes (KEY=VAL). Repeatable.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be added/removed without changing anything")
+
+	return cmd
+}
+
+// runningCommandPanes returns live panes whose process tree runs command.
+func runningCommandPanes(panes []tmux.PaneDetails, command string) []tmux.PaneDetails {
+	running := make([]tmux.PaneDetails, 0, len(panes))
+	for _, p := range panes {
+		if p.Dead {
+			continue
+		}
+		tree, _ := tmux.ProcessTree(p.PID)
+		if paneRunsCommand(p, tree, command) {
+			running = append(running, p)
+		}
+	}
+	return running
+}
+
+// planScale returns how many panes to add and which running panes to kill
+// (highest pane index first) to reach count.
+func planScale(running []tmux.PaneDetails, count int) (int, []tmux.PaneDetails) {
+	if len(running) <= count {
+		return count - len(running), nil
+	}
+	sorted := append([]tmux.PaneDetails(nil), running...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PaneIndex > sorted[j].PaneIndex })
+	return 0, sorted[:len(running)-count]
+}
+
+func writeScaleResult(cmd *cobra.Command, outputOpts output.OutputOptions, result scaleResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, id := range result.Added {
+			_, _ = fmt.Fprintf(out, "+%s\n", id)
+		}
+		for _, id := range result.Removed {
+			_, _ = fmt.Fprintf(out, "-%s\n", id)
+		}
+		return nil
+	}
+
+	prefix := ""
+	if result.DryRun {
+		prefix = "[dry-run] "
+	}
+	_, _ = fmt.Fprintf(out, "%sScaled %q in %s/%s: %d -> %d running.\n", prefix, result.Command, result.Session, result.Window, result.Running, result.Count)
+	if len(result.Added) > 0 {
+		_, _ = fmt.Fprintf(out, "  added:   %s\n", strings.Join(result.Added, ", "))
+	}
+	if len(result.Removed) > 0 {
+		_, _ = fmt.Fprintf(out, "  removed: %s\n", strings.Join(result.Removed, ", "))
+	}
+	return nil
+}
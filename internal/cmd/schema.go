@@ -0,0 +1,289 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaVersion is bumped whenever a documented --output json shape changes.
+const schemaVersion = 1
+
+type schemaEntry struct {
+	Command     string
+	Description string
+	// Value is nil for commands that print other commands' output as is.
+	Value  any
+	Stream bool
+}
+
+func schemaRegistry() []schemaEntry {
+	return []schemaEntry{
+		{Command: "alias gc", Description: "Aliases removed because their pane no longer exists.", Value: aliasGCResult{}},
+		{Command: "alias list", Description: "Saved pane aliases.", Value: []aliasEntry{}},
+		{Command: "alias resolve", Description: "A resolved pane alias.", Value: aliasEntry{}},
+		{Command: "alias set", Description: "The alias that was saved.", Value: aliasEntry{}},
+		{Command: "alias set-from-window", Description: "Aliases derived from pane titles.", Value: aliasFromWindowResult{}},
+		{Command: "alias unset", Description: "Alias removal result.", Value: aliasUnsetResult{}},
+		{Command: "annotate", Description: "Marker printed into a pane and its checkpoint.", Value: annotateResult{}},
+		{Command: "attach", Description: "Session that would be attached.", Value: attachResult{}},
+		{Command: "bind install", Description: "Keybinding install result.", Value: bindResult{}},
+		{Command: "bind show", Description: "Keybindings that would be installed.", Value: bindResult{}},
+		{Command: "bind uninstall", Description: "Keybinding removal result.", Value: bindResult{}},
+		{Command: "blame", Description: "Input sent to a pane, by actor.", Value: blameResult{}},
+		{Command: "capture", Description: "Captured pane output.", Value: captureResult{}},
+		{Command: "cd", Description: "Verified pane directory change.", Value: cdResult{}},
+		{Command: "checkpoint compare", Description: "Pane state compared with a named checkpoint.", Value: checkpointResult{}},
+		{Command: "checkpoint save", Description: "Saved pane state checkpoint.", Value: checkpointResult{}},
+		{Command: "cleanup", Description: "Session cleanup result.", Value: cleanupResult{}},
+		{Command: "completion install", Description: "Installed completion script.", Value: completionInstallResult{}},
+		{Command: "compose", Description: "Window of Compose service panes.", Value: composeResult{}},
+		{Command: "copy-mode", Description: "Copy-mode search and copy result.", Value: copyModeResult{}},
+		{Command: "default clear", Description: "Default pane after removal.", Value: defaultPaneResult{}},
+		{Command: "default set", Description: "The session's new default pane.", Value: defaultPaneResult{}},
+		{Command: "default show", Description: "The session's default pane.", Value: defaultPaneResult{}},
+		{Command: "dev", Description: "Project dev window and the pane in each slot.", Value: devResult{}},
+		{Command: "diff", Description: "Pane output diff against a checkpoint or another pane.", Value: diffResult{}},
+		{Command: "ensure", Description: "Session/window/pane reconciliation result.", Value: ensureResult{}},
+		{Command: "escape", Description: "Escape key action result.", Value: actionResult{}},
+		{Command: "exec", Description: "One NDJSON result per finished command.", Value: execResult{}, Stream: true},
+		{Command: "fanout", Description: "Per-host results of an SSH fan-out.", Value: fanoutResult{}},
+		{Command: "fmt", Description: "Expanded tmux format strings keyed by format.", Value: fmtResult{}},
+		{Command: "follow", Description: "One NDJSON event per streamed line.", Value: followEvent{}, Stream: true},
+		{Command: "handoff", Description: "Session summary for handing work over.", Value: handoffReport{}},
+		{Command: "history", Description: "Recent commands recorded by a pane's shell hook.", Value: historyResult{}},
+		{Command: "hooks install", Description: "Lifecycle hook install result.", Value: hooksResult{}},
+		{Command: "hooks show", Description: "Lifecycle hooks that would be installed.", Value: hooksResult{}},
+		{Command: "hooks uninstall", Description: "Lifecycle hook removal result.", Value: hooksResult{}},
+		{Command: "idle", Description: "Idle/busy state of each pane.", Value: idleReport{}},
+		{Command: "init", Description: "First-run setup steps and their outcome.", Value: initResult{}},
+		{Command: "inspect", Description: "Pane metadata and process tree.", Value: inspectSnapshot{}},
+		{Command: "interrupt", Description: "Ctrl+C action result.", Value: actionResult{}},
+		{Command: "key", Description: "Special keys sent to a pane.", Value: keyResult{}},
+		{Command: "kill", Description: "Pane kill result.", Value: killResult{}},
+		{Command: "launch", Description: "Newly launched pane.", Value: launchResult{}},
+		{Command: "list", Description: "Panes across all sessions.", Value: []paneInfo{}},
+		{Command: "locate", Description: "Panes matching a metadata query.", Value: []paneSnapshot{}},
+		{Command: "message", Description: "Clients shown a notification.", Value: messageResult{}},
+		{Command: "monitor", Description: "Pane activity snapshot.", Value: monitorSnapshot{}},
+		{Command: "on-change", Description: "One NDJSON event per command sent.", Value: onChangeEvent{}, Stream: true},
+		{Command: "open-ref", Description: "File reference opened in an editor pane.", Value: openRefResult{}},
+		{Command: "panes", Description: "Panes with extended metadata.", Value: []paneSnapshot{}},
+		{Command: "pipeline", Description: "Pipeline execution report.", Value: pipelineReport{}},
+		{Command: "preset", Description: "Window built from a preset and the pane in each slot.", Value: presetResult{}},
+		{Command: "reap", Description: "Exit codes of dead panes that were removed.", Value: reapResult{}},
+		{Command: "recipes", Description: "Common workflows.", Value: []recipe{}},
+		{Command: "recipes run", Description: "Each step's own output, in order; see the step command's schema.", Value: nil},
+		{Command: "report activity", Description: "Busy time per pane with an hourly heatmap.", Value: activityReport{}},
+		{Command: "report record", Description: "One NDJSON line per activity sample taken.", Value: activityRecordTick{}, Stream: true},
+		{Command: "repl eval", Description: "REPL evaluation result.", Value: replEvalResult{}},
+		{Command: "run", Description: "Captured output of a command run.", Value: runResult{}},
+		{Command: "scale", Description: "Worker panes added/removed to reach the target count.", Value: scaleResult{}},
+		{Command: "scroll", Description: "Pane scroll position after scrolling.", Value: scrollResult{}},
+		{Command: "send", Description: "Text/keys sent to a pane.", Value: sendResult{}},
+		{Command: "sessions", Description: "tmux sessions.", Value: []sessionInfo{}},
+		{Command: "setenv", Description: "Variables exported into a pane's shell.", Value: setenvResult{}},
+		{Command: "shell-hook install", Description: "Shell hook loaded into a pane's shell.", Value: shellHookResult{}},
+		{Command: "ship list", Description: "Panes whose output is being shipped.", Value: shipResult{}},
+		{Command: "ship start", Description: "Panes now shipping output and their sinks.", Value: shipResult{}},
+		{Command: "ship stop", Description: "Panes no longer shipping output.", Value: shipResult{}},
+		{Command: "signal", Description: "Signal delivery result.", Value: signalResult{}},
+		{Command: "status", Description: "Current tmux location.", Value: statusSnapshot{}},
+		{Command: "stop", Description: "Interrupt/kill result.", Value: stopResult{}},
+		{Command: "then", Description: "Follow-up command sent once a pane finished.", Value: thenResult{}},
+		{Command: "triage", Description: "Error findings in a pane's recent output.", Value: triageReport{}},
+		{Command: "undo", Description: "Operations reverted, or the undo journal with --list.", Value: undoResult{}},
+		{Command: "version", Description: "CLI/tmux version and feature report.", Value: versionReport{}},
+		{Command: "wait", Description: "Idle wait result.", Value: waitResult{}},
+		{Command: "watch", Description: "One NDJSON event per rule match.", Value: watchEvent{}, Stream: true},
+		{Command: "windows", Description: "tmux windows.", Value: []tmux.Window{}},
+	}
+}
+
+func newSchemaCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "schema [command]",
+		Short: "Emit JSON Schemas for --output json",
+		Long: `Emit JSON Schema (draft 2020-12) documents describing each command's
+--output json structure. Without arguments, all schemas are emitted keyed by
+command name. Schemas carry a version that is bumped on breaking changes.`,
+		Example: `  arc-tmux schema
+  arc-tmux schema run
+  arc-tmux schema "alias list"
+  arc-tmux schema --output quiet`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+
+			entries := schemaRegistry()
+			name := strings.TrimSpace(strings.Join(args, " "))
+			if name != "" {
+				entry, ok := findSchemaEntry(entries, name)
+				if !ok {
+					return fmt.Errorf("no schema for command %q", name)
+				}
+				entries = []schemaEntry{entry}
+			}
+
+			out := cmd.OutOrStdout()
+			var doc any
+			if name != "" {
+				doc = commandSchema(entries[0])
+			} else {
+				all := make(map[string]any, len(entries))
+				for _, e := range entries {
+					all[e.Command] = commandSchema(e)
+				}
+				doc = all
+			}
+
+			switch {
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(doc)
+			case outputOpts.Is(output.OutputQuiet):
+				for _, e := range entries {
+					_, _ = fmt.Fprintln(out, e.Command)
+				}
+				return nil
+			case outputOpts.Is(output.OutputTable) && name == "":
+				_, _ = fmt.Fprintf(out, "Schemas (v%d):\n", selectedAPIVersion)
+				for _, e := range entries {
+					_, _ = fmt.Fprintf(out, "  %-14s %s\n", e.Command, e.Description)
+				}
+				return nil
+			}
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			return enc.Encode(doc)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputJSON)
+	return cmd
+}
+
+func findSchemaEntry(entries []schemaEntry, name string) (schemaEntry, bool) {
+	needle := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	for _, e := range entries {
+		if e.Command == needle {
+			return e, true
+		}
+	}
+	return schemaEntry{}, false
+}
+
+func commandSchema(entry schemaEntry) map[string]any {
+	doc := map[string]any{}
+	if entry.Value != nil {
+		doc = jsonSchemaFor(reflect.TypeOf(entry.Value))
+	}
+	doc["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	doc["$id"] = fmt.Sprintf("arc-tmux://schemas/v%d/%s", selectedAPIVersion, strings.ReplaceAll(entry.Command, " ", "-"))
+	doc["title"] = "arc-tmux " + entry.Command
+	doc["description"] = entry.Description
+	doc["x-arc-tmux-schema-version"] = selectedAPIVersion
+	if entry.Stream {
+		doc["x-arc-tmux-stream"] = "ndjson"
+	}
+	return doc
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func jsonSchemaFor(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		inner := jsonSchemaFor(t.Elem())
+		if typ, ok := inner["type"].(string); ok {
+			inner["type"] = []string{typ, "null"}
+		}
+		return inner
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": jsonSchemaFor(t.Elem())}
+	case reflect.Struct:
+		props := make(map[string]any)
+		required := make([]string, 0)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, omitEmpty, skip := jsonFieldName(field)
+			if skip {
+				continue
+			}
+			prop := jsonSchemaFor(field.Type)
+			if k := field.Type.Kind(); !omitEmpty && (k == reflect.Slice || k == reflect.Map) {
+				// nil slices/maps encode as null.
+				prop["type"] = []string{prop["type"].(string), "null"}
+			}
+			props[name] = prop
+			if !omitEmpty {
+				required = append(required, name)
+			}
+		}
+		sort.Strings(required)
+		doc := map[string]any{
+			"type":                 "object",
+			"properties":           props,
+			"additionalProperties": false,
+		}
+		if len(required) > 0 {
+			doc["required"] = required
+		}
+		return doc
+	default:
+		return map[string]any{}
+	}
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	omitEmpty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
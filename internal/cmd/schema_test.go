@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestJSONSchemaForStruct(t *testing.T) {
+	doc := jsonSchemaFor(reflect.TypeOf(runResult{}))
+	props, ok := doc["properties"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected properties map, got %#v", doc["properties"])
+	}
+	code, ok := props["exit_code"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected exit_code property, got %#v", props)
+	}
+	if types, ok := code["type"].([]string); !ok || types[0] != "integer" || types[1] != "null" {
+		t.Fatalf("unexpected exit_code type: %#v", code["type"])
+	}
+	required, _ := doc["required"].([]string)
+	for _, name := range required {
+		if name == "exit_code" || name == "wait_error" {
+			t.Fatalf("omitempty field %s should not be required", name)
+		}
+	}
+}
+
+func TestSchemaRegistryCoversCommands(t *testing.T) {
+	root := NewRootCmd()
+	for _, entry := range schemaRegistry() {
+		found, _, err := root.Find(strings.Fields(entry.Command))
+		if err != nil || found == root {
+			t.Fatalf("schema entry %q does not match a command", entry.Command)
+		}
+	}
+	if _, ok := findSchemaEntry(schemaRegistry(), "  Alias   LIST "); !ok {
+		t.Fatalf("expected normalized lookup to succeed")
+	}
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		// schema prints the schemas themselves; hidden commands are internal.
+		if c.HasParent() && !c.Hidden && c.Name() != "schema" && c.Flags().Lookup("output") != nil {
+			name := strings.TrimPrefix(c.CommandPath(), c.Root().Name()+" ")
+			if _, ok := findSchemaEntry(schemaRegistry(), name); !ok {
+				t.Errorf("command %q has --output but no schema entry", name)
+			}
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+	if doc := commandSchema(schemaEntry{Command: "recipes run"}); doc["type"] != nil {
+		t.Fatalf("expected an unconstrained schema for a nil value, got %#v", doc)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// screenColor is a terminal color: the default color, one of the 256 indexed
+// colors, or a 24-bit RGB value.
+type screenColor struct {
+	Kind  int // colorDefault, colorIndexed, or colorRGB
+	Index int
+	RGB   color.RGBA
+}
+
+const (
+	colorDefault = iota
+	colorIndexed
+	colorRGB
+)
+
+// screenStyle is the SGR state of a cell.
+type screenStyle struct {
+	FG        screenColor
+	BG        screenColor
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+	Reverse   bool
+}
+
+type screenCell struct {
+	Rune  rune
+	Style screenStyle
+}
+
+// screen is a pane capture parsed into styled cells, one row per line.
+type screen struct {
+	Rows  [][]screenCell
+	Width int
+}
+
+// parseANSIScreen parses a capture-pane -e capture. SGR sequences set the
+// style of the following cells; other escape sequences are dropped.
+func parseANSIScreen(raw string) screen {
+	raw = strings.TrimSuffix(raw, "\n")
+	var scr screen
+	var style screenStyle
+	for _, line := range strings.Split(raw, "\n") {
+		var row []screenCell
+		runes := []rune(line)
+		for i := 0; i < len(runes); i++ {
+			r := runes[i]
+			if r != 0x1b {
+				if r == '\t' {
+					for len(row)%8 != 7 {
+						row = append(row, screenCell{Rune: ' ', Style: style})
+					}
+					r = ' '
+				}
+				if r < 0x20 {
+					continue
+				}
+				row = append(row, screenCell{Rune: r, Style: style})
+				continue
+			}
+			if i+1 >= len(runes) {
+				break
+			}
+			switch runes[i+1] {
+			case '[':
+				end := i + 2
+				for end < len(runes) && (runes[end] < 0x40 || runes[end] > 0x7e) {
+					end++
+				}
+				if end < len(runes) && runes[end] == 'm' {
+					style = applySGR(style, string(runes[i+2:end]))
+				}
+				i = end
+			case ']':
+				// OSC (e.g. hyperlinks) ends with BEL or ESC \.
+				end := i + 2
+				for end < len(runes) && runes[end] != 0x07 && !(runes[end] == 0x1b && end+1 < len(runes) && runes[end+1] == '\\') {
+					end++
+				}
+				if end < len(runes) && runes[end] == 0x1b {
+					end++
+				}
+				i = end
+			default:
+				i++
+			}
+		}
+		if len(row) > scr.Width {
+			scr.Width = len(row)
+		}
+		scr.Rows = append(scr.Rows, row)
+	}
+	return scr
+}
+
+// applySGR applies the parameters of one "ESC [ ... m" sequence.
+func applySGR(style screenStyle, params string) screenStyle {
+	if params == "" {
+		return screenStyle{}
+	}
+	fields := strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' })
+	codes := make([]int, len(fields))
+	for i, f := range fields {
+		codes[i], _ = strconv.Atoi(f)
+	}
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		switch {
+		case code == 0:
+			style = screenStyle{}
+		case code == 1:
+			style.Bold = true
+		case code == 2:
+			style.Dim = true
+		case code == 3:
+			style.Italic = true
+		case code == 4:
+			style.Underline = true
+		case code == 7:
+			style.Reverse = true
+		case code == 22:
+			style.Bold, style.Dim = false, false
+		case code == 23:
+			style.Italic = false
+		case code == 24:
+			style.Underline = false
+		case code == 27:
+			style.Reverse = false
+		case code >= 30 && code <= 37:
+			style.FG = screenColor{Kind: colorIndexed, Index: code - 30}
+		case code == 39:
+			style.FG = screenColor{}
+		case code >= 40 && code <= 47:
+			style.BG = screenColor{Kind: colorIndexed, Index: code - 40}
+		case code == 49:
+			style.BG = screenColor{}
+		case code >= 90 && code <= 97:
+			style.FG = screenColor{Kind: colorIndexed, Index: code - 90 + 8}
+		case code >= 100 && code <= 107:
+			style.BG = screenColor{Kind: colorIndexed, Index: code - 100 + 8}
+		case code == 38 || code == 48:
+			c, used := parseExtendedColor(codes[i+1:])
+			i += used
+			if code == 38 {
+				style.FG = c
+			} else {
+				style.BG = c
+			}
+		}
+	}
+	return style
+}
+
+// parseExtendedColor parses the arguments after 38 or 48 ("5;N" or
+// "2;R;G;B"), returning the color and the number of arguments consumed.
+func parseExtendedColor(args []int) (screenColor, int) {
+	if len(args) >= 2 && args[0] == 5 {
+		return screenColor{Kind: colorIndexed, Index: args[1] & 0xff}, 2
+	}
+	if len(args) >= 4 && args[0] == 2 {
+		return screenColor{Kind: colorRGB, RGB: color.RGBA{uint8(args[1]), uint8(args[2]), uint8(args[3]), 0xff}}, 4
+	}
+	return screenColor{}, len(args)
+}
+
+var (
+	screenDefaultFG = color.RGBA{0xd0, 0xd0, 0xd0, 0xff}
+	screenDefaultBG = color.RGBA{0x1c, 0x1c, 0x1c, 0xff}
+)
+
+// screenBasePalette is the xterm palette for the 16 standard colors.
+var screenBasePalette = [16]color.RGBA{
+	{0x00, 0x00, 0x00, 0xff}, {0xcd, 0x00, 0x00, 0xff}, {0x00, 0xcd, 0x00, 0xff}, {0xcd, 0xcd, 0x00, 0xff},
+	{0x00, 0x00, 0xee, 0xff}, {0xcd, 0x00, 0xcd, 0xff}, {0x00, 0xcd, 0xcd, 0xff}, {0xe5, 0xe5, 0xe5, 0xff},
+	{0x7f, 0x7f, 0x7f, 0xff}, {0xff, 0x00, 0x00, 0xff}, {0x00, 0xff, 0x00, 0xff}, {0xff, 0xff, 0x00, 0xff},
+	{0x5c, 0x5c, 0xff, 0xff}, {0xff, 0x00, 0xff, 0xff}, {0x00, 0xff, 0xff, 0xff}, {0xff, 0xff, 0xff, 0xff},
+}
+
+// indexedColor maps an xterm 256-color index to RGB.
+func indexedColor(index int) color.RGBA {
+	switch {
+	case index < 16:
+		return screenBasePalette[index]
+	case index < 232:
+		levels := [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+		i := index - 16
+		return color.RGBA{levels[i/36], levels[(i/6)%6], levels[i%6], 0xff}
+	default:
+		gray := uint8(8 + (index-232)*10)
+		return color.RGBA{gray, gray, gray, 0xff}
+	}
+}
+
+// colors resolves a style to the foreground and background actually drawn,
+// applying reverse video, bold-as-bright, and dim.
+func (s screenStyle) colors() (fg color.RGBA, bg color.RGBA) {
+	resolve := func(c screenColor, def color.RGBA, bright bool) color.RGBA {
+		switch c.Kind {
+		case colorIndexed:
+			if bright && c.Index < 8 {
+				return indexedColor(c.Index + 8)
+			}
+			return indexedColor(c.Index)
+		case colorRGB:
+			return c.RGB
+		default:
+			return def
+		}
+	}
+	fg = resolve(s.FG, screenDefaultFG, s.Bold)
+	bg = resolve(s.BG, screenDefaultBG, false)
+	if s.Reverse {
+		fg, bg = bg, fg
+	}
+	if s.Dim {
+		fg = color.RGBA{uint8((uint16(fg.R) + uint16(bg.R)) / 2), uint8((uint16(fg.G) + uint16(bg.G)) / 2), uint8((uint16(fg.B) + uint16(bg.B)) / 2), 0xff}
+	}
+	return fg, bg
+}
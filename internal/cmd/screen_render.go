@@ -0,0 +1,304 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PNG cell geometry: each cell is a 6x9 grid of font pixels (a 5x7 glyph with
+// a one-pixel gap), each drawn as a pngScale x pngScale square.
+const (
+	pngScale      = 2
+	pngCellWidth  = 6 * pngScale
+	pngCellHeight = 9 * pngScale
+)
+
+// SVG cell geometry, in user units, for a 14px monospace font.
+const (
+	svgFontSize   = 14
+	svgCellWidth  = 8.4
+	svgCellHeight = 17
+)
+
+// renderScreenPNG draws scr with the built-in 5x7 bitmap font. Characters
+// without a glyph are drawn as '?'; box-drawing lines are drawn as lines.
+func renderScreenPNG(scr screen, w io.Writer) error {
+	width, height := scr.Width, len(scr.Rows)
+	if width == 0 {
+		width = 1
+	}
+	if height == 0 {
+		height = 1
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width*pngCellWidth, height*pngCellHeight))
+	fillRect(img, img.Bounds(), screenDefaultBG)
+	for y, row := range scr.Rows {
+		for x, cell := range row {
+			fg, bg := cell.Style.colors()
+			x0, y0 := x*pngCellWidth, y*pngCellHeight
+			fillRect(img, image.Rect(x0, y0, x0+pngCellWidth, y0+pngCellHeight), bg)
+			drawCellGlyph(img, x0, y0, cell.Rune, fg, cell.Style.Bold)
+			if cell.Style.Underline {
+				fillRect(img, image.Rect(x0, y0+8*pngScale, x0+pngCellWidth, y0+9*pngScale), fg)
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// setFontPixel fills font pixel (px, py) of the cell at (x0, y0).
+func setFontPixel(img *image.RGBA, x0 int, y0 int, px int, py int, c color.RGBA) {
+	fillRect(img, image.Rect(x0+px*pngScale, y0+py*pngScale, x0+(px+1)*pngScale, y0+(py+1)*pngScale), c)
+}
+
+func drawCellGlyph(img *image.RGBA, x0 int, y0 int, r rune, fg color.RGBA, bold bool) {
+	if r == ' ' {
+		return
+	}
+	if drawBoxRune(img, x0, y0, r, fg) {
+		return
+	}
+	glyph, ok := glyphFor(r)
+	if !ok {
+		glyph, _ = glyphFor('?')
+	}
+	for col, bits := range glyph {
+		for row := 0; row < 7; row++ {
+			if bits&(1<<row) == 0 {
+				continue
+			}
+			setFontPixel(img, x0, y0, col, row+1, fg)
+			if bold {
+				setFontPixel(img, x0, y0, col+1, row+1, fg)
+			}
+		}
+	}
+}
+
+// boxRunes maps box-drawing and block characters to the arms they draw:
+// left, right, up, down. Full block fills the cell.
+var boxRunes = map[rune][4]bool{
+	'─': {true, true, false, false}, '━': {true, true, false, false},
+	'│': {false, false, true, true}, '┃': {false, false, true, true},
+	'┌': {false, true, false, true}, '┐': {true, false, false, true},
+	'└': {false, true, true, false}, '┘': {true, false, true, false},
+	'├': {false, true, true, true}, '┤': {true, false, true, true},
+	'┬': {true, true, false, true}, '┴': {true, true, true, false},
+	'┼': {true, true, true, true},
+	'╭': {false, true, false, true}, '╮': {true, false, false, true},
+	'╰': {false, true, true, false}, '╯': {true, false, true, false},
+}
+
+func drawBoxRune(img *image.RGBA, x0 int, y0 int, r rune, fg color.RGBA) bool {
+	if r == '█' {
+		fillRect(img, image.Rect(x0, y0, x0+pngCellWidth, y0+pngCellHeight), fg)
+		return true
+	}
+	arms, ok := boxRunes[r]
+	if !ok {
+		return false
+	}
+	midX, midY := x0+pngCellWidth/2-pngScale/2, y0+pngCellHeight/2-pngScale/2
+	if arms[0] {
+		fillRect(img, image.Rect(x0, midY, midX+pngScale, midY+pngScale), fg)
+	}
+	if arms[1] {
+		fillRect(img, image.Rect(midX, midY, x0+pngCellWidth, midY+pngScale), fg)
+	}
+	if arms[2] {
+		fillRect(img, image.Rect(midX, y0, midX+pngScale, midY+pngScale), fg)
+	}
+	if arms[3] {
+		fillRect(img, image.Rect(midX, midY, midX+pngScale, y0+pngCellHeight), fg)
+	}
+	return true
+}
+
+// renderScreenSVG writes scr as SVG text runs over background rectangles,
+// leaving glyphs to the viewer's monospace font.
+func renderScreenSVG(scr screen, w io.Writer) error {
+	width := float64(scr.Width) * svgCellWidth
+	height := float64(len(scr.Rows)) * svgCellHeight
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n", svgNum(width), svgNum(height), svgNum(width), svgNum(height))
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(screenDefaultBG))
+	fmt.Fprintf(&b, `<g font-family="Menlo, Consolas, 'DejaVu Sans Mono', monospace" font-size="%d" xml:space="preserve">`+"\n", svgFontSize)
+	for y, row := range scr.Rows {
+		top := float64(y) * svgCellHeight
+		for start := 0; start < len(row); {
+			end := start + 1
+			for end < len(row) && row[end].Style == row[start].Style {
+				end++
+			}
+			fg, bg := row[start].Style.colors()
+			x := float64(start) * svgCellWidth
+			span := float64(end-start) * svgCellWidth
+			if bg != screenDefaultBG {
+				fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%d" fill="%s"/>`+"\n", svgNum(x), svgNum(top), svgNum(span), svgCellHeight, hexColor(bg))
+			}
+			text := make([]rune, 0, end-start)
+			for _, cell := range row[start:end] {
+				text = append(text, cell.Rune)
+			}
+			if strings.TrimSpace(string(text)) != "" {
+				fmt.Fprintf(&b, `<text x="%s" y="%s" fill="%s" textLength="%s" lengthAdjust="spacingAndGlyphs"%s>%s</text>`+"\n",
+					svgNum(x), svgNum(top+svgCellHeight-4), hexColor(fg), svgNum(span), svgTextAttrs(row[start].Style), xmlEscape(string(text)))
+			}
+			start = end
+		}
+	}
+	b.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func svgTextAttrs(s screenStyle) string {
+	var attrs string
+	if s.Bold {
+		attrs += ` font-weight="bold"`
+	}
+	if s.Italic {
+		attrs += ` font-style="italic"`
+	}
+	if s.Underline {
+		attrs += ` text-decoration="underline"`
+	}
+	return attrs
+}
+
+// svgNum formats a coordinate to two decimals without trailing zeros.
+func svgNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
+
+// glyphFor returns the 5x7 glyph for printable ASCII: five columns, bit 0 at
+// the top.
+func glyphFor(r rune) ([5]byte, bool) {
+	if r < 0x20 || r > 0x7e {
+		return [5]byte{}, false
+	}
+	return font5x7[r-0x20], true
+}
+
+var font5x7 = [95][5]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00}, // ' '
+	{0x00, 0x00, 0x5f, 0x00, 0x00}, // !
+	{0x00, 0x07, 0x00, 0x07, 0x00}, // "
+	{0x14, 0x7f, 0x14, 0x7f, 0x14}, // #
+	{0x24, 0x2a, 0x7f, 0x2a, 0x12}, // $
+	{0x23, 0x13, 0x08, 0x64, 0x62}, // %
+	{0x36, 0x49, 0x55, 0x22, 0x50}, // &
+	{0x00, 0x05, 0x03, 0x00, 0x00}, // '
+	{0x00, 0x1c, 0x22, 0x41, 0x00}, // (
+	{0x00, 0x41, 0x22, 0x1c, 0x00}, // )
+	{0x14, 0x08, 0x3e, 0x08, 0x14}, // *
+	{0x08, 0x08, 0x3e, 0x08, 0x08}, // +
+	{0x00, 0x50, 0x30, 0x00, 0x00}, // ,
+	{0x08, 0x08, 0x08, 0x08, 0x08}, // -
+	{0x00, 0x60, 0x60, 0x00, 0x00}, // .
+	{0x20, 0x10, 0x08, 0x04, 0x02}, // /
+	{0x3e, 0x51, 0x49, 0x45, 0x3e}, // 0
+	{0x00, 0x42, 0x7f, 0x40, 0x00}, // 1
+	{0x42, 0x61, 0x51, 0x49, 0x46}, // 2
+	{0x21, 0x41, 0x45, 0x4b, 0x31}, // 3
+	{0x18, 0x14, 0x12, 0x7f, 0x10}, // 4
+	{0x27, 0x45, 0x45, 0x45, 0x39}, // 5
+	{0x3c, 0x4a, 0x49, 0x49, 0x30}, // 6
+	{0x01, 0x71, 0x09, 0x05, 0x03}, // 7
+	{0x36, 0x49, 0x49, 0x49, 0x36}, // 8
+	{0x06, 0x49, 0x49, 0x29, 0x1e}, // 9
+	{0x00, 0x36, 0x36, 0x00, 0x00}, // :
+	{0x00, 0x56, 0x36, 0x00, 0x00}, // ;
+	{0x08, 0x14, 0x22, 0x41, 0x00}, // <
+	{0x14, 0x14, 0x14, 0x14, 0x14}, // =
+	{0x00, 0x41, 0x22, 0x14, 0x08}, // >
+	{0x02, 0x01, 0x51, 0x09, 0x06}, // ?
+	{0x32, 0x49, 0x79, 0x41, 0x3e}, // @
+	{0x7e, 0x11, 0x11, 0x11, 0x7e}, // A
+	{0x7f, 0x49, 0x49, 0x49, 0x36}, // B
+	{0x3e, 0x41, 0x41, 0x41, 0x22}, // C
+	{0x7f, 0x41, 0x41, 0x22, 0x1c}, // D
+	{0x7f, 0x49, 0x49, 0x49, 0x41}, // E
+	{0x7f, 0x09, 0x09, 0x09, 0x01}, // F
+	{0x3e, 0x41, 0x49, 0x49, 0x7a}, // G
+	{0x7f, 0x08, 0x08, 0x08, 0x7f}, // H
+	{0x00, 0x41, 0x7f, 0x41, 0x00}, // I
+	{0x20, 0x40, 0x41, 0x3f, 0x01}, // J
+	{0x7f, 0x08, 0x14, 0x22, 0x41}, // K
+	{0x7f, 0x40, 0x40, 0x40, 0x40}, // L
+	{0x7f, 0x02, 0x0c, 0x02, 0x7f}, // M
+	{0x7f, 0x04, 0x08, 0x10, 0x7f}, // N
+	{0x3e, 0x41, 0x41, 0x41, 0x3e}, // O
+	{0x7f, 0x09, 0x09, 0x09, 0x06}, // P
+	{0x3e, 0x41, 0x51, 0x21, 0x5e}, // Q
+	{0x7f, 0x09, 0x19, 0x29, 0x46}, // R
+	{0x46, 0x49, 0x49, 0x49, 0x31}, // S
+	{0x01, 0x01, 0x7f, 0x01, 0x01}, // T
+	{0x3f, 0x40, 0x40, 0x40, 0x3f}, // U
+	{0x1f, 0x20, 0x40, 0x20, 0x1f}, // V
+	{0x3f, 0x40, 0x38, 0x40, 0x3f}, // W
+	{0x63, 0x14, 0x08, 0x14, 0x63}, // X
+	{0x07, 0x08, 0x70, 0x08, 0x07}, // Y
+	{0x61, 0x51, 0x49, 0x45, 0x43}, // Z
+	{0x00, 0x7f, 0x41, 0x41, 0x00}, // [
+	{0x02, 0x04, 0x08, 0x10, 0x20}, // backslash
+	{0x00, 0x41, 0x41, 0x7f, 0x00}, // ]
+	{0x04, 0x02, 0x01, 0x02, 0x04}, // ^
+	{0x40, 0x40, 0x40, 0x40, 0x40}, // _
+	{0x00, 0x01, 0x02, 0x04, 0x00}, // `
+	{0x20, 0x54, 0x54, 0x54, 0x78}, // a
+	{0x7f, 0x48, 0x44, 0x44, 0x38}, // b
+	{0x38, 0x44, 0x44, 0x44, 0x20}, // c
+	{0x38, 0x44, 0x44, 0x48, 0x7f}, // d
+	{0x38, 0x54, 0x54, 0x54, 0x18}, // e
+	{0x08, 0x7e, 0x09, 0x01, 0x02}, // f
+	{0x0c, 0x52, 0x52, 0x52, 0x3e}, // g
+	{0x7f, 0x08, 0x04, 0x04, 0x78}, // h
+	{0x00, 0x44, 0x7d, 0x40, 0x00}, // i
+	{0x20, 0x40, 0x44, 0x3d, 0x00}, // j
+	{0x7f, 0x10, 0x28, 0x44, 0x00}, // k
+	{0x00, 0x41, 0x7f, 0x40, 0x00}, // l
+	{0x7c, 0x04, 0x18, 0x04, 0x78}, // m
+	{0x7c, 0x08, 0x04, 0x04, 0x78}, // n
+	{0x38, 0x44, 0x44, 0x44, 0x38}, // o
+	{0x7c, 0x14, 0x14, 0x14, 0x08}, // p
+	{0x08, 0x14, 0x14, 0x18, 0x7c}, // q
+	{0x7c, 0x08, 0x04, 0x04, 0x08}, // r
+	{0x48, 0x54, 0x54, 0x54, 0x20}, // s
+	{0x04, 0x3f, 0x44, 0x40, 0x20}, // t
+	{0x3c, 0x40, 0x40, 0x20, 0x7c}, // u
+	{0x1c, 0x20, 0x40, 0x20, 0x1c}, // v
+	{0x3c, 0x40, 0x30, 0x40, 0x3c}, // w
+	{0x44, 0x28, 0x10, 0x28, 0x44}, // x
+	{0x0c, 0x50, 0x50, 0x50, 0x3c}, // y
+	{0x44, 0x64, 0x54, 0x4c, 0x44}, // z
+	{0x00, 0x08, 0x36, 0x41, 0x00}, // {
+	{0x00, 0x00, 0x7f, 0x00, 0x00}, // |
+	{0x00, 0x41, 0x36, 0x08, 0x00}, // }
+	{0x08, 0x04, 0x08, 0x10, 0x08}, // ~
+}
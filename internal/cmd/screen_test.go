@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestParseANSIScreen(t *testing.T) {
+	scr := parseANSIScreen("\x1b[1;31mERR\x1b[0m ok\n\x1b[38;5;208mx\x1b[48;2;1;2;3my\x1b]8;;http://x\x07z\n")
+	if len(scr.Rows) != 2 || scr.Width != 6 {
+		t.Fatalf("unexpected screen size: %d rows, width %d", len(scr.Rows), scr.Width)
+	}
+	err := scr.Rows[0][0]
+	if err.Rune != 'E' || !err.Style.Bold || err.Style.FG.Index != 1 {
+		t.Fatalf("unexpected first cell: %+v", err)
+	}
+	if scr.Rows[0][3].Style != (screenStyle{}) {
+		t.Fatalf("expected reset after SGR 0, got %+v", scr.Rows[0][3].Style)
+	}
+	row := scr.Rows[1]
+	if len(row) != 3 || row[0].Style.FG.Index != 208 || row[1].Style.BG.RGB.B != 3 || row[2].Rune != 'z' {
+		t.Fatalf("unexpected second row: %+v", row)
+	}
+}
+
+func TestScreenColors(t *testing.T) {
+	fg, bg := screenStyle{FG: screenColor{Kind: colorIndexed, Index: 1}, Bold: true, Reverse: true}.colors()
+	if fg != screenDefaultBG || bg != screenBasePalette[9] {
+		t.Fatalf("unexpected colors fg=%v bg=%v", fg, bg)
+	}
+	if c := indexedColor(196); c.R != 0xff || c.G != 0 || c.B != 0 {
+		t.Fatalf("unexpected color 196: %v", c)
+	}
+}
+
+func TestRenderScreen(t *testing.T) {
+	scr := parseANSIScreen("\x1b[32m<ok>\x1b[0m │\n")
+	var svg bytes.Buffer
+	if err := renderScreenSVG(scr, &svg); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(svg.String(), `fill="#00cd00"`) || !strings.Contains(svg.String(), "&lt;ok&gt;") {
+		t.Fatalf("unexpected svg:\n%s", svg.String())
+	}
+	var out bytes.Buffer
+	if err := renderScreenPNG(scr, &out); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 6*pngCellWidth || b.Dy() != pngCellHeight {
+		t.Fatalf("unexpected image size %v", b)
+	}
+}
+
+func TestCaptureImageName(t *testing.T) {
+	if got := captureImageName("dev:2.0", "png"); got != "dev-2-0.png" {
+		t.Fatalf("captureImageName = %q", got)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type scrollResult struct {
+	PaneID   string `json:"pane_id" yaml:"pane_id"`
+	Action   string `json:"action" yaml:"action"`
+	Lines    int    `json:"lines,omitempty" yaml:"lines,omitempty"`
+	Position int    `json:"position" yaml:"position"`
+	InMode   bool   `json:"in_mode" yaml:"in_mode"`
+}
+
+func newScrollCmd() *cobra.Command {
+	var paneArg string
+	var up, down int
+	var toTop, toBottom, exit bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "scroll",
+		Short: "Scroll a pane's view through its history",
+		Long: `Reposition a pane's view using copy mode.
+
+Exactly one of --up, --down, --to-top, --to-bottom, or --exit is required.
+Scrolling enters copy mode; --exit returns the pane to its live output.
+The reported position is how many lines the view sits above the bottom.`,
+		Example: `  arc-tmux scroll --pane=fe:2.0 --up 200
+  arc-tmux scroll --pane=@current --to-top
+  arc-tmux scroll --pane=@current --exit`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			actions := 0
+			for _, set := range []bool{up != 0, down != 0, toTop, toBottom, exit} {
+				if set {
+					actions++
+				}
+			}
+			if actions != 1 {
+				return fmt.Errorf("exactly one of --up, --down, --to-top, --to-bottom, or --exit is required")
+			}
+			if up < 0 || down < 0 {
+				return fmt.Errorf("--up and --down must be positive")
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+
+			result := scrollResult{PaneID: handle.Target}
+			switch {
+			case exit:
+				result.Action = "exit"
+				cursor, err := tmux.CopyCursorPosition(handle.ID)
+				if err != nil {
+					return err
+				}
+				if cursor.InMode {
+					if err := tmux.CopyModeCommand(handle.ID, "cancel"); err != nil {
+						return err
+					}
+				}
+			default:
+				if err := tmux.CopyMode(handle.ID); err != nil {
+					return err
+				}
+				switch {
+				case up > 0:
+					result.Action, result.Lines = "up", up
+					err = tmux.CopyModeRepeat(handle.ID, up, "scroll-up")
+				case down > 0:
+					result.Action, result.Lines = "down", down
+					err = tmux.CopyModeRepeat(handle.ID, down, "scroll-down")
+				case toTop:
+					result.Action = "top"
+					err = tmux.CopyModeCommand(handle.ID, "history-top")
+				case toBottom:
+					result.Action = "bottom"
+					err = tmux.CopyModeCommand(handle.ID, "history-bottom")
+				}
+				if err != nil {
+					return err
+				}
+				cursor, err := tmux.CopyCursorPosition(handle.ID)
+				if err != nil {
+					return err
+				}
+				result.Position = cursor.Scroll
+				result.InMode = cursor.InMode
+			}
+			return writeScrollResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().IntVar(&up, "up", 0, "Scroll up this many lines")
+	cmd.Flags().IntVar(&down, "down", 0, "Scroll down this many lines")
+	cmd.Flags().BoolVar(&toTop, "to-top", false, "Scroll to the start of the history")
+	cmd.Flags().BoolVar(&toBottom, "to-bottom", false, "Scroll to the bottom, staying in copy mode")
+	cmd.Flags().BoolVar(&exit, "exit", false, "Leave copy mode and return to live output")
+
+	return cmd
+}
+
+func writeScrollResult(cmd *cobra.Command, outputOpts output.OutputOptions, result scrollResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		return nil
+	}
+	if result.Action == "exit" {
+		_, _ = fmt.Fprintf(out, "Left copy mode (%s)\n", result.PaneID)
+		return nil
+	}
+	_, _ = fmt.Fprintf(out, "Scrolled %s; %d line(s) above bottom (%s)\n", result.Action, result.Position, result.PaneID)
+	return nil
+}
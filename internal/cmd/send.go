@@ -17,15 +17,22 @@ import (
 
 func newSendCmd() *cobra.Command {
 	var paneArg string
+	var windowArg string
 	var enter bool
 	var delayEnter float64
 	var keys []string
+	var setTitle bool
+	var keepTitle bool
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
 		Use:   "send [text]",
 		Short: "Send text to a tmux pane",
-		Long:  "Send literal text or tmux key names to a pane. By default we press Enter after the text.",
+		Long: `Send literal text or tmux key names to a pane. By default we press Enter after the text.
+
+--set-title titles the pane with the text. send does not wait for the command,
+so the title stays until the program or shell sets another one; run
+--set-title restores the previous title when the command finishes.`,
 		Example: `  # Basic send (auto-enter)
   arc-tmux send "npm test" --pane=fe:2.0
 
@@ -33,7 +40,13 @@ func newSendCmd() *cobra.Command {
   arc-tmux send "export SECRET=" --pane=fe:2.0 --enter=false
 
   # Send raw tmux keys
-  arc-tmux send --pane=fe:2.0 --key C-x --key C-c`,
+  arc-tmux send --pane=fe:2.0 --key C-x --key C-c
+
+  # Send to whichever pane is active in the "api" window
+  arc-tmux send "rs" --window dev:api
+
+  # Send to every pane matching a query
+  arc-tmux locate node -o quiet | arc-tmux send "rs" --pane -`,
 		Args: func(_ *cobra.Command, args []string) error {
 			if len(args) == 0 && len(keys) == 0 {
 				return fmt.Errorf("requires text or at least one --key")
@@ -45,58 +58,73 @@ func newSendCmd() *cobra.Command {
 				return err
 			}
 
-			target, err := resolvePaneTarget(paneArg)
+			handles, bulk, err := resolvePaneOrWindowTargets(cmd, paneArg, windowArg)
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
-				return err
-			}
 
 			d := time.Duration(delayEnter * float64(time.Second))
 			text := strings.Join(args, " ")
-			if text != "" {
-				if err := tmux.SendLiteral(target, text, enter, d); err != nil {
-					return err
+			results := make([]sendResult, 0, len(handles))
+			for _, h := range handles {
+				if text != "" && setTitle {
+					if _, err := setCommandTitle(h.ID, text, keepTitle); err != nil {
+						return err
+					}
 				}
-			}
-			if len(keys) > 0 {
-				if err := tmux.SendKeys(target, keys); err != nil {
-					return err
+				if text != "" {
+					if err := tmux.SendLiteral(h.ID, text, enter, d); err != nil {
+						return err
+					}
+				}
+				if len(keys) > 0 {
+					if err := tmux.SendKeys(h.ID, keys); err != nil {
+						return err
+					}
 				}
+				results = append(results, sendResult{
+					PaneID:    h.Target,
+					Text:      text,
+					Keys:      keys,
+					Enter:     enter,
+					DelaySecs: delayEnter,
+				})
 			}
 
-			result := sendResult{
-				PaneID:    target,
-				Text:      text,
-				Keys:      keys,
-				Enter:     enter,
-				DelaySecs: delayEnter,
+			var doc any = results[0]
+			if bulk {
+				doc = results
 			}
 			out := cmd.OutOrStdout()
 			switch {
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
-				return enc.Encode(result)
+				return enc.Encode(doc)
 			case outputOpts.Is(output.OutputYAML):
 				enc := yaml.NewEncoder(out)
 				defer func() { _ = enc.Close() }()
-				return enc.Encode(result)
+				return enc.Encode(doc)
 			case outputOpts.Is(output.OutputQuiet):
 				return nil
 			}
+			if bulk {
+				_, _ = fmt.Fprintf(out, "Text sent to %d panes\n", len(results))
+				return nil
+			}
 			_, _ = fmt.Fprintln(out, "Text sent")
 			return nil
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
-	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, - for stdin)")
+	cmd.Flags().StringVar(&windowArg, "window", "", "Target the active pane of this window (SESSION:WINDOW, index or name)")
 	cmd.Flags().StringArrayVar(&keys, "key", nil, "Send tmux key names (repeatable, e.g., C-x, Up, Enter)")
 	cmd.Flags().BoolVar(&enter, "enter", true, "Press Enter after sending text")
 	cmd.Flags().Float64Var(&delayEnter, "delay-enter", 1.0, "Delay in seconds before pressing Enter")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().BoolVar(&setTitle, "set-title", false, "Title the pane with the text sent")
+	cmd.Flags().BoolVar(&keepTitle, "keep-title", false, "With --set-title, leave panes that already have a title of their own alone")
 
 	return cmd
 }
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func addAllServersFlag(cmd *cobra.Command, target *bool) {
+	cmd.Flags().BoolVar(target, "all-servers", false, "Aggregate across every tmux server socket in TMUX_TMPDIR and the config sockets list")
+}
+
+// checkAllServers rejects --porcelain with --all-servers: porcelain columns
+// are fixed and have no room for the server label.
+func checkAllServers(allServers bool, porcelain bool) error {
+	if allServers && porcelain {
+		return errors.New("use either --porcelain or --all-servers, not both")
+	}
+	return nil
+}
+
+// serverSockets returns the sockets --all-servers visits: those discovered in
+// the tmux socket directory plus the config file's sockets list.
+func serverSockets() ([]string, error) {
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		return nil, err
+	}
+	return tmux.DiscoverSockets(cfg.Sockets), nil
+}
+
+// forEachServer runs fn against each server socket in turn. Servers that are
+// no longer running (stale sockets) are skipped.
+func forEachServer(fn func(socket string) error) error {
+	sockets, err := serverSockets()
+	if err != nil {
+		return err
+	}
+	for _, socket := range sockets {
+		err := tmux.WithServerSocket(socket, func() error { return fn(socket) })
+		if err != nil && !errors.Is(err, tmux.ErrNoTmuxServer) {
+			return err
+		}
+	}
+	return nil
+}
+
+// serverLabel is the short name shown for a socket in tables (tmux -L name).
+func serverLabel(socket string) string {
+	if socket == "" {
+		return "-"
+	}
+	if filepath.Dir(socket) == tmux.SocketDir() {
+		return filepath.Base(socket)
+	}
+	return socket
+}
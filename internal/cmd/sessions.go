@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -16,6 +17,7 @@ import (
 )
 
 type sessionInfo struct {
+	Socket     string    `json:"socket,omitempty" yaml:"socket,omitempty"`
 	Name       string    `json:"name" yaml:"name"`
 	Windows    int       `json:"windows" yaml:"windows"`
 	Attached   int       `json:"attached" yaml:"attached"`
@@ -25,41 +27,65 @@ type sessionInfo struct {
 
 func newSessionsCmd() *cobra.Command {
 	var outputOpts output.OutputOptions
+	var porcelain bool
+	var allServers bool
 
 	cmd := &cobra.Command{
 		Use:   "sessions",
 		Short: "List tmux sessions",
 		Long:  "List tmux sessions with window counts and activity timestamps.",
 		Example: `  arc-tmux sessions
-  arc-tmux sessions --output json`,
+  arc-tmux sessions --output json
+  arc-tmux sessions --all-servers`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-
-			sessions, err := tmux.ListSessions()
-			if err != nil {
-				if err == tmux.ErrNoTmuxServer {
-					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
-					return nil
-				}
+			if err := checkPorcelain(cmd, porcelain); err != nil {
+				return err
+			}
+			if err := checkAllServers(allServers, porcelain); err != nil {
 				return err
 			}
 
-			items := make([]sessionInfo, 0, len(sessions))
-			for _, s := range sessions {
-				items = append(items, sessionInfo{
-					Name:       s.Name,
-					Windows:    s.Windows,
-					Attached:   s.Attached,
-					CreatedAt:  s.CreatedAt,
-					ActivityAt: s.ActivityAt,
+			var items []sessionInfo
+			if allServers {
+				err := forEachServer(func(socket string) error {
+					found, err := listSessionInfos(socket)
+					items = append(items, found...)
+					return err
 				})
+				if err != nil {
+					return err
+				}
+			} else {
+				found, err := listSessionInfos("")
+				if err != nil {
+					if err == tmux.ErrNoTmuxServer {
+						if !porcelain {
+							_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tmux server is running.")
+						}
+						return nil
+					}
+					return err
+				}
+				items = found
+			}
+			if items == nil {
+				items = []sessionInfo{}
 			}
-			sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+
+			sort.Slice(items, func(i, j int) bool {
+				if items[i].Socket != items[j].Socket {
+					return items[i].Socket < items[j].Socket
+				}
+				return items[i].Name < items[j].Name
+			})
 
 			out := cmd.OutOrStdout()
 			switch {
+			case porcelain:
+				return writePorcelain(out, sessionPorcelainRows(items))
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
@@ -82,20 +108,54 @@ func newSessionsCmd() *cobra.Command {
 				return nil
 			}
 
-			_, _ = fmt.Fprintln(out, "Sessions:")
+			headers := []string{"SESSION", "WINDOWS", "ATTACHED", "CREATED", "ACTIVITY"}
+			if allServers {
+				headers = append([]string{"SERVER"}, headers...)
+			}
+			table := newTextTable(headers...)
 			for _, s := range items {
-				_, _ = fmt.Fprintf(out, "  %s  windows=%d  attached=%d  created=%s  activity=%s\n",
-					s.Name,
-					s.Windows,
-					s.Attached,
-					formatTime(s.CreatedAt),
-					formatRelative(s.ActivityAt),
-				)
+				attached := styledCell(strconv.Itoa(s.Attached), styleActive)
+				if s.Attached == 0 {
+					attached.Style = styleMuted
+				}
+				row := []tableCell{
+					cell(s.Name),
+					cell(strconv.Itoa(s.Windows)),
+					attached,
+					cell(formatTime(s.CreatedAt)),
+					cell(formatRelative(s.ActivityAt)),
+				}
+				if allServers {
+					row = append([]tableCell{cell(serverLabel(s.Socket))}, row...)
+				}
+				table.addRow(row...)
 			}
-			return nil
+			return table.render(out)
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	addPorcelainFlag(cmd, "sessions", &porcelain)
+	addAllServersFlag(cmd, &allServers)
 	return cmd
 }
+
+// listSessionInfos lists sessions on the current server, labeled with socket.
+func listSessionInfos(socket string) ([]sessionInfo, error) {
+	sessions, err := tmux.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]sessionInfo, 0, len(sessions))
+	for _, s := range sessions {
+		items = append(items, sessionInfo{
+			Socket:     socket,
+			Name:       s.Name,
+			Windows:    s.Windows,
+			Attached:   s.Attached,
+			CreatedAt:  s.CreatedAt,
+			ActivityAt: s.ActivityAt,
+		})
+	}
+	return items, nil
+}
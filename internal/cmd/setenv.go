@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// setenvAckOption is the pane option the shell sets once it has re-exported
+// the variables, proving the input reached the shell.
+const setenvAckOption = "@arc_tmux_setenv"
+
+type setenvResult struct {
+	PaneID   string   `json:"pane_id" yaml:"pane_id"`
+	Session  string   `json:"session" yaml:"session"`
+	Keys     []string `json:"keys" yaml:"keys"`
+	Verified bool     `json:"verified" yaml:"verified"`
+}
+
+func newSetenvCmd() *cobra.Command {
+	var paneArg string
+	var timeout float64
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "setenv KEY=VAL...",
+		Short: "Export variables into a pane's running shell",
+		Long: `Export environment variables into the shell already running in a pane.
+
+Values are stored in the session environment (so new panes inherit them) and
+the pane's shell re-exports them with "tmux show-environment", so secrets are
+never typed into the pane or its history. The shell then acknowledges through
+a pane option; if it does not within --timeout (a foreground program swallowed
+the input), the command fails.
+
+The pane must be idle at a POSIX shell (sh, bash, zsh, dash, ksh).`,
+		Example: `  arc-tmux setenv --pane=@api AWS_SESSION_TOKEN="$TOKEN"
+  arc-tmux locate api -o quiet | arc-tmux setenv --pane - API_KEY="$KEY" REGION=eu-west-1`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			vars, err := parseEnvVars(args)
+			if err != nil {
+				return newCodedError(errInvalidEnv, err.Error(), err)
+			}
+			handles, bulk, err := resolvePaneTargets(cmd, paneArg)
+			if err != nil {
+				return err
+			}
+			keys := make([]string, 0, len(vars))
+			for _, v := range vars {
+				keys = append(keys, v.Key)
+			}
+
+			// Check every pane before changing any environment.
+			sessions := make([]string, len(handles))
+			for i, h := range handles {
+				pane, err := tmux.PaneDetailsForTarget(h.ID)
+				if err != nil {
+					return err
+				}
+				if !isPosixShell(pane.Command) {
+					return newCodedError(errPaneBusy, fmt.Sprintf("pane %s is running %q; setenv needs an idle POSIX shell", h.Target, pane.Command), nil)
+				}
+				sessions[i] = pane.Session
+			}
+
+			done := map[string]bool{}
+			results := make([]setenvResult, 0, len(handles))
+			for i, h := range handles {
+				if !done[sessions[i]] {
+					for _, v := range vars {
+						if err := tmux.SetEnvironment(sessions[i], v.Key, v.Value); err != nil {
+							return err
+						}
+					}
+					done[sessions[i]] = true
+				}
+				if err := reexportPaneEnv(h.ID, keys, time.Duration(timeout*float64(time.Second))); err != nil {
+					return fmt.Errorf("%s: %w", h.Target, err)
+				}
+				results = append(results, setenvResult{PaneID: h.Target, Session: sessions[i], Keys: keys, Verified: true})
+			}
+
+			var doc any = results[0]
+			if bulk {
+				doc = results
+			}
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(doc)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(doc)
+			case outputOpts.Is(output.OutputQuiet):
+				return nil
+			}
+			for _, result := range results {
+				_, _ = fmt.Fprintf(out, "Exported %s (%s)\n", strings.Join(result.Keys, ", "), result.PaneID)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, - for stdin)")
+	cmd.Flags().Float64Var(&timeout, "timeout", 5, "Seconds to wait for the shell to confirm the export")
+
+	return cmd
+}
+
+// reexportPaneEnv makes the pane's shell load keys from the session
+// environment and waits for its acknowledgement.
+func reexportPaneEnv(paneID string, keys []string, timeout time.Duration) error {
+	nonce := newRunID()
+	if err := tmux.SendLiteral(paneID, reexportCommand(keys, nonce), true, 0); err != nil {
+		return err
+	}
+	defer func() { _ = tmux.UnsetPaneOption(paneID, setenvAckOption) }()
+	deadline := time.Now().Add(tmux.BoundTimeout(timeout))
+	for {
+		value, _, err := tmux.PaneOption(paneID, setenvAckOption)
+		if err != nil {
+			return err
+		}
+		if value == nonce {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if tmux.DeadlineExceeded() {
+				return tmux.ErrDeadlineExceeded
+			}
+			return errors.New("shell did not confirm the export; a foreground program may have read the input")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// reexportCommand builds the shell line; the leading space keeps it out of
+// history for shells ignoring space-prefixed commands.
+func reexportCommand(keys []string, nonce string) string {
+	parts := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf(`eval "$(tmux show-environment -s %s)"`, key))
+	}
+	parts = append(parts, fmt.Sprintf(`tmux set-option -p -t "$TMUX_PANE" %s %s`, setenvAckOption, nonce))
+	return " " + strings.Join(parts, " && ")
+}
+
+func isPosixShell(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	switch filepath.Base(strings.TrimPrefix(fields[0], "-")) {
+	case "sh", "bash", "zsh", "dash", "ksh", "mksh":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReexportCommand(t *testing.T) {
+	got := reexportCommand([]string{"API_KEY", "REGION"}, "abc123")
+	want := ` eval "$(tmux show-environment -s API_KEY)" && eval "$(tmux show-environment -s REGION)" && tmux set-option -p -t "$TMUX_PANE" @arc_tmux_setenv abc123`
+	if got != want {
+		t.Fatalf("reexportCommand = %q\nwant %q", got, want)
+	}
+	if strings.Contains(got, "=") {
+		t.Fatalf("values must never be typed into the pane: %q", got)
+	}
+}
+
+func TestIsPosixShell(t *testing.T) {
+	for command, want := range map[string]bool{"-zsh": true, "/bin/bash": true, "fish": false, "node": false, "": false} {
+		if got := isPosixShell(command); got != want {
+			t.Fatalf("isPosixShell(%q) = %v, want %v", command, got, want)
+		}
+	}
+}
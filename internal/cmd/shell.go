@@ -4,7 +4,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 )
 
@@ -71,6 +73,20 @@ func envAssignments(vars []envVar) string {
 	return strings.Join(parts, " ")
 }
 
+// resolveStartDir makes a --cwd value absolute for tmux's -c flag, which would
+// otherwise resolve relative paths against the tmux server's directory.
+func resolveStartDir(cwd string) (string, error) {
+	cwd = strings.TrimSpace(cwd)
+	if cwd == "" {
+		return "", nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return resolvePaneDir(cwd, wd)
+}
+
 func buildRunCommand(command string, cwd string, env []envVar) string {
 	trimmed := strings.TrimSpace(command)
 	if trimmed == "" {
@@ -99,3 +115,58 @@ func buildCommandWithEnv(command string, env []envVar) string {
 	}
 	return assignments + " " + command
 }
+
+// splitCommandLine splits a command line into words using shell-like quoting:
+// single quotes are literal, double quotes allow backslash escapes, and
+// unquoted backslashes escape the next character. No expansion is performed.
+func splitCommandLine(line string) ([]string, error) {
+	var words []string
+	var cur strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape")
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
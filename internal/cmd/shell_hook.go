@@ -0,0 +1,277 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// bashShellHook records each command through PROMPT_COMMAND. PS0 (bash 4.4+)
+// is expanded as a command starts, and its arithmetic flags that one ran, so
+// pressing Enter on an empty line records nothing.
+const bashShellHook = `# arc-tmux shell hook (bash 4.4+): records each command's exit status in the
+# pane's @arc_tmux_last option and its last 100 commands for arc-tmux history.
+if [ -n "$TMUX_PANE" ] && [ -z "$__arc_tmux_hooked" ]; then
+  __arc_tmux_hooked=1
+  __arc_tmux_seq=0
+  __arc_tmux_ran=0
+  __arc_tmux_precmd() {
+    local st=$? cmd rec
+    if [ "$__arc_tmux_ran" = 1 ]; then
+      __arc_tmux_ran=0
+      __arc_tmux_seq=$((__arc_tmux_seq + 1))
+      cmd=$(HISTTIMEFORMAT= builtin history 1)
+      [[ $cmd =~ ^[[:space:]]*[0-9]+[*]?[[:space:]]+(.*)$ ]] && cmd=${BASH_REMATCH[1]}
+      rec="$st $__arc_tmux_seq ${EPOCHSECONDS:-$(date +%s)} ${cmd//[$'\t\n']/ }"
+      command tmux set-option -p -t "$TMUX_PANE" @arc_tmux_last "$rec" \; \
+        set-option -p -t "$TMUX_PANE" "@arc_tmux_history_$((__arc_tmux_seq % 100))" "$rec" 2>/dev/null
+    fi
+    return $st
+  }
+  PS0=${PS0-}'${__arc_tmux_ran:0:$((__arc_tmux_ran=1,0))}'
+  PROMPT_COMMAND="__arc_tmux_precmd${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+  command tmux set-option -p -t "$TMUX_PANE" @arc_tmux_hook "bash $$" 2>/dev/null
+fi
+`
+
+const zshShellHook = `# arc-tmux shell hook (zsh): records each command's exit status in the
+# pane's @arc_tmux_last option and its last 100 commands for arc-tmux history.
+if [[ -n $TMUX_PANE && -z $__arc_tmux_hooked ]]; then
+  typeset -g __arc_tmux_hooked=1 __arc_tmux_cmd=
+  typeset -gi __arc_tmux_seq=0 __arc_tmux_ran=0
+  zmodload zsh/datetime 2>/dev/null
+  __arc_tmux_preexec() { __arc_tmux_cmd=$1; __arc_tmux_ran=1 }
+  __arc_tmux_precmd() {
+    local st=$? rec
+    (( __arc_tmux_ran )) || return $st
+    __arc_tmux_ran=0
+    (( __arc_tmux_seq++ ))
+    rec="$st $__arc_tmux_seq ${EPOCHSECONDS:-$(date +%s)} ${__arc_tmux_cmd//[$'\t\n']/ }"
+    command tmux set-option -p -t "$TMUX_PANE" @arc_tmux_last "$rec" \; \
+      set-option -p -t "$TMUX_PANE" "@arc_tmux_history_$((__arc_tmux_seq % 100))" "$rec" 2>/dev/null
+    return $st
+  }
+  autoload -Uz add-zsh-hook
+  add-zsh-hook preexec __arc_tmux_preexec
+  add-zsh-hook precmd __arc_tmux_precmd
+  command tmux set-option -p -t "$TMUX_PANE" @arc_tmux_hook "zsh $$" 2>/dev/null
+fi
+`
+
+// shellHookScript returns the hook for a shell, or false when the shell has
+// none.
+func shellHookScript(shell string) (string, bool) {
+	switch filepath.Base(strings.TrimPrefix(strings.TrimSpace(shell), "-")) {
+	case "bash":
+		return bashShellHook, true
+	case "zsh":
+		return zshShellHook, true
+	}
+	return "", false
+}
+
+type shellHookResult struct {
+	PaneID string `json:"pane_id" yaml:"pane_id"`
+	Shell  string `json:"shell" yaml:"shell"`
+	Script string `json:"script" yaml:"script"`
+	// AlreadyInstalled is set when the pane's shell had loaded the hook
+	// before, so nothing was sent.
+	AlreadyInstalled bool `json:"already_installed" yaml:"already_installed"`
+}
+
+func newShellHookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shell-hook",
+		Short: "Record exit statuses through a shell prompt hook",
+		Long: `Manage an opt-in prompt hook that makes a pane's shell record the exit
+status and text of every command it finishes, so arc-tmux can report exit
+codes without wrapping commands in sentinels.
+
+After each command the hook sets the pane option @arc_tmux_last to
+"STATUS SEQ EPOCH COMMAND" (bash 4.4+ through PROMPT_COMMAND and PS0, zsh
+through precmd/preexec). Once it is loaded:
+
+  run       reports exit_code for every command; --exit-code reads it from
+            the hook instead of wrapping the command (not with --segment)
+  monitor   reports last_command with the exit status
+  panes     reports last_command
+  history   lists the last 100 commands run in the pane
+
+"install" loads the hook into running shells; "show" prints it for a shell
+rc file, so every new shell has it.`,
+		Example: `  arc-tmux shell-hook install --pane @api
+  arc-tmux locate --session dev -o quiet | arc-tmux shell-hook install --pane -
+  arc-tmux shell-hook show --shell zsh >> ~/.zshrc`,
+	}
+
+	cmd.AddCommand(
+		newShellHookInstallCmd(),
+		newShellHookShowCmd(),
+	)
+	return cmd
+}
+
+func newShellHookInstallCmd() *cobra.Command {
+	var paneArg string
+	var timeout float64
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Load the hook into the shells running in panes",
+		Long: `Load the shell hook into the bash or zsh shell running in each pane. The
+hook is written to the arc-tmux cache directory and sourced with a
+space-prefixed command, which shells ignoring space-prefixed commands keep
+out of history; loading it twice is a no-op.
+
+The pane must be idle at its own bash or zsh prompt. The hook confirms it
+loaded through a pane option; if it does not within --timeout (a foreground
+program read the input, or a nested shell loaded it), the command fails.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			handles, bulk, err := resolvePaneTargets(cmd, paneArg)
+			if err != nil {
+				return err
+			}
+
+			// Check every pane before sending to any of them.
+			panes := make([]tmux.PaneDetails, len(handles))
+			for i, h := range handles {
+				if panes[i], err = tmux.PaneDetailsForTarget(h.ID); err != nil {
+					return err
+				}
+				if _, ok := shellHookScript(panes[i].Command); !ok {
+					return newCodedError(errPaneBusy, fmt.Sprintf("pane %s is running %q; shell-hook needs an idle bash or zsh prompt", h.Target, panes[i].Command), nil)
+				}
+			}
+
+			results := make([]shellHookResult, 0, len(handles))
+			for i, h := range handles {
+				result, err := installShellHook(h.ID, panes[i], time.Duration(timeout*float64(time.Second)))
+				if err != nil {
+					return fmt.Errorf("%s: %w", h.Target, err)
+				}
+				result.PaneID = h.Target
+				results = append(results, result)
+			}
+
+			var doc any = results[0]
+			if bulk {
+				doc = results
+			}
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(doc)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(doc)
+			case outputOpts.Is(output.OutputQuiet):
+				return nil
+			}
+			for _, result := range results {
+				if result.AlreadyInstalled {
+					_, _ = fmt.Fprintf(out, "Shell hook already loaded in %s (%s)\n", result.PaneID, result.Shell)
+					continue
+				}
+				_, _ = fmt.Fprintf(out, "Loaded shell hook in %s (%s)\n", result.PaneID, result.Shell)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, - for stdin)")
+	cmd.Flags().Float64Var(&timeout, "timeout", 5, "Seconds to wait for the shell to confirm the hook loaded")
+	return cmd
+}
+
+func newShellHookShowCmd() *cobra.Command {
+	var shell string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the hook for a shell rc file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if strings.TrimSpace(shell) == "" {
+				shell = os.Getenv("SHELL")
+			}
+			script, ok := shellHookScript(shell)
+			if !ok {
+				return fmt.Errorf("no shell hook for %q; use --shell bash or --shell zsh", shell)
+			}
+			_, err := fmt.Fprint(cmd.OutOrStdout(), script)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&shell, "shell", "", "Shell to print the hook for: bash or zsh (default: $SHELL)")
+	return cmd
+}
+
+// installShellHook writes the hook for the pane's shell to the cache
+// directory, sources it in the pane, and waits for the hook to report in.
+func installShellHook(paneID string, pane tmux.PaneDetails, timeout time.Duration) (shellHookResult, error) {
+	shell := filepath.Base(strings.TrimPrefix(pane.Command, "-"))
+	script, _ := shellHookScript(shell)
+	path := shellHookFile(shell)
+	result := shellHookResult{Shell: shell, Script: path}
+	if pane.ShellHook == shell {
+		result.AlreadyInstalled = true
+		return result, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return result, err
+	}
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		return result, err
+	}
+	if err := tmux.SendLiteral(paneID, " . "+shellQuoteSingle(path), true, 0); err != nil {
+		return result, err
+	}
+	deadline := time.Now().Add(tmux.BoundTimeout(timeout))
+	for {
+		details, err := tmux.PaneDetailsForTarget(paneID)
+		if err != nil {
+			return result, err
+		}
+		if details.ShellHook != "" {
+			return result, nil
+		}
+		if time.Now().After(deadline) {
+			if tmux.DeadlineExceeded() {
+				return result, tmux.ErrDeadlineExceeded
+			}
+			return result, errors.New("shell did not confirm the hook; a foreground program may have read the input, or a nested shell loaded it")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func shellHookFile(shell string) string {
+	name := "shell-hook." + shell
+	if dir, err := os.UserCacheDir(); err == nil && strings.TrimSpace(dir) != "" {
+		return filepath.Join(dir, "arc-tmux", name)
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".arc-tmux-"+name)
+	}
+	return filepath.Join(os.TempDir(), "arc-tmux-"+name)
+}
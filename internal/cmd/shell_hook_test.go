@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestShellHookScript(t *testing.T) {
+	for _, shell := range []string{"bash", "-zsh", "/usr/bin/zsh"} {
+		script, ok := shellHookScript(shell)
+		if !ok {
+			t.Fatalf("%s: expected a hook", shell)
+		}
+		for _, option := range []string{tmux.ShellHookOption, tmux.LastCommandOption} {
+			if !strings.Contains(script, option) {
+				t.Fatalf("%s: hook does not set %s", shell, option)
+			}
+		}
+	}
+	if _, ok := shellHookScript("fish"); ok {
+		t.Fatal("expected no hook for fish")
+	}
+}
@@ -56,3 +56,17 @@ func TestBuildRunCommandWithCommand(t *testing.T) {
 		t.Fatalf("unexpected command: %s", cmd)
 	}
 }
+
+func TestSplitCommandLine(t *testing.T) {
+	words, err := splitCommandLine(`arc-tmux run "npm test -- \"a b\"" --pane='dev:1.0' x\ y`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"arc-tmux", "run", `npm test -- "a b"`, "--pane=dev:1.0", "x y"}
+	if strings.Join(words, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected words: %q", words)
+	}
+	if _, err := splitCommandLine(`run "unterminated`); err == nil {
+		t.Fatalf("expected unterminated quote error")
+	}
+}
@@ -0,0 +1,439 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// shipOption is the pane option recording where a pane's output is shipped.
+const shipOption = "@arc_tmux_ship"
+
+// shipEscapePattern matches the terminal escape sequences "_ship" removes:
+// CSI, OSC, charset selection, and keypad modes.
+var shipEscapePattern = regexp.MustCompile(`\x1b(\[[0-9;?<=>!]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[()][0-9A-Za-z]|[=>78])`)
+
+type shipResult struct {
+	// Action is "start", "stop", or "list".
+	Action string     `json:"action" yaml:"action"`
+	Panes  []shipPane `json:"panes" yaml:"panes"`
+}
+
+type shipPane struct {
+	Pane   string `json:"pane" yaml:"pane"`
+	PaneID string `json:"pane_id" yaml:"pane_id"`
+	// Sink is file:PATH, syslog:TAG, or command:CMD.
+	Sink string `json:"sink,omitempty" yaml:"sink,omitempty"`
+	// Active is false once the pipe has stopped, e.g. because the sink exited.
+	Active bool `json:"active" yaml:"active"`
+}
+
+func newShipCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ship",
+		Short: "Stream pane output to files, syslog, or a log shipper",
+		Long: `Continuously copy new output from panes to a log file, syslog, or any
+command that reads lines on stdin, so agent console output reaches central
+logging without per-run captures.
+
+Shipping uses tmux pipe-pane, so it keeps running after arc-tmux exits and
+stops when the pane closes or "ship stop" is run. Each line is cleaned of
+escape sequences and prefixed with logfmt labels (session, window, pane, and
+any --label) unless --raw is given.`,
+		Example: `  arc-tmux ship start --pane @agent --file ~/logs/{session}-{window}.log
+  arc-tmux ship start --window dev:1 --syslog --label team=infra
+  arc-tmux ship start --pane @agent --command "vector --config ship.toml"
+  arc-tmux ship list
+  arc-tmux ship stop --pane @agent`,
+	}
+
+	cmd.AddCommand(
+		newShipStartCmd(),
+		newShipStopCmd(),
+		newShipListCmd(),
+	)
+
+	return cmd
+}
+
+func newShipStartCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var windowArg string
+	var file string
+	var syslog bool
+	var syslogTag string
+	var command string
+	var labels []string
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start shipping pane output",
+		Long: `Start shipping new output from the pane (or every pane in --window) to one
+sink: --file (appended; {session}, {window}, and {pane} expand per pane),
+--syslog (through logger), or --command (any command reading stdin, such as
+vector, fluent-bit, or a curl loop to an HTTP endpoint). A pane can ship to
+one sink at a time.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			sinks := 0
+			for _, set := range []bool{strings.TrimSpace(file) != "", syslog, strings.TrimSpace(command) != ""} {
+				if set {
+					sinks++
+				}
+			}
+			if sinks != 1 {
+				return errors.New("pass exactly one of --file, --syslog, or --command")
+			}
+			extra, err := parseShipLabels(labels)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(file) != "" {
+				// The filter loads the policy again; a bad one would only
+				// show up as a pipe that stopped.
+				if _, err := loadRotationPolicy(); err != nil {
+					return err
+				}
+			}
+			handles, _, err := resolvePaneOrWindowTargets(cmd, paneArg, windowArg)
+			if err != nil {
+				return err
+			}
+			result := shipResult{Action: "start", Panes: []shipPane{}}
+			for _, h := range handles {
+				details, err := tmux.PaneDetailsForTarget(h.ID)
+				if err != nil {
+					return err
+				}
+				var sink, sinkCmd, path string
+				switch {
+				case syslog:
+					sink = "syslog:" + syslogTag
+					sinkCmd = "logger -t " + shellQuoteSingle(syslogTag)
+				case command != "":
+					sink = "command:" + strings.TrimSpace(command)
+					sinkCmd = strings.TrimSpace(command)
+				default:
+					if path, err = shipFilePath(file, details); err != nil {
+						return err
+					}
+					if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+						return err
+					}
+					sink = "file:" + path
+				}
+				prefix := ""
+				if !raw {
+					prefix = shipLabelPrefix(details, extra)
+				}
+				// The filter runs under the tmux server's environment, so it is
+				// pointed at this command's config for log_rotation.
+				filter := "ARC_TMUX_CONFIG=" + shellQuoteSingle(defaultConfigFile()) + " " + arcTmuxExecutable()
+				if err := tmux.PipePane(h.ID, shipPipeCommand(filter, prefix, raw, path, sinkCmd)); err != nil {
+					if errors.Is(err, tmux.ErrPanePiped) {
+						return newCodedError(errPaneBusy, fmt.Sprintf("%s already has a pipe-pane (see ship list, or ship stop)", h.Target), err)
+					}
+					return err
+				}
+				if err := tmux.SetPaneOption(h.ID, shipOption, sink); err != nil {
+					return err
+				}
+				result.Panes = append(result.Panes, shipPane{Pane: h.Target, PaneID: h.ID, Sink: sink, Active: true})
+			}
+			return writeShipResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name, - for stdin)")
+	cmd.Flags().StringVar(&windowArg, "window", "", "Ship every pane in this window")
+	cmd.Flags().StringVar(&file, "file", "", "Append output to this file ({session}, {window}, {pane} expand)")
+	cmd.Flags().BoolVar(&syslog, "syslog", false, "Send output to syslog through logger")
+	cmd.Flags().StringVar(&syslogTag, "syslog-tag", "arc-tmux", "Tag for --syslog messages")
+	cmd.Flags().StringVar(&command, "command", "", "Pipe output to this shell command's stdin")
+	cmd.Flags().StringArrayVar(&labels, "label", nil, "Extra KEY=VALUE label for every line. Repeatable.")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Ship the pane's bytes as-is, without cleaning or labels")
+	return cmd
+}
+
+func newShipStopCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var windowArg string
+
+	cmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop shipping pane output",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			handles, _, err := resolvePaneOrWindowTargets(cmd, paneArg, windowArg)
+			if err != nil {
+				return err
+			}
+			result := shipResult{Action: "stop", Panes: []shipPane{}}
+			for _, h := range handles {
+				sink, _, err := tmux.PaneOption(h.ID, shipOption)
+				if err != nil {
+					return err
+				}
+				if err := tmux.StopTee(h.ID); err != nil {
+					return fmt.Errorf("tmux pipe-pane: %w", err)
+				}
+				if err := tmux.UnsetPaneOption(h.ID, shipOption); err != nil {
+					return err
+				}
+				result.Panes = append(result.Panes, shipPane{Pane: h.Target, PaneID: h.ID, Sink: sink})
+			}
+			return writeShipResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name, - for stdin)")
+	cmd.Flags().StringVar(&windowArg, "window", "", "Stop every pane in this window")
+	return cmd
+}
+
+func newShipListCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List panes whose output is being shipped",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			resolved, err := resolveSessionTarget(session)
+			if err != nil {
+				return err
+			}
+			panes, err := tmux.ListPanesDetailed()
+			if err != nil && !errors.Is(err, tmux.ErrNoTmuxServer) {
+				return err
+			}
+			result := shipResult{Action: "list", Panes: []shipPane{}}
+			for _, p := range panes {
+				if resolved != "" && p.Session != resolved {
+					continue
+				}
+				sink, ok, err := tmux.PaneOption(p.PaneID, shipOption)
+				if err != nil || !ok {
+					continue
+				}
+				active, err := tmux.PanePiped(p.PaneID)
+				if err != nil {
+					return err
+				}
+				result.Panes = append(result.Panes, shipPane{Pane: formattedPaneID(&p), PaneID: p.PaneID, Sink: sink, Active: active})
+			}
+			return writeShipResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&session, "session", "", "Only list panes in this session (name, @current, or @managed)")
+	return cmd
+}
+
+// parseShipLabels parses KEY=VALUE labels, sorted by key.
+func parseShipLabels(raw []string) ([][2]string, error) {
+	labels := make([][2]string, 0, len(raw))
+	for _, l := range raw {
+		key, value, ok := strings.Cut(l, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t\"") {
+			return nil, fmt.Errorf("invalid --label %q (want KEY=VALUE)", l)
+		}
+		labels = append(labels, [2]string{key, value})
+	}
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i][0] < labels[j][0] })
+	return labels, nil
+}
+
+// shipLabelPrefix renders the labels put in front of every shipped line.
+func shipLabelPrefix(p tmux.PaneDetails, extra [][2]string) string {
+	pairs := append([][2]string{
+		{"session", p.Session},
+		{"window", p.WindowName},
+		{"pane", formattedPaneID(&p)},
+	}, extra...)
+	parts := make([]string, 0, len(pairs))
+	for _, kv := range pairs {
+		value := kv[1]
+		if value == "" || strings.ContainsAny(value, " \t\"=") {
+			value = strconv.Quote(value)
+		}
+		parts = append(parts, kv[0]+"="+value)
+	}
+	return strings.Join(parts, " ") + " "
+}
+
+// shipPipeCommand builds the pipe-pane command. File output is written by
+// "arc-tmux _ship" so it can rotate; otherwise output goes to sink, through
+// _ship unless raw. tmux expands formats in the command, so # is doubled.
+func shipPipeCommand(filterExe string, prefix string, raw bool, file string, sink string) string {
+	filter := filterExe + " _ship"
+	if raw {
+		filter += " --raw"
+	} else {
+		filter += " --prefix " + shellQuoteSingle(prefix)
+	}
+	var command string
+	switch {
+	case file != "":
+		command = filter + " --file " + shellQuoteSingle(file)
+	case raw:
+		command = sink
+	default:
+		command = filter + " | " + sink
+	}
+	return strings.ReplaceAll(command, "#", "##")
+}
+
+// newShipFilterCmd is the filter ship start puts between a pane and its
+// sink. It is not meant to be run by hand.
+func newShipFilterCmd() *cobra.Command {
+	var prefix string
+	var raw bool
+	var file string
+
+	cmd := &cobra.Command{
+		Use:    "_ship",
+		Short:  "Clean, label, and write piped pane output (used by arc-tmux ship)",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			w := cmd.OutOrStdout()
+			if file != "" {
+				policy, err := loadRotationPolicy()
+				if err != nil {
+					return err
+				}
+				f, err := openRotatingFile(file, policy)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = f.Close() }()
+				w = f
+			}
+			if raw {
+				_, err := io.Copy(w, cmd.InOrStdin())
+				return err
+			}
+			return filterShipLines(cmd.InOrStdin(), w, prefix)
+		},
+	}
+
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Text put in front of every line")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Copy bytes unchanged")
+	cmd.Flags().StringVar(&file, "file", "", "Append to this file, rotating it per log_rotation")
+	return cmd
+}
+
+// filterShipLines copies lines from r to w with escape sequences and carriage
+// returns removed and prefix prepended, writing each line as soon as it ends.
+func filterShipLines(r io.Reader, w io.Writer, prefix string) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			line = shipEscapePattern.ReplaceAllString(strings.TrimSuffix(line, "\n"), "")
+			line = strings.ReplaceAll(line, "\r", "")
+			if _, werr := io.WriteString(w, prefix+line+"\n"); werr != nil {
+				return werr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// shipFilePath expands {session}, {window}, and {pane} (the pane ID without
+// %) in path and makes it absolute.
+func shipFilePath(path string, p tmux.PaneDetails) (string, error) {
+	path = strings.NewReplacer(
+		"{session}", p.Session,
+		"{window}", p.WindowName,
+		"{pane}", strings.TrimPrefix(p.PaneID, "%"),
+	).Replace(strings.TrimSpace(path))
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+	return filepath.Abs(path)
+}
+
+func writeShipResult(cmd *cobra.Command, outputOpts output.OutputOptions, result shipResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, p := range result.Panes {
+			_, _ = fmt.Fprintln(out, p.Pane)
+		}
+		return nil
+	}
+	switch result.Action {
+	case "start":
+		for _, p := range result.Panes {
+			_, _ = fmt.Fprintf(out, "Shipping %s to %s.\n", p.Pane, p.Sink)
+		}
+		return nil
+	case "stop":
+		for _, p := range result.Panes {
+			_, _ = fmt.Fprintf(out, "Stopped shipping %s.\n", p.Pane)
+		}
+		return nil
+	}
+	if len(result.Panes) == 0 {
+		_, _ = fmt.Fprintln(out, "No panes are being shipped.")
+		return nil
+	}
+	table := newTextTable("PANE", "SINK", "STATE")
+	for _, p := range result.Panes {
+		state := styledCell("active", styleActive)
+		if !p.Active {
+			state = styledCell("stopped", styleError)
+		}
+		table.addRow(cell(p.Pane), cell(p.Sink), state)
+	}
+	return table.render(out)
+}
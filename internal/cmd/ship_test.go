@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestParseShipLabels(t *testing.T) {
+	labels, err := parseShipLabels([]string{"team=infra", "env=prod"})
+	if err != nil {
+		t.Fatalf("parseShipLabels: %v", err)
+	}
+	if len(labels) != 2 || labels[0] != [2]string{"env", "prod"} || labels[1] != [2]string{"team", "infra"} {
+		t.Fatalf("labels = %v, want sorted env, team", labels)
+	}
+	for _, bad := range []string{"team", "=x", "a b=c"} {
+		if _, err := parseShipLabels([]string{bad}); err == nil {
+			t.Errorf("parseShipLabels(%q) accepted an invalid label", bad)
+		}
+	}
+}
+
+func TestShipLabelPrefix(t *testing.T) {
+	pane := tmux.PaneDetails{Session: "dev", WindowIndex: 1, WindowName: "api", PaneIndex: 0, PaneID: "%4"}
+	got := shipLabelPrefix(pane, [][2]string{{"note", "two words"}})
+	want := `session=dev window=api pane=dev:1.0 note="two words" `
+	if got != want {
+		t.Fatalf("shipLabelPrefix = %q, want %q", got, want)
+	}
+}
+
+func TestShipPipeCommand(t *testing.T) {
+	got := shipPipeCommand("/bin/arc-tmux", "pane=dev:1.0 ", false, "", "logger -t 'a#1'")
+	want := "/bin/arc-tmux _ship --prefix 'pane=dev:1.0 ' | logger -t 'a##1'"
+	if got != want {
+		t.Fatalf("shipPipeCommand = %q, want %q", got, want)
+	}
+	if got := shipPipeCommand("/bin/arc-tmux", "", true, "", "logger -t x"); got != "logger -t x" {
+		t.Fatalf("raw shipPipeCommand = %q", got)
+	}
+	got = shipPipeCommand("/bin/arc-tmux", "", true, "/tmp/a.log", "")
+	if want := "/bin/arc-tmux _ship --raw --file '/tmp/a.log'"; got != want {
+		t.Fatalf("raw file shipPipeCommand = %q, want %q", got, want)
+	}
+}
+
+func TestFilterShipLines(t *testing.T) {
+	in := "\x1b[31mred\x1b[0m line\r\n\x1b]0;title\x07plain\npartial"
+	var out bytes.Buffer
+	if err := filterShipLines(strings.NewReader(in), &out, "p=1 "); err != nil {
+		t.Fatalf("filterShipLines: %v", err)
+	}
+	want := "p=1 red line\np=1 plain\np=1 partial\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestShipFilePath(t *testing.T) {
+	pane := tmux.PaneDetails{Session: "dev", WindowName: "api", PaneID: "%4"}
+	got, err := shipFilePath("/var/log/arc/{session}-{window}-{pane}.log", pane)
+	if err != nil {
+		t.Fatalf("shipFilePath: %v", err)
+	}
+	if got != "/var/log/arc/dev-api-4.log" {
+		t.Fatalf("shipFilePath = %q", got)
+	}
+}
@@ -5,7 +5,9 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -20,75 +22,165 @@ type signalResult struct {
 	PaneID string `json:"pane_id" yaml:"pane_id"`
 	PID    int    `json:"pid" yaml:"pid"`
 	Signal string `json:"signal" yaml:"signal"`
+	// Processes lists every PID signalled with --tree.
+	Processes []signalProcess `json:"processes,omitempty" yaml:"processes,omitempty"`
+}
+
+// signalProcess is the outcome of signalling one PID of a pane's tree.
+type signalProcess struct {
+	PID     int    `json:"pid" yaml:"pid"`
+	Command string `json:"command" yaml:"command"`
+	Sent    bool   `json:"sent" yaml:"sent"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
 func newSignalCmd() *cobra.Command {
 	var outputOpts output.OutputOptions
 	var paneArg string
 	var sig string
+	var tree bool
+	var excludeShell bool
 
 	cmd := &cobra.Command{
 		Use:   "signal",
 		Short: "Send a signal to a pane's PID",
-		Long:  "Send a signal to the process running in a tmux pane.",
+		Long: `Send a signal to the process running in a tmux pane.
+
+By default only the pane's own process (usually the shell) is signalled. --tree
+signals every process under the pane, children before parents, and reports
+each PID; --exclude-shell leaves the pane's shell itself alone.`,
 		Example: `  arc-tmux signal --pane=fe:2.0 --signal TERM
-  arc-tmux signal --pane=@current --signal KILL`,
+  arc-tmux signal --pane=fe:2.0 --tree --exclude-shell --output json
+  arc-tmux signal --pane=@current --signal KILL
+  arc-tmux locate node -o quiet | arc-tmux signal --pane - --signal HUP`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-			target, err := resolvePaneTarget(paneArg)
+			handles, bulk, err := resolvePaneTargets(cmd, paneArg)
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
-				return err
-			}
 
-			pane, err := tmux.PaneDetailsForTarget(target)
+			parsed, name, err := parseSignal(sig)
 			if err != nil {
 				return err
 			}
-			if pane.PID <= 0 {
-				return fmt.Errorf("pane PID not available")
+			if excludeShell && !tree {
+				return fmt.Errorf("--exclude-shell requires --tree")
 			}
 
-			parsed, name, err := parseSignal(sig)
-			if err != nil {
-				return err
+			results := make([]signalResult, 0, len(handles))
+			var treeErr error
+			for _, h := range handles {
+				pane, err := tmux.PaneDetailsForTarget(h.ID)
+				if err != nil {
+					return err
+				}
+				if pane.PID <= 0 {
+					return fmt.Errorf("pane PID not available")
+				}
+				result := signalResult{PaneID: h.Target, PID: pane.PID, Signal: name}
+				if tree {
+					nodes, err := tmux.ProcessTree(pane.PID)
+					if err != nil {
+						return err
+					}
+					result.Processes = signalTree(nodes, parsed, excludeShell)
+					for _, p := range result.Processes {
+						if p.Error != "" && treeErr == nil {
+							treeErr = fmt.Errorf("signal %s to pid %d: %s", name, p.PID, p.Error)
+						}
+					}
+				} else if err := syscall.Kill(pane.PID, parsed); err != nil {
+					return fmt.Errorf("signal %s to pid %d: %w", name, pane.PID, err)
+				}
+				results = append(results, result)
 			}
 
-			if err := syscall.Kill(pane.PID, parsed); err != nil {
-				return fmt.Errorf("signal %s to pid %d: %w", name, pane.PID, err)
+			var doc any = results[0]
+			if bulk {
+				doc = results
 			}
-
-			result := signalResult{PaneID: target, PID: pane.PID, Signal: name}
 			out := cmd.OutOrStdout()
 			switch {
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
-				return enc.Encode(result)
+				if err := enc.Encode(doc); err != nil {
+					return err
+				}
+				return treeErr
 			case outputOpts.Is(output.OutputYAML):
 				enc := yaml.NewEncoder(out)
 				defer func() { _ = enc.Close() }()
-				return enc.Encode(result)
+				if err := enc.Encode(doc); err != nil {
+					return err
+				}
+				return treeErr
 			case outputOpts.Is(output.OutputQuiet):
-				_, _ = fmt.Fprintln(out, result.PID)
-				return nil
+				for _, result := range results {
+					if !tree {
+						_, _ = fmt.Fprintln(out, result.PID)
+						continue
+					}
+					for _, p := range result.Processes {
+						if p.Sent {
+							_, _ = fmt.Fprintln(out, p.PID)
+						}
+					}
+				}
+				return treeErr
+			}
+			for _, result := range results {
+				if !tree {
+					_, _ = fmt.Fprintf(out, "Sent %s to pid %d (%s)\n", result.Signal, result.PID, result.PaneID)
+					continue
+				}
+				if len(result.Processes) == 0 {
+					_, _ = fmt.Fprintf(out, "No processes to signal in %s\n", result.PaneID)
+				}
+				for _, p := range result.Processes {
+					if p.Sent {
+						_, _ = fmt.Fprintf(out, "Sent %s to pid %d %s (%s)\n", result.Signal, p.PID, commandProgram(p.Command), result.PaneID)
+					} else {
+						_, _ = fmt.Fprintf(out, "Failed %s to pid %d %s (%s): %s\n", result.Signal, p.PID, commandProgram(p.Command), result.PaneID, p.Error)
+					}
+				}
 			}
-			_, _ = fmt.Fprintf(out, "Sent %s to pid %d (%s)\n", name, pane.PID, target)
-			return nil
+			return treeErr
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
-	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, @name)")
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, @name, - for stdin)")
 	cmd.Flags().StringVar(&sig, "signal", "TERM", "Signal name or number (e.g., TERM, KILL, INT)")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().BoolVar(&tree, "tree", false, "Signal every process under the pane, children first")
+	cmd.Flags().BoolVar(&excludeShell, "exclude-shell", false, "With --tree, do not signal the pane's shell")
 	return cmd
 }
 
+// signalTree signals the processes of a pane's tree, deepest first so parents
+// cannot respawn children that were already signalled. Processes that exited
+// in the meantime count as sent.
+func signalTree(nodes []tmux.ProcessNode, sig syscall.Signal, excludeShell bool) []signalProcess {
+	ordered := append([]tmux.ProcessNode(nil), nodes...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Depth > ordered[j].Depth })
+	results := make([]signalProcess, 0, len(ordered))
+	for _, node := range ordered {
+		if excludeShell && node.Depth == 0 && isShellCommand(node.Command) {
+			continue
+		}
+		p := signalProcess{PID: node.PID, Command: node.Command, Sent: true}
+		if err := syscall.Kill(node.PID, sig); err != nil && !errors.Is(err, syscall.ESRCH) {
+			p.Sent = false
+			p.Error = err.Error()
+		}
+		results = append(results, p)
+	}
+	return results
+}
+
 func parseSignal(raw string) (syscall.Signal, string, error) {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestSignalTreeOrderAndExcludeShell(t *testing.T) {
+	self := os.Getpid()
+	nodes := []tmux.ProcessNode{
+		{PID: self, Command: "-bash", Depth: 0},
+		{PID: self, Command: "npm run dev", Depth: 1},
+		{PID: self, Command: "node server.js", Depth: 2},
+	}
+	// Signal 0 only checks that the PID exists.
+	got := signalTree(nodes, syscall.Signal(0), false)
+	if len(got) != 3 || got[0].Command != "node server.js" || got[2].Command != "-bash" {
+		t.Fatalf("expected children before the shell, got %+v", got)
+	}
+	for _, p := range got {
+		if !p.Sent || p.Error != "" {
+			t.Fatalf("expected signal 0 to succeed, got %+v", p)
+		}
+	}
+
+	got = signalTree(nodes, syscall.Signal(0), true)
+	if len(got) != 2 || got[1].Command != "npm run dev" {
+		t.Fatalf("expected shell to be excluded, got %+v", got)
+	}
+}
@@ -5,7 +5,9 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -21,6 +23,9 @@ type statusSnapshot struct {
 	WindowName     string       `json:"window_name,omitempty" yaml:"window_name,omitempty"`
 	PaneIndex      int          `json:"pane_index,omitempty" yaml:"pane_index,omitempty"`
 	PaneID         string       `json:"pane_id,omitempty" yaml:"pane_id,omitempty"`
+	BaseIndex      int          `json:"base_index,omitempty" yaml:"base_index,omitempty"`
+	PaneBaseIndex  int          `json:"pane_base_index,omitempty" yaml:"pane_base_index,omitempty"`
+	IndexOrigin    string       `json:"index_origin,omitempty" yaml:"index_origin,omitempty"`
 	Panes          []statusPane `json:"panes,omitempty" yaml:"panes,omitempty"`
 	ManagedSession string       `json:"managed_session,omitempty" yaml:"managed_session,omitempty"`
 }
@@ -32,19 +37,34 @@ type statusPane struct {
 	Active  bool   `json:"active" yaml:"active"`
 }
 
+// serverStatus summarizes one tmux server for status --all-servers.
+type serverStatus struct {
+	Socket   string `json:"socket" yaml:"socket"`
+	Running  bool   `json:"running" yaml:"running"`
+	Sessions int    `json:"sessions" yaml:"sessions"`
+	Windows  int    `json:"windows" yaml:"windows"`
+	Panes    int    `json:"panes" yaml:"panes"`
+	Attached int    `json:"attached" yaml:"attached"`
+}
+
 func newStatusCmd() *cobra.Command {
 	var outputOpts output.OutputOptions
+	var allServers bool
 
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show current tmux location",
 		Long:  "Inside tmux: prints your current session/window plus all panes. Outside: shows managed session.",
 		Example: `  arc-tmux status
-  arc-tmux status --output json`,
+  arc-tmux status --output json
+  arc-tmux status --all-servers`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
+			if allServers {
+				return runServersStatus(cmd, outputOpts)
+			}
 
 			var snap statusSnapshot
 
@@ -87,6 +107,14 @@ func newStatusCmd() *cobra.Command {
 					PaneIndex:   pane,
 					PaneID:      fid,
 					Panes:       currentPanes,
+					IndexOrigin: indexOriginTmux,
+				}
+				if tmux.ZeroBasedIndexes() {
+					snap.IndexOrigin = indexOriginZero
+				}
+				if base, paneBase, err := tmux.BaseIndexes(sess); err == nil {
+					snap.BaseIndex = base
+					snap.PaneBaseIndex = paneBase
 				}
 			} else {
 				snap = statusSnapshot{
@@ -145,10 +173,86 @@ func newStatusCmd() *cobra.Command {
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	addAllServersFlag(cmd, &allServers)
 
 	return cmd
 }
 
+// runServersStatus reports session, window, and pane counts for every server
+// socket, including stale sockets whose server has exited.
+func runServersStatus(cmd *cobra.Command, outputOpts output.OutputOptions) error {
+	sockets, err := serverSockets()
+	if err != nil {
+		return err
+	}
+	servers := make([]serverStatus, 0, len(sockets))
+	for _, socket := range sockets {
+		st := serverStatus{Socket: socket}
+		err := tmux.WithServerSocket(socket, func() error {
+			sessions, err := tmux.ListSessions()
+			if err != nil {
+				return err
+			}
+			panes, err := tmux.ListPanes()
+			if err != nil {
+				return err
+			}
+			st.Running = true
+			st.Sessions = len(sessions)
+			st.Panes = len(panes)
+			for _, s := range sessions {
+				st.Windows += s.Windows
+				st.Attached += s.Attached
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, tmux.ErrNoTmuxServer) {
+			return err
+		}
+		servers = append(servers, st)
+	}
+
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(servers)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(servers)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, st := range servers {
+			if st.Running {
+				_, _ = fmt.Fprintln(out, st.Socket)
+			}
+		}
+		return nil
+	}
+
+	if len(servers) == 0 {
+		_, _ = fmt.Fprintf(out, "No tmux server sockets found in %s.\n", tmux.SocketDir())
+		return nil
+	}
+	table := newTextTable("SERVER", "STATE", "SESSIONS", "WINDOWS", "PANES", "ATTACHED")
+	for _, st := range servers {
+		state := styledCell("running", styleActive)
+		if !st.Running {
+			state = styledCell("stale", styleMuted)
+		}
+		table.addRow(
+			cell(serverLabel(st.Socket)),
+			state,
+			cell(strconv.Itoa(st.Sessions)),
+			cell(strconv.Itoa(st.Windows)),
+			cell(strconv.Itoa(st.Panes)),
+			cell(strconv.Itoa(st.Attached)),
+		)
+	}
+	return table.render(out)
+}
+
 func splitFormattedID(fid string) (session string, window string) {
 	if fid == "" {
 		return "", ""
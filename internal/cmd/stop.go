@@ -44,9 +44,11 @@ func newStopCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
 				return err
 			}
+			target = handle.Target
 
 			if timeout <= 0 {
 				timeout = 30
@@ -56,18 +58,18 @@ func newStopCmd() *cobra.Command {
 			}
 
 			result := stopResult{PaneID: target}
-			if err := tmux.Interrupt(target); err != nil {
+			if err := tmux.Interrupt(handle.ID); err != nil {
 				return err
 			}
 			result.Interrupted = true
 
-			waitErr := tmux.WaitIdle(target, time.Duration(idle*float64(time.Second)), time.Duration(timeout*float64(time.Second)))
+			waitErr := tmux.WaitIdle(handle.ID, time.Duration(idle*float64(time.Second)), time.Duration(timeout*float64(time.Second)))
 			if waitErr != nil {
 				result.WaitError = waitErr.Error()
 				if isTimeout(waitErr) {
 					result.TimedOut = true
 					if killOnTimeout {
-						if err := tmux.Kill(target); err != nil {
+						if err := tmux.Kill(handle.ID); err != nil {
 							return err
 						}
 						result.Killed = true
@@ -121,7 +123,6 @@ func newStopCmd() *cobra.Command {
 	cmd.Flags().Float64Var(&idle, "idle", 2.0, "Seconds of inactivity to consider idle")
 	cmd.Flags().Float64Var(&timeout, "timeout", 30.0, "Maximum seconds to wait before kill")
 	cmd.Flags().BoolVar(&killOnTimeout, "kill", true, "Kill the pane if it fails to become idle")
-	_ = cmd.MarkFlagRequired("pane")
 	return cmd
 }
 
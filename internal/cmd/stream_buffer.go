@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"sync"
+)
+
+// defaultStreamBuffer is how many lines a stream queues for a slow reader
+// before dropping the oldest.
+const defaultStreamBuffer = 1000
+
+// streamBufferStats describes a lineRing over its lifetime.
+type streamBufferStats struct {
+	Capacity int `json:"capacity"`
+	Queued   int `json:"queued"`
+	Peak     int `json:"peak"`
+	Pushed   int `json:"pushed"`
+	Written  int `json:"written"`
+	Dropped  int `json:"dropped"`
+}
+
+// lineRing is a bounded FIFO of stream events between a capture loop and a
+// writer that may be slower than the pane. When it is full, push drops the
+// oldest events and counts them, so capturing never blocks on the reader and
+// memory stays bounded; the writer reports the count as a drop marker.
+type lineRing struct {
+	mu      sync.Mutex
+	ready   *sync.Cond
+	buf     []followEvent
+	head    int
+	size    int
+	dropped int
+	closed  bool
+	err     error
+	stats   streamBufferStats
+}
+
+func newLineRing(capacity int) *lineRing {
+	if capacity <= 0 {
+		capacity = defaultStreamBuffer
+	}
+	r := &lineRing{buf: make([]followEvent, capacity)}
+	r.ready = sync.NewCond(&r.mu)
+	r.stats.Capacity = capacity
+	return r
+}
+
+// push queues events, dropping the oldest queued ones when the ring is full.
+// It returns the error that stopped the writer, if any, so the capture loop
+// can stop too.
+func (r *lineRing) push(events []followEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	for _, ev := range events {
+		if r.size == len(r.buf) {
+			r.head = (r.head + 1) % len(r.buf)
+			r.size--
+			r.dropped++
+			r.stats.Dropped++
+		}
+		r.buf[(r.head+r.size)%len(r.buf)] = ev
+		r.size++
+		r.stats.Pushed++
+	}
+	if r.size > r.stats.Peak {
+		r.stats.Peak = r.size
+	}
+	if len(events) > 0 {
+		r.ready.Signal()
+	}
+	return nil
+}
+
+// pop waits for queued events and takes all of them, with the number of
+// events dropped before the oldest one. ok is false once the ring is closed
+// and drained.
+func (r *lineRing) pop() (events []followEvent, dropped int, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for r.size == 0 && r.dropped == 0 && !r.closed {
+		r.ready.Wait()
+	}
+	if r.size == 0 && r.dropped == 0 {
+		return nil, 0, false
+	}
+	events = make([]followEvent, r.size)
+	for i := range events {
+		events[i] = r.buf[(r.head+i)%len(r.buf)]
+	}
+	dropped = r.dropped
+	r.head, r.size, r.dropped = 0, 0, 0
+	return events, dropped, true
+}
+
+// written records events the writer finished, or the error that stopped it.
+func (r *lineRing) written(n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.Written += n
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// failure is the error that stopped the writer, if any.
+func (r *lineRing) failure() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// close lets pop drain what is queued and then report the end.
+func (r *lineRing) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	r.ready.Broadcast()
+}
+
+func (r *lineRing) snapshot() streamBufferStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.stats
+	stats.Queued = r.size
+	return stats
+}
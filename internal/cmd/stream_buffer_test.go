@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLineRingDropsOldest(t *testing.T) {
+	r := newLineRing(3)
+	if err := r.push(followEvents([]string{"a", "b", "c", "d", "e"})); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	events, dropped, ok := r.pop()
+	if !ok || dropped != 2 || len(events) != 3 || events[0].Line != "c" || events[2].Line != "e" {
+		t.Fatalf("unexpected pop: %+v dropped=%d ok=%v", events, dropped, ok)
+	}
+	r.written(len(events), nil)
+	st := r.snapshot()
+	if st.Pushed != 5 || st.Dropped != 2 || st.Written != 3 || st.Peak != 3 || st.Queued != 0 {
+		t.Fatalf("unexpected stats: %+v", st)
+	}
+
+	_ = r.push(followEvents([]string{"f"}))
+	r.close()
+	if events, dropped, ok := r.pop(); !ok || dropped != 0 || len(events) != 1 || events[0].Line != "f" {
+		t.Fatalf("expected queued events to drain after close, got %+v", events)
+	}
+	if _, _, ok := r.pop(); ok {
+		t.Fatalf("expected closed ring to report the end")
+	}
+}
+
+func TestLineRingWriterFailure(t *testing.T) {
+	r := newLineRing(0)
+	if r.snapshot().Capacity != defaultStreamBuffer {
+		t.Fatalf("expected default capacity")
+	}
+	failed := errors.New("broken pipe")
+	r.written(0, failed)
+	if err := r.push(followEvents([]string{"a"})); !errors.Is(err, failed) {
+		t.Fatalf("expected push to report the writer failure, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// tableCell is one styled value in a table row.
+type tableCell struct {
+	Text  string
+	Style cellStyle
+}
+
+func cell(text string) tableCell { return tableCell{Text: text} }
+
+func styledCell(text string, style cellStyle) tableCell {
+	return tableCell{Text: text, Style: style}
+}
+
+// textTable renders aligned columns for the human (table) output. The last
+// column is truncated to fit the terminal width.
+type textTable struct {
+	header []string
+	rows   [][]tableCell
+}
+
+func newTextTable(header ...string) *textTable {
+	return &textTable{header: header}
+}
+
+func (t *textTable) addRow(cells ...tableCell) {
+	t.rows = append(t.rows, cells)
+}
+
+func (t *textTable) render(out io.Writer) error {
+	return t.renderWidth(out, terminalWidth(out), colorEnabled(out))
+}
+
+func (t *textTable) renderWidth(out io.Writer, width int, color bool) error {
+	widths := make([]int, len(t.header))
+	for i, h := range t.header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range t.rows {
+		for i, c := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(c.Text))
+			}
+		}
+	}
+
+	var b strings.Builder
+	header := make([]tableCell, len(t.header))
+	for i, h := range t.header {
+		header[i] = styledCell(h, styleHeader)
+	}
+	for _, row := range append([][]tableCell{header}, t.rows...) {
+		used := 0
+		for i, c := range row {
+			if i >= len(widths) {
+				break
+			}
+			text := c.Text
+			last := i == len(widths)-1 || i == len(row)-1
+			if last && width > 0 {
+				text = truncateText(text, width-used)
+			}
+			b.WriteString(colorize(text, c.Style, color))
+			if !last {
+				pad := widths[i] - utf8.RuneCountInString(text) + 2
+				b.WriteString(strings.Repeat(" ", pad))
+				used += widths[i] + 2
+			}
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// truncateText shortens s to n runes, marking the cut with an ellipsis.
+func truncateText(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	if n <= 1 {
+		return ""
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
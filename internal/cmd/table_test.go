@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextTableRender(t *testing.T) {
+	table := newTextTable("PANE", "STATE", "PATH")
+	table.addRow(cell("dev:0.0"), styledCell("active", styleActive), cell("/srv/app/services/api"))
+	table.addRow(cell("dev:10.1"), styledCell("inactive", styleMuted), cell("/tmp"))
+
+	var plain strings.Builder
+	if err := table.renderWidth(&plain, 0, false); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want := "PANE      STATE     PATH\n" +
+		"dev:0.0   active    /srv/app/services/api\n" +
+		"dev:10.1  inactive  /tmp\n"
+	if plain.String() != want {
+		t.Fatalf("unexpected table:\n%s", plain.String())
+	}
+
+	var narrow strings.Builder
+	_ = table.renderWidth(&narrow, 28, false)
+	if line := strings.Split(narrow.String(), "\n")[1]; line != "dev:0.0   active    /srv/ap…" {
+		t.Fatalf("expected truncated path, got %q", line)
+	}
+
+	var colored strings.Builder
+	_ = table.renderWidth(&colored, 0, true)
+	if !strings.Contains(colored.String(), "\x1b[32mactive\x1b[0m") {
+		t.Fatalf("expected active state in green, got %q", colored.String())
+	}
+}
+
+func TestColorEnabledHonorsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	var buf strings.Builder
+	if colorEnabled(&buf) {
+		t.Fatalf("expected color disabled for non-terminal writer with NO_COLOR")
+	}
+	if got := FormatError(&buf, newCodedError(errInvalidPane, "bad pane", nil)); got != "ERR_INVALID_PANE: bad pane" {
+		t.Fatalf("expected plain error, got %q", got)
+	}
+}
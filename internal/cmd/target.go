@@ -4,20 +4,28 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/spf13/cobra"
 	"github.com/yourorg/arc-tmux/pkg/tmux"
 )
 
+// stdinPaneArg is the --pane value that reads pane targets from stdin.
+const stdinPaneArg = "-"
+
 func resolvePaneTarget(raw string) (string, error) {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
-		return "", newCodedError(errPaneRequired, "--pane is required", nil)
+		return defaultPaneTarget()
 	}
 	if !strings.HasPrefix(trimmed, "@") {
-		return trimmed, nil
+		return resolveWindowNameTarget(trimmed)
 	}
 	switch trimmed {
 	case "@current":
@@ -46,21 +54,119 @@ func resolvePaneTarget(raw string) (string, error) {
 		sort.Strings(active)
 		return active[0], nil
 	default:
+		if strings.HasPrefix(trimmed, linkedSelectorPrefix) {
+			return resolveLinkedPane(trimmed)
+		}
+		if native, ok, err := relativePaneTarget(trimmed); ok {
+			if err != nil {
+				return "", err
+			}
+			handle, err := tmux.ResolveTarget(native)
+			if err != nil {
+				return "", newCodedError(errInvalidPane, fmt.Sprintf("cannot resolve %s", trimmed), err)
+			}
+			return handle.Target, nil
+		}
 		alias := strings.TrimPrefix(trimmed, "@")
 		name, err := normalizeAliasName(alias)
 		if err != nil {
 			return "", err
 		}
-		aliases, err := loadAliases(defaultAliasFile())
+		entry, ok, err := lookupAlias(name, "", "")
 		if err != nil {
 			return "", err
 		}
-		target, ok := aliases[name]
 		if !ok {
 			return "", newCodedError(errUnknownSelector, fmt.Sprintf("unknown pane selector: %s", trimmed), nil)
 		}
+		return entry.Target, nil
+	}
+}
+
+// relativePaneTarget maps relative selectors (@up, @down, @left, @right, @last,
+// @current+N, @current-N) to native tmux target tokens.
+func relativePaneTarget(selector string) (string, bool, error) {
+	switch selector {
+	case "@up":
+		return "{up-of}", true, nil
+	case "@down":
+		return "{down-of}", true, nil
+	case "@left":
+		return "{left-of}", true, nil
+	case "@right":
+		return "{right-of}", true, nil
+	case "@last":
+		return "{last}", true, nil
+	}
+	rest := strings.TrimPrefix(selector, "@current")
+	if rest == selector || rest == "" || (rest[0] != '+' && rest[0] != '-') {
+		return "", false, nil
+	}
+	n, err := strconv.Atoi(rest[1:])
+	if err != nil || n < 0 {
+		return "", true, newCodedError(errUnknownSelector, fmt.Sprintf("invalid relative selector: %s", selector), nil)
+	}
+	return fmt.Sprintf(":.%c%d", rest[0], n), true, nil
+}
+
+// resolveWindowNameTarget rewrites session:windowname.pane to session:index.pane.
+// Targets whose window part is already numeric are returned unchanged.
+func resolveWindowNameTarget(target string) (string, error) {
+	session, rest, ok := strings.Cut(target, ":")
+	if !ok {
 		return target, nil
 	}
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 {
+		return target, nil
+	}
+	window, pane := rest[:dot], rest[dot+1:]
+	if _, err := strconv.Atoi(window); err == nil {
+		return target, nil
+	}
+	wins, err := tmux.ListWindows(session)
+	if err != nil {
+		return "", err
+	}
+	idx, err := windowIndexByName(wins, window)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%d.%s", session, idx, pane), nil
+}
+
+// windowIndexByName finds the single window named name; numeric names are
+// treated as indexes.
+func windowIndexByName(wins []tmux.Window, name string) (int, error) {
+	if idx, err := strconv.Atoi(name); err == nil {
+		return idx, nil
+	}
+	var matches []int
+	for _, w := range wins {
+		if w.Name == name {
+			matches = append(matches, w.WindowIndex)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return 0, newCodedError(errInvalidPane, fmt.Sprintf("no window named %q", name), nil)
+	case 1:
+		return matches[0], nil
+	default:
+		return 0, newCodedError(errAmbiguousPane, fmt.Sprintf("window name %q matches %d windows", name, len(matches)), nil)
+	}
+}
+
+// matchesWindow reports whether a pane belongs to window, given as an index or name.
+func matchesWindow(p tmux.PaneDetails, window string) bool {
+	window = strings.TrimSpace(window)
+	if window == "" {
+		return true
+	}
+	if idx, err := strconv.Atoi(window); err == nil {
+		return p.WindowIndex == idx
+	}
+	return p.WindowName == window
 }
 
 func resolveSessionTarget(raw string) (string, error) {
@@ -88,9 +194,147 @@ func resolveSessionTarget(raw string) (string, error) {
 	}
 }
 
-func validatePaneTarget(target string) error {
-	if err := tmux.ValidateTarget(target); err != nil {
-		return newCodedError(errInvalidPane, err.Error(), err)
+// canonicalPaneTarget resolves a tmux target to a pane_id-backed handle,
+// failing with ERR_INVALID_PANE when tmux cannot find the pane. Commands send
+// tmux calls to handle.ID so window renumbering mid-invocation cannot
+// redirect them, and report handle.Target to the user.
+func canonicalPaneTarget(target string) (tmux.PaneHandle, error) {
+	handle, err := tmux.ResolveTarget(target)
+	if err != nil {
+		if errors.Is(err, tmux.ErrNoTmuxServer) {
+			return tmux.PaneHandle{}, err
+		}
+		return tmux.PaneHandle{}, newCodedError(errInvalidPane, fmt.Sprintf("cannot resolve pane %s", target), err)
+	}
+	noteTranscriptTarget(handle)
+	return handle, nil
+}
+
+// resolvePaneTargets resolves --pane into one or more pane handles.
+// When raw is "-", newline-separated targets are read from stdin and bulk is true.
+func resolvePaneTargets(cmd *cobra.Command, raw string) (handles []tmux.PaneHandle, bulk bool, err error) {
+	if strings.TrimSpace(raw) != stdinPaneArg {
+		target, err := resolvePaneTarget(raw)
+		if err != nil {
+			return nil, false, err
+		}
+		handle, err := canonicalPaneTarget(target)
+		if err != nil {
+			return nil, false, err
+		}
+		return []tmux.PaneHandle{handle}, false, nil
+	}
+	lines, err := readPaneLines(cmd.InOrStdin())
+	if err != nil {
+		return nil, true, err
+	}
+	if len(lines) == 0 {
+		return nil, true, newCodedError(errPaneRequired, "no pane targets read from stdin", nil)
+	}
+	for _, line := range lines {
+		target, err := resolvePaneTarget(line)
+		if err != nil {
+			return nil, true, err
+		}
+		handle, err := canonicalPaneTarget(target)
+		if err != nil {
+			return nil, true, err
+		}
+		handles = append(handles, handle)
+	}
+	return handles, true, nil
+}
+
+// paneWindow is a window resolved from a --window flag, with the geometry
+// of its panes.
+type paneWindow struct {
+	Session string
+	Index   int
+	Panes   []tmux.PaneGeometry
+}
+
+func (w paneWindow) target() string {
+	return fmt.Sprintf("%s:%d", w.Session, w.Index)
+}
+
+// handles returns the window's panes as targets.
+func (w paneWindow) handles() []tmux.PaneHandle {
+	handles := make([]tmux.PaneHandle, 0, len(w.Panes))
+	for _, p := range w.Panes {
+		handles = append(handles, tmux.PaneHandle{ID: p.ID, Target: fmt.Sprintf("%s.%d", w.target(), p.Index)})
+	}
+	return handles
+}
+
+// resolveWindowArg resolves SESSION:WINDOW, where WINDOW is an index or
+// a window name, and reads the geometry of its panes.
+func resolveWindowArg(arg string) (paneWindow, error) {
+	sessArg, winArg, ok := strings.Cut(strings.TrimSpace(arg), ":")
+	if !ok || strings.TrimSpace(winArg) == "" {
+		return paneWindow{}, newCodedError(errInvalidPane, fmt.Sprintf("--window must be SESSION:WINDOW, got %q", arg), nil)
+	}
+	sess, err := resolveExistingSessionName(sessArg)
+	if err != nil {
+		return paneWindow{}, err
+	}
+	wins, err := tmux.ListWindows(sess)
+	if err != nil {
+		return paneWindow{}, err
+	}
+	idx, err := windowIndexByName(wins, strings.TrimSpace(winArg))
+	if err != nil {
+		return paneWindow{}, err
+	}
+	w := paneWindow{Session: sess, Index: idx}
+	if w.Panes, err = tmux.WindowPanes(tmux.WindowTarget(sess, idx)); err != nil {
+		return paneWindow{}, err
+	}
+	return w, nil
+}
+
+// activePane returns the window's active pane.
+func (w paneWindow) activePane() (tmux.PaneHandle, error) {
+	handles := w.handles()
+	for i, p := range w.Panes {
+		if p.Active {
+			return handles[i], nil
+		}
+	}
+	return tmux.PaneHandle{}, newCodedError(errNoActivePane, fmt.Sprintf("no active pane in window %s", w.target()), nil)
+}
+
+// resolvePaneOrWindowTargets is resolvePaneTargets, or the active pane of
+// the --window given in windowArg, looked up now rather than when a script
+// was written.
+func resolvePaneOrWindowTargets(cmd *cobra.Command, paneArg string, windowArg string) ([]tmux.PaneHandle, bool, error) {
+	if strings.TrimSpace(windowArg) == "" {
+		return resolvePaneTargets(cmd, paneArg)
+	}
+	if strings.TrimSpace(paneArg) != "" {
+		return nil, false, fmt.Errorf("use either --pane or --window, not both")
+	}
+	w, err := resolveWindowArg(windowArg)
+	if err != nil {
+		return nil, false, err
+	}
+	handle, err := w.activePane()
+	if err != nil {
+		return nil, false, err
+	}
+	return []tmux.PaneHandle{handle}, false, nil
+}
+
+func readPaneLines(in io.Reader) ([]string, error) {
+	var lines []string
+	seen := make(map[string]bool)
+	s := bufio.NewScanner(in)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
 	}
-	return nil
+	return lines, s.Err()
 }
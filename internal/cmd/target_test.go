@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/yourorg/arc-tmux/pkg/tmux"
 )
 
 func TestResolveSessionTargetManaged(t *testing.T) {
@@ -18,3 +22,88 @@ func TestResolveSessionTargetManaged(t *testing.T) {
 		t.Fatalf("unexpected session: %s", resolved)
 	}
 }
+
+func TestReadPaneLines(t *testing.T) {
+	lines, err := readPaneLines(strings.NewReader("dev:1.0\n\n# comment\n  dev:1.1  \ndev:1.0\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lines) != 2 || lines[0] != "dev:1.0" || lines[1] != "dev:1.1" {
+		t.Fatalf("unexpected lines: %#v", lines)
+	}
+}
+
+func TestRelativePaneTarget(t *testing.T) {
+	cases := map[string]string{
+		"@up":        "{up-of}",
+		"@last":      "{last}",
+		"@current+1": ":.+1",
+		"@current-2": ":.-2",
+	}
+	for selector, want := range cases {
+		got, ok, err := relativePaneTarget(selector)
+		if !ok || err != nil || got != want {
+			t.Fatalf("%s: expected %q, got %q (ok=%t err=%v)", selector, want, got, ok, err)
+		}
+	}
+	if _, ok, _ := relativePaneTarget("@api"); ok {
+		t.Fatal("expected alias to fall through")
+	}
+	if _, ok, err := relativePaneTarget("@current+x"); !ok || err == nil {
+		t.Fatal("expected error for malformed offset")
+	}
+}
+
+func TestWindowIndexByName(t *testing.T) {
+	wins := []tmux.Window{
+		{Session: "dev", WindowIndex: 0, Name: "editor"},
+		{Session: "dev", WindowIndex: 2, Name: "build"},
+		{Session: "dev", WindowIndex: 3, Name: "logs"},
+		{Session: "dev", WindowIndex: 4, Name: "logs"},
+	}
+	if idx, err := windowIndexByName(wins, "build"); err != nil || idx != 2 {
+		t.Fatalf("expected 2, got %d (%v)", idx, err)
+	}
+	if idx, err := windowIndexByName(wins, "7"); err != nil || idx != 7 {
+		t.Fatalf("expected numeric passthrough, got %d (%v)", idx, err)
+	}
+	if _, err := windowIndexByName(wins, "missing"); err == nil {
+		t.Fatal("expected error for unknown window")
+	}
+	_, err := windowIndexByName(wins, "logs")
+	if ce, ok := err.(*codedError); !ok || ce.Code != errAmbiguousPane {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+}
+
+func TestResolvePaneTargetEmptyWithoutDefault(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("ARC_TMUX_SESSION", "")
+	_, err := resolvePaneTarget("  ")
+	var coded *codedError
+	if !errors.As(err, &coded) || coded.Code != errPaneRequired {
+		t.Fatalf("expected %s, got %v", errPaneRequired, err)
+	}
+}
+
+func TestPaneWindowActivePane(t *testing.T) {
+	w := paneWindow{Session: "dev", Index: 2, Panes: []tmux.PaneGeometry{
+		{ID: "%4", Index: 0},
+		{ID: "%7", Index: 1, Active: true},
+	}}
+	h, err := w.activePane()
+	if err != nil || h.ID != "%7" || h.Target != "dev:2.1" {
+		t.Fatalf("unexpected active pane %+v (%v)", h, err)
+	}
+	w.Panes[1].Active = false
+	var coded *codedError
+	if _, err := w.activePane(); !errors.As(err, &coded) || coded.Code != errNoActivePane {
+		t.Fatalf("expected %s, got %v", errNoActivePane, err)
+	}
+}
+
+func TestResolvePaneOrWindowTargetsConflict(t *testing.T) {
+	if _, _, err := resolvePaneOrWindowTargets(nil, "dev:1.0", "dev:1"); err == nil {
+		t.Fatal("expected --pane and --window together to fail")
+	}
+}
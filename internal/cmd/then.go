@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+type thenResult struct {
+	PaneID  string `json:"pane_id" yaml:"pane_id"`
+	Command string `json:"command" yaml:"command"`
+	// WaitedSeconds is how long the pane took to finish what it was doing.
+	WaitedSeconds float64 `json:"waited_seconds" yaml:"waited_seconds"`
+	// Foreground is the program in the foreground when the command was sent.
+	Foreground string `json:"foreground" yaml:"foreground"`
+}
+
+func newThenCmd() *cobra.Command {
+	var paneArg string
+	var windowArg string
+	var afterIdle float64
+	var timeout float64
+	var force bool
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "then <command>",
+		Short: "Send a command once a pane finishes what it is doing",
+		Long: `Wait for a pane to finish its current work, then send the next command:
+a simple way to chain steps without a pipeline file.
+
+The pane counts as finished once it has printed nothing for --after-idle
+seconds and a shell is back in the foreground. A program that goes quiet
+while still running (a server, a REPL) keeps arc-tmux waiting; pass --force
+to send as soon as the pane is quiet. Gives up after --timeout seconds,
+with ERR_PANE_BUSY if a program is still in the foreground.`,
+		Example: `  arc-tmux then --pane @build --after-idle 5 "make deploy"
+  arc-tmux send "npm run build" --pane @web && arc-tmux then --pane @web "npm run serve"
+  arc-tmux then --window dev:db --timeout 1800 "psql -f seed.sql"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if afterIdle <= 0 {
+				afterIdle = 2
+			}
+			if timeout <= 0 {
+				return fmt.Errorf("--timeout must be positive")
+			}
+			handles, _, err := resolvePaneOrWindowTargets(cmd, paneArg, windowArg)
+			if err != nil {
+				return err
+			}
+			if len(handles) != 1 {
+				return fmt.Errorf("then sends to a single pane; got %d", len(handles))
+			}
+			h := handles[0]
+			text := strings.Join(args, " ")
+
+			start := time.Now()
+			deadline := start.Add(time.Duration(timeout * float64(time.Second)))
+			var foreground string
+			for {
+				waitErr := tmux.WaitIdle(h.ID, time.Duration(afterIdle*float64(time.Second)), time.Until(deadline))
+				if waitErr == nil {
+					details, err := tmux.PaneDetailsForTarget(h.ID)
+					if err != nil {
+						return err
+					}
+					foreground = details.Command
+				}
+				send, err := thenReady(h.Target, waitErr, foreground, force, timeout)
+				if err != nil {
+					return err
+				}
+				if send {
+					break
+				}
+				time.Sleep(300 * time.Millisecond)
+			}
+			if err := tmux.SendLiteral(h.ID, text, true, 0); err != nil {
+				return err
+			}
+			result := thenResult{
+				PaneID:        h.Target,
+				Command:       text,
+				WaitedSeconds: time.Since(start).Seconds(),
+				Foreground:    foreground,
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(result)
+			case outputOpts.Is(output.OutputQuiet):
+				return nil
+			}
+			_, _ = fmt.Fprintf(out, "Sent to %s after %.1fs: %s\n", result.PaneID, result.WaitedSeconds, result.Command)
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().StringVar(&windowArg, "window", "", "Target the active pane of this window (SESSION:WINDOW, index or name)")
+	cmd.Flags().Float64Var(&afterIdle, "after-idle", 2.0, "Seconds without output before the pane counts as finished")
+	cmd.Flags().Float64Var(&timeout, "timeout", 600, "Maximum seconds to wait before giving up")
+	cmd.Flags().BoolVar(&force, "force", false, "Send once the pane is quiet, even if a program other than a shell is running")
+	return cmd
+}
+
+// thenReady decides one round of then's wait. waitErr is how waiting for the
+// pane to go quiet ended, and foreground the program in the foreground then
+// (after a timeout, the last one seen). The pane is ready once it is quiet
+// with a shell in the foreground, or with force whatever runs; running out of
+// time with a program in the foreground is ERR_PANE_BUSY. Not ready and no
+// error means wait another round.
+func thenReady(target string, waitErr error, foreground string, force bool, timeout float64) (bool, error) {
+	if waitErr != nil {
+		if foreground != "" && isTimeout(waitErr) {
+			return false, newCodedError(errPaneBusy, fmt.Sprintf("pane %s is still running %q after %gs; pass --force to send anyway", target, foreground, timeout), nil)
+		}
+		return false, waitErr
+	}
+	return force || isShellCommand(foreground), nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestThenReady(t *testing.T) {
+	timeout := errors.New("timeout waiting for idle")
+	cases := []struct {
+		name       string
+		waitErr    error
+		foreground string
+		force      bool
+		want       bool
+		wantCode   string
+		wantErr    bool
+	}{
+		{name: "finished", foreground: "bash", want: true},
+		{name: "quiet but busy", foreground: "node"},
+		{name: "busy with force", foreground: "node", force: true, want: true},
+		{name: "timed out while busy", waitErr: timeout, foreground: "node", wantCode: errPaneBusy},
+		{name: "never went quiet", waitErr: timeout, wantErr: true},
+		{name: "pane gone", waitErr: errors.New("can't find pane"), foreground: "node", wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := thenReady("dev:1.0", c.waitErr, c.foreground, c.force, 60)
+		if got != c.want {
+			t.Fatalf("%s: ready = %v, want %v", c.name, got, c.want)
+		}
+		var coded *codedError
+		switch {
+		case c.wantCode != "":
+			if !errors.As(err, &coded) || coded.Code != c.wantCode {
+				t.Fatalf("%s: err = %v, want %s", c.name, err, c.wantCode)
+			}
+		case c.wantErr:
+			if err == nil || errors.As(err, &coded) {
+				t.Fatalf("%s: err = %v, want the wait error", c.name, err)
+			}
+		case err != nil:
+			t.Fatalf("%s: unexpected error %v", c.name, err)
+		}
+	}
+}
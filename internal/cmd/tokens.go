@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenizerChunk splits text the way the cl100k and o200k pre-tokenizers do:
+// contractions, words with their leading space, short digit groups,
+// punctuation runs, and whitespace.
+var tokenizerChunk = regexp.MustCompile(`(?i:'s|'t|'re|'ve|'m|'ll|'d)| ?\pL+| ?\pN{1,3}| ?[^\s\pL\pN]+|\s+`)
+
+// tokenizers estimate token counts for --max-tokens. No vocabulary is
+// bundled: a word chunk costs one token per charsPerToken runes (common words
+// are a single token), and punctuation one per three runes, an approximation
+// of what the real encoders produce.
+var tokenizers = map[string]float64{
+	"cl100k": 6.0,
+	"o200k":  6.5,
+}
+
+// estimateTokens approximates how many tokens s encodes to with tokenizer.
+func estimateTokens(s string, tokenizer string) int {
+	charsPerToken := tokenizers[tokenizer]
+	if charsPerToken == 0 {
+		charsPerToken = tokenizers["cl100k"]
+	}
+	total := 0
+	for _, chunk := range tokenizerChunk.FindAllString(s, -1) {
+		trimmed := strings.TrimPrefix(chunk, " ")
+		r, _ := utf8.DecodeRuneInString(trimmed)
+		runes := float64(utf8.RuneCountInString(chunk))
+		switch {
+		case strings.TrimSpace(chunk) == "":
+			// Whitespace runs merge into few tokens.
+			total += int(math.Ceil(runes / 16))
+		case unicode.IsLetter(r):
+			total += int(math.Ceil(runes / charsPerToken))
+		case unicode.IsDigit(r):
+			total++
+		default:
+			total += int(math.Ceil(float64(utf8.RuneCountInString(trimmed)) / 3))
+		}
+	}
+	return total
+}
+
+func validateTokenizer(name string) error {
+	if _, ok := tokenizers[name]; ok {
+		return nil
+	}
+	names := make([]string, 0, len(tokenizers))
+	for n := range tokenizers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("invalid --tokenizer %q (%s)", name, strings.Join(names, "|"))
+}
+
+// truncateTokens keeps whole lines of s within an estimated budget of max
+// tokens, cutting inside a line only when a single line is over budget.
+func truncateTokens(s string, max int, keep string, tokenizer string) (string, bool) {
+	if estimateTokens(s, tokenizer) <= max {
+		return s, false
+	}
+	// The blank rows below a pane's prompt carry no context.
+	if trimmed := strings.TrimRight(s, " \n"); trimmed != "" {
+		s = trimmed + "\n"
+		if estimateTokens(s, tokenizer) <= max {
+			return s, true
+		}
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	costs := make([]int, len(lines))
+	for i, line := range lines {
+		costs[i] = estimateTokens(line, tokenizer)
+	}
+	switch keep {
+	case keepHead:
+		n, used := 0, 0
+		for n < len(lines) && used+costs[n] <= max {
+			used += costs[n]
+			n++
+		}
+		if n == 0 {
+			return headBytes(lines[0], tokenBytes(lines[0], costs[0], max)), true
+		}
+		return strings.Join(lines[:n], ""), true
+	case keepBoth:
+		marker := ensureNewline(fmt.Sprintf("[... %d lines truncated ...]", len(lines)))
+		budget := max - estimateTokens(marker, tokenizer)
+		headBudget := (budget + 1) / 2
+		head, used := 0, 0
+		for head < len(lines) && used+costs[head] <= headBudget {
+			used += costs[head]
+			head++
+		}
+		tail := len(lines)
+		for tail > head && used+costs[tail-1] <= budget {
+			tail--
+			used += costs[tail]
+		}
+		return strings.Join(lines[:head], "") + ensureNewline(fmt.Sprintf("[... %d lines truncated ...]", tail-head)) +
+			strings.Join(lines[tail:], ""), true
+	default:
+		start, used := len(lines), 0
+		for start > 0 && used+costs[start-1] <= max {
+			start--
+			used += costs[start]
+		}
+		if start == len(lines) {
+			last := lines[len(lines)-1]
+			return tailBytes(last, tokenBytes(last, costs[len(costs)-1], max)), true
+		}
+		return strings.Join(lines[start:], ""), true
+	}
+}
+
+// tokenBytes scales the byte length of line, estimated at cost tokens, down
+// to a budget of max tokens.
+func tokenBytes(line string, cost int, max int) int {
+	if cost <= 0 {
+		return len(line)
+	}
+	return len(line) * max / cost
+}
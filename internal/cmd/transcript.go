@@ -0,0 +1,237 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// transcriptOutputLimit caps the output copied into each transcript record.
+const transcriptOutputLimit = 4096
+
+// transcriptEntry is one JSON line in a transcript file.
+type transcriptEntry struct {
+	Time       time.Time `json:"time"`
+	Actor      string    `json:"actor,omitempty"`
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	Targets    []string  `json:"targets,omitempty"`
+	Output     string    `json:"output,omitempty"`
+	Truncated  bool      `json:"truncated,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// transcriptRecorder collects one invocation's record until FlushTranscript.
+type transcriptRecorder struct {
+	mu      sync.Mutex
+	path    string
+	entry   transcriptEntry
+	started time.Time
+	output  []byte
+}
+
+// activeTranscript records the outermost invocation. Commands a recipe runs
+// through a nested root share it rather than starting their own, so their
+// targets and output land in the one record.
+var activeTranscript *transcriptRecorder
+
+var unsafeTranscriptChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func addTranscriptFlag(root *cobra.Command) {
+	root.PersistentFlags().String("transcript", "", "Append a JSON record of this command, its targets, and its output to a file; {session} expands to the target session (env ARC_TMUX_TRANSCRIPT)")
+}
+
+// startTranscript begins recording when --transcript, ARC_TMUX_TRANSCRIPT, or
+// the config's transcript path is set, teeing the command's stdout.
+func startTranscript(cmd *cobra.Command) {
+	path := configuredTranscript(cmd)
+	if path == "" || cmd.Name() == "__complete" || activeTranscript != nil {
+		return
+	}
+	rec := &transcriptRecorder{
+		path:    path,
+		started: time.Now(),
+		entry: transcriptEntry{
+			Actor:   currentActor,
+			Command: cmd.CommandPath(),
+			Args:    os.Args[1:],
+		},
+	}
+	cmd.SetOut(io.MultiWriter(cmd.OutOrStdout(), rec))
+	activeTranscript = rec
+}
+
+// configuredTranscript is the transcript path from --transcript,
+// ARC_TMUX_TRANSCRIPT, or the config, before {session} expansion.
+func configuredTranscript(cmd *cobra.Command) string {
+	cfg, _ := loadConfig(defaultConfigFile())
+	path := strings.TrimSpace(cfg.Transcript)
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_TRANSCRIPT")); env != "" {
+		path = env
+	}
+	if f := cmd.Flags().Lookup("transcript"); f != nil && f.Changed {
+		path = strings.TrimSpace(f.Value.String())
+	}
+	return path
+}
+
+// Write keeps the first transcriptOutputLimit bytes of output.
+func (r *transcriptRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	room := transcriptOutputLimit - len(r.output)
+	switch {
+	case room <= 0:
+		r.entry.Truncated = r.entry.Truncated || len(p) > 0
+	case len(p) > room:
+		r.output = append(r.output, p[:room]...)
+		r.entry.Truncated = true
+	default:
+		r.output = append(r.output, p...)
+	}
+	return len(p), nil
+}
+
+// noteTranscriptTarget records a resolved pane in the active transcript.
+func noteTranscriptTarget(handle tmux.PaneHandle) {
+	rec := activeTranscript
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	label := handle.Target
+	if handle.ID != "" {
+		label += " (" + handle.ID + ")"
+	}
+	for _, seen := range rec.entry.Targets {
+		if seen == label {
+			return
+		}
+	}
+	rec.entry.Targets = append(rec.entry.Targets, label)
+}
+
+// FlushTranscript appends the active invocation's record, with runErr as its
+// error, to the transcript file. Write failures are reported on stderr but
+// never change the command's outcome.
+func FlushTranscript(runErr error) {
+	rec := activeTranscript
+	if rec == nil {
+		return
+	}
+	activeTranscript = nil
+	rec.mu.Lock()
+	entry := rec.entry
+	entry.Time = rec.started.UTC()
+	entry.Output = string(rec.output)
+	entry.DurationMS = time.Since(rec.started).Milliseconds()
+	rec.mu.Unlock()
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+	if err := appendTranscript(transcriptPath(rec.path, entry.Targets), entry); err != nil {
+		_, _ = io.WriteString(os.Stderr, "warning: transcript: "+err.Error()+"\n")
+	}
+}
+
+// transcriptPath expands {session} to the first target's session, or
+// "default" when no pane was resolved. The name is made safe as a single path
+// element, so a session named "../x" or "a/b" stays in the directory.
+func transcriptPath(path string, targets []string) string {
+	if !strings.Contains(path, "{session}") {
+		return path
+	}
+	session := "default"
+	if len(targets) > 0 {
+		if name, _, ok := strings.Cut(targets[0], ":"); ok && name != "" {
+			session = name
+		}
+	}
+	session = unsafeTranscriptChars.ReplaceAllString(session, "_")
+	if strings.Trim(session, ".") == "" {
+		session = "_"
+	}
+	return strings.ReplaceAll(path, "{session}", session)
+}
+
+func appendTranscript(path string, entry transcriptEntry) error {
+	policy, err := loadRotationPolicy()
+	if err != nil {
+		return err
+	}
+	f, err := openRotatingFile(path, policy)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// loadTranscriptEntries reads the records keep accepts, including those in
+// rotated copies of the transcript, oldest first. A missing transcript yields
+// no records; malformed lines are skipped.
+func loadTranscriptEntries(path string, keep func(transcriptEntry) bool) ([]transcriptEntry, error) {
+	var entries []transcriptEntry
+	rotated, err := rotatedLogs(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, r := range rotated {
+		if entries, err = readTranscriptEntries(r.Path, keep, entries); err != nil {
+			return nil, err
+		}
+	}
+	return readTranscriptEntries(path, keep, entries)
+}
+
+func readTranscriptEntries(path string, keep func(transcriptEntry) bool, entries []transcriptEntry) ([]transcriptEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		defer func() { _ = zr.Close() }()
+		r = zr
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e transcriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if keep(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestTranscriptPath(t *testing.T) {
+	if got := transcriptPath("/tmp/t.jsonl", []string{"dev:0.1 (%3)"}); got != "/tmp/t.jsonl" {
+		t.Fatalf("plain path = %q", got)
+	}
+	if got := transcriptPath("/tmp/{session}.jsonl", []string{"dev:0.1 (%3)"}); got != "/tmp/dev.jsonl" {
+		t.Fatalf("session path = %q", got)
+	}
+	if got := transcriptPath("/tmp/{session}.jsonl", nil); got != "/tmp/default.jsonl" {
+		t.Fatalf("no-target path = %q", got)
+	}
+	if got := transcriptPath("/tmp/{session}/t.jsonl", []string{"../etc:0.0 (%1)"}); got != "/tmp/.._etc/t.jsonl" {
+		t.Fatalf("traversal path = %q", got)
+	}
+	if got := transcriptPath("/tmp/{session}/t.jsonl", []string{"..:0.0 (%1)"}); got != "/tmp/_/t.jsonl" {
+		t.Fatalf("dot-dot path = %q", got)
+	}
+}
+
+func TestStartTranscriptNested(t *testing.T) {
+	t.Setenv("ARC_TMUX_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv("ARC_TMUX_TRANSCRIPT", filepath.Join(t.TempDir(), "t.jsonl"))
+	outer := &transcriptRecorder{path: "outer"}
+	activeTranscript = outer
+	t.Cleanup(func() { activeTranscript = nil })
+	startTranscript(&cobra.Command{Use: "capture"})
+	if activeTranscript != outer {
+		t.Fatalf("a nested invocation replaced the outer transcript recorder")
+	}
+}
+
+func TestTranscriptRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "t.jsonl")
+	rec := &transcriptRecorder{path: path, entry: transcriptEntry{Command: "arc-tmux capture"}}
+	activeTranscript = rec
+	noteTranscriptTarget(tmux.PaneHandle{ID: "%3", Target: "dev:0.1"})
+	noteTranscriptTarget(tmux.PaneHandle{ID: "%3", Target: "dev:0.1"})
+	_, _ = rec.Write([]byte(strings.Repeat("x", transcriptOutputLimit+10)))
+	FlushTranscript(nil)
+	if activeTranscript != nil {
+		t.Fatalf("FlushTranscript left the recorder active")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("transcript mode = %o, want 600", perm)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry transcriptEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("decode %q: %v", data, err)
+	}
+	if len(entry.Targets) != 1 || entry.Targets[0] != "dev:0.1 (%3)" {
+		t.Fatalf("targets = %q", entry.Targets)
+	}
+	if len(entry.Output) != transcriptOutputLimit || !entry.Truncated {
+		t.Fatalf("expected output truncated to %d bytes, got %d (truncated=%v)", transcriptOutputLimit, len(entry.Output), entry.Truncated)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// triagePattern is an error signature for "triage". Match captures
+// (?P<message>) and optionally (?P<file>), (?P<line>), and (?P<col>); when
+// it has no file, Location is searched for one in the Within lines after the
+// match (or in the indented lines before it, with LocationBefore: Python
+// prints the frame first).
+type triagePattern struct {
+	Name     string `json:"name" yaml:"name"`
+	Kind     string `json:"kind" yaml:"kind"`
+	Match    string `json:"match" yaml:"match"`
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+	// LocationBefore searches the lines before the match, nearest first.
+	LocationBefore bool `json:"location_before,omitempty" yaml:"location_before,omitempty"`
+	Within         int  `json:"within,omitempty" yaml:"within,omitempty"`
+	// RequireLocation drops matches without a location, for patterns whose
+	// message line alone is too generic.
+	RequireLocation bool `json:"require_location,omitempty" yaml:"require_location,omitempty"`
+
+	match    *regexp.Regexp
+	location *regexp.Regexp
+}
+
+// defaultTriagePatterns are the built-in signatures, most specific first.
+var defaultTriagePatterns = []triagePattern{
+	{Name: "go", Kind: "compile", Match: `^\s*(?P<file>[\w./-]+\.go):(?P<line>\d+)(?::(?P<col>\d+))?: (?P<message>.+)$`},
+	{Name: "cc", Kind: "compile", Match: `^(?P<file>[\w./-]+\.\w+):(?P<line>\d+):(?P<col>\d+): (?:fatal )?error: (?P<message>.+)$`},
+	{Name: "tsc", Kind: "compile", Match: `^(?P<file>[\w./-]+\.[cm]?[jt]sx?)(?:\((?P<line>\d+),(?P<col>\d+)\): |:(?P<line2>\d+):(?P<col2>\d+) - )error (?P<message>TS\d+: .+)$`},
+	{Name: "rust", Kind: "compile", Match: `^error(?:\[E\d+\])?: (?P<message>.+)$`, Location: `^\s*--> (?P<file>[^:\s]+):(?P<line>\d+):(?P<col>\d+)`, Within: 1},
+	{Name: "go-panic", Kind: "panic", Match: `^panic: (?P<message>.+)$`, Location: `^\s+(?P<file>\S+\.go):(?P<line>\d+)`, Within: 40},
+	{Name: "node", Kind: "exception", Match: `^(?:Uncaught )?(?P<message>\w*Error(?: \[\w+\])?: .+)$`, Location: `^\s+at (?:.+ \()?(?:file://)?(?P<file>[^()\s]+?):(?P<line>\d+):(?P<col>\d+)\)?$`, Within: 1, RequireLocation: true},
+	{Name: "python", Kind: "exception", Match: `^(?P<message>(?:[A-Za-z_]\w*\.)*[A-Z]\w*(?:Error|Exception|Interrupt|Exit)\b.*)$`, Location: `^\s*File "(?P<file>[^"]+)", line (?P<line>\d+)`, LocationBefore: true, Within: 40, RequireLocation: true},
+	{Name: "java", Kind: "exception", Match: `^Exception in thread "[^"]*" (?P<message>.+)$`, Location: `^\s+at .+\((?P<file>[\w$.-]+\.java):(?P<line>\d+)\)$`, Within: 1},
+	{Name: "pytest", Kind: "test", Match: `^FAILED (?P<file>[\w./-]+\.py)::(?P<message>.+)$`},
+	{Name: "go-test", Kind: "test", Match: `^\s*--- FAIL: (?P<message>.+)$`},
+	{Name: "jest", Kind: "test", Match: `^\s*● (?P<message>.+ › .+)$`},
+	{Name: "npm", Kind: "package", Match: `^npm (?:ERR!|error) (?P<message>\S.*)$`},
+	{Name: "pip", Kind: "package", Match: `^ERROR: (?P<message>.+)$`},
+	{Name: "make", Kind: "build", Match: `^(?:g?make)(?:\[\d+\])?: \*\*\* (?P<message>.+)$`},
+	{Name: "error", Kind: "error", Match: `^(?:error|fatal|ERROR|FATAL)(?:\[\w+\])?: (?P<message>.+)$`},
+}
+
+type triageReport struct {
+	Pane     string          `json:"pane" yaml:"pane"`
+	Lines    int             `json:"lines" yaml:"lines"`
+	Findings []triageFinding `json:"findings" yaml:"findings"`
+}
+
+type triageFinding struct {
+	Pattern string `json:"pattern" yaml:"pattern"`
+	Kind    string `json:"kind" yaml:"kind"`
+	File    string `json:"file,omitempty" yaml:"file,omitempty"`
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Column  int    `json:"column,omitempty" yaml:"column,omitempty"`
+	Message string `json:"message" yaml:"message"`
+	// OutputLine is the 1-based line of the capture the match is on.
+	OutputLine int    `json:"output_line" yaml:"output_line"`
+	Excerpt    string `json:"excerpt" yaml:"excerpt"`
+}
+
+// ref renders the finding as an editor-friendly FILE:LINE[:COL] reference.
+func (f triageFinding) ref() string {
+	switch {
+	case f.File == "":
+		return ""
+	case f.Line == 0:
+		return f.File
+	case f.Column == 0:
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", f.File, f.Line, f.Column)
+}
+
+func newTriageCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var paneArg string
+	var lines int
+	var context int
+	var kinds []string
+
+	cmd := &cobra.Command{
+		Use:   "triage",
+		Short: "Find errors in a pane's recent output",
+		Long: `Scan a pane's recent output for known error signatures and report each
+finding with its file, line, column, message, and an excerpt of the output
+around it.
+
+Built-in signatures cover Go, C/C++ (gcc/clang), TypeScript, and Rust
+compiler errors; Go panics; Python, Node.js, and Java exceptions; pytest, go
+test, and Jest failures; npm, pip, and make errors; and generic "error:"
+lines. Add your own with triage_patterns in the config: each has a name, a
+kind, a match regexp capturing (?P<message>) and optionally (?P<file>),
+(?P<line>), and (?P<col>), and an optional location regexp searched within
+the following (or, with location_before, preceding) lines. Configured
+patterns are tried first.`,
+		Example: `  arc-tmux triage --pane @server
+  arc-tmux triage --pane dev:1.0 --lines 2000 --kind compile,test -o json
+  arc-tmux triage --pane @tests -o quiet`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			if context < 0 {
+				return errors.New("--context must be >= 0")
+			}
+			patterns, err := triagePatterns()
+			if err != nil {
+				return err
+			}
+			target, err := resolvePaneTarget(paneArg)
+			if err != nil {
+				return err
+			}
+			handle, err := canonicalPaneTarget(target)
+			if err != nil {
+				return err
+			}
+			capture, err := tmux.CaptureJoined(handle.ID, lines)
+			if err != nil {
+				return err
+			}
+			outLines := splitLines(capture)
+			report := triageReport{Pane: handle.Target, Lines: len(outLines), Findings: []triageFinding{}}
+			for _, f := range triageOutput(outLines, patterns, context) {
+				if len(kinds) == 0 || containsFold(kinds, f.Kind) {
+					report.Findings = append(report.Findings, f)
+				}
+			}
+			return writeTriageReport(cmd, outputOpts, report)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @name)")
+	cmd.Flags().IntVar(&lines, "lines", 500, "Scan the last N lines (0 for full)")
+	cmd.Flags().IntVar(&context, "context", 2, "Lines of output to include after each match in its excerpt")
+	cmd.Flags().StringSliceVar(&kinds, "kind", nil, "Only report these kinds (compile, panic, exception, test, package, build, error)")
+	return cmd
+}
+
+// triagePatterns compiles triage_patterns from the config file followed by
+// the built-in patterns, so configured patterns win.
+func triagePatterns() ([]triagePattern, error) {
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		return nil, err
+	}
+	patterns := make([]triagePattern, 0, len(cfg.TriagePatterns)+len(defaultTriagePatterns))
+	for _, p := range append(append([]triagePattern{}, cfg.TriagePatterns...), defaultTriagePatterns...) {
+		compiled, err := compileTriagePattern(p)
+		if err != nil {
+			return nil, fmt.Errorf("triage_patterns: %w", err)
+		}
+		patterns = append(patterns, compiled)
+	}
+	return patterns, nil
+}
+
+func compileTriagePattern(p triagePattern) (triagePattern, error) {
+	if strings.TrimSpace(p.Name) == "" {
+		return p, fmt.Errorf("pattern %q has no name", p.Match)
+	}
+	if strings.TrimSpace(p.Kind) == "" {
+		p.Kind = "error"
+	}
+	re, err := regexp.Compile(p.Match)
+	if err != nil {
+		return p, fmt.Errorf("%s: invalid match %q: %w", p.Name, p.Match, err)
+	}
+	if re.SubexpIndex("message") < 0 {
+		return p, fmt.Errorf("%s: match %q must capture (?P<message>...)", p.Name, p.Match)
+	}
+	p.match = re
+	if strings.TrimSpace(p.Location) != "" {
+		if p.location, err = regexp.Compile(p.Location); err != nil {
+			return p, fmt.Errorf("%s: invalid location %q: %w", p.Name, p.Location, err)
+		}
+		if p.location.SubexpIndex("file") < 0 {
+			return p, fmt.Errorf("%s: location %q must capture (?P<file>...)", p.Name, p.Location)
+		}
+		if p.Within <= 0 {
+			p.Within = 5
+		}
+	}
+	return p, nil
+}
+
+// triageOutput returns the findings in lines, one per line at most. A match
+// on the line right after a finding of the same pattern without a file (npm
+// and pip print one error over several lines) continues that finding.
+func triageOutput(lines []string, patterns []triagePattern, context int) []triageFinding {
+	findings := []triageFinding{}
+	lastHit := -1
+	for i, line := range lines {
+		for _, p := range patterns {
+			m := p.match.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			f := triageFinding{Pattern: p.Name, Kind: p.Kind, Message: strings.TrimSpace(subexp(p.match, m, "message")), OutputLine: i + 1}
+			f.File, f.Line, f.Column = triageLocation(p.match, m)
+			start, last := i, i
+			if f.File == "" && p.location != nil {
+				if at, ok := findTriageLocation(lines, i, p); ok {
+					lm := p.location.FindStringSubmatch(lines[at])
+					f.File, f.Line, f.Column = triageLocation(p.location, lm)
+					start, last = min(start, at), max(last, at)
+				}
+			}
+			if f.File == "" && p.RequireLocation {
+				continue
+			}
+			if n := len(findings); n > 0 && f.File == "" && findings[n-1].Pattern == p.Name && findings[n-1].File == "" && lastHit == i-1 {
+				lastHit = i
+				break
+			}
+			end := min(len(lines), last+1+context)
+			f.Excerpt = strings.Join(lines[start:end], "\n")
+			findings = append(findings, f)
+			lastHit = i
+			break
+		}
+	}
+	return findings
+}
+
+func findTriageLocation(lines []string, at int, p triagePattern) (int, bool) {
+	if p.LocationBefore {
+		// Only the indented stack trace directly above the match counts.
+		for i := at - 1; i >= 0 && i >= at-p.Within; i-- {
+			if p.location.MatchString(lines[i]) {
+				return i, true
+			}
+			if !strings.HasPrefix(lines[i], " ") && !strings.HasPrefix(lines[i], "\t") {
+				break
+			}
+		}
+		return 0, false
+	}
+	for i := at + 1; i < len(lines) && i <= at+p.Within; i++ {
+		if p.location.MatchString(lines[i]) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// triageLocation reads file, line, and col from a match; line2 and col2 are
+// alternate spellings for patterns with two location formats.
+func triageLocation(re *regexp.Regexp, m []string) (string, int, int) {
+	file := subexp(re, m, "file")
+	line, _ := strconv.Atoi(firstNonEmpty(subexp(re, m, "line"), subexp(re, m, "line2")))
+	col, _ := strconv.Atoi(firstNonEmpty(subexp(re, m, "col"), subexp(re, m, "col2")))
+	return file, line, col
+}
+
+func subexp(re *regexp.Regexp, m []string, name string) string {
+	if i := re.SubexpIndex(name); i >= 0 && i < len(m) {
+		return m[i]
+	}
+	return ""
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeTriageReport(cmd *cobra.Command, outputOpts output.OutputOptions, report triageReport) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(report)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, f := range report.Findings {
+			if ref := f.ref(); ref != "" {
+				_, _ = fmt.Fprintf(out, "%s: %s\n", ref, f.Message)
+			} else {
+				_, _ = fmt.Fprintln(out, f.Message)
+			}
+		}
+		return nil
+	}
+	if len(report.Findings) == 0 {
+		_, _ = fmt.Fprintf(out, "No errors found in the last %d lines of %s.\n", report.Lines, report.Pane)
+		return nil
+	}
+	table := newTextTable("KIND", "LOCATION", "MESSAGE")
+	for _, f := range report.Findings {
+		table.addRow(styledCell(f.Kind, styleError), cell(f.ref()), cell(f.Message))
+	}
+	return table.render(out)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func builtinTriagePatterns(t *testing.T) []triagePattern {
+	t.Helper()
+	patterns := make([]triagePattern, 0, len(defaultTriagePatterns))
+	for _, p := range defaultTriagePatterns {
+		compiled, err := compileTriagePattern(p)
+		if err != nil {
+			t.Fatalf("built-in pattern %s: %v", p.Name, err)
+		}
+		patterns = append(patterns, compiled)
+	}
+	return patterns
+}
+
+func TestTriageOutput(t *testing.T) {
+	out := strings.Join([]string{
+		"$ go build ./...",
+		"# example.com/app",
+		"./main.go:12:5: undefined: frob",
+		"$ python app.py",
+		"Traceback (most recent call last):",
+		`  File "/srv/app.py", line 8, in <module>`,
+		"    main()",
+		"KeyError: 'user'",
+		"$ node server.js",
+		"TypeError: Cannot read properties of undefined (reading 'id')",
+		"    at handler (/srv/server.js:21:13)",
+		"    at Layer.handle (/srv/node_modules/express/lib/router/layer.js:95:5)",
+		"error[E0308]: mismatched types",
+		"  --> src/lib.rs:4:18",
+		"npm ERR! code ELIFECYCLE",
+		"npm ERR! errno 1",
+		"FAILED tests/test_api.py::test_login - AssertionError",
+		"src/app.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'.",
+		"Error: something generic",
+	}, "\n")
+	findings := triageOutput(splitLines(out), builtinTriagePatterns(t), 1)
+	want := []struct {
+		pattern string
+		ref     string
+		message string
+	}{
+		{"go", "./main.go:12:5", "undefined: frob"},
+		{"python", "/srv/app.py:8", "KeyError: 'user'"},
+		{"node", "/srv/server.js:21:13", "TypeError: Cannot read properties of undefined (reading 'id')"},
+		{"rust", "src/lib.rs:4:18", "mismatched types"},
+		{"npm", "", "code ELIFECYCLE"},
+		{"pytest", "tests/test_api.py", "test_login - AssertionError"},
+		{"tsc", "src/app.ts:3:7", "TS2322: Type 'string' is not assignable to type 'number'."},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("got %d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+	for i, w := range want {
+		f := findings[i]
+		if f.Pattern != w.pattern || f.ref() != w.ref || f.Message != w.message {
+			t.Errorf("finding %d = %s %q %q, want %s %q %q", i, f.Pattern, f.ref(), f.Message, w.pattern, w.ref, w.message)
+		}
+	}
+	python := findings[1]
+	if python.OutputLine != 8 || !strings.HasPrefix(python.Excerpt, `  File "/srv/app.py"`) || !strings.Contains(python.Excerpt, "$ node server.js") {
+		t.Fatalf("python excerpt = %q (line %d)", python.Excerpt, python.OutputLine)
+	}
+}
+
+func TestCompileTriagePatternValidates(t *testing.T) {
+	if _, err := compileTriagePattern(triagePattern{Name: "x", Match: `^oops (.+)$`}); err == nil {
+		t.Fatalf("expected a pattern without a message group to fail")
+	}
+	if _, err := compileTriagePattern(triagePattern{Name: "x", Match: `^(?P<message>.+)$`, Location: `at (\d+)`}); err == nil {
+		t.Fatalf("expected a location without a file group to fail")
+	}
+	p, err := compileTriagePattern(triagePattern{Name: "deploy", Match: `^DEPLOY FAILED: (?P<message>.+)$`})
+	if err != nil || p.Kind != "error" {
+		t.Fatalf("compileTriagePattern = %+v, %v", p, err)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+// Output retention strategies for --keep.
+const (
+	keepHead = "head"
+	keepTail = "tail"
+	keepBoth = "both"
+)
+
+// outputCap limits how much captured output a command returns, so an
+// accidental "cat largefile" cannot flood an agent's context.
+type outputCap struct {
+	MaxBytes  int
+	MaxLines  int
+	MaxTokens int
+	Tokenizer string
+	Keep      string
+}
+
+func addOutputCapFlags(cmd *cobra.Command, c *outputCap) {
+	cmd.Flags().IntVar(&c.MaxBytes, "max-bytes", 0, "Truncate output to N bytes (0 for no limit)")
+	cmd.Flags().IntVar(&c.MaxLines, "max-lines", 0, "Truncate output to N lines (0 for no limit)")
+	cmd.Flags().IntVar(&c.MaxTokens, "max-tokens", 0, "Truncate output to about N LLM tokens, in whole lines (0 for no limit)")
+	cmd.Flags().StringVar(&c.Tokenizer, "tokenizer", "cl100k", "Tokenizer to estimate --max-tokens with: cl100k or o200k")
+	cmd.Flags().StringVar(&c.Keep, "keep", keepTail, "Part of truncated output to keep: head, tail, or both (start and end)")
+}
+
+func (c outputCap) validate() error {
+	if c.MaxBytes < 0 || c.MaxLines < 0 || c.MaxTokens < 0 {
+		return fmt.Errorf("--max-bytes, --max-lines, and --max-tokens must be >= 0")
+	}
+	if c.MaxTokens > 0 {
+		if err := validateTokenizer(c.Tokenizer); err != nil {
+			return err
+		}
+	}
+	switch c.Keep {
+	case keepHead, keepTail, keepBoth:
+		return nil
+	default:
+		return fmt.Errorf("invalid --keep %q (head|tail|both)", c.Keep)
+	}
+}
+
+// limited reports whether any cap is set.
+func (c outputCap) limited() bool {
+	return c.MaxBytes > 0 || c.MaxLines > 0 || c.MaxTokens > 0
+}
+
+// apply caps s by lines, bytes, and then tokens, reporting whether anything
+// was cut.
+// With keep "both" a marker line replaces the dropped middle.
+func (c outputCap) apply(s string) (string, bool) {
+	truncated := false
+	if c.MaxLines > 0 {
+		var cut bool
+		s, cut = truncateLines(s, c.MaxLines, c.Keep)
+		truncated = truncated || cut
+	}
+	if c.MaxBytes > 0 {
+		var cut bool
+		s, cut = truncateBytes(s, c.MaxBytes, c.Keep)
+		truncated = truncated || cut
+	}
+	if c.MaxTokens > 0 {
+		var cut bool
+		s, cut = truncateTokens(s, c.MaxTokens, c.Keep, c.Tokenizer)
+		truncated = truncated || cut
+	}
+	return s, truncated
+}
+
+func truncateLines(s string, max int, keep string) (string, bool) {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= max {
+		return s, false
+	}
+	switch keep {
+	case keepHead:
+		return strings.Join(lines[:max], ""), true
+	case keepBoth:
+		head := (max + 1) / 2
+		tail := max - head
+		dropped := len(lines) - max
+		return strings.Join(lines[:head], "") + ensureNewline(fmt.Sprintf("[... %d lines truncated ...]", dropped)) +
+			strings.Join(lines[len(lines)-tail:], ""), true
+	default:
+		return strings.Join(lines[len(lines)-max:], ""), true
+	}
+}
+
+func truncateBytes(s string, max int, keep string) (string, bool) {
+	if len(s) <= max {
+		return s, false
+	}
+	switch keep {
+	case keepHead:
+		return headBytes(s, max), true
+	case keepBoth:
+		head := headBytes(s, (max+1)/2)
+		tail := tailBytes(s, max-(max+1)/2)
+		dropped := len(s) - len(head) - len(tail)
+		return ensureNewline(head) + ensureNewline(fmt.Sprintf("[... %d bytes truncated ...]", dropped)) + tail, true
+	default:
+		return tailBytes(s, max), true
+	}
+}
+
+// headBytes returns at most n leading bytes of s without splitting a rune.
+func headBytes(s string, n int) string {
+	for n > 0 && n < len(s) && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// tailBytes returns at most n trailing bytes of s without splitting a rune.
+func tailBytes(s string, n int) string {
+	start := len(s) - n
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return s[start:]
+}
+
+func ensureNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
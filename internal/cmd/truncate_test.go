@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOutputCapLines(t *testing.T) {
+	in := "1\n2\n3\n4\n5\n"
+	cases := []struct {
+		keep string
+		want string
+	}{
+		{keepHead, "1\n2\n3\n4\n"},
+		{keepTail, "2\n3\n4\n5\n"},
+		{keepBoth, "1\n2\n[... 1 lines truncated ...]\n4\n5\n"},
+	}
+	for _, tc := range cases {
+		got, truncated := outputCap{MaxLines: 4, Keep: tc.keep}.apply(in)
+		if !truncated || got != tc.want {
+			t.Fatalf("keep %s: got %q (truncated=%v), want %q", tc.keep, got, truncated, tc.want)
+		}
+	}
+	if got, truncated := (outputCap{MaxLines: 5, Keep: keepTail}).apply(in); truncated || got != in {
+		t.Fatalf("expected output under the cap unchanged, got %q", got)
+	}
+}
+
+func TestOutputCapBytesKeepsRunes(t *testing.T) {
+	got, truncated := outputCap{MaxBytes: 4, Keep: keepTail}.apply("abcé")
+	if !truncated || got != "bcé" {
+		t.Fatalf("got %q (truncated=%v)", got, truncated)
+	}
+	got, _ = outputCap{MaxBytes: 4, Keep: keepHead}.apply("aébc")
+	if got != "aéb" {
+		t.Fatalf("got %q", got)
+	}
+	got, _ = outputCap{MaxBytes: 4, Keep: keepBoth}.apply("abcdefgh")
+	if got != "ab\n[... 4 bytes truncated ...]\ngh" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestOutputCapValidate(t *testing.T) {
+	if err := (outputCap{Keep: "middle"}).validate(); err == nil {
+		t.Fatalf("expected invalid --keep to fail")
+	}
+	if err := (outputCap{MaxLines: -1, Keep: keepTail}).validate(); err == nil {
+		t.Fatalf("expected negative cap to fail")
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	cases := map[string]int{
+		"":                     0,
+		"hello world":          2,
+		"hello, world!\n":      5,
+		"12345":                2,
+		"internationalization": 4,
+	}
+	for in, want := range cases {
+		if got := estimateTokens(in, "cl100k"); got != want {
+			t.Errorf("estimateTokens(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestOutputCapTokens(t *testing.T) {
+	in := "one\ntwo\nthree\nfour\n"
+	got, truncated := outputCap{MaxTokens: 4, Tokenizer: "cl100k", Keep: keepTail}.apply(in)
+	if !truncated || got != "three\nfour\n" {
+		t.Fatalf("tail: got %q (truncated=%v)", got, truncated)
+	}
+	got, _ = outputCap{MaxTokens: 4, Tokenizer: "cl100k", Keep: keepHead}.apply(in)
+	if got != "one\ntwo\n" {
+		t.Fatalf("head: got %q", got)
+	}
+	if got, truncated := (outputCap{MaxTokens: 100, Tokenizer: "cl100k", Keep: keepTail}).apply(in); truncated || got != in {
+		t.Fatalf("expected output under the budget unchanged, got %q", got)
+	}
+	long := strings.TrimSpace(strings.Repeat("abcd ", 40)) + "\n"
+	got, _ = outputCap{MaxTokens: 10, Tokenizer: "cl100k", Keep: keepTail}.apply(long)
+	if got == "" || len(got) >= len(long) || !strings.HasSuffix(long, got) {
+		t.Fatalf("over-budget single line: got %q", got)
+	}
+	if err := (outputCap{MaxTokens: 10, Tokenizer: "gpt2", Keep: keepTail}).validate(); err == nil {
+		t.Fatalf("expected unknown tokenizer to fail")
+	}
+}
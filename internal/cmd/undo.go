@@ -0,0 +1,291 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultTrashTTL is how long cleanup --trash keeps a session restorable.
+const defaultTrashTTL = 24 * time.Hour
+
+type undoResult struct {
+	DryRun bool `json:"dry_run" yaml:"dry_run"`
+	// Undone lists the operations reverted, most recent first; on a dry run,
+	// those that would be.
+	Undone []undoOp `json:"undone" yaml:"undone"`
+	// Operations lists the undo journal with --list, most recent first.
+	Operations []undoOp `json:"operations,omitempty" yaml:"operations,omitempty"`
+	// Purged names trashed sessions killed because their TTL passed.
+	Purged []string `json:"purged,omitempty" yaml:"purged,omitempty"`
+}
+
+func newUndoCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var session string
+	var steps int
+	var list bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Revert recent changes arc-tmux made to sessions",
+		Long: `Revert the most recent reversible change arc-tmux made, or the last --steps
+of them, newest first. Changes are kept in the undo journal (the last 50, under
+the user cache directory; ARC_TMUX_UNDO_FILE moves it, "off" disables it):
+
+  trash    cleanup --trash moved a session to the trash; undo renames it back
+  layout   ensure, preset, or scale applied a window layout; undo restores the
+           previous one (while the window still has the same panes)
+  alias    alias set, set-from-window, or unset changed a scope; undo
+           restores its aliases (dropping aliases of closed panes is not
+           journaled)
+  option   default or default clear changed a session's default pane
+
+--list shows the journal. An operation that can no longer be reverted (its
+session or panes are gone) is dropped from the journal with an error.
+
+--session restores a trashed session by its original or trash name, whether
+or not it is in the journal. Trashed sessions are kept until their TTL passes;
+cleanup and undo kill the expired ones.`,
+		Example: `  arc-tmux undo
+  arc-tmux undo --steps 3 --dry-run
+  arc-tmux undo --list -o json
+  arc-tmux undo --session api`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			result := undoResult{DryRun: dryRun, Undone: []undoOp{}}
+			if !dryRun {
+				purged, err := purgeExpiredTrash()
+				if err != nil {
+					return err
+				}
+				result.Purged = purged
+			}
+			path := defaultUndoFile()
+			var ops []undoOp
+			if path != "" {
+				if !list && !dryRun {
+					unlock, err := lockUndoJournal(path)
+					if err != nil {
+						return err
+					}
+					defer unlock()
+				}
+				var err error
+				if ops, err = loadUndoJournal(path); err != nil {
+					return err
+				}
+			}
+
+			if list {
+				result.Operations = make([]undoOp, 0, len(ops))
+				for i := len(ops) - 1; i >= 0; i-- {
+					result.Operations = append(result.Operations, ops[i])
+				}
+				return writeUndoResult(cmd, outputOpts, result)
+			}
+
+			// Trashed sessions stay restorable without a journal entry: by
+			// name with --session, or the latest when the journal is empty.
+			if strings.TrimSpace(session) != "" || len(ops) == 0 {
+				t, ok, err := restorableTrash(strings.TrimSpace(session))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					if session != "" {
+						return newCodedError(errNothingToUndo, fmt.Sprintf("no restorable trashed session %q", session), nil)
+					}
+					return newCodedError(errNothingToUndo, "nothing to undo", nil)
+				}
+				op := undoOp{Kind: undoKindTrash, Summary: fmt.Sprintf("moved session %s to the trash as %s", t.Original, t.Name), Trash: &t}
+				for _, o := range ops {
+					if o.Trash != nil && o.Trash.Name == t.Name {
+						op = o
+					}
+				}
+				result.Undone = append(result.Undone, op)
+				if dryRun {
+					return writeUndoResult(cmd, outputOpts, result)
+				}
+				if err := tmux.RestoreSession(t); err != nil {
+					return err
+				}
+				if path != "" {
+					kept := ops[:0]
+					for _, o := range ops {
+						if o.Trash == nil || o.Trash.Name != t.Name {
+							kept = append(kept, o)
+						}
+					}
+					if err := saveUndoJournal(path, kept); err != nil {
+						return err
+					}
+				}
+				return writeUndoResult(cmd, outputOpts, result)
+			}
+
+			if steps <= 0 {
+				steps = 1
+			}
+			for len(result.Undone) < steps && len(ops) > 0 {
+				op := ops[len(ops)-1]
+				if !dryRun {
+					if err := revertUndoOp(op); err != nil {
+						if saveErr := saveUndoJournal(path, ops[:len(ops)-1]); saveErr != nil {
+							return saveErr
+						}
+						if len(result.Undone) > 0 {
+							_ = writeUndoResult(cmd, outputOpts, result)
+						}
+						return fmt.Errorf("undo %q: %w (dropped from the undo journal)", op.Summary, err)
+					}
+				}
+				result.Undone = append(result.Undone, op)
+				ops = ops[:len(ops)-1]
+			}
+			if !dryRun {
+				if err := saveUndoJournal(path, ops); err != nil {
+					return err
+				}
+			}
+			return writeUndoResult(cmd, outputOpts, result)
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().IntVar(&steps, "steps", 1, "Number of recent operations to revert")
+	cmd.Flags().StringVar(&session, "session", "", "Restore this trashed session, by original or trash name")
+	cmd.Flags().BoolVar(&list, "list", false, "List the undo journal, most recent first")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be reverted")
+	return cmd
+}
+
+// restorableTrash returns the most recently trashed session that has not
+// expired, matching name when it is set.
+func restorableTrash(name string) (tmux.TrashedSession, bool, error) {
+	trash, err := tmux.ListTrash()
+	if err != nil {
+		return tmux.TrashedSession{}, false, err
+	}
+	now := time.Now()
+	live := make([]tmux.TrashedSession, 0, len(trash))
+	for _, t := range trash {
+		if !t.Expired(now) {
+			live = append(live, t)
+		}
+	}
+	t, ok := pickTrashedSession(live, name)
+	return t, ok, nil
+}
+
+// pickTrashedSession returns the most recently trashed session matching name
+// (original or trash name), or the most recent one when name is empty. trash
+// is ordered most recent first.
+func pickTrashedSession(trash []tmux.TrashedSession, name string) (tmux.TrashedSession, bool) {
+	for _, t := range trash {
+		if name == "" || t.Original == name || t.Name == name {
+			return t, true
+		}
+	}
+	return tmux.TrashedSession{}, false
+}
+
+// purgeExpiredTrash kills the trashed sessions whose TTL has passed and
+// returns their names.
+func purgeExpiredTrash() ([]string, error) {
+	trash, err := tmux.ListTrash()
+	if err != nil {
+		return nil, err
+	}
+	var purged []string
+	now := time.Now()
+	for _, t := range trash {
+		if !t.Expired(now) {
+			continue
+		}
+		if err := tmux.Cleanup(tmux.SessionTarget(t.Name)); err != nil {
+			return purged, fmt.Errorf("purge trashed session %q: %w", t.Name, err)
+		}
+		purged = append(purged, t.Name)
+	}
+	return purged, nil
+}
+
+// resolveCleanupTrash decides whether cleanup trashes rather than kills, and
+// for how long: --trash and --trash-ttl, else cleanup_trash_ttl in the config,
+// which makes trashing the default.
+func resolveCleanupTrash(cmd *cobra.Command, trash bool, ttlFlag string) (bool, time.Duration, error) {
+	cfg, _ := loadConfig(defaultConfigFile())
+	raw := strings.TrimSpace(cfg.CleanupTrashTTL)
+	if !cmd.Flags().Changed("trash") {
+		trash = raw != ""
+	}
+	if cmd.Flags().Changed("trash-ttl") {
+		raw = strings.TrimSpace(ttlFlag)
+	}
+	if !trash {
+		return false, 0, nil
+	}
+	if raw == "" {
+		return true, defaultTrashTTL, nil
+	}
+	ttl, err := parseSince(raw)
+	if err != nil {
+		return false, 0, fmt.Errorf("invalid trash TTL %q (e.g. 30m, 24h, 7d)", raw)
+	}
+	return true, ttl, nil
+}
+
+func writeUndoResult(cmd *cobra.Command, outputOpts output.OutputOptions, result undoResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case outputOpts.Is(output.OutputJSON):
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputYAML):
+		enc := yaml.NewEncoder(out)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(result)
+	case outputOpts.Is(output.OutputQuiet):
+		for _, op := range result.Undone {
+			_, _ = fmt.Fprintln(out, op.Kind)
+		}
+		return nil
+	}
+	for _, name := range result.Purged {
+		_, _ = fmt.Fprintf(out, "Purged expired trashed session %q\n", name)
+	}
+	if result.Operations != nil {
+		if len(result.Operations) == 0 {
+			_, _ = fmt.Fprintln(out, "Nothing to undo.")
+			return nil
+		}
+		table := newTextTable("WHEN", "ACTOR", "COMMAND", "CHANGE")
+		for _, op := range result.Operations {
+			table.addRow(cell(formatRelative(op.Time)), cell(op.Actor), cell(op.Command), cell(op.Summary))
+		}
+		return table.render(out)
+	}
+	verb := "Undid"
+	if result.DryRun {
+		verb = "Dry run: would undo"
+	}
+	for _, op := range result.Undone {
+		_, _ = fmt.Fprintf(out, "%s: %s\n", verb, op.Summary)
+	}
+	return nil
+}
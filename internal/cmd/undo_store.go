@@ -0,0 +1,296 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+// undoJournalLimit caps how many operations the undo journal remembers.
+const undoJournalLimit = 50
+
+// Kinds of reversible operation in the undo journal.
+const (
+	undoKindTrash  = "trash"
+	undoKindLayout = "layout"
+	undoKindAlias  = "alias"
+	undoKindOption = "option"
+)
+
+// undoOp is one reversible change in the undo journal, with what is needed to
+// put things back: exactly one of Trash, Layout, Aliases, or Option is set,
+// matching Kind.
+type undoOp struct {
+	Time    time.Time `json:"time" yaml:"time"`
+	Actor   string    `json:"actor" yaml:"actor"`
+	Command string    `json:"command" yaml:"command"`
+	Kind    string    `json:"kind" yaml:"kind"`
+	Summary string    `json:"summary" yaml:"summary"`
+
+	Trash   *tmux.TrashedSession `json:"trash,omitempty" yaml:"trash,omitempty"`
+	Layout  *undoLayout          `json:"layout,omitempty" yaml:"layout,omitempty"`
+	Aliases *undoAliases         `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Option  *undoOption          `json:"option,omitempty" yaml:"option,omitempty"`
+}
+
+// undoLayout is a window's layout before a select-layout.
+type undoLayout struct {
+	Target   string `json:"target" yaml:"target"`
+	Previous string `json:"previous" yaml:"previous"`
+}
+
+// undoAliases is an alias scope's contents before it was saved.
+type undoAliases struct {
+	Scope    string            `json:"scope" yaml:"scope"`
+	Session  string            `json:"session,omitempty" yaml:"session,omitempty"`
+	Path     string            `json:"path,omitempty" yaml:"path,omitempty"`
+	Previous map[string]string `json:"previous" yaml:"previous"`
+}
+
+// undoOption is a session option's value before it was set or unset; Set is
+// false when it was unset.
+type undoOption struct {
+	Session  string `json:"session" yaml:"session"`
+	Name     string `json:"name" yaml:"name"`
+	Set      bool   `json:"set" yaml:"set"`
+	Previous string `json:"previous,omitempty" yaml:"previous,omitempty"`
+}
+
+var (
+	undoMu sync.Mutex
+	// undoJournal is the journal file this invocation records to, set by
+	// applyUndoJournal; empty means nothing is recorded.
+	undoJournal string
+	undoCommand string
+)
+
+// defaultUndoFile is the undo journal: ARC_TMUX_UNDO_FILE or undo.json under
+// the user cache directory. "off" disables it, returning "".
+func defaultUndoFile() string {
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_UNDO_FILE")); env != "" {
+		if env == "off" {
+			return ""
+		}
+		return env
+	}
+	if dir, err := os.UserCacheDir(); err == nil && strings.TrimSpace(dir) != "" {
+		return filepath.Join(dir, "arc-tmux", "undo.json")
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.TrimSpace(home) != "" {
+		return filepath.Join(home, ".arc-tmux-undo.json")
+	}
+	return "undo.json"
+}
+
+// applyUndoJournal makes this invocation record its reversible changes.
+// undo itself records nothing, so reverting is not journaled again, and
+// neither do hidden commands such as _event, which run from tmux hooks rather
+// than for the user.
+func applyUndoJournal(cmd *cobra.Command) {
+	undoJournal, undoCommand = "", ""
+	if cmd.Name() == "__complete" || cmd.Name() == "undo" || cmd.Hidden {
+		return
+	}
+	undoJournal = defaultUndoFile()
+	undoCommand = cmd.CommandPath()
+}
+
+// recordUndo appends op to the undo journal. Failures are reported on stderr
+// but never fail the command that made the change.
+func recordUndo(op undoOp) {
+	if undoJournal == "" {
+		return
+	}
+	op.Time = time.Now().UTC()
+	op.Actor = currentActor
+	op.Command = undoCommand
+	err := updateUndoJournal(undoJournal, func(ops []undoOp) ([]undoOp, error) {
+		ops = append(ops, op)
+		if len(ops) > undoJournalLimit {
+			ops = ops[len(ops)-undoJournalLimit:]
+		}
+		return ops, nil
+	})
+	if err != nil {
+		_, _ = io.WriteString(os.Stderr, "warning: undo journal: "+err.Error()+"\n")
+	}
+}
+
+// loadUndoJournal reads the journal, oldest operation first; a missing file
+// is an empty journal.
+func loadUndoJournal(path string) ([]undoOp, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ops []undoOp
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("undo journal %s: %w", path, err)
+	}
+	return ops, nil
+}
+
+// lockUndoJournal takes the journal's lock file, so that concurrent arc-tmux
+// processes (say, several agents) neither lose entries nor undo the same one
+// twice. Hold it from loading the journal to saving it.
+func lockUndoJournal(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	return lockFile(path + ".lock")
+}
+
+// updateUndoJournal rewrites the journal with what update returns, under the
+// journal's lock.
+func updateUndoJournal(path string, update func(ops []undoOp) ([]undoOp, error)) error {
+	undoMu.Lock()
+	defer undoMu.Unlock()
+	unlock, err := lockUndoJournal(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	ops, err := loadUndoJournal(path)
+	if err != nil {
+		return err
+	}
+	ops, err = update(ops)
+	if err != nil {
+		return err
+	}
+	return saveUndoJournal(path, ops)
+}
+
+// saveUndoJournal replaces the journal through a temporary file of its own,
+// readable only by the user. Callers hold the lock (see lockUndoJournal).
+func saveUndoJournal(path string, ops []undoOp) error {
+	if ops == nil {
+		ops = []undoOp{}
+	}
+	data, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// selectLayoutUndoable applies a layout to the window containing target and
+// journals the layout it replaced.
+func selectLayoutUndoable(target string, layout string) error {
+	previous, prevErr := tmux.WindowLayout(target)
+	if err := tmux.SelectLayout(target, layout); err != nil {
+		return err
+	}
+	if prevErr != nil || previous == "" {
+		return nil
+	}
+	if current, err := tmux.WindowLayout(target); err == nil && current != previous {
+		recordUndo(undoOp{
+			Kind:    undoKindLayout,
+			Summary: fmt.Sprintf("changed the layout of %s to %s", strings.TrimPrefix(target, "="), layout),
+			Layout:  &undoLayout{Target: target, Previous: previous},
+		})
+	}
+	return nil
+}
+
+// setSessionOptionUndoable sets a session option, or unsets it when value is
+// empty, and journals the value it replaced.
+func setSessionOptionUndoable(session string, name string, value string) error {
+	previous, had, prevErr := tmux.SessionOption(session, name)
+	var err error
+	if value == "" {
+		err = tmux.UnsetSessionOption(session, name)
+	} else {
+		err = tmux.SetSessionOption(session, name, value)
+	}
+	if err != nil || prevErr != nil || previous == value {
+		return err
+	}
+	summary := fmt.Sprintf("set %s on session %s", name, session)
+	if value == "" {
+		summary = fmt.Sprintf("unset %s on session %s", name, session)
+	}
+	recordUndo(undoOp{
+		Kind:    undoKindOption,
+		Summary: summary,
+		Option:  &undoOption{Session: session, Name: name, Set: had, Previous: previous},
+	})
+	return nil
+}
+
+// recordAliasUndo journals an alias scope's contents before a save.
+func recordAliasUndo(s aliasStore, previous map[string]string, next map[string]string) {
+	if maps.Equal(previous, next) {
+		return
+	}
+	summary := fmt.Sprintf("changed %s aliases", s.Scope)
+	if s.Scope == aliasScopeSession {
+		summary = fmt.Sprintf("changed session aliases in %s", s.Session)
+	}
+	if previous == nil {
+		previous = map[string]string{}
+	}
+	recordUndo(undoOp{
+		Kind:    undoKindAlias,
+		Summary: summary,
+		Aliases: &undoAliases{Scope: s.Scope, Session: s.Session, Path: s.Path, Previous: maps.Clone(previous)},
+	})
+}
+
+// revertUndoOp puts back what op changed.
+func revertUndoOp(op undoOp) error {
+	switch {
+	case op.Trash != nil:
+		return tmux.RestoreSession(*op.Trash)
+	case op.Layout != nil:
+		if err := tmux.SelectLayout(op.Layout.Target, op.Layout.Previous); err != nil {
+			return fmt.Errorf("panes of %s no longer fit the previous layout: %w", strings.TrimPrefix(op.Layout.Target, "="), err)
+		}
+		return nil
+	case op.Aliases != nil:
+		store := aliasStore{Scope: op.Aliases.Scope, Session: op.Aliases.Session, Path: op.Aliases.Path}
+		return store.write(op.Aliases.Previous)
+	case op.Option != nil:
+		if !op.Option.Set {
+			return tmux.UnsetSessionOption(op.Option.Session, op.Option.Name)
+		}
+		return tmux.SetSessionOption(op.Option.Session, op.Option.Name, op.Option.Previous)
+	}
+	return fmt.Errorf("unknown undo operation %q", op.Kind)
+}
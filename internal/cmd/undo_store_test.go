@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRecordUndoKeepsLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "undo.json")
+	prev := undoJournal
+	undoJournal = path
+	t.Cleanup(func() { undoJournal = prev })
+
+	for i := 0; i < undoJournalLimit+5; i++ {
+		recordUndo(undoOp{Kind: undoKindLayout, Summary: fmt.Sprintf("op %d", i)})
+	}
+	ops, err := loadUndoJournal(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(ops) != undoJournalLimit {
+		t.Fatalf("journal has %d ops, want %d", len(ops), undoJournalLimit)
+	}
+	if ops[0].Summary != "op 5" || ops[len(ops)-1].Summary != fmt.Sprintf("op %d", undoJournalLimit+4) {
+		t.Fatalf("journal kept %q..%q", ops[0].Summary, ops[len(ops)-1].Summary)
+	}
+	if ops[0].Time.IsZero() {
+		t.Fatalf("expected a timestamp")
+	}
+}
+
+func TestLoadUndoJournalMissing(t *testing.T) {
+	ops, err := loadUndoJournal(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil || len(ops) != 0 {
+		t.Fatalf("missing journal = %v, %v", ops, err)
+	}
+}
+
+func TestAliasUndoRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "undo.json")
+	prev := undoJournal
+	undoJournal = path
+	t.Cleanup(func() { undoJournal = prev })
+
+	store := aliasStore{Scope: aliasScopeProject, Path: filepath.Join(dir, "aliases.json")}
+	if err := store.save(map[string]string{"api": "dev:1.0"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := store.save(map[string]string{"api": "dev:1.0", "web": "dev:2.0"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	// Saving the same contents again records nothing.
+	if err := store.save(map[string]string{"api": "dev:1.0", "web": "dev:2.0"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	ops, err := loadUndoJournal(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(ops) != 2 || ops[1].Kind != undoKindAlias {
+		t.Fatalf("journal = %+v", ops)
+	}
+	if err := revertUndoOp(ops[1]); err != nil {
+		t.Fatalf("revert: %v", err)
+	}
+	aliases, err := store.load()
+	if err != nil {
+		t.Fatalf("load aliases: %v", err)
+	}
+	if len(aliases) != 1 || aliases["api"] != "dev:1.0" {
+		t.Fatalf("aliases after undo = %v", aliases)
+	}
+	if err := revertUndoOp(ops[0]); err != nil {
+		t.Fatalf("revert: %v", err)
+	}
+	if aliases, _ = store.load(); len(aliases) != 0 {
+		t.Fatalf("aliases after second undo = %v", aliases)
+	}
+}
+
+func TestRecordUndoConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "undo.json")
+	prev := undoJournal
+	undoJournal = path
+	t.Cleanup(func() { undoJournal = prev })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recordUndo(undoOp{Kind: undoKindLayout, Summary: fmt.Sprintf("op %d", i)})
+		}(i)
+	}
+	wg.Wait()
+	ops, err := loadUndoJournal(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(ops) != 20 {
+		t.Fatalf("journal has %d ops, want 20", len(ops))
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Fatalf("journal mode = %o, want 600", mode)
+	}
+	matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+	if len(matches) != 0 {
+		t.Fatalf("temporary files left behind: %v", matches)
+	}
+}
+
+func TestApplyUndoJournalSkipsHidden(t *testing.T) {
+	t.Setenv("ARC_TMUX_UNDO_FILE", filepath.Join(t.TempDir(), "undo.json"))
+	prev := undoJournal
+	t.Cleanup(func() { undoJournal = prev })
+
+	applyUndoJournal(&cobra.Command{Use: "preset"})
+	if undoJournal == "" {
+		t.Fatalf("expected preset to be journaled")
+	}
+	applyUndoJournal(&cobra.Command{Use: "_event", Hidden: true})
+	if undoJournal != "" {
+		t.Fatalf("hidden command journaled to %q", undoJournal)
+	}
+}
+
+func TestPruneAliasesNotJournaled(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "undo.json")
+	prev := undoJournal
+	undoJournal = path
+	t.Cleanup(func() { undoJournal = prev })
+
+	store := aliasStore{Scope: aliasScopeProject, Path: filepath.Join(dir, "aliases.json")}
+	if err := store.write(map[string]string{"api": "dev:1.0", "web": "dev:2.0"}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	removed, err := pruneAliases([]aliasStore{store}, func(target string) (bool, error) {
+		return target == "dev:2.0", nil
+	}, false)
+	if err != nil || len(removed) != 1 {
+		t.Fatalf("prune = %v, %v", removed, err)
+	}
+	ops, err := loadUndoJournal(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(ops) != 0 {
+		t.Fatalf("pruning was journaled: %+v", ops)
+	}
+}
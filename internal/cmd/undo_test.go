@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func TestPickTrashedSession(t *testing.T) {
+	trash := []tmux.TrashedSession{
+		{Name: "trash-api-200", Original: "api"},
+		{Name: "trash-web-150", Original: "web"},
+		{Name: "trash-api-100", Original: "api"},
+	}
+	if got, ok := pickTrashedSession(trash, ""); !ok || got.Name != "trash-api-200" {
+		t.Fatalf("default pick = %+v", got)
+	}
+	if got, ok := pickTrashedSession(trash, "web"); !ok || got.Name != "trash-web-150" {
+		t.Fatalf("by original = %+v", got)
+	}
+	if got, ok := pickTrashedSession(trash, "trash-api-100"); !ok || got.Original != "api" {
+		t.Fatalf("by trash name = %+v", got)
+	}
+	if _, ok := pickTrashedSession(trash, "db"); ok {
+		t.Fatalf("expected no match")
+	}
+}
+
+func TestResolveCleanupTrash(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("trash", false, "")
+		cmd.Flags().String("trash-ttl", "24h", "")
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+	cfg := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("ARC_TMUX_CONFIG", cfg)
+
+	if on, _, err := resolveCleanupTrash(newCmd(), false, ""); err != nil || on {
+		t.Fatalf("default should kill: %v %v", on, err)
+	}
+	if on, ttl, err := resolveCleanupTrash(newCmd("--trash"), true, "24h"); err != nil || !on || ttl != defaultTrashTTL {
+		t.Fatalf("--trash = %v %v %v", on, ttl, err)
+	}
+	if err := os.WriteFile(cfg, []byte("cleanup_trash_ttl: 2h\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if on, ttl, err := resolveCleanupTrash(newCmd(), false, "24h"); err != nil || !on || ttl != 2*time.Hour {
+		t.Fatalf("config default = %v %v %v", on, ttl, err)
+	}
+	if on, _, err := resolveCleanupTrash(newCmd("--trash=false"), false, "24h"); err != nil || on {
+		t.Fatalf("--trash=false = %v %v", on, err)
+	}
+	if on, ttl, err := resolveCleanupTrash(newCmd("--trash-ttl", "7d"), false, "7d"); err != nil || !on || ttl != 7*24*time.Hour {
+		t.Fatalf("--trash-ttl = %v %v %v", on, ttl, err)
+	}
+	if _, _, err := resolveCleanupTrash(newCmd("--trash-ttl", "soon"), false, "soon"); err == nil {
+		t.Fatalf("expected invalid TTL error")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// version is overridden at build time via -ldflags "-X .../internal/cmd.version=...".
+var version = "dev"
+
+type versionReport struct {
+	Version       string        `json:"version" yaml:"version"`
+	GoVersion     string        `json:"go_version" yaml:"go_version"`
+	Platform      string        `json:"platform" yaml:"platform"`
+	TmuxVersion   string        `json:"tmux_version,omitempty" yaml:"tmux_version,omitempty"`
+	ServerVersion string        `json:"server_version,omitempty" yaml:"server_version,omitempty"`
+	ServerRunning bool          `json:"server_running" yaml:"server_running"`
+	Features      tmux.Features `json:"features" yaml:"features"`
+	Errors        []string      `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+func newVersionCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show arc-tmux, tmux, and server versions",
+		Long: `Print the arc-tmux version together with the tmux binary version, the running
+server version, and which optional tmux features are available.
+
+Features are derived from the server version when a server is running,
+otherwise from the tmux binary.`,
+		Example: `  arc-tmux version
+  arc-tmux version --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+
+			report := buildVersionReport()
+			out := cmd.OutOrStdout()
+			switch {
+			case outputOpts.Is(output.OutputJSON):
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			case outputOpts.Is(output.OutputYAML):
+				enc := yaml.NewEncoder(out)
+				defer func() { _ = enc.Close() }()
+				return enc.Encode(report)
+			case outputOpts.Is(output.OutputQuiet):
+				_, _ = fmt.Fprintln(out, report.Version)
+				return nil
+			}
+
+			_, _ = fmt.Fprintf(out, "arc-tmux %s (%s, %s)\n", report.Version, report.GoVersion, report.Platform)
+			_, _ = fmt.Fprintf(out, "  tmux:   %s\n", valueOrDash(report.TmuxVersion))
+			server := valueOrDash(report.ServerVersion)
+			if !report.ServerRunning {
+				server = "not running"
+			}
+			_, _ = fmt.Fprintf(out, "  server: %s\n", server)
+			_, _ = fmt.Fprintf(out, "  features: control_mode=%t popups=%t extended_formats=%t\n",
+				report.Features.ControlMode,
+				report.Features.Popups,
+				report.Features.ExtendedFormats,
+			)
+			for _, msg := range report.Errors {
+				_, _ = fmt.Fprintf(out, "  warning: %s\n", msg)
+			}
+			return nil
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	return cmd
+}
+
+func buildVersionReport() versionReport {
+	report := versionReport{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	client, err := tmux.ClientVersion()
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	} else {
+		report.TmuxVersion = client.String()
+	}
+
+	featureSource := client
+	server, err := tmux.ServerVersion()
+	switch {
+	case err == nil:
+		report.ServerRunning = true
+		report.ServerVersion = server.String()
+		featureSource = server
+	case !errors.Is(err, tmux.ErrNoTmuxServer):
+		report.Errors = append(report.Errors, err.Error())
+	}
+	report.Features = tmux.FeaturesFor(featureSource)
+	return report
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
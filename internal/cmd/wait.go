@@ -5,7 +5,11 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -16,24 +20,66 @@ import (
 
 func newWaitCmd() *cobra.Command {
 	var paneArg string
+	var windowArg string
+	var session string
+	var allPanes bool
+	var screenBoundary bool
 	var idle, timeout float64
+	var progressOpts progressOptions
 	var outputOpts output.OutputOptions
 
 	cmd := &cobra.Command{
 		Use:   "wait",
 		Short: "Wait until pane becomes idle",
-		Long:  "Poll a pane until it stops printing output.",
+		Long: `Poll a pane until it stops printing output. With --pane -, panes read from
+stdin are waited on in turn, each with the full --timeout.
+
+--all-panes waits for every pane in --session (default: current or
+ARC_TMUX_SESSION) at once, until all of them are idle or --timeout passes. A
+pane that went idle early but printed again while the others were busy is
+waited on again (counted as rewaits), so the session is idle as a whole when
+wait returns. Each pane's result and elapsed time are reported.
+
+A full-screen program (vim, less, htop) keeps the pane in the alternate
+screen: it may go quiet while still running, or redraw forever and never look
+idle. Each result reports alternate_screen, and a timeout names the program.
+--screen-boundary stops waiting as soon as the pane enters or leaves the
+alternate screen, reported as screen_switched.`,
 		Example: `  # Wait up to 2 minutes for a compile step
-  arc-tmux wait --pane=fe:2.0 --idle=2 --timeout=120`,
+  arc-tmux wait --pane=fe:2.0 --idle=2 --timeout=120
+
+  # Wait for several panes in turn
+  arc-tmux panes --session dev -o quiet | arc-tmux wait --pane -
+
+  # Wait at most 5 minutes in total for every pane in a session
+  arc-tmux wait --session dev --all-panes --timeout 300 -o json
+
+  # Return once the pane opens (or quits) a pager or editor
+  arc-tmux wait --pane=fe:2.0 --screen-boundary --timeout=30`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := outputOpts.Resolve(); err != nil {
 				return err
 			}
-			target, err := resolvePaneTarget(paneArg)
+			var handles []tmux.PaneHandle
+			var bulk bool
+			var err error
+			switch {
+			case allPanes:
+				if strings.TrimSpace(paneArg) != "" || strings.TrimSpace(windowArg) != "" {
+					return fmt.Errorf("--all-panes cannot be combined with --pane or --window")
+				}
+				handles, err = sessionPaneTargets(session)
+				bulk = true
+			case strings.TrimSpace(session) != "":
+				return fmt.Errorf("--session requires --all-panes")
+			default:
+				handles, bulk, err = resolvePaneOrWindowTargets(cmd, paneArg, windowArg)
+			}
 			if err != nil {
 				return err
 			}
-			if err := validatePaneTarget(target); err != nil {
+			progress, err := progressOpts.open(cmd)
+			if err != nil {
 				return err
 			}
 
@@ -41,23 +87,88 @@ func newWaitCmd() *cobra.Command {
 				timeout = 60
 			}
 
-			waitErr := tmux.WaitIdle(target, time.Duration(idle*float64(time.Second)), time.Duration(timeout*float64(time.Second)))
-			result := waitResult{PaneID: target}
-			if waitErr != nil {
-				result.WaitError = waitErr.Error()
-				if isTimeout(waitErr) {
-					result.TimedOut = true
+			// waitPane waits for one pane for up to budget and reports how
+			// the wait ended.
+			waitPane := func(h tmux.PaneHandle, budget time.Duration) waitResult {
+				paneProgress := progress.with("", h.Target).withOutputProgress(h.ID, nil)
+				paneProgress.emit(progressEvent{Event: "wait_started"})
+				result := waitResult{PaneID: h.Target}
+				var err error
+				if screenBoundary {
+					var altScreen bool
+					if altScreen, err = tmux.AlternateScreen(h.ID); err == nil {
+						err = tmux.WaitIdleOrScreenSwitch(h.ID, time.Duration(idle*float64(time.Second)), budget, altScreen, paneProgress.idleFunc())
+					}
+				} else {
+					err = tmux.WaitIdleFunc(h.ID, time.Duration(idle*float64(time.Second)), budget, paneProgress.idleFunc())
 				}
+				if errors.Is(err, tmux.ErrScreenSwitched) {
+					result.ScreenSwitched = true
+					err = nil
+				}
+				var screenCommand string
+				result.AlternateScreen, screenCommand = paneScreenState(h.ID)
+				err = explainScreenWait(err, result.AlternateScreen, screenCommand)
+				if err != nil {
+					result.WaitError = err.Error()
+					result.TimedOut = isTimeout(err)
+					result.err = err
+				} else if !result.ScreenSwitched {
+					result.Idle = true
+				}
+				status := "idle"
+				switch {
+				case result.ScreenSwitched:
+					status = "screen_switched"
+				case result.TimedOut:
+					status = "timeout"
+				case !result.Idle:
+					status = "error"
+				}
+				paneProgress.emit(progressEvent{Event: "wait_finished", Status: status, Error: result.WaitError})
+				return result
+			}
+
+			total := time.Duration(timeout * float64(time.Second))
+			var results []waitResult
+			if allPanes {
+				// What each pane showed when it went idle, to tell whether it
+				// printed again while the others were still busy.
+				screens := make([]string, len(handles))
+				results = waitAllPanes(len(handles), time.Now().Add(total), func(i int, budget time.Duration) waitResult {
+					result := waitPane(handles[i], budget)
+					if result.Idle {
+						screens[i], _ = tmux.Capture(handles[i].ID, 200)
+					}
+					return result
+				}, func(i int) bool {
+					current, err := tmux.Capture(handles[i].ID, 200)
+					return err == nil && current != screens[i]
+				})
 			} else {
-				result.Idle = true
+				results = make([]waitResult, 0, len(handles))
+				for _, h := range handles {
+					results = append(results, waitPane(h, total))
+				}
+			}
+			var waitErr error
+			for _, result := range results {
+				if result.err != nil {
+					waitErr = result.err
+					break
+				}
 			}
 
+			var doc any = results[0]
+			if bulk {
+				doc = results
+			}
 			out := cmd.OutOrStdout()
 			switch {
 			case outputOpts.Is(output.OutputJSON):
 				enc := json.NewEncoder(out)
 				enc.SetIndent("", "  ")
-				if err := enc.Encode(result); err != nil {
+				if err := enc.Encode(doc); err != nil {
 					return err
 				}
 				if waitErr != nil && isTimeout(waitErr) {
@@ -67,7 +178,7 @@ func newWaitCmd() *cobra.Command {
 			case outputOpts.Is(output.OutputYAML):
 				enc := yaml.NewEncoder(out)
 				defer func() { _ = enc.Close() }()
-				if err := enc.Encode(result); err != nil {
+				if err := enc.Encode(doc); err != nil {
 					return err
 				}
 				if waitErr != nil && isTimeout(waitErr) {
@@ -75,30 +186,52 @@ func newWaitCmd() *cobra.Command {
 				}
 				return waitErr
 			case outputOpts.Is(output.OutputQuiet):
-				if result.Idle {
-					_, _ = fmt.Fprintln(out, "idle")
-					return waitErr
-				}
-				if result.TimedOut {
-					_, _ = fmt.Fprintln(out, "timeout")
-					return waitErr
+				for _, result := range results {
+					status := ""
+					if result.ScreenSwitched {
+						status = "screen_switched"
+					} else if result.Idle {
+						status = "idle"
+					} else if result.TimedOut {
+						status = "timeout"
+					}
+					if status == "" {
+						continue
+					}
+					if bulk {
+						_, _ = fmt.Fprintf(out, "%s %s\n", result.PaneID, status)
+					} else {
+						_, _ = fmt.Fprintln(out, status)
+					}
 				}
 				return waitErr
 			}
-			if result.Idle {
-				_, _ = fmt.Fprintf(out, "Pane %s is idle.\n", target)
-			} else if result.TimedOut {
-				_, _ = fmt.Fprintf(out, "Pane %s did not become idle in time.\n", target)
+			for _, result := range results {
+				if result.ScreenSwitched {
+					if result.AlternateScreen {
+						_, _ = fmt.Fprintf(out, "Pane %s switched to the alternate screen.\n", result.PaneID)
+					} else {
+						_, _ = fmt.Fprintf(out, "Pane %s left the alternate screen.\n", result.PaneID)
+					}
+				} else if result.Idle {
+					_, _ = fmt.Fprintf(out, "Pane %s is idle.\n", result.PaneID)
+				} else if result.TimedOut {
+					_, _ = fmt.Fprintf(out, "Pane %s did not become idle in time.\n", result.PaneID)
+				}
 			}
 			return waitErr
 		},
 	}
 
 	outputOpts.AddOutputFlags(cmd, output.OutputTable)
-	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active)")
+	cmd.Flags().StringVar(&paneArg, "pane", "", "Target tmux pane (e.g., fe:4.1, @current, @active, - for stdin)")
+	cmd.Flags().StringVar(&windowArg, "window", "", "Target the active pane of this window (SESSION:WINDOW, index or name)")
 	cmd.Flags().Float64Var(&idle, "idle", 2.0, "Seconds of inactivity to consider idle")
-	cmd.Flags().Float64Var(&timeout, "timeout", 60.0, "Maximum seconds to wait")
-	_ = cmd.MarkFlagRequired("pane")
+	cmd.Flags().Float64Var(&timeout, "timeout", 60.0, "Maximum seconds to wait (with --all-panes, for all panes together)")
+	cmd.Flags().StringVar(&session, "session", "", "Session whose panes --all-panes waits for (name, @current, or @managed)")
+	cmd.Flags().BoolVar(&allPanes, "all-panes", false, "Wait for every pane in the session at once, until all are idle")
+	cmd.Flags().BoolVar(&screenBoundary, "screen-boundary", false, "Stop waiting when the pane enters or leaves the alternate screen (vim, less, htop)")
+	progressOpts.addFlags(cmd)
 
 	return cmd
 }
@@ -108,4 +241,89 @@ type waitResult struct {
 	Idle      bool   `json:"idle" yaml:"idle"`
 	TimedOut  bool   `json:"timed_out" yaml:"timed_out"`
 	WaitError string `json:"wait_error,omitempty" yaml:"wait_error,omitempty"`
+	// AlternateScreen is set when the pane was showing a full-screen program
+	// as the wait ended; ScreenSwitched when --screen-boundary ended it.
+	AlternateScreen bool `json:"alternate_screen" yaml:"alternate_screen"`
+	ScreenSwitched  bool `json:"screen_switched,omitempty" yaml:"screen_switched,omitempty"`
+	// ElapsedSeconds and Rewaits are set with --all-panes: how long until the
+	// pane's wait last ended, and how often it printed again after going idle.
+	ElapsedSeconds float64 `json:"elapsed_seconds,omitempty" yaml:"elapsed_seconds,omitempty"`
+	Rewaits        int     `json:"rewaits,omitempty" yaml:"rewaits,omitempty"`
+
+	err error
+}
+
+// waitAllPanes runs wait for panes 0..n-1 at once, each with the time left
+// until deadline. Once all have finished, every idle pane that printed since
+// is waited on again, until a round leaves them all unchanged or the deadline
+// passes.
+func waitAllPanes(n int, deadline time.Time, wait func(i int, budget time.Duration) waitResult, printed func(i int) bool) []waitResult {
+	start := time.Now()
+	results := make([]waitResult, n)
+	pending := make([]int, n)
+	for i := range pending {
+		pending[i] = i
+	}
+	for len(pending) > 0 {
+		var wg sync.WaitGroup
+		for _, i := range pending {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				result := wait(i, time.Until(deadline))
+				result.ElapsedSeconds = time.Since(start).Seconds()
+				result.Rewaits = results[i].Rewaits
+				results[i] = result
+			}(i)
+		}
+		wg.Wait()
+		pending = pending[:0]
+		if !time.Now().Before(deadline) {
+			break
+		}
+		for i := range results {
+			if results[i].Idle && printed(i) {
+				results[i].Rewaits++
+				pending = append(pending, i)
+			}
+		}
+	}
+	return results
+}
+
+// sessionPaneTargets lists handles for every pane in session (default:
+// current or ARC_TMUX_SESSION), in window and pane order.
+func sessionPaneTargets(session string) ([]tmux.PaneHandle, error) {
+	resolved, err := resolveSessionTarget(session)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := defaultPaneSessionArg(resolved)
+	if err != nil {
+		return nil, err
+	}
+	panes, err := tmux.ListPanesDetailed()
+	if err != nil {
+		return nil, err
+	}
+	var matched []tmux.PaneDetails
+	for _, p := range panes {
+		if p.Session == sess {
+			matched = append(matched, p)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, newCodedError(errInvalidSession, fmt.Sprintf("session %q has no panes", sess), nil)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].WindowIndex != matched[j].WindowIndex {
+			return matched[i].WindowIndex < matched[j].WindowIndex
+		}
+		return matched[i].PaneIndex < matched[j].PaneIndex
+	})
+	handles := make([]tmux.PaneHandle, 0, len(matched))
+	for i := range matched {
+		handles = append(handles, tmux.PaneHandle{ID: matched[i].PaneID, Target: formattedPaneID(&matched[i])})
+	}
+	return handles, nil
 }
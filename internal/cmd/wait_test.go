@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWaitAllPanesRewaitsPanesThatPrintAgain(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[int]int{}
+	wait := func(i int, budget time.Duration) waitResult {
+		mu.Lock()
+		calls[i]++
+		mu.Unlock()
+		if budget <= 0 {
+			t.Errorf("pane %d got no budget", i)
+		}
+		if i == 2 {
+			return waitResult{PaneID: "dev:1.2", TimedOut: true, WaitError: "timeout waiting for idle"}
+		}
+		return waitResult{PaneID: "dev:1.0", Idle: true}
+	}
+	// Pane 1 prints again once after its first wait; pane 0 stays quiet.
+	printedOnce := false
+	printed := func(i int) bool {
+		if i == 1 && !printedOnce {
+			printedOnce = true
+			return true
+		}
+		return false
+	}
+	results := waitAllPanes(3, time.Now().Add(time.Minute), wait, printed)
+	if len(results) != 3 {
+		t.Fatalf("got %d results", len(results))
+	}
+	if calls[0] != 1 || calls[1] != 2 || calls[2] != 1 {
+		t.Fatalf("wait calls = %v, want pane 1 waited twice", calls)
+	}
+	if results[1].Rewaits != 1 || results[0].Rewaits != 0 || !results[1].Idle {
+		t.Fatalf("results = %+v", results)
+	}
+	if !results[2].TimedOut || results[2].Rewaits != 0 {
+		t.Fatalf("timed-out pane = %+v", results[2])
+	}
+}
+
+func TestWaitAllPanesRunsConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+	wait := func(i int, budget time.Duration) waitResult {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return waitResult{Idle: true}
+	}
+	waitAllPanes(4, time.Now().Add(time.Minute), wait, func(int) bool { return false })
+	if peak < 2 {
+		t.Fatalf("panes were waited on one at a time (peak %d)", peak)
+	}
+}
+
+func TestWaitAllPanesStopsAtDeadline(t *testing.T) {
+	calls := 0
+	wait := func(i int, budget time.Duration) waitResult {
+		calls++
+		return waitResult{Idle: true}
+	}
+	// A pane that keeps printing is not waited on again once time is up.
+	waitAllPanes(1, time.Now().Add(-time.Second), wait, func(int) bool { return true })
+	if calls != 1 {
+		t.Fatalf("wait called %d times past the deadline", calls)
+	}
+}
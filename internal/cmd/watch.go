@@ -0,0 +1,469 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// watchRule is a watch_rules config entry: when a new line of output in Pane
+// matches Match, or a file under Paths changes, Keys and then Send go to
+// Target (default: Pane) and Script runs locally. A rule fires at most once
+// per Cooldown seconds.
+type watchRule struct {
+	Name  string `json:"name" yaml:"name"`
+	Pane  string `json:"pane,omitempty" yaml:"pane,omitempty"`
+	Match string `json:"match,omitempty" yaml:"match,omitempty"`
+	// Paths are files or directories, relative to Pane's (else Target's)
+	// directory, whose changes fire the rule.
+	Paths    []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Target   string   `json:"target,omitempty" yaml:"target,omitempty"`
+	Keys     []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Send     string   `json:"send,omitempty" yaml:"send,omitempty"`
+	Script   string   `json:"script,omitempty" yaml:"script,omitempty"`
+	Cooldown float64  `json:"cooldown,omitempty" yaml:"cooldown,omitempty"`
+}
+
+// defaultWatchCooldown applies to rules that do not set cooldown.
+const defaultWatchCooldown = 30
+
+// watchEvent is one NDJSON line emitted when a rule matches.
+type watchEvent struct {
+	Time string `json:"time" yaml:"time"`
+	Rule string `json:"rule" yaml:"rule"`
+	Pane string `json:"pane" yaml:"pane"`
+	Line string `json:"line" yaml:"line"`
+	// Action is "fired", "dry_run", or "cooldown" when rate limiting
+	// suppressed the rule.
+	Action string `json:"action" yaml:"action"`
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// activeWatchRule is a rule with its panes resolved and pattern compiled.
+type activeWatchRule struct {
+	watchRule
+	re        *regexp.Regexp
+	pane      tmux.PaneHandle
+	target    tmux.PaneHandle
+	keys      []string
+	paths     []string
+	files     map[string]fileStamp
+	lastFired time.Time
+}
+
+func newWatchCmd() *cobra.Command {
+	var outputOpts output.OutputOptions
+	var ruleNames []string
+	var adhoc watchRule
+	var dryRun bool
+	var interval float64
+	var duration float64
+	var lines int
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Act on pane output matching rules",
+		Long: `Poll panes and act when new output matches a rule: send keys or a command to
+a pane, or run a local script. Rules come from the "watch_rules" list in the
+config file (all of them, or those named with --rule), or from --pane and
+--match for a single ad-hoc rule:
+
+  watch_rules:
+    - name: restart-api
+      pane: "@api"
+      match: "panic:|EADDRINUSE"
+      keys: [C-c]
+      send: npm run dev
+      cooldown: 60
+    - name: page-me
+      pane: dev:2.0
+      match: "FATAL"
+      script: notify-send "arc-tmux" "$ARC_TMUX_LINE"
+
+A rule with paths (files or directories, relative to the pane's directory)
+also fires when a file under them changes; match is then optional.
+
+When a new line matches, keys and then send (typed, followed by Enter) go to
+target (default: the watched pane) and script runs with sh -c, with
+ARC_TMUX_RULE, ARC_TMUX_PANE, and ARC_TMUX_LINE set. A rule fires at most once
+per cooldown seconds (default 30); matches in between are reported as
+"cooldown". --dry-run reports matches without acting.
+
+Entries in the config's "links" list that have a trigger or paths run as
+rules too, sending the link's command to its target (default cooldown 5):
+
+  links:
+    - name: tests
+      pane: "@editor"
+      target: "@tests"
+      command: go test ./...
+      paths: [.]
+
+Each match is printed as it happens; with --output json as NDJSON events.`,
+		Example: `  arc-tmux watch
+  arc-tmux watch --rule restart-api --dry-run --output json
+  arc-tmux watch --pane @api --match 'panic:' --key C-c --send "npm run dev" --cooldown 60`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := outputOpts.Resolve(); err != nil {
+				return err
+			}
+			rules, err := selectWatchRules(cmd, adhoc, ruleNames)
+			if err != nil {
+				return err
+			}
+			active, err := activateWatchRules(rules)
+			if err != nil {
+				return err
+			}
+			if interval <= 0 {
+				interval = 1
+			}
+
+			out := cmd.OutOrStdout()
+			var yamlEnc *yaml.Encoder
+			if outputOpts.Is(output.OutputYAML) {
+				yamlEnc = yaml.NewEncoder(out)
+				defer func() { _ = yamlEnc.Close() }()
+			}
+			emit := func(event watchEvent) error {
+				switch {
+				case outputOpts.Is(output.OutputJSON):
+					return json.NewEncoder(out).Encode(event)
+				case outputOpts.Is(output.OutputYAML):
+					return yamlEnc.Encode(event)
+				case outputOpts.Is(output.OutputQuiet):
+					_, err := fmt.Fprintf(out, "%s\t%s\n", event.Rule, event.Action)
+					return err
+				}
+				line := fmt.Sprintf("%s [%s] %s: %s", event.Time, event.Rule, event.Action, event.Line)
+				if event.Error != "" {
+					line += " (error: " + event.Error + ")"
+				}
+				_, err := fmt.Fprintln(out, line)
+				return err
+			}
+
+			var deadline time.Time
+			if duration > 0 {
+				deadline = time.Now().Add(time.Duration(duration * float64(time.Second)))
+			}
+			prev := map[string][]string{}
+			ticker := time.NewTicker(time.Duration(interval * float64(time.Second)))
+			defer ticker.Stop()
+			for first := true; ; first = false {
+				fresh := map[string][]string{}
+				for _, rule := range active {
+					id := rule.pane.ID
+					if rule.re == nil {
+						continue
+					}
+					if _, ok := fresh[id]; ok {
+						continue
+					}
+					capture, err := tmux.CaptureJoined(id, lines)
+					if err != nil {
+						return err
+					}
+					curr := trimTrailingBlank(splitLines(capture))
+					// The first capture is the baseline; only later output counts.
+					if !first {
+						fresh[id] = completedLines(prev[id], curr)
+					} else {
+						fresh[id] = nil
+					}
+					prev[id] = curr
+				}
+				for _, rule := range active {
+					events := evaluateWatchRule(rule, fresh[rule.pane.ID], time.Now(), dryRun)
+					if len(rule.paths) > 0 {
+						files, err := snapshotFiles(rule.paths)
+						if err != nil {
+							return err
+						}
+						if changed := changedFiles(rule.files, files); !first && len(changed) > 0 {
+							events = append(events, triggerWatchRule(rule, describeChangedFiles(changed), time.Now(), dryRun))
+						}
+						rule.files = files
+					}
+					for _, event := range events {
+						if err := emit(event); err != nil {
+							return err
+						}
+					}
+				}
+				if !deadline.IsZero() && time.Now().After(deadline) {
+					return nil
+				}
+				<-ticker.C
+			}
+		},
+	}
+
+	outputOpts.AddOutputFlags(cmd, output.OutputTable)
+	cmd.Flags().StringArrayVar(&ruleNames, "rule", nil, "Only run this watch_rules entry (repeatable)")
+	cmd.Flags().StringVar(&adhoc.Pane, "pane", "", "Pane to watch for an ad-hoc rule")
+	cmd.Flags().StringVar(&adhoc.Match, "match", "", "Regular expression for an ad-hoc rule")
+	cmd.Flags().StringVar(&adhoc.Target, "target", "", "Pane to act on (default: the watched pane)")
+	cmd.Flags().StringArrayVar(&adhoc.Keys, "key", nil, "Special key to send on a match (repeatable)")
+	cmd.Flags().StringVar(&adhoc.Send, "send", "", "Command to type (with Enter) on a match")
+	cmd.Flags().StringVar(&adhoc.Script, "script", "", "Local shell command to run on a match")
+	cmd.Flags().Float64Var(&adhoc.Cooldown, "cooldown", defaultWatchCooldown, "Minimum seconds between firings of the ad-hoc rule")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report matches without acting")
+	cmd.Flags().Float64Var(&interval, "interval", 1.0, "Polling interval in seconds")
+	cmd.Flags().Float64Var(&duration, "duration", 0, "Stop after N seconds (0 to run until interrupted)")
+	cmd.Flags().IntVar(&lines, "lines", 200, "Lines captured per poll (0 for full)")
+	return cmd
+}
+
+// selectWatchRules returns the ad-hoc rule when --pane or --match is given,
+// otherwise the config's watch_rules, filtered by names.
+func selectWatchRules(cmd *cobra.Command, adhoc watchRule, names []string) ([]watchRule, error) {
+	if strings.TrimSpace(adhoc.Pane) != "" || strings.TrimSpace(adhoc.Match) != "" {
+		if len(names) > 0 {
+			return nil, errors.New("use either --rule or --pane/--match, not both")
+		}
+		adhoc.Name = "adhoc"
+		return []watchRule{adhoc}, nil
+	}
+	for _, flag := range []string{"target", "key", "send", "script", "cooldown"} {
+		if cmd.Flags().Changed(flag) {
+			return nil, fmt.Errorf("--%s requires --pane and --match", flag)
+		}
+	}
+	cfg, err := loadConfig(defaultConfigFile())
+	if err != nil {
+		return nil, err
+	}
+	all := append(append([]watchRule{}, cfg.WatchRules...), linkWatchRules(cfg.Links)...)
+	if len(all) == 0 {
+		return nil, errors.New("no watch_rules or triggered links in the config; pass --pane and --match for an ad-hoc rule")
+	}
+	if len(names) == 0 {
+		return all, nil
+	}
+	var rules []watchRule
+	for _, name := range names {
+		found := false
+		for _, rule := range all {
+			if rule.Name == strings.TrimSpace(name) {
+				rules = append(rules, rule)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no watch rule named %q", name)
+		}
+	}
+	return rules, nil
+}
+
+// activateWatchRules validates rules, compiles their patterns, and resolves
+// their panes.
+func activateWatchRules(rules []watchRule) ([]*activeWatchRule, error) {
+	active := make([]*activeWatchRule, 0, len(rules))
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.Name) == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		hasMatch := strings.TrimSpace(rule.Match) != ""
+		if !hasMatch && len(rule.Paths) == 0 {
+			return nil, fmt.Errorf("watch rule %q: set match or paths", rule.Name)
+		}
+		if hasMatch && strings.TrimSpace(rule.Pane) == "" {
+			return nil, fmt.Errorf("watch rule %q: match needs a pane", rule.Name)
+		}
+		if strings.TrimSpace(rule.Pane) == "" && strings.TrimSpace(rule.Target) == "" {
+			return nil, fmt.Errorf("watch rule %q: set pane or target", rule.Name)
+		}
+		if len(rule.Keys) == 0 && rule.Send == "" && strings.TrimSpace(rule.Script) == "" {
+			return nil, fmt.Errorf("watch rule %q: set keys, send, or script", rule.Name)
+		}
+		var re *regexp.Regexp
+		if hasMatch {
+			var err error
+			if re, err = regexp.Compile(rule.Match); err != nil {
+				return nil, fmt.Errorf("watch rule %q: invalid match %q: %w", rule.Name, rule.Match, err)
+			}
+		}
+		keys := make([]string, 0, len(rule.Keys))
+		for _, key := range rule.Keys {
+			name, err := normalizeKeyName(key)
+			if err != nil {
+				return nil, fmt.Errorf("watch rule %q: %w", rule.Name, err)
+			}
+			keys = append(keys, name)
+		}
+		var pane tmux.PaneHandle
+		if strings.TrimSpace(rule.Pane) != "" {
+			var err error
+			if pane, err = resolveWatchPane(rule.Pane); err != nil {
+				return nil, fmt.Errorf("watch rule %q: %w", rule.Name, err)
+			}
+		}
+		target := pane
+		if strings.TrimSpace(rule.Target) != "" {
+			var err error
+			if target, err = resolveWatchPane(rule.Target); err != nil {
+				return nil, fmt.Errorf("watch rule %q: %w", rule.Name, err)
+			}
+		}
+		var paths []string
+		if len(rule.Paths) > 0 {
+			base := pane
+			if base.ID == "" {
+				base = target
+			}
+			details, err := tmux.PaneDetailsForTarget(base.ID)
+			if err != nil {
+				return nil, fmt.Errorf("watch rule %q: %w", rule.Name, err)
+			}
+			if paths, err = resolveWatchPaths(rule.Paths, details.Path); err != nil {
+				return nil, fmt.Errorf("watch rule %q: paths: %w", rule.Name, err)
+			}
+		}
+		if rule.Cooldown <= 0 {
+			rule.Cooldown = defaultWatchCooldown
+		}
+		active = append(active, &activeWatchRule{watchRule: rule, re: re, pane: pane, target: target, keys: keys, paths: paths})
+	}
+	return active, nil
+}
+
+func resolveWatchPane(raw string) (tmux.PaneHandle, error) {
+	target, err := resolvePaneTarget(raw)
+	if err != nil {
+		return tmux.PaneHandle{}, err
+	}
+	return canonicalPaneTarget(target)
+}
+
+// evaluateWatchRule returns an event for each new line the rule matches,
+// firing it for the first match outside its cooldown.
+func evaluateWatchRule(rule *activeWatchRule, lines []string, now time.Time, dryRun bool) []watchEvent {
+	if rule.re == nil {
+		return nil
+	}
+	var events []watchEvent
+	for _, line := range lines {
+		if rule.re.MatchString(line) {
+			events = append(events, triggerWatchRule(rule, line, now, dryRun))
+		}
+	}
+	return events
+}
+
+// triggerWatchRule fires the rule for line unless it is cooling down.
+func triggerWatchRule(rule *activeWatchRule, line string, now time.Time, dryRun bool) watchEvent {
+	event := watchEvent{
+		Time:   now.UTC().Format(time.RFC3339),
+		Rule:   rule.Name,
+		Pane:   rule.pane.Target,
+		Line:   line,
+		Target: rule.target.Target,
+	}
+	cooldown := time.Duration(rule.Cooldown * float64(time.Second))
+	switch {
+	case !rule.lastFired.IsZero() && now.Sub(rule.lastFired) < cooldown:
+		event.Action = "cooldown"
+	case dryRun:
+		event.Action = "dry_run"
+		rule.lastFired = now
+	default:
+		event.Action = "fired"
+		rule.lastFired = now
+		if err := fireWatchRule(rule, line); err != nil {
+			event.Error = err.Error()
+		}
+	}
+	return event
+}
+
+// describeChangedFiles is the line reported when files under a rule's paths
+// change.
+func describeChangedFiles(files []string) string {
+	if len(files) == 1 {
+		return "changed: " + files[0]
+	}
+	return fmt.Sprintf("changed: %s and %d more", files[0], len(files)-1)
+}
+
+func fireWatchRule(rule *activeWatchRule, line string) error {
+	if len(rule.keys) > 0 {
+		if err := tmux.SendKeys(rule.target.ID, rule.keys); err != nil {
+			return err
+		}
+	}
+	if rule.Send != "" {
+		if err := tmux.SendLiteral(rule.target.ID, rule.Send, true, 0); err != nil {
+			return err
+		}
+	}
+	if script := strings.TrimSpace(rule.Script); script != "" {
+		c := exec.Command("sh", "-c", script)
+		c.Env = append(os.Environ(),
+			"ARC_TMUX_RULE="+rule.Name,
+			"ARC_TMUX_PANE="+rule.pane.Target,
+			"ARC_TMUX_LINE="+line,
+		)
+		var stderr bytes.Buffer
+		c.Stdout = io.Discard
+		c.Stderr = &stderr
+		if err := c.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("script: %w: %s", err, msg)
+			}
+			return fmt.Errorf("script: %w", err)
+		}
+	}
+	return nil
+}
+
+// completedLines returns the lines of curr finished since prev. The last line
+// of a capture may still be written to (a prompt being typed at, a partial
+// line), so it is left out of both the overlap check and the result; it is
+// reported once a later line follows it.
+func completedLines(prev []string, curr []string) []string {
+	if len(curr) == 0 {
+		return nil
+	}
+	done := curr[:len(curr)-1]
+	if len(prev) > 0 {
+		prev = prev[:len(prev)-1]
+	}
+	if len(prev) == 0 {
+		return done
+	}
+	maxOverlap := len(prev)
+	if len(done) < maxOverlap {
+		maxOverlap = len(done)
+	}
+	for k := maxOverlap; k > 0; k-- {
+		if equalSlice(prev[len(prev)-k:], done[:k]) {
+			return done[k:]
+		}
+	}
+	return done
+}
+
+func trimTrailingBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
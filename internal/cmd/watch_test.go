@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCompletedLinesSkipsPromptLine(t *testing.T) {
+	prev := []string{"$ make", "building", "$ "}
+	curr := []string{"$ make", "building", "$ printf 'panic: boom\\n'", "panic: boom", "$ "}
+	got := completedLines(prev, curr)
+	want := []string{"$ printf 'panic: boom\\n'", "panic: boom"}
+	if !equalSlice(got, want) {
+		t.Fatalf("completedLines = %q, want %q", got, want)
+	}
+	if again := completedLines(curr, curr); len(again) != 0 {
+		t.Fatalf("expected no new lines, got %q", again)
+	}
+}
+
+func TestCompletedLinesScrolled(t *testing.T) {
+	prev := []string{"a", "b", "c", "$ "}
+	curr := []string{"c", "d", "$ "}
+	got := completedLines(prev, curr)
+	if !equalSlice(got, []string{"d"}) {
+		t.Fatalf("completedLines = %q", got)
+	}
+}
+
+func TestEvaluateWatchRuleCooldown(t *testing.T) {
+	rule := &activeWatchRule{
+		watchRule: watchRule{Name: "panic", Cooldown: 10},
+		re:        regexp.MustCompile(`^panic:`),
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := evaluateWatchRule(rule, []string{"ok", "panic: one", "panic: two"}, now, true)
+	if len(events) != 2 || events[0].Action != "dry_run" || events[1].Action != "cooldown" {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	events = evaluateWatchRule(rule, []string{"panic: three"}, now.Add(11*time.Second), true)
+	if len(events) != 1 || events[0].Action != "dry_run" {
+		t.Fatalf("expected rule to fire after cooldown, got %+v", events)
+	}
+}
+
+func TestActivateWatchRulesRequiresAction(t *testing.T) {
+	_, err := activateWatchRules([]watchRule{{Name: "noop", Pane: "dev:1.0", Match: "x"}})
+	if err == nil {
+		t.Fatalf("expected error for rule without keys, send, or script")
+	}
+	_, err = activateWatchRules([]watchRule{{Name: "bad", Pane: "dev:1.0", Match: "(", Send: "x"}})
+	if err == nil {
+		t.Fatalf("expected error for invalid pattern")
+	}
+	_, err = activateWatchRules([]watchRule{{Name: "idle", Pane: "dev:1.0", Send: "x"}})
+	if err == nil {
+		t.Fatalf("expected error for rule without match or paths")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-tmux/pkg/tmux"
+)
+
+func addShellFlags(root *cobra.Command) {
+	root.PersistentFlags().String("wrap-shell", "", `Shell that runs command strings: a path, or "default-shell" for tmux's (env ARC_TMUX_WRAP_SHELL; default sh)`)
+	root.PersistentFlags().Bool("login-shell", true, "Run the wrapper shell as a login shell (env ARC_TMUX_LOGIN_SHELL)")
+}
+
+// applyShellMode picks the wrapper shell from --wrap-shell/--login-shell, the
+// environment, or the config file, in that order. Config errors are left to
+// the commands that read the config.
+func applyShellMode(cmd *cobra.Command) {
+	cfg, _ := loadConfig(defaultConfigFile())
+	shell := strings.TrimSpace(cfg.WrapShell)
+	if env := strings.TrimSpace(os.Getenv("ARC_TMUX_WRAP_SHELL")); env != "" {
+		shell = env
+	}
+	if f := cmd.Flags().Lookup("wrap-shell"); f != nil && f.Changed {
+		shell = strings.TrimSpace(f.Value.String())
+	}
+	login := true
+	if cfg.LoginShell != nil {
+		login = *cfg.LoginShell
+	}
+	if v, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("ARC_TMUX_LOGIN_SHELL"))); err == nil {
+		login = v
+	}
+	if f := cmd.Flags().Lookup("login-shell"); f != nil && f.Changed {
+		login = f.Value.String() == "true"
+	}
+	tmux.SetCommandShell(shell, login)
+}
+
+// segmentShell returns the shell command line that runs POSIX sentinel
+// wrappers; shells without POSIX syntax (fish, nu, ...) fall back to sh.
+func segmentShell() string {
+	shell, login := tmux.CommandShell()
+	if !isPosixShell(shell) {
+		shell = "sh"
+	}
+	if strings.ContainsAny(shell, " \t'\"$`\\;&|<>()") {
+		shell = shellQuoteSingle(shell)
+	}
+	if login {
+		return shell + " -lc"
+	}
+	return shell + " -c"
+}
@@ -13,8 +13,10 @@ import (
 
 func main() {
 	root := cmd.NewRootCmd()
-	if err := root.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	err := root.Execute()
+	cmd.FlushTranscript(err)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, cmd.FormatError(os.Stderr, err))
 		os.Exit(1)
 	}
 }
@@ -6,7 +6,6 @@ package tmux
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"os/user"
 	"strings"
 	"time"
@@ -44,23 +43,24 @@ func ApplyAgentSessionStyle(session string, meta AgentSessionMeta) error {
 	}
 	statusLeft := fmt.Sprintf(" #[fg=colour16,bg=colour220,bold] ARC-TMUX #[default] %s ", owner)
 	statusRight := " #[fg=colour245]agent#[default] "
+	target := SessionTarget(session)
 	commands := [][]string{
-		{"set-option", "-t", session, "@arc_tmux", "1"},
-		{"set-option", "-t", session, "@arc_tmux_owner", meta.Owner},
-		{"set-option", "-t", session, "@arc_tmux_host", meta.Host},
-		{"set-option", "-t", session, "@arc_tmux_created_at", meta.CreatedAt},
-		{"set-environment", "-t", session, "ARC_TMUX", "1"},
-		{"set-environment", "-t", session, "ARC_TMUX_OWNER", meta.Owner},
-		{"set-environment", "-t", session, "ARC_TMUX_HOST", meta.Host},
-		{"set-option", "-t", session, "status-style", "bg=colour236,fg=colour15"},
-		{"set-option", "-t", session, "status-left", statusLeft},
-		{"set-option", "-t", session, "status-right", statusRight},
-		{"set-option", "-t", session, "status-left-length", "40"},
-		{"set-option", "-t", session, "status-right-length", "40"},
-		{"set-option", "-t", session, "default-command", "sh"},
+		{"set-option", "-t", target, "@arc_tmux", "1"},
+		{"set-option", "-t", target, "@arc_tmux_owner", meta.Owner},
+		{"set-option", "-t", target, "@arc_tmux_host", meta.Host},
+		{"set-option", "-t", target, "@arc_tmux_created_at", meta.CreatedAt},
+		{"set-environment", "-t", target, "ARC_TMUX", "1"},
+		{"set-environment", "-t", target, "ARC_TMUX_OWNER", meta.Owner},
+		{"set-environment", "-t", target, "ARC_TMUX_HOST", meta.Host},
+		{"set-option", "-t", target, "status-style", "bg=colour236,fg=colour15"},
+		{"set-option", "-t", target, "status-left", statusLeft},
+		{"set-option", "-t", target, "status-right", statusRight},
+		{"set-option", "-t", target, "status-left-length", "40"},
+		{"set-option", "-t", target, "status-right-length", "40"},
+		{"set-option", "-t", target, "default-command", "sh"},
 	}
 	for _, args := range commands {
-		if err := exec.Command("tmux", args...).Run(); err != nil {
+		if err := tmuxCommand(args...).Run(); err != nil {
 			return fmt.Errorf("tmux %s: %w", args[0], err)
 		}
 	}
@@ -82,7 +82,7 @@ func ApplyAgentWindowStyle(session string, windowIndex int) error {
 	if _, err := ensureTmux(); err != nil {
 		return fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	target := fmt.Sprintf("%s:%d", session, windowIndex)
+	target := WindowTarget(session, windowIndex)
 	commands := [][]string{
 		{"set-window-option", "-t", target, "window-status-style", "fg=colour250,bg=colour236"},
 		{"set-window-option", "-t", target, "window-status-format", " #I:#W "},
@@ -92,7 +92,7 @@ func ApplyAgentWindowStyle(session string, windowIndex int) error {
 		{"set-window-option", "-t", target, "pane-active-border-style", "fg=colour208,bold"},
 	}
 	for _, args := range commands {
-		if err := exec.Command("tmux", args...).Run(); err != nil {
+		if err := tmuxCommand(args...).Run(); err != nil {
 			return fmt.Errorf("tmux %s: %w", args[0], err)
 		}
 	}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package tmux
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client is a terminal attached to the tmux server.
+type Client struct {
+	Name    string `json:"name" yaml:"name"`
+	Session string `json:"session" yaml:"session"`
+	TTY     string `json:"tty" yaml:"tty"`
+}
+
+// ListClients returns the clients attached to the server. No server running
+// yields ErrNoTmuxServer.
+func ListClients() ([]Client, error) {
+	if _, err := ensureTmux(); err != nil {
+		return nil, err
+	}
+	cmd := tmuxCommand("list-clients", "-F", "#{client_name}\t#{client_session}\t#{client_tty}")
+	var out, errBuf bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errBuf
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(errBuf.String())
+		lower := strings.ToLower(msg)
+		if strings.Contains(lower, "no server running") || strings.Contains(lower, "error connecting") {
+			return nil, ErrNoTmuxServer
+		}
+		if msg != "" {
+			return nil, fmt.Errorf("tmux list-clients: %s", msg)
+		}
+		return nil, fmt.Errorf("tmux list-clients: %w", err)
+	}
+	return parseClientsOutput(out.String()), nil
+}
+
+func parseClientsOutput(output string) []Client {
+	var clients []Client
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "\t", 3)
+		for len(parts) < 3 {
+			parts = append(parts, "")
+		}
+		clients = append(clients, Client{Name: parts[0], Session: parts[1], TTY: parts[2]})
+	}
+	return clients
+}
+
+// FocusPane switches client to target's session and makes target the
+// current window and active pane.
+func FocusPane(client string, target string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	if err := tmuxCommand("switch-client", "-c", client, "-t", target).Run(); err != nil {
+		return fmt.Errorf("tmux switch-client: %w", err)
+	}
+	if err := tmuxCommand("select-window", "-t", target).Run(); err != nil {
+		return fmt.Errorf("tmux select-window: %w", err)
+	}
+	return SelectPane(target)
+}
+
+// MessageOptions controls how DisplayMessage shows text.
+type MessageOptions struct {
+	// Duration overrides the display-time option when positive (tmux 3.2+).
+	Duration time.Duration
+	// Style is a tmux style such as "fg=white,bg=red".
+	Style string
+}
+
+// DisplayMessage shows message in client's status line. The text is shown
+// literally; tmux format characters are escaped.
+func DisplayMessage(client string, message string, opts MessageOptions) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	args := []string{"display-message", "-c", client}
+	if opts.Duration > 0 {
+		args = append(args, "-d", strconv.FormatInt(opts.Duration.Milliseconds(), 10))
+	}
+	text := escapeFormat(message)
+	if strings.TrimSpace(opts.Style) != "" {
+		text = "#[" + opts.Style + "]" + text
+	}
+	if err := tmuxCommand(append(args, text)...).Run(); err != nil {
+		return fmt.Errorf("tmux display-message: %w", err)
+	}
+	return nil
+}
+
+// PopupOptions sizes and styles a popup shown by DisplayPopup.
+type PopupOptions struct {
+	Width  int
+	Height int
+	// Style is the popup's tmux style (tmux 3.3+).
+	Style string
+}
+
+// DisplayPopup opens a popup on client running the shell command cmdStr; the
+// popup closes when the command exits (tmux 3.2+).
+func DisplayPopup(client string, cmdStr string, opts PopupOptions) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	args := []string{"display-popup", "-c", client, "-E"}
+	if opts.Width > 0 {
+		args = append(args, "-w", strconv.Itoa(opts.Width))
+	}
+	if opts.Height > 0 {
+		args = append(args, "-h", strconv.Itoa(opts.Height))
+	}
+	if strings.TrimSpace(opts.Style) != "" {
+		args = append(args, "-s", opts.Style)
+	}
+	if err := tmuxCommand(append(args, cmdStr)...).Run(); err != nil {
+		return fmt.Errorf("tmux display-popup: %w", err)
+	}
+	return nil
+}
+
+// escapeFormat doubles '#' so tmux does not expand the text as a format.
+func escapeFormat(text string) string {
+	return strings.ReplaceAll(text, "#", "##")
+}
+
+// RingBell writes a bell character to the client's terminal.
+func RingBell(client Client) error {
+	if strings.TrimSpace(client.TTY) == "" {
+		return fmt.Errorf("client %s has no tty", client.Name)
+	}
+	f, err := os.OpenFile(client.TTY, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = f.Write([]byte("\a"))
+	return err
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package tmux
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// ErrDeadlineExceeded is returned by waits cut short by SetDeadline.
+var ErrDeadlineExceeded = errors.New("deadline exceeded")
+
+var (
+	deadlineMu     sync.Mutex
+	deadlineAt     time.Time
+	deadlineCtx    = context.Background()
+	deadlineCancel context.CancelFunc
+)
+
+// SetDeadline bounds every subsequent tmux call and wait in this process; a
+// tmux invocation still running at the deadline is killed. A zero time clears it.
+func SetDeadline(t time.Time) {
+	deadlineMu.Lock()
+	defer deadlineMu.Unlock()
+	if deadlineCancel != nil {
+		deadlineCancel()
+		deadlineCancel = nil
+	}
+	deadlineAt = t
+	if t.IsZero() {
+		deadlineCtx = context.Background()
+		return
+	}
+	deadlineCtx, deadlineCancel = context.WithDeadline(context.Background(), t)
+}
+
+// DeadlineExceeded reports whether a deadline is set and has passed.
+func DeadlineExceeded() bool {
+	deadlineMu.Lock()
+	defer deadlineMu.Unlock()
+	return !deadlineAt.IsZero() && !time.Now().Before(deadlineAt)
+}
+
+// BoundTimeout caps d to the time remaining before the deadline.
+func BoundTimeout(d time.Duration) time.Duration {
+	deadlineMu.Lock()
+	defer deadlineMu.Unlock()
+	return boundTimeout(d, deadlineAt, time.Now())
+}
+
+func boundTimeout(d time.Duration, deadline time.Time, now time.Time) time.Duration {
+	if deadline.IsZero() {
+		return d
+	}
+	remaining := deadline.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	if remaining < d {
+		return remaining
+	}
+	return d
+}
+
+func tmuxCommand(args ...string) *exec.Cmd {
+	deadlineMu.Lock()
+	ctx := deadlineCtx
+	deadlineMu.Unlock()
+	return exec.CommandContext(ctx, "tmux", serverArgs(nativeTargetArgs(args))...)
+}
+
+// waitTimeoutError distinguishes a deadline cut from an ordinary wait timeout.
+func waitTimeoutError(msg string) error {
+	if DeadlineExceeded() {
+		return ErrDeadlineExceeded
+	}
+	return errors.New(msg)
+}
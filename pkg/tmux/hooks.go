@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package tmux
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hookName addresses one entry of a hook array, so arc-tmux's hooks sit
+// alongside the user's own rather than replacing them.
+func hookName(name string, index int) string {
+	return fmt.Sprintf("%s[%d]", name, index)
+}
+
+// SetHook sets the global hook name[index] to run command.
+func SetHook(name string, index int, command string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand("set-hook", "-g", hookName(name, index), command)
+	return err
+}
+
+// UnsetHook removes the global hook name[index].
+func UnsetHook(name string, index int) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand("set-hook", "-gu", hookName(name, index))
+	return err
+}
+
+// Hook returns the command of the global hook name[index]; ok is false when
+// it is unset.
+func Hook(name string, index int) (string, bool, error) {
+	if _, err := ensureTmux(); err != nil {
+		return "", false, err
+	}
+	out, err := runTargetCommand("show-hooks", "-g", hookName(name, index))
+	if err != nil {
+		return "", false, err
+	}
+	return parseHookOutput(out, hookName(name, index))
+}
+
+func parseHookOutput(output string, name string) (string, bool, error) {
+	line := strings.TrimSpace(output)
+	if line == "" {
+		return "", false, nil
+	}
+	rest, ok := strings.CutPrefix(line, name)
+	if !ok {
+		return "", false, fmt.Errorf("unexpected show-hooks output %q", line)
+	}
+	command := strings.TrimSpace(rest)
+	return command, command != "", nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package tmux
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	indexMu   sync.Mutex
+	zeroBased bool
+	// baseIndexCache holds base-index/pane-base-index per server socket and
+	// session ("" is the default server or the current session) once they
+	// have been queried.
+	baseIndexCache = map[baseIndexKey][2]int{}
+)
+
+type baseIndexKey struct {
+	socket  string
+	session string
+}
+
+// SetZeroBasedIndexes makes window and pane indexes zero-based regardless of
+// the server's base-index and pane-base-index options: indexes read from tmux
+// are shifted down, and numeric session:window.pane targets are shifted back
+// up before they reach tmux. Requires tmux 3.2+ for format arithmetic.
+func SetZeroBasedIndexes(on bool) {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	zeroBased = on
+	baseIndexCache = map[baseIndexKey][2]int{}
+}
+
+// ZeroBasedIndexes reports whether indexes are normalized to start at 0.
+func ZeroBasedIndexes() bool {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	return zeroBased
+}
+
+// BaseIndexes returns the base-index and pane-base-index options that apply
+// to session (the current session when empty).
+func BaseIndexes(session string) (int, int, error) {
+	if _, err := ensureTmux(); err != nil {
+		return 0, 0, fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	args := []string{"display-message", "-p"}
+	if session != "" {
+		args = append(args, "-t", SessionTarget(session))
+	}
+	args = append(args, "#{base-index}\t#{pane-base-index}")
+	out, err := runTargetCommand(args...)
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseBaseIndexes(out)
+}
+
+func parseBaseIndexes(out string) (int, int, error) {
+	winRaw, paneRaw, ok := strings.Cut(strings.TrimSpace(out), "\t")
+	if !ok {
+		return 0, 0, fmt.Errorf("unexpected base indexes %q", out)
+	}
+	win, err := strconv.Atoi(winRaw)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected base-index %q", winRaw)
+	}
+	pane, err := strconv.Atoi(paneRaw)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected pane-base-index %q", paneRaw)
+	}
+	return win, pane, nil
+}
+
+// WindowIndexFormat returns the format that expands to a window index in the
+// configured origin.
+func WindowIndexFormat() string {
+	if ZeroBasedIndexes() {
+		return "#{e|-:#{window_index},#{base-index}}"
+	}
+	return "#{window_index}"
+}
+
+// PaneIndexFormat returns the format that expands to a pane index in the
+// configured origin.
+func PaneIndexFormat() string {
+	if ZeroBasedIndexes() {
+		return "#{e|-:#{pane_index},#{pane-base-index}}"
+	}
+	return "#{pane_index}"
+}
+
+// formattedIDFormat expands to session:window.pane in the configured origin.
+func formattedIDFormat() string {
+	return "#{session_name}:" + WindowIndexFormat() + "." + PaneIndexFormat()
+}
+
+// nativeTargetArgs rewrites the value of each -t flag from zero-based indexes
+// to the server's. Arguments are returned unchanged outside zero-based mode.
+func nativeTargetArgs(args []string) []string {
+	if !ZeroBasedIndexes() {
+		return args
+	}
+	var out []string
+	for i, arg := range args {
+		if arg != "-t" || i+1 >= len(args) {
+			continue
+		}
+		native, ok := nativeTarget(args[i+1])
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = append([]string(nil), args...)
+		}
+		out[i+1] = native
+	}
+	if out == nil {
+		return args
+	}
+	return out
+}
+
+// nativeTarget shifts the numeric window and pane parts of a
+// session:window.pane target by the session's base indexes. Targets without
+// numeric parts (names, %N, @N, {tokens}, relative +N) are left alone.
+func nativeTarget(target string) (string, bool) {
+	session, rest, ok := strings.Cut(target, ":")
+	if !ok || rest == "" {
+		return "", false
+	}
+	window, pane, hasPane := strings.Cut(rest, ".")
+	winNum := isIndex(window)
+	paneNum := hasPane && isIndex(pane)
+	if !winNum && !paneNum {
+		return "", false
+	}
+	winBase, paneBase, err := cachedBaseIndexes(strings.TrimPrefix(session, "="))
+	if err != nil {
+		return "", false
+	}
+	if winNum {
+		n, _ := strconv.Atoi(window)
+		window = strconv.Itoa(n + winBase)
+	}
+	if paneNum {
+		n, _ := strconv.Atoi(pane)
+		pane = strconv.Itoa(n + paneBase)
+	}
+	native := session + ":" + window
+	if hasPane {
+		native += "." + pane
+	}
+	return native, true
+}
+
+func cachedBaseIndexes(session string) (int, int, error) {
+	key := baseIndexKey{socket: ServerSocket(), session: session}
+	indexMu.Lock()
+	cached, ok := baseIndexCache[key]
+	indexMu.Unlock()
+	if ok {
+		return cached[0], cached[1], nil
+	}
+	win, pane, err := BaseIndexes(session)
+	if err != nil {
+		return 0, 0, err
+	}
+	indexMu.Lock()
+	baseIndexCache[key] = [2]int{win, pane}
+	indexMu.Unlock()
+	return win, pane, nil
+}
+
+func isIndex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	serverMu     sync.Mutex
+	serverSocket string
+)
+
+// SetServerSocket points every subsequent tmux call at the server listening
+// on path (tmux -S). An empty path uses the default server.
+func SetServerSocket(path string) {
+	serverMu.Lock()
+	serverSocket = path
+	serverMu.Unlock()
+}
+
+// ServerSocket returns the socket set by SetServerSocket, or "" for the
+// default server.
+func ServerSocket() string {
+	serverMu.Lock()
+	defer serverMu.Unlock()
+	return serverSocket
+}
+
+// WithServerSocket runs fn against the server on path, then restores the
+// previous socket.
+func WithServerSocket(path string, fn func() error) error {
+	prev := ServerSocket()
+	SetServerSocket(path)
+	defer SetServerSocket(prev)
+	return fn()
+}
+
+func serverArgs(args []string) []string {
+	socket := ServerSocket()
+	if socket == "" {
+		return args
+	}
+	return append([]string{"-S", socket}, args...)
+}
+
+// SocketDir returns the directory tmux creates sockets in for this user:
+// $TMUX_TMPDIR (or /tmp) followed by tmux-UID.
+func SocketDir() string {
+	base := strings.TrimSpace(os.Getenv("TMUX_TMPDIR"))
+	if base == "" {
+		base = "/tmp"
+	}
+	return filepath.Join(base, fmt.Sprintf("tmux-%d", os.Getuid()))
+}
+
+// SocketPath resolves a socket name (as given to tmux -L) to a path in
+// SocketDir; values containing a slash are returned unchanged.
+func SocketPath(name string) string {
+	if strings.Contains(name, "/") {
+		return name
+	}
+	return filepath.Join(SocketDir(), name)
+}
+
+// DiscoverSockets lists the sockets in SocketDir plus extra (names or paths),
+// deduplicated and sorted. Sockets are not probed; servers that have exited
+// leave stale sockets behind.
+func DiscoverSockets(extra []string) []string {
+	seen := map[string]bool{}
+	var sockets []string
+	add := func(path string) {
+		if path == "" || seen[path] {
+			return
+		}
+		seen[path] = true
+		sockets = append(sockets, path)
+	}
+	dir := SocketDir()
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, entry := range entries {
+			if entry.Type()&os.ModeSocket != 0 {
+				add(filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	for _, name := range extra {
+		if name = strings.TrimSpace(name); name != "" {
+			add(SocketPath(name))
+		}
+	}
+	sort.Strings(sockets)
+	return sockets
+}
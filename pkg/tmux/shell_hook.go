@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package tmux
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pane user options written by the arc-tmux shell hook. ShellHookOption is
+// set when the hook is loaded, to "SHELL PID" of the shell that loaded it;
+// LastCommandOption is rewritten after every command, to
+// "STATUS SEQ EPOCH COMMAND", and the same record is kept in the history
+// slot HistoryOptionPrefix + SEQ%HistorySlots.
+const (
+	ShellHookOption     = "@arc_tmux_hook"
+	LastCommandOption   = "@arc_tmux_last"
+	HistoryOptionPrefix = "@arc_tmux_history_"
+	HistorySlots        = 100
+)
+
+// HookCommand is the last command a pane's shell finished, as recorded by
+// the shell hook. Seq counts commands since the hook was loaded, so a change
+// in Seq means another command finished.
+type HookCommand struct {
+	ExitCode int       `json:"exit_code" yaml:"exit_code"`
+	Seq      int       `json:"seq" yaml:"seq"`
+	At       time.Time `json:"at" yaml:"at"`
+	Command  string    `json:"command" yaml:"command"`
+}
+
+// ParseHookCommand parses a LastCommandOption value; ok is false when it is
+// unset or malformed.
+func ParseHookCommand(raw string) (HookCommand, bool) {
+	fields := strings.SplitN(strings.TrimRight(raw, "\n"), " ", 4)
+	if len(fields) < 3 {
+		return HookCommand{}, false
+	}
+	code, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return HookCommand{}, false
+	}
+	seq, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return HookCommand{}, false
+	}
+	hc := HookCommand{ExitCode: code, Seq: seq, At: parseEpoch(fields[2])}
+	if len(fields) == 4 {
+		hc.Command = strings.TrimSpace(fields[3])
+	}
+	return hc, true
+}
+
+// parseShellHook parses a ShellHookOption value into the shell name and the
+// PID of the shell that loaded the hook.
+func parseShellHook(raw string) (string, int, bool) {
+	fields := strings.Fields(raw)
+	if len(fields) != 2 {
+		return "", 0, false
+	}
+	pid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return "", 0, false
+	}
+	return fields[0], pid, true
+}
+
+// HookHistory returns the commands the shell hook recorded in a pane, oldest
+// first. The history slots are read in a single display-message.
+func HookHistory(target string) ([]HookCommand, error) {
+	if _, err := ensureTmux(); err != nil {
+		return nil, err
+	}
+	slots := make([]string, HistorySlots)
+	for i := range slots {
+		slots[i] = fmt.Sprintf("#{%s%d}", HistoryOptionPrefix, i)
+	}
+	out, err := runTargetCommand("display-message", "-p", "-t", target, strings.Join(slots, "\t"))
+	if err != nil {
+		return nil, err
+	}
+	return parseHookHistory(out), nil
+}
+
+// parseHookHistory parses tab-separated history slots. Records are ordered by
+// time, then sequence, since a new shell in the pane starts counting again.
+func parseHookHistory(output string) []HookCommand {
+	var history []HookCommand
+	for _, slot := range strings.Split(strings.TrimRight(output, "\n"), "\t") {
+		if hc, ok := ParseHookCommand(slot); ok {
+			history = append(history, hc)
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		if !history[i].At.Equal(history[j].At) {
+			return history[i].At.Before(history[j].At)
+		}
+		return history[i].Seq < history[j].Seq
+	})
+	return history
+}
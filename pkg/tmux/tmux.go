@@ -8,12 +8,14 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/sha1"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -70,6 +72,39 @@ type PaneDetails struct {
 	Path         string    `json:"path"`
 	PID          int       `json:"pid"`
 	ActivityAt   time.Time `json:"activity_at"`
+	Dead         bool      `json:"dead"`
+	DeadStatus   int       `json:"dead_status,omitempty"`
+	StartCommand string    `json:"start_command,omitempty"`
+	// DeadSignal is the signal that killed a dead pane's command; tmux then
+	// reports a DeadStatus of 0 (see ExitCode).
+	DeadSignal int `json:"dead_signal,omitempty"`
+	// WindowActivityAt is the window's last activity; it stands in for
+	// ActivityAt on servers without pane_activity (tmux < 3.4).
+	WindowActivityAt time.Time `json:"window_activity_at"`
+	// CursorX and CursorY locate the cursor on the visible screen.
+	CursorX int `json:"cursor_x"`
+	CursorY int `json:"cursor_y"`
+	// ScrollPosition is how far copy mode is scrolled back (0 when not
+	// scrolled); HistorySize is the number of lines in the scrollback.
+	ScrollPosition int `json:"scroll_position"`
+	HistorySize    int `json:"history_size"`
+	// AlternateScreen is set while a full-screen program (vim, less, htop)
+	// has switched the pane to the alternate screen.
+	AlternateScreen bool `json:"alternate_screen"`
+	// ShellHook names the shell (bash, zsh) when the arc-tmux shell hook is
+	// loaded in the pane's own shell, and LastCommand is that hook's record
+	// of the last command the shell finished.
+	ShellHook   string       `json:"shell_hook,omitempty"`
+	LastCommand *HookCommand `json:"last_command,omitempty"`
+}
+
+// ExitCode is a dead pane's exit code, with a command killed by a signal
+// reported the way shells do, as 128 plus the signal number.
+func (p PaneDetails) ExitCode() int {
+	if p.DeadSignal > 0 {
+		return 128 + p.DeadSignal
+	}
+	return p.DeadStatus
 }
 
 // ProcessInfo represents a process from ps output.
@@ -100,7 +135,7 @@ func HasSession(name string) (bool, error) {
 		return false, fmt.Errorf("tmux not found in PATH: %w", err)
 	}
 	target := exactSessionTarget(name)
-	cmd := exec.Command("tmux", "has-session", "-t", target)
+	cmd := tmuxCommand("has-session", "-t", target)
 	var errBuf bytes.Buffer
 	cmd.Stderr = &errBuf
 	err := cmd.Run()
@@ -127,6 +162,95 @@ func exactSessionTarget(name string) string {
 	return "=" + name
 }
 
+// ErrInvalidSessionName is returned for session names tmux cannot address.
+var ErrInvalidSessionName = errors.New("invalid session name")
+
+// ValidateSessionName rejects names that tmux would silently rewrite (colons,
+// dots) or that would be parsed as flags (leading dash).
+func ValidateSessionName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: name is empty", ErrInvalidSessionName)
+	case strings.HasPrefix(name, "-"):
+		return fmt.Errorf("%w %q: must not start with '-'", ErrInvalidSessionName, name)
+	case strings.ContainsAny(name, ":."):
+		return fmt.Errorf("%w %q: must not contain ':' or '.'", ErrInvalidSessionName, name)
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f {
+			return fmt.Errorf("%w %q: contains control characters", ErrInvalidSessionName, name)
+		}
+	}
+	return nil
+}
+
+// SessionTarget returns an exact-match target for a session, so "dev" never
+// prefix-matches "dev2". The trailing colon makes it valid wherever tmux
+// expects a session, window, or pane target.
+func SessionTarget(name string) string {
+	return exactSessionTarget(name) + ":"
+}
+
+// DefaultPaneOption is the session user option holding the pane used when
+// --pane is omitted.
+const DefaultPaneOption = "@arc_tmux_default_pane"
+
+// SessionOption returns a session option value; ok is false when it is unset.
+func SessionOption(session string, name string) (string, bool, error) {
+	out, err := runTargetCommand("show-options", "-qv", "-t", SessionTarget(session), name)
+	if err != nil {
+		return "", false, err
+	}
+	value := strings.TrimRight(out, "\n")
+	return value, value != "", nil
+}
+
+// SetSessionOption sets a session option.
+func SetSessionOption(session string, name string, value string) error {
+	_, err := runTargetCommand("set-option", "-t", SessionTarget(session), name, value)
+	return err
+}
+
+// UnsetSessionOption removes a session option.
+func UnsetSessionOption(session string, name string) error {
+	_, err := runTargetCommand("set-option", "-u", "-t", SessionTarget(session), name)
+	return err
+}
+
+// SetEnvironment sets a variable in the session environment, which new panes
+// inherit and "tmux show-environment" reports to running shells.
+func SetEnvironment(session string, key string, value string) error {
+	_, err := runTargetCommand("set-environment", "-t", SessionTarget(session), key, value)
+	return err
+}
+
+// PaneOption returns a pane option value; ok is false when it is unset.
+func PaneOption(target string, name string) (string, bool, error) {
+	out, err := runTargetCommand("show-options", "-pqv", "-t", target, name)
+	if err != nil {
+		return "", false, err
+	}
+	value := strings.TrimRight(out, "\n")
+	return value, value != "", nil
+}
+
+// SetPaneOption sets a pane option.
+func SetPaneOption(target string, name string, value string) error {
+	_, err := runTargetCommand("set-option", "-p", "-t", target, name, value)
+	return err
+}
+
+// UnsetPaneOption removes a pane option.
+func UnsetPaneOption(target string, name string) error {
+	_, err := runTargetCommand("set-option", "-pu", "-t", target, name)
+	return err
+}
+
+// WindowTarget returns an exact-match session:window target.
+func WindowTarget(session string, windowIndex int) string {
+	return fmt.Sprintf("%s:%d", exactSessionTarget(session), windowIndex)
+}
+
 // ListPanes returns panes across all sessions.
 func ListPanes() ([]Pane, error) {
 	if _, err := ensureTmux(); err != nil {
@@ -134,13 +258,13 @@ func ListPanes() ([]Pane, error) {
 	}
 	format := strings.Join([]string{
 		"#{session_name}",
-		"#{window_index}",
-		"#{pane_index}",
+		WindowIndexFormat(),
+		PaneIndexFormat(),
 		"#{?pane_active,1,0}",
 		"#{pane_current_command}",
 		"#{pane_title}",
 	}, "\t")
-	cmd := exec.Command("tmux", "list-panes", "-a", "-F", format)
+	cmd := tmuxCommand("list-panes", "-a", "-F", format)
 	var out, errBuf bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &errBuf
@@ -177,7 +301,7 @@ func wrapListPanesError(runErr error, stderr string) error {
 	msg := strings.TrimSpace(stderr)
 	lower := strings.ToLower(msg)
 	switch {
-	case strings.Contains(lower, "no server running"):
+	case strings.Contains(lower, "no server running"), strings.Contains(lower, "error connecting"):
 		return ErrNoTmuxServer
 	default:
 		if msg != "" {
@@ -214,6 +338,37 @@ func parseSessionsOutput(output string) ([]Session, error) {
 	return sessions, scanner.Err()
 }
 
+// paneDetailsFormat is the list-panes format parsed by parsePaneDetailsOutput.
+func paneDetailsFormat() string {
+	return strings.Join([]string{
+		"#{session_name}",
+		WindowIndexFormat(),
+		"#{window_name}",
+		"#{?window_active,1,0}",
+		PaneIndexFormat(),
+		"#{pane_id}",
+		"#{?pane_active,1,0}",
+		"#{pane_current_command}",
+		"#{pane_title}",
+		"#{pane_current_path}",
+		"#{pane_pid}",
+		"#{pane_activity}",
+		"#{?pane_dead,1,0}",
+		"#{pane_dead_status}",
+		"#{pane_start_command}",
+		"#{window_activity}",
+		"#{cursor_x}",
+		"#{cursor_y}",
+		"#{scroll_position}",
+		"#{history_size}",
+		"#{?alternate_on,1,0}",
+		"#{pane_dead_signal}",
+		"#{" + ShellHookOption + "}",
+		// Last, so a stray tab in a recorded command cannot shift the fields.
+		"#{" + LastCommandOption + "}",
+	}, "\t")
+}
+
 func parsePaneDetailsOutput(output string) ([]PaneDetails, error) {
 	var panes []PaneDetails
 	scanner := bufio.NewScanner(strings.NewReader(output))
@@ -232,24 +387,84 @@ func parsePaneDetailsOutput(output string) ([]PaneDetails, error) {
 		paneActive := parts[6] == "1"
 		pid, _ := strconv.Atoi(parts[10])
 		activity := parseEpoch(parts[11])
+		var dead bool
+		var deadStatus int
+		if len(parts) >= 14 {
+			dead = parts[12] == "1"
+			deadStatus, _ = strconv.Atoi(parts[13])
+		}
+		var startCommand string
+		if len(parts) >= 15 {
+			startCommand = unquoteStartCommand(parts[14])
+		}
+		var windowActivity time.Time
+		if len(parts) >= 16 {
+			windowActivity = parseEpoch(parts[15])
+		}
+		var cursorX, cursorY, scroll, history int
+		var alternate bool
+		if len(parts) >= 21 {
+			cursorX, _ = strconv.Atoi(parts[16])
+			cursorY, _ = strconv.Atoi(parts[17])
+			scroll, _ = strconv.Atoi(parts[18])
+			history, _ = strconv.Atoi(parts[19])
+			alternate = parts[20] == "1"
+		}
+		var deadSignal int
+		if len(parts) >= 22 {
+			deadSignal, _ = strconv.Atoi(parts[21])
+		}
+		var shellHook string
+		var lastCommand *HookCommand
+		if len(parts) >= 24 {
+			if shell, hookPID, ok := parseShellHook(parts[22]); ok && hookPID == pid {
+				shellHook = shell
+				if hc, ok := ParseHookCommand(strings.Join(parts[23:], "\t")); ok {
+					lastCommand = &hc
+				}
+			}
+		}
 		panes = append(panes, PaneDetails{
-			Session:      parts[0],
-			WindowIndex:  winIdx,
-			WindowName:   parts[2],
-			WindowActive: winActive,
-			PaneIndex:    paneIdx,
-			PaneID:       parts[5],
-			Active:       paneActive,
-			Command:      parts[7],
-			Title:        parts[8],
-			Path:         parts[9],
-			PID:          pid,
-			ActivityAt:   activity,
+			Session:          parts[0],
+			WindowIndex:      winIdx,
+			WindowName:       parts[2],
+			WindowActive:     winActive,
+			PaneIndex:        paneIdx,
+			PaneID:           parts[5],
+			Active:           paneActive,
+			Command:          parts[7],
+			Title:            parts[8],
+			Path:             parts[9],
+			PID:              pid,
+			ActivityAt:       activity,
+			Dead:             dead,
+			DeadStatus:       deadStatus,
+			DeadSignal:       deadSignal,
+			StartCommand:     startCommand,
+			WindowActivityAt: windowActivity,
+			CursorX:          cursorX,
+			CursorY:          cursorY,
+			ScrollPosition:   scroll,
+			HistorySize:      history,
+			AlternateScreen:  alternate,
+			ShellHook:        shellHook,
+			LastCommand:      lastCommand,
 		})
 	}
 	return panes, scanner.Err()
 }
 
+// unquoteStartCommand undoes the double quoting tmux applies to
+// pane_start_command when it contains spaces.
+func unquoteStartCommand(raw string) string {
+	if len(raw) >= 2 && strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) {
+		if unquoted, err := strconv.Unquote(raw); err == nil {
+			return unquoted
+		}
+	}
+	return raw
+}
+
 func parseEpoch(raw string) time.Time {
 	if strings.TrimSpace(raw) == "" {
 		return time.Time{}
@@ -271,15 +486,15 @@ func ListWindows(session string) ([]Window, error) {
 	}
 	format := strings.Join([]string{
 		"#{session_name}",
-		"#{window_index}",
+		WindowIndexFormat(),
 		"#{?window_active,1,0}",
 		"#{window_name}",
 	}, "\t")
 	args := []string{"list-windows", "-F", format}
 	if session != "" {
-		args = append(args, "-t", session)
+		args = append(args, "-t", exactSessionTarget(session))
 	}
-	cmd := exec.Command("tmux", args...)
+	cmd := tmuxCommand(args...)
 	var out, errBuf bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &errBuf
@@ -327,7 +542,7 @@ func ListSessions() ([]Session, error) {
 		"#{session_created}",
 		"#{session_activity}",
 	}, "\t")
-	cmd := exec.Command("tmux", "list-sessions", "-F", format)
+	cmd := tmuxCommand("list-sessions", "-F", format)
 	var out, errBuf bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &errBuf
@@ -341,7 +556,7 @@ func wrapListSessionsError(runErr error, stderr string) error {
 	msg := strings.TrimSpace(stderr)
 	lower := strings.ToLower(msg)
 	switch {
-	case strings.Contains(lower, "no server running"):
+	case strings.Contains(lower, "no server running"), strings.Contains(lower, "error connecting"):
 		return ErrNoTmuxServer
 	default:
 		if msg != "" {
@@ -356,21 +571,7 @@ func ListPanesDetailed() ([]PaneDetails, error) {
 	if _, err := ensureTmux(); err != nil {
 		return nil, fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	format := strings.Join([]string{
-		"#{session_name}",
-		"#{window_index}",
-		"#{window_name}",
-		"#{?window_active,1,0}",
-		"#{pane_index}",
-		"#{pane_id}",
-		"#{?pane_active,1,0}",
-		"#{pane_current_command}",
-		"#{pane_title}",
-		"#{pane_current_path}",
-		"#{pane_pid}",
-		"#{pane_activity}",
-	}, "\t")
-	cmd := exec.Command("tmux", "list-panes", "-a", "-F", format)
+	cmd := tmuxCommand("list-panes", "-a", "-F", paneDetailsFormat())
 	var out, errBuf bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &errBuf
@@ -385,21 +586,7 @@ func PaneDetailsForTarget(target string) (PaneDetails, error) {
 	if _, err := ensureTmux(); err != nil {
 		return PaneDetails{}, fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	format := strings.Join([]string{
-		"#{session_name}",
-		"#{window_index}",
-		"#{window_name}",
-		"#{?window_active,1,0}",
-		"#{pane_index}",
-		"#{pane_id}",
-		"#{?pane_active,1,0}",
-		"#{pane_current_command}",
-		"#{pane_title}",
-		"#{pane_current_path}",
-		"#{pane_pid}",
-		"#{pane_activity}",
-	}, "\t")
-	cmd := exec.Command("tmux", "display-message", "-p", "-t", target, format)
+	cmd := tmuxCommand("display-message", "-p", "-t", target, paneDetailsFormat())
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -415,12 +602,82 @@ func PaneDetailsForTarget(target string) (PaneDetails, error) {
 	return panes[0], nil
 }
 
-// ValidateTarget performs basic sanity checks on a target id.
-func ValidateTarget(target string) error {
-	if strings.Count(target, ":") != 1 || strings.Count(target, ".") != 1 {
-		return errors.New("invalid pane id; expected session:window.pane")
+// PaneHandle identifies a pane by its immutable pane_id alongside its
+// session:window.pane form at resolution time.
+type PaneHandle struct {
+	ID     string // %N
+	Target string // session:window.pane
+}
+
+// ResolveTarget canonicalizes any tmux target (session:window.pane, %N,
+// window names, tokens such as {up-of}) into a PaneHandle.
+func ResolveTarget(target string) (PaneHandle, error) {
+	if _, err := ensureTmux(); err != nil {
+		return PaneHandle{}, fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	// display-message falls back to another pane when the target is missing,
+	// so check existence with capture-pane, which resolves targets strictly.
+	if _, err := runTargetCommand("capture-pane", "-p", "-t", target, "-S", "0", "-E", "0"); err != nil {
+		return PaneHandle{}, err
+	}
+	format := "#{pane_id}\t" + formattedIDFormat()
+	out, err := runTargetCommand("display-message", "-p", "-t", target, format)
+	if err != nil {
+		return PaneHandle{}, err
+	}
+	return parsePaneHandle(out)
+}
+
+func runTargetCommand(args ...string) (string, error) {
+	cmd := tmuxCommand(args...)
+	var out, errBuf bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errBuf
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(errBuf.String())
+		if strings.Contains(strings.ToLower(msg), "no server running") {
+			return "", ErrNoTmuxServer
+		}
+		if msg != "" {
+			return "", fmt.Errorf("tmux %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("tmux %s: %w", args[0], err)
+	}
+	return out.String(), nil
+}
+
+func parsePaneHandle(line string) (PaneHandle, error) {
+	id, target, ok := strings.Cut(strings.TrimSpace(line), "\t")
+	if !ok || !strings.HasPrefix(id, "%") || target == "" {
+		return PaneHandle{}, fmt.Errorf("unexpected pane handle %q", line)
+	}
+	return PaneHandle{ID: id, Target: target}, nil
+}
+
+// InputRecorder is told about every input sent to a pane: text typed
+// literally, then the tmux key names sent after it (e.g. C-m for Enter).
+type InputRecorder func(target string, text string, keys []string)
+
+var (
+	inputMu       sync.Mutex
+	inputRecorder InputRecorder
+)
+
+// SetInputRecorder installs the recorder called by SendLiteral, SendKeys,
+// Interrupt, and Escape after each successful send; nil removes it.
+func SetInputRecorder(r InputRecorder) {
+	inputMu.Lock()
+	defer inputMu.Unlock()
+	inputRecorder = r
+}
+
+func recordInput(target string, text string, keys []string) {
+	inputMu.Lock()
+	r := inputRecorder
+	inputMu.Unlock()
+	if r != nil {
+		r(target, text, keys)
 	}
-	return nil
 }
 
 // SendLiteral sends literal text to the pane; if enter is true, sends Enter with optional delay.
@@ -428,17 +685,20 @@ func SendLiteral(target string, text string, enter bool, delayEnter time.Duratio
 	if _, err := ensureTmux(); err != nil {
 		return fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	if err := exec.Command("tmux", "send-keys", "-t", target, "-l", text).Run(); err != nil {
+	if err := tmuxCommand("send-keys", "-t", target, "-l", text).Run(); err != nil {
 		return fmt.Errorf("tmux send-keys: %w", err)
 	}
 	if enter {
 		if delayEnter > 0 {
 			time.Sleep(delayEnter)
 		}
-		if err := exec.Command("tmux", "send-keys", "-t", target, "C-m").Run(); err != nil {
+		if err := tmuxCommand("send-keys", "-t", target, "C-m").Run(); err != nil {
 			return fmt.Errorf("tmux send-keys enter: %w", err)
 		}
+		recordInput(target, text, []string{"C-m"})
+		return nil
 	}
+	recordInput(target, text, nil)
 	return nil
 }
 
@@ -451,9 +711,10 @@ func SendKeys(target string, keys []string) error {
 		return fmt.Errorf("tmux not found in PATH: %w", err)
 	}
 	args := append([]string{"send-keys", "-t", target}, keys...)
-	if err := exec.Command("tmux", args...).Run(); err != nil {
+	if err := tmuxCommand(args...).Run(); err != nil {
 		return fmt.Errorf("tmux send-keys: %w", err)
 	}
+	recordInput(target, "", keys)
 	return nil
 }
 
@@ -466,7 +727,7 @@ func Capture(target string, lines int) (string, error) {
 	if lines > 0 {
 		args = append(args, "-S", fmt.Sprintf("-%d", lines))
 	}
-	cmd := exec.Command("tmux", args...)
+	cmd := tmuxCommand(args...)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -475,6 +736,25 @@ func Capture(target string, lines int) (string, error) {
 	return out.String(), nil
 }
 
+// CaptureRaw returns a pane's content byte for byte: escape sequences for
+// colors and attributes are included (-e) and trailing spaces kept (-N).
+func CaptureRaw(target string, lines int) ([]byte, error) {
+	if _, err := ensureTmux(); err != nil {
+		return nil, fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	args := []string{"capture-pane", "-p", "-e", "-N", "-t", target}
+	if lines > 0 {
+		args = append(args, "-S", fmt.Sprintf("-%d", lines))
+	}
+	cmd := tmuxCommand(args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("tmux capture-pane: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
 // CaptureJoined returns the visible content of a pane, joining wrapped lines.
 func CaptureJoined(target string, lines int) (string, error) {
 	if _, err := ensureTmux(); err != nil {
@@ -484,7 +764,7 @@ func CaptureJoined(target string, lines int) (string, error) {
 	if lines > 0 {
 		args = append(args, "-S", fmt.Sprintf("-%d", lines))
 	}
-	cmd := exec.Command("tmux", args...)
+	cmd := tmuxCommand(args...)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -498,7 +778,7 @@ func PaneActivity(target string) (time.Time, error) {
 	if _, err := ensureTmux(); err != nil {
 		return time.Time{}, fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	cmd := exec.Command("tmux", "display-message", "-p", "-t", target, "#{pane_activity}")
+	cmd := tmuxCommand("display-message", "-p", "-t", target, "#{pane_activity}")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -512,6 +792,112 @@ func PaneActivity(target string) (time.Time, error) {
 	return time.Unix(secs, 0), nil
 }
 
+// ErrScreenSwitched is returned by WaitIdleOrScreenSwitch when the pane enters
+// or leaves the alternate screen (vim, less, htop) while waiting.
+var ErrScreenSwitched = errors.New("pane switched to or from the alternate screen")
+
+// AlternateScreen reports whether target is showing the alternate screen,
+// as full-screen programs such as vim, less, and htop do.
+func AlternateScreen(target string) (bool, error) {
+	if _, err := ensureTmux(); err != nil {
+		return false, fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	cmd := tmuxCommand("display-message", "-p", "-t", target, "#{alternate_on}")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return false, fmt.Errorf("tmux display-message: %w", err)
+	}
+	return strings.TrimSpace(out.String()) == "1", nil
+}
+
+// ErrPanePiped is returned by TeePane and PipePane when the source pane
+// already has a pipe-pane.
+var ErrPanePiped = errors.New("pane already has an active pipe-pane")
+
+// TeePane mirrors new output from src onto dst's terminal via pipe-pane until
+// StopTee is called. dst only displays the bytes; its own process never reads them.
+func TeePane(src string, dst string) error {
+	if _, err := ensureTmux(); err != nil {
+		return fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	tty, err := PaneTTY(dst)
+	if err != nil {
+		return err
+	}
+	return PipePane(src, "cat >> '"+tty+"'")
+}
+
+// PaneTTY returns the terminal device of target's pane.
+func PaneTTY(target string) (string, error) {
+	tty, err := runTargetCommand("display-message", "-p", "-t", target, "#{pane_tty}")
+	if err != nil {
+		return "", err
+	}
+	tty = strings.TrimSpace(tty)
+	if !strings.HasPrefix(tty, "/dev/") || strings.ContainsAny(tty, "'\"") {
+		return "", fmt.Errorf("unexpected pane_tty %q for %s", tty, target)
+	}
+	return tty, nil
+}
+
+// WritePaneTerminal writes text to target's terminal, so the pane displays it
+// as if its program had printed it; the program itself never reads it.
+func WritePaneTerminal(target string, text string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	tty, err := PaneTTY(target)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(tty, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(text); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// PipePane starts piping new output from target to the stdin of the shell
+// command command until StopTee is called.
+func PipePane(target string, command string) error {
+	if _, err := ensureTmux(); err != nil {
+		return fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	piped, err := PanePiped(target)
+	if err != nil {
+		return err
+	}
+	if piped {
+		return ErrPanePiped
+	}
+	if err := tmuxCommand("pipe-pane", "-O", "-t", target, command).Run(); err != nil {
+		return fmt.Errorf("tmux pipe-pane: %w", err)
+	}
+	return nil
+}
+
+// PanePiped reports whether target has an active pipe-pane.
+func PanePiped(target string) (bool, error) {
+	piped, err := runTargetCommand("display-message", "-p", "-t", target, "#{pane_pipe}")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(piped) == "1", nil
+}
+
+// StopTee closes the pipe-pane opened by TeePane or PipePane.
+func StopTee(src string) error {
+	if _, err := ensureTmux(); err != nil {
+		return fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	return tmuxCommand("pipe-pane", "-t", src).Run()
+}
+
 // ProcessTree returns the process tree rooted at pid, including the root.
 func ProcessTree(pid int) ([]ProcessNode, error) {
 	if pid <= 0 {
@@ -595,15 +981,50 @@ func buildProcessTree(rootPID int, procs []ProcessInfo) []ProcessNode {
 
 // WaitIdle waits until pane output is stable for idleDur or timeout hits.
 func WaitIdle(target string, idleDur time.Duration, timeout time.Duration) error {
+	return WaitIdleFunc(target, idleDur, timeout, nil)
+}
+
+// IdleStatus reports WaitIdleFunc progress after each poll.
+type IdleStatus struct {
+	// Idle is how long the pane has been quiet.
+	Idle time.Duration
+	// Remaining is how much quiet time is still needed to count as idle.
+	Remaining time.Duration
+}
+
+// WaitIdleFunc is WaitIdle with a callback invoked after every poll.
+func WaitIdleFunc(target string, idleDur time.Duration, timeout time.Duration, progress func(IdleStatus)) error {
+	return waitIdle(target, idleDur, timeout, progress, nil)
+}
+
+// WaitIdleOrScreenSwitch is WaitIdleFunc that also returns ErrScreenSwitched
+// as soon as the pane's alternate-screen state differs from altScreen. Pass
+// the state from before the command was sent, so a program that switches
+// before the first poll is still caught.
+func WaitIdleOrScreenSwitch(target string, idleDur time.Duration, timeout time.Duration, altScreen bool, progress func(IdleStatus)) error {
+	return waitIdle(target, idleDur, timeout, progress, &altScreen)
+}
+
+func waitIdle(target string, idleDur time.Duration, timeout time.Duration, progress func(IdleStatus), altScreen *bool) error {
 	if _, err := ensureTmux(); err != nil {
 		return fmt.Errorf("tmux not found in PATH: %w", err)
 	}
 	poll := 300 * time.Millisecond
-	deadline := time.Now().Add(timeout)
+	deadline := time.Now().Add(BoundTimeout(timeout))
+	screenSwitched := func() bool {
+		if altScreen == nil {
+			return false
+		}
+		current, err := AlternateScreen(target)
+		return err == nil && current != *altScreen
+	}
 	if lastActivity, err := PaneActivity(target); err == nil {
 		for {
 			if time.Now().After(deadline) {
-				return errors.New("timeout waiting for idle")
+				return waitTimeoutError("timeout waiting for idle")
+			}
+			if screenSwitched() {
+				return ErrScreenSwitched
 			}
 			current, err := PaneActivity(target)
 			if err != nil {
@@ -615,6 +1036,7 @@ func WaitIdle(target string, idleDur time.Duration, timeout time.Duration) error
 			if time.Since(lastActivity) >= idleDur {
 				return nil
 			}
+			reportIdle(progress, time.Since(lastActivity), idleDur)
 			time.Sleep(poll)
 		}
 	}
@@ -622,7 +1044,10 @@ func WaitIdle(target string, idleDur time.Duration, timeout time.Duration) error
 	lastChange := time.Now()
 	for {
 		if time.Now().After(deadline) {
-			return errors.New("timeout waiting for idle")
+			return waitTimeoutError("timeout waiting for idle")
+		}
+		if screenSwitched() {
+			return ErrScreenSwitched
 		}
 		s, err := Capture(target, 200)
 		if err != nil {
@@ -637,6 +1062,47 @@ func WaitIdle(target string, idleDur time.Duration, timeout time.Duration) error
 				return nil
 			}
 		}
+		reportIdle(progress, time.Since(lastChange), idleDur)
+		time.Sleep(poll)
+	}
+}
+
+func reportIdle(progress func(IdleStatus), idle time.Duration, idleDur time.Duration) {
+	if progress == nil {
+		return
+	}
+	progress(IdleStatus{Idle: idle, Remaining: idleDur - idle})
+}
+
+// OutputHash is the hex SHA-1 of captured pane output, as reported by monitor.
+func OutputHash(output string) string {
+	h := sha1.Sum([]byte(output))
+	return hex.EncodeToString(h[:])
+}
+
+// WaitOutputChange polls the last lines of target until the OutputHash of the
+// capture differs from baseline, or from the first capture when baseline is
+// empty. It returns the latest capture, including on timeout.
+func WaitOutputChange(target string, lines int, baseline string, timeout time.Duration) (string, error) {
+	if _, err := ensureTmux(); err != nil {
+		return "", fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	poll := 300 * time.Millisecond
+	deadline := time.Now().Add(BoundTimeout(timeout))
+	for {
+		s, err := Capture(target, lines)
+		if err != nil {
+			return "", err
+		}
+		hash := OutputHash(s)
+		if baseline == "" {
+			baseline = hash
+		} else if hash != baseline {
+			return s, nil
+		}
+		if time.Now().After(deadline) {
+			return s, waitTimeoutError("timeout waiting for output change")
+		}
 		time.Sleep(poll)
 	}
 }
@@ -646,7 +1112,11 @@ func Interrupt(target string) error {
 	if _, err := ensureTmux(); err != nil {
 		return fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	return exec.Command("tmux", "send-keys", "-t", target, "C-c").Run()
+	if err := tmuxCommand("send-keys", "-t", target, "C-c").Run(); err != nil {
+		return err
+	}
+	recordInput(target, "", []string{"C-c"})
+	return nil
 }
 
 // Escape sends Escape key to the target pane.
@@ -654,7 +1124,11 @@ func Escape(target string) error {
 	if _, err := ensureTmux(); err != nil {
 		return fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	return exec.Command("tmux", "send-keys", "-t", target, "Escape").Run()
+	if err := tmuxCommand("send-keys", "-t", target, "Escape").Run(); err != nil {
+		return err
+	}
+	recordInput(target, "", []string{"Escape"})
+	return nil
 }
 
 // Kill kills the target pane, guarded against self-kill.
@@ -666,7 +1140,7 @@ func Kill(target string) error {
 	if _, err := ensureTmux(); err != nil {
 		return fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	return exec.Command("tmux", "kill-pane", "-t", target).Run()
+	return tmuxCommand("kill-pane", "-t", target).Run()
 }
 
 // CurrentPaneID returns the current pane id in session:window.pane format.
@@ -674,7 +1148,7 @@ func CurrentPaneID() (string, error) {
 	if _, err := ensureTmux(); err != nil {
 		return "", fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	cmd := exec.Command("tmux", "display-message", "-p", "#{session_name}:#{window_index}.#{pane_index}")
+	cmd := tmuxCommand("display-message", "-p", formattedIDFormat())
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -688,8 +1162,8 @@ func CurrentLocation() (string, int, int, string, error) {
 	if _, err := ensureTmux(); err != nil {
 		return "", 0, 0, "", fmt.Errorf("tmux not found in PATH: %w", err)
 	}
-	format := "#{session_name}\t#{window_index}\t#{pane_index}\t#{session_name}:#{window_index}.#{pane_index}"
-	cmd := exec.Command("tmux", "display-message", "-p", format)
+	format := "#{session_name}\t" + WindowIndexFormat() + "\t" + PaneIndexFormat() + "\t" + formattedIDFormat()
+	cmd := tmuxCommand("display-message", "-p", format)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
@@ -709,12 +1183,15 @@ func EnsureSession(name string) error {
 	if _, err := ensureTmux(); err != nil {
 		return err
 	}
+	if err := ValidateSessionName(name); err != nil {
+		return err
+	}
 	if exists, err := HasSession(name); err != nil {
 		return err
 	} else if exists {
 		return nil
 	}
-	if err := exec.Command("tmux", "new-session", "-d", "-s", name).Run(); err != nil {
+	if err := tmuxCommand("new-session", "-d", "-s", name).Run(); err != nil {
 		return err
 	}
 	if strings.HasPrefix(name, "arc-") {
@@ -730,7 +1207,7 @@ func Attach(name string) error {
 	if _, err := ensureTmux(); err != nil {
 		return err
 	}
-	cmd := exec.Command("tmux", "attach-session", "-t", name)
+	cmd := tmuxCommand("attach-session", "-t", name)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -742,50 +1219,117 @@ func Cleanup(name string) error {
 	if _, err := ensureTmux(); err != nil {
 		return err
 	}
-	return exec.Command("tmux", "kill-session", "-t", name).Run()
+	return tmuxCommand("kill-session", "-t", name).Run()
+}
+
+// UseDefaultShell as the command shell means tmux's default-shell option.
+const UseDefaultShell = "default-shell"
+
+var (
+	shellMu      sync.Mutex
+	commandShell = "sh"
+	commandLogin = true
+	// resolvedDefaultShell caches default-shell once it has been queried.
+	resolvedDefaultShell string
+)
+
+// SetCommandShell sets the shell that runs command strings in new panes
+// (default "sh" as a login shell). path may be UseDefaultShell.
+func SetCommandShell(path string, login bool) {
+	shellMu.Lock()
+	defer shellMu.Unlock()
+	if strings.TrimSpace(path) == "" {
+		path = "sh"
+	}
+	commandShell = path
+	commandLogin = login
+}
+
+// CommandShell returns the shell that runs command strings and whether it
+// runs as a login shell.
+func CommandShell() (string, bool) {
+	shellMu.Lock()
+	defer shellMu.Unlock()
+	if commandShell != UseDefaultShell {
+		return commandShell, commandLogin
+	}
+	if resolvedDefaultShell == "" {
+		resolvedDefaultShell = defaultShell()
+	}
+	return resolvedDefaultShell, commandLogin
+}
+
+// defaultShell reads tmux's default-shell, falling back to $SHELL and sh.
+func defaultShell() string {
+	if out, err := runTargetCommand("show-options", "-gv", "default-shell"); err == nil {
+		if shell := strings.TrimSpace(out); shell != "" {
+			return shell
+		}
+	}
+	if shell := strings.TrimSpace(os.Getenv("SHELL")); shell != "" {
+		return shell
+	}
+	return "sh"
+}
+
+// ShellArgs returns the argv that runs cmdStr through the command shell.
+func ShellArgs(cmdStr string) []string {
+	shell, login := CommandShell()
+	flag := "-c"
+	if login {
+		flag = "-lc"
+	}
+	return []string{shell, flag, cmdStr}
 }
 
 func shellCommand(cmdStr string) []string {
 	if strings.TrimSpace(cmdStr) == "" {
 		return nil
 	}
-	return []string{"sh", "-lc", cmdStr}
+	return ShellArgs(cmdStr)
+}
+
+// startDirArgs returns tmux's -c flag for new panes, so the process starts in
+// cwd instead of cd-ing there first.
+func startDirArgs(cwd string) []string {
+	if strings.TrimSpace(cwd) == "" {
+		return nil
+	}
+	return []string{"-c", cwd}
 }
 
 // Launch creates a new pane/window and runs cmd. Returns the new pane formatted id.
 func Launch(managedSession string, cmdStr string, split string) (string, error) {
+	return LaunchDir(managedSession, cmdStr, split, "")
+}
+
+// LaunchDir is Launch with the new pane starting in cwd (when set).
+func LaunchDir(managedSession string, cmdStr string, split string, cwd string) (string, error) {
+	return LaunchPlaced(managedSession, cmdStr, split, SplitPlacement{}, cwd)
+}
+
+// LaunchPlaced is LaunchDir with the split of the current pane (inside tmux)
+// placed by placement.
+func LaunchPlaced(managedSession string, cmdStr string, split string, placement SplitPlacement, cwd string) (string, error) {
 	if _, err := ensureTmux(); err != nil {
 		return "", err
 	}
-	format := "#{session_name}:#{window_index}.#{pane_index}"
 	if InTmux() {
-		args := []string{"split-window", "-P", "-F", format}
-		if split == "h" {
-			args = append(args, "-h")
-		}
-		if split == "v" {
-			args = append(args, "-v")
-		}
-		if shellArgs := shellCommand(cmdStr); len(shellArgs) > 0 {
-			args = append(args, shellArgs...)
-		}
-		out, err := exec.Command("tmux", args...).Output()
-		if err != nil {
-			return "", fmt.Errorf("tmux split-window: %w", err)
-		}
-		return strings.TrimSpace(string(out)), nil
+		return splitWindow("", split, 0, placement, cmdStr, cwd)
 	}
+	format := formattedIDFormat()
 	if managedSession == "" {
 		managedSession = "arc-tmux"
 	}
 	if err := EnsureSession(managedSession); err != nil {
 		return "", err
 	}
-	args := []string{"new-window", "-t", managedSession, "-P", "-F", format}
+	args := []string{"new-window", "-t", SessionTarget(managedSession), "-P", "-F", format}
+	args = append(args, startDirArgs(cwd)...)
 	if shellArgs := shellCommand(cmdStr); len(shellArgs) > 0 {
 		args = append(args, shellArgs...)
 	}
-	out, err := exec.Command("tmux", args...).Output()
+	out, err := tmuxCommand(args...).Output()
 	if err != nil {
 		return "", fmt.Errorf("tmux new-window: %w", err)
 	}
@@ -794,18 +1338,37 @@ func Launch(managedSession string, cmdStr string, split string) (string, error)
 
 // NewWindow creates a new window in a session and runs cmd. Returns the new pane formatted id.
 func NewWindow(session string, name string, cmdStr string) (string, error) {
+	return NewWindowDir(session, name, cmdStr, "")
+}
+
+// NewWindowDir is NewWindow with the new pane starting in cwd (when set).
+func NewWindowDir(session string, name string, cmdStr string, cwd string) (string, error) {
+	return newWindow(session, name, cmdStr, cwd, false)
+}
+
+// NewDetachedWindow is NewWindowDir without making the new window current, so
+// attached clients keep their view.
+func NewDetachedWindow(session string, name string, cmdStr string, cwd string) (string, error) {
+	return newWindow(session, name, cmdStr, cwd, true)
+}
+
+func newWindow(session string, name string, cmdStr string, cwd string, detached bool) (string, error) {
 	if _, err := ensureTmux(); err != nil {
 		return "", err
 	}
-	format := "#{session_name}:#{window_index}.#{pane_index}"
-	args := []string{"new-window", "-t", session, "-P", "-F", format}
+	format := formattedIDFormat()
+	args := []string{"new-window", "-t", SessionTarget(session), "-P", "-F", format}
+	if detached {
+		args = append(args, "-d")
+	}
 	if strings.TrimSpace(name) != "" {
 		args = append(args, "-n", name)
 	}
+	args = append(args, startDirArgs(cwd)...)
 	if shellArgs := shellCommand(cmdStr); len(shellArgs) > 0 {
 		args = append(args, shellArgs...)
 	}
-	out, err := exec.Command("tmux", args...).Output()
+	out, err := tmuxCommand(args...).Output()
 	if err != nil {
 		return "", fmt.Errorf("tmux new-window: %w", err)
 	}
@@ -814,21 +1377,71 @@ func NewWindow(session string, name string, cmdStr string) (string, error) {
 
 // SplitWindow splits a window (or pane target) and runs cmd. Returns the new pane formatted id.
 func SplitWindow(target string, split string, cmdStr string) (string, error) {
+	return SplitWindowDir(target, split, cmdStr, "")
+}
+
+// SplitWindowDir is SplitWindow with the new pane starting in cwd (when set).
+func SplitWindowDir(target string, split string, cmdStr string, cwd string) (string, error) {
+	return SplitWindowPercent(target, split, 0, cmdStr, cwd)
+}
+
+// SplitWindowPercent is SplitWindowDir with the new pane sized to percent of the
+// split pane (0 for tmux's default even split).
+func SplitWindowPercent(target string, split string, percent int, cmdStr string, cwd string) (string, error) {
+	return SplitWindowPlaced(target, split, percent, SplitPlacement{}, cmdStr, cwd)
+}
+
+// SplitPlacement says where split-window puts the new pane. Before puts it
+// left of or above the split pane instead of after it (-b); Full makes it
+// span the whole window rather than the split pane: full width when stacked,
+// full height when side by side (-f).
+type SplitPlacement struct {
+	Before bool
+	Full   bool
+}
+
+func (p SplitPlacement) args() []string {
+	var args []string
+	if p.Before {
+		args = append(args, "-b")
+	}
+	if p.Full {
+		args = append(args, "-f")
+	}
+	return args
+}
+
+// SplitWindowPlaced is SplitWindowPercent with the new pane placed by
+// placement, so where it lands depends only on target, not on which pane is
+// active.
+func SplitWindowPlaced(target string, split string, percent int, placement SplitPlacement, cmdStr string, cwd string) (string, error) {
 	if _, err := ensureTmux(); err != nil {
 		return "", err
 	}
-	format := "#{session_name}:#{window_index}.#{pane_index}"
-	args := []string{"split-window", "-t", target, "-P", "-F", format}
+	return splitWindow(target, split, percent, placement, cmdStr, cwd)
+}
+
+// splitWindow runs split-window; an empty target splits the current pane.
+func splitWindow(target string, split string, percent int, placement SplitPlacement, cmdStr string, cwd string) (string, error) {
+	args := []string{"split-window", "-P", "-F", formattedIDFormat()}
+	if target != "" {
+		args = append(args, "-t", target)
+	}
 	if split == "h" {
 		args = append(args, "-h")
 	}
 	if split == "v" {
 		args = append(args, "-v")
 	}
+	args = append(args, placement.args()...)
+	if percent > 0 {
+		args = append(args, "-l", fmt.Sprintf("%d%%", percent))
+	}
+	args = append(args, startDirArgs(cwd)...)
 	if shellArgs := shellCommand(cmdStr); len(shellArgs) > 0 {
 		args = append(args, shellArgs...)
 	}
-	out, err := exec.Command("tmux", args...).Output()
+	out, err := tmuxCommand(args...).Output()
 	if err != nil {
 		return "", fmt.Errorf("tmux split-window: %w", err)
 	}
@@ -843,7 +1456,20 @@ func SelectLayout(target string, layout string) error {
 	if strings.TrimSpace(layout) == "" {
 		return nil
 	}
-	return exec.Command("tmux", "select-layout", "-t", target, layout).Run()
+	return tmuxCommand("select-layout", "-t", target, layout).Run()
+}
+
+// WindowLayout returns the layout string of the window containing target, in
+// the form select-layout accepts.
+func WindowLayout(target string) (string, error) {
+	if _, err := ensureTmux(); err != nil {
+		return "", err
+	}
+	out, err := runTargetCommand("display-message", "-p", "-t", target, "#{window_layout}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
 }
 
 // SetPaneTitle updates a pane title.
@@ -851,5 +1477,235 @@ func SetPaneTitle(target string, title string) error {
 	if _, err := ensureTmux(); err != nil {
 		return err
 	}
-	return exec.Command("tmux", "select-pane", "-t", target, "-T", title).Run()
+	return tmuxCommand("select-pane", "-t", target, "-T", title).Run()
+}
+
+// SelectPane makes target the active pane of its window.
+func SelectPane(target string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	return tmuxCommand("select-pane", "-t", target).Run()
+}
+
+// RespawnPane kills the pane's current process and restarts it with cmdStr
+// (or the pane's original command when cmdStr is empty).
+func RespawnPane(target string, cmdStr string) error {
+	return RespawnPaneDir(target, cmdStr, "")
+}
+
+// RespawnPaneDir is RespawnPane with the process starting in cwd (when set).
+func RespawnPaneDir(target string, cmdStr string, cwd string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	args := []string{"respawn-pane", "-k", "-t", target}
+	args = append(args, startDirArgs(cwd)...)
+	if shellArgs := shellCommand(cmdStr); len(shellArgs) > 0 {
+		args = append(args, shellArgs...)
+	}
+	if err := tmuxCommand(args...).Run(); err != nil {
+		return fmt.Errorf("tmux respawn-pane: %w", err)
+	}
+	return nil
+}
+
+// BindKey binds key in the prefix table of the running server to command.
+func BindKey(key string, command ...string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand(append([]string{"bind-key", key}, command...)...)
+	return err
+}
+
+// UnbindKey removes key from the prefix table of the running server.
+func UnbindKey(key string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand("unbind-key", key)
+	return err
+}
+
+// CopyMode puts the target pane into copy mode; panes already in copy mode are
+// left where they are.
+func CopyMode(target string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand("copy-mode", "-t", target)
+	return err
+}
+
+// CopyModeCommand runs a copy-mode command (send-keys -X) in the target pane,
+// e.g. "search-backward-text", "select-line", or "history-top".
+func CopyModeCommand(target string, command string, args ...string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand(append([]string{"send-keys", "-X", "-t", target, command}, args...)...)
+	return err
+}
+
+// CopyModeRepeat runs a copy-mode command count times in one call (send-keys
+// -X -N), e.g. "scroll-up" by 200 lines.
+func CopyModeRepeat(target string, count int, command string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand("send-keys", "-X", "-N", strconv.Itoa(count), "-t", target, command)
+	return err
+}
+
+// CopyCursor describes the copy-mode cursor of a pane. Row is the cursor's
+// line relative to the top of the visible screen (negative in history), the
+// numbering capture-pane -S/-E uses. Scroll is how many lines the view is
+// scrolled back from the bottom.
+type CopyCursor struct {
+	InMode bool
+	Row    int
+	Scroll int
+	Line   string
+}
+
+// CopyCursorPosition reports where the copy-mode cursor of target is.
+func CopyCursorPosition(target string) (CopyCursor, error) {
+	if _, err := ensureTmux(); err != nil {
+		return CopyCursor{}, err
+	}
+	out, err := runTargetCommand("display-message", "-p", "-t", target, "#{pane_in_mode}\t#{copy_cursor_y}\t#{scroll_position}\t#{copy_cursor_line}")
+	if err != nil {
+		return CopyCursor{}, err
+	}
+	return parseCopyCursor(out)
+}
+
+func parseCopyCursor(output string) (CopyCursor, error) {
+	parts := strings.SplitN(strings.TrimSuffix(output, "\n"), "\t", 4)
+	if len(parts) != 4 {
+		return CopyCursor{}, fmt.Errorf("unexpected copy-mode cursor %q", output)
+	}
+	cursor := CopyCursor{InMode: parts[0] == "1", Line: parts[3]}
+	if !cursor.InMode {
+		return cursor, nil
+	}
+	y, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return CopyCursor{}, fmt.Errorf("unexpected copy_cursor_y %q", parts[1])
+	}
+	scroll, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return CopyCursor{}, fmt.Errorf("unexpected scroll_position %q", parts[2])
+	}
+	cursor.Row = y - scroll
+	cursor.Scroll = scroll
+	return cursor, nil
+}
+
+// formatSeparator joins expressions evaluated in one display-message call;
+// tmux rewrites control characters and newlines in its output, so a
+// printable marker is used.
+const formatSeparator = ":::ARC_TMUX_FMT:::"
+
+// EvalFormats expands each tmux format string (e.g. "#{pane_width}") against
+// target in a single display-message call and returns the results in order.
+func EvalFormats(target string, formats []string) ([]string, error) {
+	if _, err := ensureTmux(); err != nil {
+		return nil, err
+	}
+	out, err := runTargetCommand("display-message", "-p", "-t", target, strings.Join(formats, formatSeparator))
+	if err != nil {
+		return nil, err
+	}
+	return splitFormatOutput(out, len(formats))
+}
+
+func splitFormatOutput(output string, count int) ([]string, error) {
+	values := strings.Split(strings.TrimSuffix(output, "\n"), formatSeparator)
+	if len(values) != count {
+		return nil, fmt.Errorf("expected %d format results, got %d", count, len(values))
+	}
+	return values, nil
+}
+
+// CaptureRange returns lines start..end of a pane (capture-pane -S/-E
+// numbering), joining wrapped lines.
+func CaptureRange(target string, start int, end int) (string, error) {
+	if _, err := ensureTmux(); err != nil {
+		return "", fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	return runTargetCommand("capture-pane", "-p", "-J", "-t", target, "-S", strconv.Itoa(start), "-E", strconv.Itoa(end))
+}
+
+// SetBuffer stores text in the named paste buffer, replacing its contents.
+func SetBuffer(name string, text string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand("set-buffer", "-b", name, "--", text)
+	return err
+}
+
+// PaneGeometry is a pane's position and size within its window, in cells.
+type PaneGeometry struct {
+	ID     string `json:"id"`
+	Index  int    `json:"index"`
+	Left   int    `json:"left"`
+	Top    int    `json:"top"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Active bool   `json:"active"`
+}
+
+// WindowPanes returns the geometry of every pane in window.
+func WindowPanes(window string) ([]PaneGeometry, error) {
+	if _, err := ensureTmux(); err != nil {
+		return nil, err
+	}
+	format := strings.Join([]string{
+		"#{pane_id}",
+		PaneIndexFormat(),
+		"#{pane_left}",
+		"#{pane_top}",
+		"#{pane_width}",
+		"#{pane_height}",
+		"#{?pane_active,1,0}",
+	}, "\t")
+	out, err := runTargetCommand("list-panes", "-t", window, "-F", format)
+	if err != nil {
+		return nil, err
+	}
+	return parsePaneGeometry(out)
+}
+
+func parsePaneGeometry(output string) ([]PaneGeometry, error) {
+	var panes []PaneGeometry
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := strings.Split(line, "\t")
+		if len(parts) != 7 {
+			return nil, fmt.Errorf("unexpected list-panes output %q", line)
+		}
+		nums := make([]int, 5)
+		for i := range nums {
+			n, err := strconv.Atoi(parts[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("unexpected list-panes output %q", line)
+			}
+			nums[i] = n
+		}
+		panes = append(panes, PaneGeometry{
+			ID:     parts[0],
+			Index:  nums[0],
+			Left:   nums[1],
+			Top:    nums[2],
+			Width:  nums[3],
+			Height: nums[4],
+			Active: parts[6] == "1",
+		})
+	}
+	return panes, nil
 }
@@ -1,6 +1,14 @@
 package tmux
 
-import "testing"
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestParseSessionsOutput(t *testing.T) {
 	input := "dev\t3\t1\t1700000000\t1700000100\n"
@@ -44,6 +52,73 @@ func TestParsePaneDetailsOutput(t *testing.T) {
 	}
 }
 
+func TestParsePaneDetailsOutputDead(t *testing.T) {
+	input := "dev\t2\tapi\t1\t0\t%5\t1\tnpm\tserver\t/srv\t1234\t1700000200\t1\t137\t\"npm run \\\"build\\\"\"\n"
+	panes, err := parsePaneDetailsOutput(input)
+	if err != nil {
+		t.Fatalf("parsePaneDetailsOutput error: %v", err)
+	}
+	if len(panes) != 1 || !panes[0].Dead || panes[0].DeadStatus != 137 || panes[0].StartCommand != `npm run "build"` {
+		t.Fatalf("unexpected dead state: %+v", panes)
+	}
+}
+
+func TestParsePaneDetailsOutputSignaled(t *testing.T) {
+	input := "dev\t2\tapi\t1\t0\t%5\t1\tsleep\tserver\t/srv\t1234\t1700000200\t1\t\tsleep 60\t1700000200\t0\t0\t0\t0\t0\t9\t\t\n"
+	panes, err := parsePaneDetailsOutput(input)
+	if err != nil {
+		t.Fatalf("parsePaneDetailsOutput error: %v", err)
+	}
+	if len(panes) != 1 || !panes[0].Dead || panes[0].DeadStatus != 0 || panes[0].DeadSignal != 9 {
+		t.Fatalf("unexpected dead state: %+v", panes)
+	}
+	if code := panes[0].ExitCode(); code != 137 {
+		t.Fatalf("ExitCode() = %d, want 137", code)
+	}
+}
+
+func TestParsePaneDetailsOutputShellHook(t *testing.T) {
+	base := "dev\t2\tapi\t1\t0\t%5\t1\tbash\tbuild\t/srv\t1234\t1700000200\t0\t\t\t1700000200\t0\t5\t0\t10\t0\t"
+	panes, err := parsePaneDetailsOutput(base + "\tbash 1234\t2 7 1700000300 make test\tlint\n")
+	if err != nil {
+		t.Fatalf("parsePaneDetailsOutput error: %v", err)
+	}
+	p := panes[0]
+	if p.ShellHook != "bash" || p.LastCommand == nil {
+		t.Fatalf("expected a shell hook record: %+v", p)
+	}
+	want := HookCommand{ExitCode: 2, Seq: 7, At: time.Unix(1700000300, 0), Command: "make test\tlint"}
+	if *p.LastCommand != want {
+		t.Fatalf("last command = %+v, want %+v", *p.LastCommand, want)
+	}
+
+	// A hook loaded by a nested shell does not describe the pane's shell.
+	panes, err = parsePaneDetailsOutput(base + "\tbash 999\t0 1 1700000300 ls\n")
+	if err != nil {
+		t.Fatalf("parsePaneDetailsOutput error: %v", err)
+	}
+	if panes[0].ShellHook != "" || panes[0].LastCommand != nil {
+		t.Fatalf("expected no shell hook: %+v", panes[0])
+	}
+}
+
+func TestParseHookHistory(t *testing.T) {
+	// Slot 0 holds seq 100 after wrapping; the last slots hold an earlier
+	// shell's commands, which count from 1 again.
+	output := "0 100 1700000500 make\t\t1 2 1700000100 ls /nope\t\t0 99 1700000400 make clean\t0 5 1700000050 cd /srv\n"
+	history := parseHookHistory(output)
+	var seqs []int
+	for _, hc := range history {
+		seqs = append(seqs, hc.Seq)
+	}
+	if len(seqs) != 4 || seqs[0] != 5 || seqs[1] != 2 || seqs[2] != 99 || seqs[3] != 100 {
+		t.Fatalf("unexpected history order: %v", seqs)
+	}
+	if history[1].ExitCode != 1 || history[1].Command != "ls /nope" {
+		t.Fatalf("unexpected record: %+v", history[1])
+	}
+}
+
 func TestParseProcessList(t *testing.T) {
 	input := "123 1 /bin/bash -l\n456 123 node server.js\n"
 	procs, err := parseProcessList(input)
@@ -79,3 +154,312 @@ func TestBuildProcessTree(t *testing.T) {
 		t.Fatalf("unexpected child depth: %+v", nodes)
 	}
 }
+
+func TestParseVersion(t *testing.T) {
+	v := ParseVersion("tmux 3.3a\n")
+	if v.Raw != "3.3a" || v.Major != 3 || v.Minor != 3 || v.Patch != "a" {
+		t.Fatalf("unexpected version: %+v", v)
+	}
+	if !v.AtLeast(3, 2) || v.AtLeast(3, 4) {
+		t.Fatalf("unexpected AtLeast results for %+v", v)
+	}
+	next := ParseVersion("tmux next-3.4")
+	if next.Major != 3 || next.Minor != 4 {
+		t.Fatalf("unexpected next version: %+v", next)
+	}
+	next = ParseVersion("tmux next-3.5")
+	if next.Raw != "next-3.5" || next.Major != 3 || next.Minor != 5 || next.Patch != "" {
+		t.Fatalf("unexpected next version: %+v", next)
+	}
+	// OpenBSD reports the OS release, not a tmux version.
+	openbsd := ParseVersion("tmux openbsd-7.4")
+	if openbsd.Raw != "openbsd-7.4" || openbsd.Major != 0 || openbsd.Minor != 0 {
+		t.Fatalf("unexpected openbsd version: %+v", openbsd)
+	}
+	if !openbsd.AtLeast(3, 2) {
+		t.Fatalf("expected openbsd to satisfy feature checks")
+	}
+	master := ParseVersion("tmux master")
+	if !master.AtLeast(3, 2) {
+		t.Fatalf("expected master to satisfy feature checks")
+	}
+}
+
+func TestFeaturesFor(t *testing.T) {
+	f := FeaturesFor(ParseVersion("3.1c"))
+	if !f.ControlMode || f.Popups || f.ExtendedFormats {
+		t.Fatalf("unexpected features for 3.1c: %+v", f)
+	}
+	if got := FeaturesFor(Version{}); got.ControlMode {
+		t.Fatalf("expected no features for empty version: %+v", got)
+	}
+}
+
+func TestParsePaneHandle(t *testing.T) {
+	h, err := parsePaneHandle("%12\tdev:build.0\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.ID != "%12" || h.Target != "dev:build.0" {
+		t.Fatalf("unexpected handle: %+v", h)
+	}
+	if _, err := parsePaneHandle("dev:0.0"); err == nil {
+		t.Fatal("expected error for missing pane_id")
+	}
+}
+
+func TestValidateSessionName(t *testing.T) {
+	for _, name := range []string{"dev", "arc-agent_1", "my session"} {
+		if err := ValidateSessionName(name); err != nil {
+			t.Fatalf("%q: unexpected error: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "  ", "a:b", "v1.2", "-dev", "bad\tname"} {
+		if err := ValidateSessionName(name); !errors.Is(err, ErrInvalidSessionName) {
+			t.Fatalf("%q: expected ErrInvalidSessionName, got %v", name, err)
+		}
+	}
+	if got := WindowTarget("dev", 2); got != "=dev:2" {
+		t.Fatalf("unexpected window target %q", got)
+	}
+}
+
+func TestBoundTimeout(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		d        time.Duration
+		deadline time.Time
+		want     time.Duration
+	}{
+		{5 * time.Second, time.Time{}, 5 * time.Second},
+		{5 * time.Second, now.Add(10 * time.Second), 5 * time.Second},
+		{5 * time.Second, now.Add(2 * time.Second), 2 * time.Second},
+		{5 * time.Second, now.Add(-time.Second), 0},
+	}
+	for _, c := range cases {
+		if got := boundTimeout(c.d, c.deadline, now); got != c.want {
+			t.Fatalf("boundTimeout(%s, %v) = %s, want %s", c.d, c.deadline, got, c.want)
+		}
+	}
+}
+
+func TestParseCopyCursor(t *testing.T) {
+	cursor, err := parseCopyCursor("1\t3\t120\terror: build failed\n")
+	if err != nil {
+		t.Fatalf("parseCopyCursor error: %v", err)
+	}
+	if !cursor.InMode || cursor.Row != -117 || cursor.Scroll != 120 || cursor.Line != "error: build failed" {
+		t.Fatalf("unexpected cursor: %+v", cursor)
+	}
+	cursor, err = parseCopyCursor("0\t\t\t\n")
+	if err != nil || cursor.InMode {
+		t.Fatalf("expected pane outside copy mode, got %+v (%v)", cursor, err)
+	}
+	if _, err := parseCopyCursor("garbage"); err == nil {
+		t.Fatalf("expected error for malformed output")
+	}
+}
+
+func TestShellArgs(t *testing.T) {
+	defer SetCommandShell("sh", true)
+	if got := ShellArgs("echo hi"); strings.Join(got, " ") != "sh -lc echo hi" {
+		t.Fatalf("default ShellArgs = %q", got)
+	}
+	SetCommandShell("/usr/bin/zsh", false)
+	if got := ShellArgs("echo hi"); strings.Join(got, " ") != "/usr/bin/zsh -c echo hi" {
+		t.Fatalf("zsh ShellArgs = %q", got)
+	}
+}
+
+func TestParseBaseIndexes(t *testing.T) {
+	win, pane, err := parseBaseIndexes("1\t1\n")
+	if err != nil || win != 1 || pane != 1 {
+		t.Fatalf("parseBaseIndexes = %d, %d, %v", win, pane, err)
+	}
+	if _, _, err := parseBaseIndexes("1"); err == nil {
+		t.Fatalf("expected error for malformed output")
+	}
+}
+
+func TestNativeTargetArgs(t *testing.T) {
+	defer SetZeroBasedIndexes(false)
+	args := []string{"send-keys", "-t", "dev:0.0", "ls"}
+	if got := nativeTargetArgs(args); strings.Join(got, " ") != "send-keys -t dev:0.0 ls" {
+		t.Fatalf("tmux origin rewrote args: %q", got)
+	}
+	SetZeroBasedIndexes(true)
+	indexMu.Lock()
+	baseIndexCache[baseIndexKey{session: "dev"}] = [2]int{1, 1}
+	baseIndexCache[baseIndexKey{socket: "/tmp/other", session: "dev"}] = [2]int{5, 0}
+	indexMu.Unlock()
+	cases := map[string]string{
+		"dev:0.0":    "dev:1.1",
+		"=dev:2":     "=dev:3",
+		"dev:api.0":  "dev:api.1",
+		"dev:0.+1":   "dev:1.+1",
+		"dev:":       "dev:",
+		"%3":         "%3",
+		"dev:{last}": "dev:{last}",
+	}
+	for in, want := range cases {
+		got := nativeTargetArgs([]string{"send-keys", "-t", in})
+		if got[2] != want {
+			t.Fatalf("nativeTargetArgs(%q) = %q, want %q", in, got[2], want)
+		}
+	}
+	if args[2] != "dev:0.0" {
+		t.Fatalf("nativeTargetArgs modified its input: %q", args)
+	}
+	err := WithServerSocket("/tmp/other", func() error {
+		if got := nativeTargetArgs([]string{"send-keys", "-t", "dev:0.0"}); got[2] != "dev:5.0" {
+			t.Fatalf("other server's dev:0.0 = %q, want dev:5.0", got[2])
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := nativeTargetArgs([]string{"send-keys", "-t", "dev:0.0"}); got[2] != "dev:1.1" {
+		t.Fatalf("default server's dev:0.0 = %q after switching back, want dev:1.1", got[2])
+	}
+	if WindowIndexFormat() != "#{e|-:#{window_index},#{base-index}}" {
+		t.Fatalf("zero-based WindowIndexFormat = %q", WindowIndexFormat())
+	}
+}
+
+func TestDiscoverSockets(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMUX_TMPDIR", tmp)
+	dir := SocketDir()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("unix", filepath.Join(dir, "work"))
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer ln.Close()
+	if err := os.WriteFile(filepath.Join(dir, "notes"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got := DiscoverSockets([]string{"extra", "/srv/tmux.sock", "work"})
+	want := []string{"/srv/tmux.sock", filepath.Join(dir, "extra"), filepath.Join(dir, "work")}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("DiscoverSockets = %q, want %q", got, want)
+	}
+}
+
+func TestServerArgs(t *testing.T) {
+	defer SetServerSocket("")
+	if got := serverArgs([]string{"ls"}); strings.Join(got, " ") != "ls" {
+		t.Fatalf("default serverArgs = %q", got)
+	}
+	_ = WithServerSocket("/tmp/x.sock", func() error {
+		if got := serverArgs([]string{"ls"}); strings.Join(got, " ") != "-S /tmp/x.sock ls" {
+			t.Fatalf("serverArgs = %q", got)
+		}
+		return nil
+	})
+	if ServerSocket() != "" {
+		t.Fatalf("WithServerSocket did not restore the default server")
+	}
+}
+
+func TestParseClientsOutput(t *testing.T) {
+	clients := parseClientsOutput("/dev/pts/3\tdev\t/dev/pts/3\n\n/dev/pts/7\tops\t/dev/pts/7\n")
+	if len(clients) != 2 {
+		t.Fatalf("expected 2 clients, got %d", len(clients))
+	}
+	if clients[1].Name != "/dev/pts/7" || clients[1].Session != "ops" || clients[1].TTY != "/dev/pts/7" {
+		t.Fatalf("unexpected client: %+v", clients[1])
+	}
+}
+
+func TestEscapeFormat(t *testing.T) {
+	if got := escapeFormat("build #12 at #{pane_id}"); got != "build ##12 at ##{pane_id}" {
+		t.Fatalf("unexpected escape: %q", got)
+	}
+}
+
+func TestParseHookOutput(t *testing.T) {
+	cmd, ok, err := parseHookOutput("pane-exited[90] run-shell -b \"arc-tmux _event pane-exited\"\n", "pane-exited[90]")
+	if err != nil || !ok || cmd != `run-shell -b "arc-tmux _event pane-exited"` {
+		t.Fatalf("unexpected hook: %q %v %v", cmd, ok, err)
+	}
+	if _, ok, err := parseHookOutput("pane-exited[90] \n", "pane-exited[90]"); err != nil || ok {
+		t.Fatalf("expected unset hook, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestParsePaneGeometry(t *testing.T) {
+	panes, err := parsePaneGeometry("%1\t0\t0\t0\t80\t24\t1\n%2\t1\t81\t0\t79\t24\t0\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(panes) != 2 || !panes[0].Active || panes[1].Left != 81 || panes[1].Width != 79 {
+		t.Fatalf("unexpected panes: %+v", panes)
+	}
+	if _, err := parsePaneGeometry("%1\t0\tx\n"); err == nil {
+		t.Fatalf("expected error for malformed line")
+	}
+}
+
+func TestOutputHash(t *testing.T) {
+	if got := OutputHash(""); got != "da39a3ee5e6b4b0d3255bfef95601890afd80709" {
+		t.Fatalf("OutputHash(\"\") = %s", got)
+	}
+	if OutputHash("a\n") == OutputHash("b\n") {
+		t.Fatalf("expected different hashes")
+	}
+}
+
+func TestSplitFormatOutput(t *testing.T) {
+	values, err := splitFormatOutput("80x24"+formatSeparator+"3,7"+formatSeparator+"\n", 3)
+	if err != nil || len(values) != 3 || values[0] != "80x24" || values[1] != "3,7" || values[2] != "" {
+		t.Fatalf("splitFormatOutput = %q, %v", values, err)
+	}
+	if _, err := splitFormatOutput("80x24\n", 2); err == nil {
+		t.Fatalf("expected count mismatch to fail")
+	}
+}
+
+func TestParsePaneDetailsOutputScreen(t *testing.T) {
+	input := "dev\t2\tapi\t1\t0\t%5\t1\tvim\tedit\t/srv\t1234\t\t0\t\tvim\t1700000300\t4\t10\t0\t1500\t1\n"
+	panes, err := parsePaneDetailsOutput(input)
+	if err != nil {
+		t.Fatalf("parsePaneDetailsOutput error: %v", err)
+	}
+	p := panes[0]
+	if p.WindowActivityAt.Unix() != 1700000300 || !p.ActivityAt.IsZero() {
+		t.Fatalf("unexpected activity: %+v", p)
+	}
+	if p.CursorX != 4 || p.CursorY != 10 || p.ScrollPosition != 0 || p.HistorySize != 1500 || !p.AlternateScreen {
+		t.Fatalf("unexpected screen state: %+v", p)
+	}
+}
+
+func TestParseTrashOutput(t *testing.T) {
+	out := "dev\t\n" +
+		"trash-api-1700000000\t1700000000 1700086400 api\n" +
+		"trash-web-1700000500\t1700000500 1700001000 web\n" +
+		"odd\tnot a record\n"
+	trash := parseTrashOutput(out)
+	if len(trash) != 2 {
+		t.Fatalf("expected 2 trashed sessions, got %+v", trash)
+	}
+	if trash[0].Name != "trash-web-1700000500" || trash[0].Original != "web" || trash[1].Original != "api" {
+		t.Fatalf("expected most recent first, got %+v", trash)
+	}
+	if !trash[0].Expired(time.Unix(1700001000, 0)) || trash[1].Expired(time.Unix(1700001000, 0)) {
+		t.Fatalf("unexpected expiry: %+v", trash)
+	}
+}
+
+func TestSplitPlacementArgs(t *testing.T) {
+	if args := (SplitPlacement{}).args(); len(args) != 0 {
+		t.Fatalf("default placement args = %v", args)
+	}
+	if got := strings.Join(SplitPlacement{Before: true, Full: true}.args(), " "); got != "-b -f" {
+		t.Fatalf("placement args = %q", got)
+	}
+}
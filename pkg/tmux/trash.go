@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package tmux
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrashPrefix starts the name of every session moved to the trash.
+const TrashPrefix = "trash-"
+
+// TrashedOption is the session user option marking a trashed session, set to
+// "TRASHED_EPOCH EXPIRES_EPOCH ORIGINAL_NAME".
+const TrashedOption = "@arc_tmux_trashed"
+
+// ErrSessionExists is returned when restoring a session whose original name
+// has been taken since it was trashed.
+var ErrSessionExists = errors.New("session already exists")
+
+// TrashedSession is a session renamed into the trash instead of killed.
+type TrashedSession struct {
+	Name      string    `json:"name" yaml:"name"`
+	Original  string    `json:"original" yaml:"original"`
+	TrashedAt time.Time `json:"trashed_at" yaml:"trashed_at"`
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+// Expired reports whether the grace period for restoring t has passed.
+func (t TrashedSession) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// RenameSession renames a session.
+func RenameSession(name string, newName string) error {
+	if _, err := ensureTmux(); err != nil {
+		return err
+	}
+	_, err := runTargetCommand("rename-session", "-t", exactSessionTarget(name), newName)
+	return err
+}
+
+// TrashSession detaches every client from a session and renames it into the
+// trash, where it can be restored until ttl passes.
+func TrashSession(name string, ttl time.Duration) (TrashedSession, error) {
+	if _, err := ensureTmux(); err != nil {
+		return TrashedSession{}, err
+	}
+	now := time.Now()
+	t := TrashedSession{
+		Name:      fmt.Sprintf("%s%s-%d", TrashPrefix, name, now.Unix()),
+		Original:  name,
+		TrashedAt: now.Truncate(time.Second),
+		ExpiresAt: now.Add(ttl).Truncate(time.Second),
+	}
+	value := fmt.Sprintf("%d %d %s", t.TrashedAt.Unix(), t.ExpiresAt.Unix(), name)
+	if err := SetSessionOption(name, TrashedOption, value); err != nil {
+		return TrashedSession{}, err
+	}
+	if _, err := runTargetCommand("detach-client", "-s", exactSessionTarget(name)); err != nil {
+		return TrashedSession{}, err
+	}
+	if err := RenameSession(name, t.Name); err != nil {
+		_ = UnsetSessionOption(name, TrashedOption)
+		return TrashedSession{}, err
+	}
+	return t, nil
+}
+
+// RestoreSession renames a trashed session back to its original name.
+func RestoreSession(t TrashedSession) error {
+	exists, err := HasSession(t.Original)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %q", ErrSessionExists, t.Original)
+	}
+	if err := RenameSession(t.Name, t.Original); err != nil {
+		return err
+	}
+	return UnsetSessionOption(t.Original, TrashedOption)
+}
+
+// ListTrash returns the trashed sessions, most recently trashed first.
+func ListTrash() ([]TrashedSession, error) {
+	if _, err := ensureTmux(); err != nil {
+		return nil, err
+	}
+	cmd := tmuxCommand("list-sessions", "-F", "#{session_name}\t#{"+TrashedOption+"}")
+	var out, errBuf bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errBuf
+	if err := cmd.Run(); err != nil {
+		wrapped := wrapListSessionsError(err, errBuf.String())
+		if errors.Is(wrapped, ErrNoTmuxServer) {
+			return nil, nil
+		}
+		return nil, wrapped
+	}
+	return parseTrashOutput(out.String()), nil
+}
+
+func parseTrashOutput(output string) []TrashedSession {
+	var trash []TrashedSession
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		name, raw, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.SplitN(raw, " ", 3)
+		if len(fields) != 3 || fields[2] == "" {
+			continue
+		}
+		if _, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+			continue
+		}
+		trash = append(trash, TrashedSession{
+			Name:      name,
+			Original:  fields[2],
+			TrashedAt: parseEpoch(fields[0]),
+			ExpiresAt: parseEpoch(fields[1]),
+		})
+	}
+	sort.SliceStable(trash, func(i, j int) bool { return trash[i].TrashedAt.After(trash[j].TrashedAt) })
+	return trash
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Arc Engineering
+// SPDX-License-Identifier: MIT
+
+package tmux
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed tmux version such as "3.3a" or "next-3.4".
+type Version struct {
+	Raw   string `json:"raw"`
+	Major int    `json:"major"`
+	Minor int    `json:"minor"`
+	Patch string `json:"patch,omitempty"`
+}
+
+// String returns the raw version string.
+func (v Version) String() string { return v.Raw }
+
+// AtLeast reports whether v is at least major.minor.
+// Unparseable versions (e.g. "master") are treated as the newest release.
+func (v Version) AtLeast(major, minor int) bool {
+	if v.Major == 0 && v.Minor == 0 {
+		return v.Raw != ""
+	}
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+// Features reports which optional tmux capabilities are available.
+type Features struct {
+	ControlMode     bool `json:"control_mode"`
+	Popups          bool `json:"popups"`
+	ExtendedFormats bool `json:"extended_formats"`
+}
+
+// FeaturesFor derives optional capabilities from a tmux version.
+func FeaturesFor(v Version) Features {
+	if v.Raw == "" {
+		return Features{}
+	}
+	return Features{
+		ControlMode:     v.AtLeast(1, 8),
+		Popups:          v.AtLeast(3, 2),
+		ExtendedFormats: v.AtLeast(3, 2),
+	}
+}
+
+// ParseVersion parses the output of "tmux -V" or "#{version}".
+func ParseVersion(raw string) Version {
+	trimmed := strings.TrimSpace(raw)
+	trimmed = strings.TrimPrefix(trimmed, "tmux ")
+	v := Version{Raw: trimmed}
+	// Development builds report "next-3.5". Other prefixed forms, such as
+	// OpenBSD's "openbsd-7.4", carry an OS release rather than a tmux
+	// version and stay unparsed.
+	num := strings.TrimPrefix(trimmed, "next-")
+	parts := strings.SplitN(num, ".", 2)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return v
+	}
+	v.Major = major
+	if len(parts) < 2 {
+		return v
+	}
+	minorStr := parts[1]
+	end := 0
+	for end < len(minorStr) && minorStr[end] >= '0' && minorStr[end] <= '9' {
+		end++
+	}
+	v.Minor, _ = strconv.Atoi(minorStr[:end])
+	v.Patch = minorStr[end:]
+	return v
+}
+
+// ClientVersion returns the version of the tmux binary in PATH.
+func ClientVersion() (Version, error) {
+	if _, err := ensureTmux(); err != nil {
+		return Version{}, fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	out, err := tmuxCommand("-V").Output()
+	if err != nil {
+		return Version{}, fmt.Errorf("tmux -V: %w", err)
+	}
+	return ParseVersion(string(out)), nil
+}
+
+// ServerVersion returns the version reported by the running tmux server.
+func ServerVersion() (Version, error) {
+	if _, err := ensureTmux(); err != nil {
+		return Version{}, fmt.Errorf("tmux not found in PATH: %w", err)
+	}
+	cmd := tmuxCommand("display-message", "-p", "#{version}")
+	var out, errBuf bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errBuf
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(errBuf.String())
+		lower := strings.ToLower(msg)
+		switch {
+		case strings.Contains(lower, "no server running"), strings.Contains(lower, "error connecting"):
+			return Version{}, ErrNoTmuxServer
+		case msg != "":
+			return Version{}, fmt.Errorf("tmux display-message: %s", msg)
+		default:
+			return Version{}, fmt.Errorf("tmux display-message: %w", err)
+		}
+	}
+	return ParseVersion(out.String()), nil
+}